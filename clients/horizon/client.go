@@ -284,6 +284,38 @@ func (c *Client) LoadAccountTransactions(accountID string, params ...interface{}
 	return tx, errors.Wrap(err, "decoding response to transaction")
 }
 
+// LoadAccountPayments loads a page of the payments, path payments, account
+// creations and merges of an account.
+func (c *Client) LoadAccountPayments(accountID string, params ...interface{}) (PaymentsPage, error) {
+	payments := PaymentsPage{}
+
+	c.fixURLOnce.Do(c.fixURL)
+	query := url.Values{}
+
+	for _, param := range params {
+		switch param := param.(type) {
+		case Limit:
+			query.Add("limit", strconv.Itoa(int(param)))
+		case Order:
+			query.Add("order", string(param))
+		case Cursor:
+			query.Add("cursor", string(param))
+		default:
+			return payments, errors.Errorf("Undefined parameter (%T): %+v", param, param)
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/accounts/%s/payments?%s", c.URL, accountID, query.Encode())
+
+	resp, err := c.getRequest(endpoint)
+	if err != nil {
+		return payments, errors.Wrap(err, "loading endpoint")
+	}
+
+	err = decodeResponse(resp, &payments)
+	return payments, errors.Wrap(err, "decoding response to payments")
+}
+
 // LoadOperation loads a single operation from Horizon server
 func (c *Client) LoadOperation(operationID string) (payment Payment, err error) {
 	c.fixURLOnce.Do(c.fixURL)
@@ -387,6 +419,32 @@ func (c *Client) LoadOrderBook(
 	return
 }
 
+// LoadPaths loads the payment paths from `sourceAccount` to
+// `destinationAccount` which deliver `destinationAmount` of `destinationAsset`.
+func (c *Client) LoadPaths(
+	sourceAccount, destinationAccount string,
+	destinationAsset Asset,
+	destinationAmount string,
+) (paths PathsPage, err error) {
+	c.fixURLOnce.Do(c.fixURL)
+	query := url.Values{}
+
+	query.Add("source_account", sourceAccount)
+	query.Add("destination_account", destinationAccount)
+	query.Add("destination_asset_type", destinationAsset.Type)
+	query.Add("destination_asset_code", destinationAsset.Code)
+	query.Add("destination_asset_issuer", destinationAsset.Issuer)
+	query.Add("destination_amount", destinationAmount)
+
+	resp, err := c.getRequest(c.URL + "/paths?" + query.Encode())
+	if err != nil {
+		return
+	}
+
+	err = decodeResponse(resp, &paths)
+	return
+}
+
 func (c *Client) stream(
 	ctx context.Context,
 	baseURL string,
@@ -108,8 +108,10 @@ type ClientInterface interface {
 	LoadMemo(p *Payment) error
 	LoadOperation(operationID string) (payment Payment, err error)
 	LoadOrderBook(selling Asset, buying Asset, params ...interface{}) (orderBook OrderBookSummary, err error)
+	LoadPaths(sourceAccount, destinationAccount string, destinationAsset Asset, destinationAmount string) (paths PathsPage, err error)
 	LoadTransaction(transactionID string) (transaction Transaction, err error)
 	LoadAccountTransactions(transactionID string, params ...interface{}) (transactions TransactionsPage, err error)
+	LoadAccountPayments(accountID string, params ...interface{}) (payments PaymentsPage, err error)
 	SequenceForAccount(accountID string) (xdr.SequenceNumber, error)
 	StreamLedgers(ctx context.Context, cursor *Cursor, handler LedgerHandler) error
 	StreamPayments(ctx context.Context, accountID string, cursor *Cursor, handler PaymentHandler) error
@@ -293,6 +293,64 @@ func TestLoadAccountTransactions(t *testing.T) {
 	}
 }
 
+func TestLoadAccountPayments(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		URL:  "https://localhost",
+		HTTP: hmock,
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/GCK6ALX65S5KTQRKX3OWG5DNCJ7XMI62N55TKRM6ZI2YNWDSO3PX3YSZ/payments?cursor=a&limit=50&order=asc",
+	).ReturnString(200, accountPaymentsResponse)
+
+	payments, err := client.LoadAccountPayments("GCK6ALX65S5KTQRKX3OWG5DNCJ7XMI62N55TKRM6ZI2YNWDSO3PX3YSZ", Cursor("a"), Limit(50), OrderAsc)
+	if assert.NoError(t, err) && assert.Len(t, payments.Embedded.Records, 1) {
+		payment := payments.Embedded.Records[0]
+		assert.Equal(t, "path_payment", payment.Type)
+		assert.Equal(t, "12884905985", payment.PagingToken)
+		assert.Equal(t, "10.0000000", payment.Amount)
+		assert.Equal(t, "USD", payment.AssetCode)
+		assert.Equal(t, "20.0000000", payment.SourceAmount)
+		assert.Equal(t, "native", payment.SourceAssetType)
+	}
+
+	_, err = client.LoadAccountPayments("GCK6ALX65S5KTQRKX3OWG5DNCJ7XMI62N55TKRM6ZI2YNWDSO3PX3YSZ", "a")
+	assert.Error(t, err)
+}
+
+func TestLoadPaths(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		URL:  "https://localhost",
+		HTTP: hmock,
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/paths?destination_account=GAEDTJ4PPEFVW5XV2S7LUXBEHNQMX5Q2GM562RJGOQG7GVCE5H3HIB4V&destination_amount=20&destination_asset_code=EUR&destination_asset_issuer=GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN&destination_asset_type=credit_alphanum4&source_account=GARSFJNXJIHO6ULUBK3DBYKVSIZE7SC72S5DYBCHU7DKL22UXKVD7MXP",
+	).ReturnString(200, pathsResponse)
+
+	paths, err := client.LoadPaths(
+		"GARSFJNXJIHO6ULUBK3DBYKVSIZE7SC72S5DYBCHU7DKL22UXKVD7MXP",
+		"GAEDTJ4PPEFVW5XV2S7LUXBEHNQMX5Q2GM562RJGOQG7GVCE5H3HIB4V",
+		Asset{"credit_alphanum4", "EUR", "GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN"},
+		"20",
+	)
+	if assert.NoError(t, err) && assert.Len(t, paths.Embedded.Records, 2) {
+		path := paths.Embedded.Records[0]
+		assert.Equal(t, "native", path.SourceAssetType)
+		assert.Equal(t, "30.0000000", path.SourceAmount)
+		assert.Equal(t, "EUR", path.DestinationAssetCode)
+		assert.Equal(t, "20.0000000", path.DestinationAmount)
+		if assert.Len(t, path.Path, 1) {
+			assert.Equal(t, "USD", path.Path[0].Code)
+		}
+		assert.Equal(t, "USD", paths.Embedded.Records[1].SourceAssetCode)
+	}
+}
+
 func TestLoadTransaction(t *testing.T) {
 	hmock := httptest.NewClient()
 	client := &Client{
@@ -753,6 +811,28 @@ var accountOffersResponse = `{
   }
 }`
 
+var accountPaymentsResponse = `{
+  "_links": {},
+  "_embedded": {
+    "records": [
+      {
+        "id": "12884905985",
+        "paging_token": "12884905985",
+        "type": "path_payment",
+        "from": "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2",
+        "to": "GCK6ALX65S5KTQRKX3OWG5DNCJ7XMI62N55TKRM6ZI2YNWDSO3PX3YSZ",
+        "asset_type": "credit_alphanum4",
+        "asset_code": "USD",
+        "asset_issuer": "GDI73WJ4SX7LOG3XZDJC3KCK6ED6E5NBYK2JUBQSPBCNNWEG3ZN7T75U",
+        "amount": "10.0000000",
+        "source_amount": "20.0000000",
+        "source_asset_type": "native",
+        "transaction_hash": "1d69be46da491ce35b1241f65fa0f7471f94bc7e87ea031264c11144245acdc1"
+      }
+    ]
+  }
+}`
+
 var accountTransactionsResponse = `{
     "_links": {
         "self": {
@@ -1281,3 +1361,41 @@ var internalServerError = `{
   "details":  "Horizon unavailible",
   "instance": "d3465740-ec3a-4a0b-9d4a-c9ea734ce58a"
 }`
+
+var pathsResponse = `{
+  "_links": {
+    "self": {
+      "href": ""
+    }
+  },
+  "_embedded": {
+    "records": [
+      {
+        "source_asset_type": "native",
+        "source_amount": "30.0000000",
+        "destination_asset_type": "credit_alphanum4",
+        "destination_asset_code": "EUR",
+        "destination_asset_issuer": "GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN",
+        "destination_amount": "20.0000000",
+        "path": [
+          {
+            "asset_type": "credit_alphanum4",
+            "asset_code": "USD",
+            "asset_issuer": "GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN"
+          }
+        ]
+      },
+      {
+        "source_asset_type": "credit_alphanum4",
+        "source_asset_code": "USD",
+        "source_asset_issuer": "GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN",
+        "source_amount": "22.0000000",
+        "destination_asset_type": "credit_alphanum4",
+        "destination_asset_code": "EUR",
+        "destination_asset_issuer": "GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN",
+        "destination_amount": "20.0000000",
+        "path": []
+      }
+    ]
+  }
+}`
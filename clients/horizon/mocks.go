@@ -101,6 +101,16 @@ func (m *MockClient) LoadOrderBook(
 	return a.Get(0).(OrderBookSummary), a.Error(1)
 }
 
+// LoadPaths is a mocking a method
+func (m *MockClient) LoadPaths(
+	sourceAccount, destinationAccount string,
+	destinationAsset Asset,
+	destinationAmount string,
+) (paths PathsPage, err error) {
+	a := m.Called(sourceAccount, destinationAccount, destinationAsset, destinationAmount)
+	return a.Get(0).(PathsPage), a.Error(1)
+}
+
 // LoadTransaction is a mocking a method
 func (m *MockClient) LoadTransaction(transactionID string) (transaction Transaction, err error) {
 	a := m.Called(transactionID)
@@ -113,6 +123,14 @@ func (m *MockClient) LoadAccountTransactions(accountId string, params ...interfa
 	return a.Get(0).(TransactionsPage), a.Error(1)
 }
 
+// LoadAccountPayments is a mocking a method
+func (m *MockClient) LoadAccountPayments(accountID string, params ...interface{}) (payments PaymentsPage, err error) {
+	args := []interface{}{accountID}
+	args = append(args, params...)
+	a := m.Called(args...)
+	return a.Get(0).(PaymentsPage), a.Error(1)
+}
+
 // SequenceForAccount is a mocking a method
 func (m *MockClient) SequenceForAccount(accountID string) (xdr.SequenceNumber, error) {
 	a := m.Called(accountID)
@@ -111,6 +111,24 @@ type TransactionsPage struct {
 	} `json:"_embedded"`
 }
 
+// PathsPage returns a list of payment paths
+type PathsPage struct {
+	Links    hal.Links `json:"_links"`
+	Embedded struct {
+		Records []Path `json:"records"`
+	} `json:"_embedded"`
+}
+
+// Deprecated: use protocols/horizon instead
+type Path = hProtocol.Path
+
+type PaymentsPage struct {
+	Links    hal.Links `json:"_links"`
+	Embedded struct {
+		Records []Payment `json:"records"`
+	} `json:"_embedded"`
+}
+
 type Payment struct {
 	ID          string `json:"id"`
 	Type        string `json:"type"`
@@ -146,6 +164,12 @@ type Payment struct {
 	AssetIssuer string `json:"asset_issuer"`
 	Amount      string `json:"amount"`
 
+	// path_payment fields
+	SourceAmount      string `json:"source_amount"`
+	SourceAssetType   string `json:"source_asset_type"`
+	SourceAssetCode   string `json:"source_asset_code"`
+	SourceAssetIssuer string `json:"source_asset_issuer"`
+
 	// transaction fields
 	TransactionHash string `json:"transaction_hash"`
 	Memo            struct {
@@ -41,6 +41,36 @@ func (h *AuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Decrypt attachment
+	if authRequest.Encryption != "" {
+		if h.EncryptionKey == "" {
+			h.writeJSON(w, ErrorResponse{
+				Code:    "invalid_request",
+				Message: "Encryption is not supported",
+			}, http.StatusBadRequest)
+			return
+		}
+
+		var encryptionKey *[32]byte
+		encryptionKey, err = complianceProtocol.ParseEncryptionKey(h.EncryptionKey)
+		if err != nil {
+			h.writeError(w, errors.Wrap(err, "invalid EncryptionKey"))
+			return
+		}
+
+		err = authData.Decrypt(encryptionKey)
+		if err == nil {
+			err = authData.Validate()
+		}
+		if err != nil {
+			h.writeJSON(w, ErrorResponse{
+				Code:    "invalid_data",
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Create response
 	response := &complianceProtocol.AuthResponse{}
 
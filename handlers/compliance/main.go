@@ -65,6 +65,10 @@ type AuthHandler struct {
 	// PersistTransaction save authorized transaction to persistent storage so
 	// memo preimage (attachment) can be fetched when a transaction is sent.
 	PersistTransaction func(data compliance.AuthData) error
+	// EncryptionKey is the hex encoded secret key of the ENCRYPTION_KEY
+	// published in stellar.toml, used to decrypt encrypted attachments.
+	// Requests with encrypted attachments are rejected when empty.
+	EncryptionKey string
 }
 
 var _ Strategy = &CallbackStrategy{}
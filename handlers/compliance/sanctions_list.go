@@ -0,0 +1,229 @@
+package compliance
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// sanctionedEntry is a single entry (individual, entity, vessel...) of a
+// sanctions list.
+type sanctionedEntry struct {
+	ID   string
+	Name string
+	// Normalized names of the entry, including aliases
+	names        []string
+	addresses    [][]string
+	datesOfBirth []dateOfBirth
+}
+
+// sanctionsList is a sanctions list loaded in memory.
+type sanctionsList struct {
+	entries []*sanctionedEntry
+}
+
+// sanctionsListFiles returns the files a sanctions list is loaded from.  The
+// addresses and aliases of CSV lists are loaded from `add.csv` and `alt.csv`
+// files next to the main file, when they exist, like OFAC distributes them.
+func sanctionsListFiles(path string) []string {
+	if strings.ToLower(filepath.Ext(path)) == ".xml" {
+		return []string{path}
+	}
+
+	dir := filepath.Dir(path)
+	return []string{path, filepath.Join(dir, "add.csv"), filepath.Join(dir, "alt.csv")}
+}
+
+// loadSanctionsList loads the sanctions list at `path`, in the XML or CSV
+// layout of the OFAC SDN list depending on the extension of the file.
+func loadSanctionsList(path string) (*sanctionsList, error) {
+	if strings.ToLower(filepath.Ext(path)) == ".xml" {
+		return loadSanctionsListXML(path)
+	}
+	return loadSanctionsListCSV(path)
+}
+
+// sdnList is the root element of the OFAC SDN list in XML
+type sdnList struct {
+	Entries []struct {
+		UID       string `xml:"uid"`
+		FirstName string `xml:"firstName"`
+		LastName  string `xml:"lastName"`
+		Akas      []struct {
+			FirstName string `xml:"firstName"`
+			LastName  string `xml:"lastName"`
+		} `xml:"akaList>aka"`
+		Addresses []struct {
+			Address1        string `xml:"address1"`
+			Address2        string `xml:"address2"`
+			Address3        string `xml:"address3"`
+			City            string `xml:"city"`
+			StateOrProvince string `xml:"stateOrProvince"`
+			PostalCode      string `xml:"postalCode"`
+			Country         string `xml:"country"`
+		} `xml:"addressList>address"`
+		DatesOfBirth []string `xml:"dateOfBirthList>dateOfBirthItem>dateOfBirth"`
+	} `xml:"sdnEntry"`
+}
+
+func loadSanctionsListXML(path string) (*sanctionsList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error opening sanctions list")
+	}
+	defer file.Close()
+
+	var sdn sdnList
+	err = xml.NewDecoder(file).Decode(&sdn)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error decoding sanctions list")
+	}
+
+	list := &sanctionsList{}
+	for _, e := range sdn.Entries {
+		entry := &sanctionedEntry{
+			ID:   e.UID,
+			Name: strings.TrimSpace(e.FirstName + " " + e.LastName),
+		}
+		entry.addName(e.FirstName + " " + e.LastName)
+		for _, aka := range e.Akas {
+			entry.addName(aka.FirstName + " " + aka.LastName)
+		}
+		for _, a := range e.Addresses {
+			entry.addAddress(a.Address1, a.Address2, a.Address3, a.City, a.StateOrProvince, a.PostalCode, a.Country)
+		}
+		for _, value := range e.DatesOfBirth {
+			entry.addDateOfBirth(value)
+		}
+		list.entries = append(list.entries, entry)
+	}
+
+	return list, nil
+}
+
+// OFAC CSV files use `-0-` for empty fields.
+const sdnEmptyField = "-0-"
+
+func loadSanctionsListCSV(path string) (*sanctionsList, error) {
+	list := &sanctionsList{}
+	entries := map[string]*sanctionedEntry{}
+
+	// sdn.csv: ent_num, SDN_Name, SDN_Type, Program, Title, Call_Sign,
+	// Vess_type, Tonnage, GRT, Vess_flag, Vess_owner, Remarks
+	err := readSDNCSV(path, true, func(record []string) {
+		entry := &sanctionedEntry{ID: record[0], Name: record[1]}
+		entry.addName(record[1])
+		if len(record) > 11 {
+			// Dates of birth are in the remarks: `DOB 05 Jan 1962; POB Havana, Cuba`
+			for _, remark := range strings.Split(record[11], ";") {
+				remark = strings.TrimSpace(remark)
+				if strings.HasPrefix(remark, "DOB ") {
+					entry.addDateOfBirth(strings.TrimPrefix(remark, "DOB "))
+				}
+			}
+		}
+		entries[entry.ID] = entry
+		list.entries = append(list.entries, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+
+	// add.csv: ent_num, Add_num, Address, City/State/Province/Postal Code, Country, Add_remarks
+	err = readSDNCSV(filepath.Join(dir, "add.csv"), false, func(record []string) {
+		if entry, ok := entries[record[0]]; ok && len(record) > 4 {
+			entry.addAddress(record[2], record[3], record[4])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// alt.csv: ent_num, alt_num, alt_type, alt_name, alt_remarks
+	err = readSDNCSV(filepath.Join(dir, "alt.csv"), false, func(record []string) {
+		if entry, ok := entries[record[0]]; ok && len(record) > 3 {
+			entry.addName(record[3])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// readSDNCSV calls `fn` for every record of the CSV file at `path`, with empty
+// fields replaced by empty strings.  Files which are not `required` are
+// skipped when they do not exist.
+func readSDNCSV(path string, required bool, fn func(record []string)) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) && !required {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "Error opening sanctions list")
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Error reading %s", filepath.Base(path))
+		}
+
+		// OFAC files end with a SUB (0x1A) character
+		if len(record) < 2 {
+			continue
+		}
+
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+			if record[i] == sdnEmptyField {
+				record[i] = ""
+			}
+		}
+		fn(record)
+	}
+
+	return nil
+}
+
+func (e *sanctionedEntry) addName(name string) {
+	name = normalizeName(name)
+	if name == "" {
+		return
+	}
+	for _, n := range e.names {
+		if n == name {
+			return
+		}
+	}
+	e.names = append(e.names, name)
+}
+
+func (e *sanctionedEntry) addAddress(parts ...string) {
+	tokens := addressTokens(parts...)
+	if len(tokens) > 0 {
+		e.addresses = append(e.addresses, tokens)
+	}
+}
+
+func (e *sanctionedEntry) addDateOfBirth(value string) {
+	if dob, ok := parseDateOfBirth(value); ok {
+		e.datesOfBirth = append(e.datesOfBirth, dob)
+	}
+}
@@ -0,0 +1,203 @@
+package compliance
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	proto "github.com/stellar/go/protocols/compliance"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+)
+
+const (
+	// DefaultSanctionsThreshold is the default minimum score of a match for a
+	// sender to be pending.
+	DefaultSanctionsThreshold = 0.9
+	// DefaultSanctionsPending is the default number of seconds returned in
+	// the `pending` field of pending responses.
+	DefaultSanctionsPending = 3600
+	// DefaultSanctionsReloadInterval is the default minimum time between two
+	// checks of the sanctions list files for changes.
+	DefaultSanctionsReloadInterval = 10 * time.Second
+)
+
+// SanctionsListStrategy screens senders against a local sanctions list in the
+// layout of the OFAC Specially Designated Nationals (SDN) list, so that no
+// sanctions service is needed.
+//
+// Names, addresses and dates of birth from the `sender_info` of the
+// attachment (see compliance.SenderInfo) are normalized and fuzzy-matched
+// against the entries of the list.  When the score of the best match is at
+// least Threshold the transaction is `pending`, so that it can be reviewed by
+// a human, otherwise it is allowed.  Senders without names are allowed.
+//
+// The list is loaded on first use and reloaded when its files change.
+// GetUserData requests are sent to GetUserDataURL like CallbackStrategy does.
+type SanctionsListStrategy struct {
+	// Path is the path of the sanctions list: an XML file (`sdn.xml`) or a
+	// CSV file (`sdn.csv`).  The addresses and aliases of CSV lists are loaded
+	// from `add.csv` and `alt.csv` in the same directory, when they exist.
+	Path string
+	// Threshold is the minimum score, between 0 and 1, of a match for a sender
+	// to be pending.  DefaultSanctionsThreshold is used when 0.
+	Threshold float64
+	// Pending is the number of seconds returned in the `pending` field of
+	// pending responses.  DefaultSanctionsPending is used when 0.
+	Pending int
+	// ReloadInterval is the minimum time between two checks of the list files
+	// for changes.  DefaultSanctionsReloadInterval is used when 0.
+	ReloadInterval time.Duration
+	// GetUserDataURL callback is used like CallbackStrategy.GetUserDataURL.
+	GetUserDataURL string
+
+	mutex     sync.Mutex
+	list      *sanctionsList
+	version   string
+	checkedAt time.Time
+}
+
+// SanctionsCheck performs AML sanctions check of the sender.
+func (s *SanctionsListStrategy) SanctionsCheck(data proto.AuthData, response *proto.AuthResponse) error {
+	list, err := s.loadList()
+	if err != nil {
+		return errors.Wrap(err, "Error loading sanctions list")
+	}
+
+	attachment, err := data.Attachment()
+	if err != nil {
+		return errors.Wrap(err, "Error unmarshalling attachment")
+	}
+
+	senderInfos := []map[string]string{attachment.Transaction.SenderInfo}
+	for _, operation := range attachment.Operations {
+		if len(operation.SenderInfo) > 0 {
+			senderInfos = append(senderInfos, operation.SenderInfo)
+		}
+	}
+
+	threshold := s.threshold()
+	response.TxStatus = proto.AuthStatusOk
+
+	for _, senderInfo := range senderInfos {
+		sender := newSanctionsSender(senderInfo)
+		if len(sender.names) == 0 {
+			continue
+		}
+
+		minNameScore := threshold - dateOfBirthMatchBoost - addressMatchBoost
+		match := list.match(sender, minNameScore)
+		if match == nil || match.Score < threshold {
+			continue
+		}
+
+		log.WithFields(log.F{
+			"sender":     data.Sender,
+			"entry_id":   match.Entry.ID,
+			"entry_name": match.Entry.Name,
+			"name_score": fmt.Sprintf("%.3f", match.NameScore),
+			"score":      fmt.Sprintf("%.3f", match.Score),
+		}).Warn("Sender matches sanctions list")
+
+		response.TxStatus = proto.AuthStatusPending
+		pending := s.Pending
+		if pending == 0 {
+			pending = DefaultSanctionsPending
+		}
+		if pending > response.Pending {
+			response.Pending = pending
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// GetUserData check if user data is required and if so decides
+// whether to allow access to customer data or not.
+func (s *SanctionsListStrategy) GetUserData(data proto.AuthData, response *proto.AuthResponse) error {
+	callbackStrategy := CallbackStrategy{GetUserDataURL: s.GetUserDataURL}
+	return callbackStrategy.GetUserData(data, response)
+}
+
+// Reload loads the sanctions list again, even when its files did not change.
+func (s *SanctionsListStrategy) Reload() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.reload(s.filesVersion())
+}
+
+// loadList returns the sanctions list, loading it again when its files
+// changed.  When reloading fails the previous list is used.
+func (s *SanctionsListStrategy) loadList() (*sanctionsList, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.list == nil {
+		err := s.reload(s.filesVersion())
+		if err != nil {
+			return nil, err
+		}
+		return s.list, nil
+	}
+
+	reloadInterval := s.ReloadInterval
+	if reloadInterval == 0 {
+		reloadInterval = DefaultSanctionsReloadInterval
+	}
+	if time.Since(s.checkedAt) < reloadInterval {
+		return s.list, nil
+	}
+	s.checkedAt = time.Now()
+
+	version := s.filesVersion()
+	if version == s.version {
+		return s.list, nil
+	}
+
+	err := s.reload(version)
+	if err != nil {
+		log.WithField("err", err).Error("Error reloading sanctions list, using the previous one")
+	}
+	return s.list, nil
+}
+
+func (s *SanctionsListStrategy) reload(version string) error {
+	list, err := loadSanctionsList(s.Path)
+	if err != nil {
+		return err
+	}
+
+	s.list = list
+	s.version = version
+	s.checkedAt = time.Now()
+	log.WithFields(log.F{"path": s.Path, "entries": len(list.entries)}).Info("Sanctions list loaded")
+	return nil
+}
+
+// filesVersion returns a string which changes whenever one of the files of
+// the list changes.
+func (s *SanctionsListStrategy) filesVersion() string {
+	var version []string
+	for _, path := range sanctionsListFiles(s.Path) {
+		info, err := os.Stat(path)
+		if err != nil {
+			version = append(version, "-")
+			continue
+		}
+		version = append(version, fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano()))
+	}
+	return strings.Join(version, ",")
+}
+
+func (s *SanctionsListStrategy) threshold() float64 {
+	if s.Threshold == 0 {
+		return DefaultSanctionsThreshold
+	}
+	return s.Threshold
+}
+
+var _ Strategy = &SanctionsListStrategy{}
@@ -0,0 +1,172 @@
+package compliance
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	proto "github.com/stellar/go/protocols/compliance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSDNCSV = `36,"AEROCARIBBEAN AIRLINES",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+2674,"SMITH, John","individual","SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"DOB 05 Jan 1962; POB Havana, Cuba."
+7157,"GARCÍA LÓPEZ, José","individual","SDNTK",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,"DOB circa 1970."
+` + "\x1a"
+
+const testAddCSV = `36,25,-0- ,"Havana",Cuba,-0-
+2674,26,"12 Calle Ocho","Havana",Cuba,-0-
+`
+
+const testAltCSV = `2674,12,"aka","SMYTHE, Johnny",-0-
+`
+
+const testSDNXML = `<?xml version="1.0" standalone="yes"?>
+<sdnList xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns="http://tempuri.org/sdnList.xsd">
+  <publshInformation><Publish_Date>01/02/2018</Publish_Date><Record_Count>2</Record_Count></publshInformation>
+  <sdnEntry>
+    <uid>306</uid>
+    <lastName>BANCO NACIONAL DE CUBA</lastName>
+    <sdnType>Entity</sdnType>
+    <akaList><aka><uid>219</uid><type>a.k.a.</type><category>strong</category><lastName>NATIONAL BANK OF CUBA</lastName></aka></akaList>
+    <addressList><address><uid>199</uid><city>Havana</city><country>Cuba</country></address></addressList>
+  </sdnEntry>
+  <sdnEntry>
+    <uid>2674</uid>
+    <lastName>SMITH</lastName>
+    <firstName>John</firstName>
+    <sdnType>Individual</sdnType>
+    <dateOfBirthList><dateOfBirthItem><uid>1</uid><dateOfBirth>05 Jan 1962</dateOfBirth><mainEntry>true</mainEntry></dateOfBirthItem></dateOfBirthList>
+  </sdnEntry>
+</sdnList>
+`
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func sanctionsCheck(t *testing.T, s *SanctionsListStrategy, senderInfo map[string]string) *proto.AuthResponse {
+	attachment, err := json.Marshal(proto.Attachment{
+		Transaction: proto.Transaction{SenderInfo: senderInfo},
+	})
+	require.NoError(t, err)
+
+	response := &proto.AuthResponse{}
+	err = s.SanctionsCheck(proto.AuthData{Sender: "alice*stellar.org", AttachmentJSON: string(attachment)}, response)
+	require.NoError(t, err)
+	return response
+}
+
+func TestSanctionsListStrategyCSV(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sanctions")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := writeTestFile(t, dir, "sdn.csv", testSDNCSV)
+	writeTestFile(t, dir, "add.csv", testAddCSV)
+	writeTestFile(t, dir, "alt.csv", testAltCSV)
+
+	s := &SanctionsListStrategy{Path: path}
+
+	testCases := []struct {
+		name       string
+		senderInfo map[string]string
+		status     proto.AuthStatus
+	}{
+		{"exact name", map[string]string{"first_name": "John", "last_name": "Smith"}, proto.AuthStatusPending},
+		{"middle name", map[string]string{"first_name": "John", "middle_name": "Paul", "last_name": "Smith"}, proto.AuthStatusPending},
+		{"typo with date of birth", map[string]string{"first_name": "Jon", "last_name": "Smith", "date_of_birth": "1962-01-05"}, proto.AuthStatusPending},
+		{"other date of birth", map[string]string{"first_name": "Jon", "last_name": "Smith", "date_of_birth": "1980-03-10"}, proto.AuthStatusOk},
+		{"alias", map[string]string{"first_name": "Johnny", "last_name": "Smythe"}, proto.AuthStatusPending},
+		{"diacritics", map[string]string{"first_name": "Jose", "last_name": "Garcia Lopez", "date_of_birth": "1970-06-01"}, proto.AuthStatusPending},
+		{"company", map[string]string{"company_name": "Aerocaribbean Airlines", "country": "Cuba"}, proto.AuthStatusPending},
+		{"not listed", map[string]string{"first_name": "Alice", "last_name": "Jones"}, proto.AuthStatusOk},
+		{"no name", map[string]string{"country": "Cuba"}, proto.AuthStatusOk},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := sanctionsCheck(t, s, tc.senderInfo)
+			assert.Equal(t, tc.status, response.TxStatus)
+			if tc.status == proto.AuthStatusPending {
+				assert.Equal(t, DefaultSanctionsPending, response.Pending)
+			}
+		})
+	}
+}
+
+func TestSanctionsListStrategyXML(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sanctions")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := &SanctionsListStrategy{Path: writeTestFile(t, dir, "sdn.xml", testSDNXML), Pending: 60}
+
+	response := sanctionsCheck(t, s, map[string]string{"company_name": "National Bank of Cuba"})
+	assert.Equal(t, proto.AuthStatusPending, response.TxStatus)
+	assert.Equal(t, 60, response.Pending)
+
+	response = sanctionsCheck(t, s, map[string]string{"first_name": "John", "last_name": "Smith", "date_of_birth": "5 Jan 1962"})
+	assert.Equal(t, proto.AuthStatusPending, response.TxStatus)
+
+	response = sanctionsCheck(t, s, map[string]string{"first_name": "Alice", "last_name": "Jones"})
+	assert.Equal(t, proto.AuthStatusOk, response.TxStatus)
+}
+
+func TestSanctionsListStrategyReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sanctions")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := writeTestFile(t, dir, "sdn.csv", testSDNCSV)
+	s := &SanctionsListStrategy{Path: path, ReloadInterval: time.Nanosecond}
+
+	alice := map[string]string{"first_name": "Alice", "last_name": "Jones"}
+	assert.Equal(t, proto.AuthStatusOk, sanctionsCheck(t, s, alice).TxStatus)
+
+	// the list is reloaded when the file changes
+	writeTestFile(t, dir, "sdn.csv", testSDNCSV+"\n9999,\"JONES, Alice\",\"individual\",\"SDGT\"\n")
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, future, future))
+	assert.Equal(t, proto.AuthStatusPending, sanctionsCheck(t, s, alice).TxStatus)
+
+	// the previous list is kept when the new one can't be loaded
+	require.NoError(t, os.Remove(path))
+	assert.Equal(t, proto.AuthStatusPending, sanctionsCheck(t, s, alice).TxStatus)
+
+	// but the list must load the first time
+	s = &SanctionsListStrategy{Path: filepath.Join(dir, "missing.csv")}
+	err = s.SanctionsCheck(proto.AuthData{AttachmentJSON: "{}"}, &proto.AuthResponse{})
+	assert.Error(t, err)
+}
+
+func TestParseDateOfBirth(t *testing.T) {
+	for value, expected := range map[string]dateOfBirth{
+		"1962-01-05":   {Year: 1962, ToYear: 1962, Month: time.January, Day: 5},
+		"05 Jan 1962":  {Year: 1962, ToYear: 1962, Month: time.January, Day: 5},
+		"Jan 1962":     {Year: 1962, ToYear: 1962, Month: time.January},
+		"1962":         {Year: 1962, ToYear: 1962},
+		"circa 1962":   {Year: 1962, ToYear: 1962},
+		"1962 to 1964": {Year: 1962, ToYear: 1964},
+	} {
+		dob, ok := parseDateOfBirth(value)
+		if assert.True(t, ok, value) {
+			assert.Equal(t, expected, dob, value)
+		}
+	}
+
+	_, ok := parseDateOfBirth("unknown")
+	assert.False(t, ok)
+
+	dob, _ := parseDateOfBirth("1963")
+	other, _ := parseDateOfBirth("1962 to 1964")
+	assert.True(t, dob.matches(other))
+	other, _ = parseDateOfBirth("05 Jan 1962")
+	assert.False(t, dob.matches(other))
+}
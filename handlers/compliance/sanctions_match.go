@@ -0,0 +1,391 @@
+package compliance
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Adjustments of the name score of a match depending on the other details
+// of the sender.
+const (
+	dateOfBirthMatchBoost      = 0.1
+	dateOfBirthMismatchPenalty = 0.2
+	addressMatchBoost          = 0.05
+	// addressMatchRatio is the minimum ratio of the words of a listed address
+	// found in the sender address for the addresses to match.
+	addressMatchRatio = 0.5
+)
+
+// sanctionsSender is the normalized sender info screened against a sanctions
+// list.
+type sanctionsSender struct {
+	names       []string
+	address     map[string]bool
+	dateOfBirth *dateOfBirth
+}
+
+// sanctionsMatch is the best match of a sender in a sanctions list.
+type sanctionsMatch struct {
+	Entry     *sanctionedEntry
+	NameScore float64
+	Score     float64
+}
+
+// newSanctionsSender builds the sender to screen from the `sender_info` of an
+// attachment, which fields are the ones of compliance.SenderInfo.
+func newSanctionsSender(info map[string]string) sanctionsSender {
+	var sender sanctionsSender
+
+	for _, name := range []string{
+		info["first_name"] + " " + info["middle_name"] + " " + info["last_name"],
+		info["first_name"] + " " + info["last_name"],
+		info["company_name"],
+	} {
+		name = normalizeName(name)
+		if name != "" && !containsString(sender.names, name) {
+			sender.names = append(sender.names, name)
+		}
+	}
+
+	sender.address = map[string]bool{}
+	for _, token := range addressTokens(info["address"], info["city"], info["province"], info["postal_code"], info["country"]) {
+		sender.address[token] = true
+	}
+
+	if dob, ok := parseDateOfBirth(info["date_of_birth"]); ok {
+		sender.dateOfBirth = &dob
+	}
+
+	return sender
+}
+
+// match returns the entry of the list best matching `sender`.  Entries which
+// names score below `minNameScore` are not considered.  It returns nil when
+// no entry is considered.
+func (l *sanctionsList) match(sender sanctionsSender, minNameScore float64) *sanctionsMatch {
+	var best *sanctionsMatch
+
+	for _, entry := range l.entries {
+		nameScore := 0.0
+		for _, senderName := range sender.names {
+			for _, name := range entry.names {
+				if jaroWinklerBound(senderName, name) < minNameScore {
+					continue
+				}
+				if score := jaroWinkler(senderName, name); score > nameScore {
+					nameScore = score
+				}
+			}
+		}
+		if nameScore < minNameScore {
+			continue
+		}
+
+		score := nameScore
+		if sender.dateOfBirth != nil && len(entry.datesOfBirth) > 0 {
+			if sender.dateOfBirth.matchesAny(entry.datesOfBirth) {
+				score += dateOfBirthMatchBoost
+			} else {
+				score -= dateOfBirthMismatchPenalty
+			}
+		}
+		if sender.matchesAddress(entry.addresses) {
+			score += addressMatchBoost
+		}
+		score = clamp(score)
+
+		if best == nil || score > best.Score {
+			best = &sanctionsMatch{Entry: entry, NameScore: nameScore, Score: score}
+		}
+	}
+
+	return best
+}
+
+func (sender sanctionsSender) matchesAddress(addresses [][]string) bool {
+	if len(sender.address) == 0 {
+		return false
+	}
+
+	for _, address := range addresses {
+		found := 0
+		for _, token := range address {
+			if sender.address[token] {
+				found++
+			}
+		}
+		if float64(found)/float64(len(address)) >= addressMatchRatio {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeName returns the lower case words of `name` without diacritics,
+// sorted so that `SMITH, John` and `John Smith` are the same.
+func normalizeName(name string) string {
+	words := normalizeWords(name)
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// addressTokens returns the distinct normalized words of the parts of an
+// address.
+func addressTokens(parts ...string) []string {
+	var tokens []string
+	for _, part := range parts {
+		for _, word := range normalizeWords(part) {
+			if !containsString(tokens, word) {
+				tokens = append(tokens, word)
+			}
+		}
+	}
+	return tokens
+}
+
+func normalizeWords(s string) []string {
+	return strings.FieldsFunc(strings.Map(func(r rune) rune {
+		r = foldRune(unicode.ToLower(r))
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s), unicode.IsSpace)
+}
+
+// diacritics maps the latin letters with diacritics to their base letter.
+var diacritics = map[rune]rune{}
+
+func init() {
+	for base, letters := range map[rune]string{
+		'a': "àáâãäåāăą",
+		'c': "çćĉċč",
+		'd': "ďđ",
+		'e': "èéêëēĕėęě",
+		'g': "ĝğġģ",
+		'h': "ĥħ",
+		'i': "ìíîïĩīĭįı",
+		'j': "ĵ",
+		'k': "ķ",
+		'l': "ĺļľŀł",
+		'n': "ñńņňŉ",
+		'o': "òóôõöøōŏő",
+		'r': "ŕŗř",
+		's': "śŝşšșß",
+		't': "ţťŧț",
+		'u': "ùúûüũūŭůűų",
+		'w': "ŵ",
+		'y': "ýÿŷ",
+		'z': "źżž",
+	} {
+		for _, letter := range letters {
+			diacritics[letter] = base
+		}
+	}
+}
+
+func foldRune(r rune) rune {
+	if base, ok := diacritics[r]; ok {
+		return base
+	}
+	return r
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of `a` and `b`, between 0
+// (no similarity) and 1 (same strings).
+func jaroWinkler(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := maxInt(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		from, to := maxInt(0, i-window), minInt(len(rb), i+window+1)
+		for j := from; j < to; j++ {
+			if matchedB[j] || ra[i] != rb[j] {
+				continue
+			}
+			matchedA[i], matchedB[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < minInt(4, minInt(len(ra), len(rb))) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// jaroWinklerBound returns the maximum Jaro-Winkler similarity of strings of
+// the lengths of `a` and `b`, so that most names are skipped without being
+// compared.
+func jaroWinklerBound(a, b string) float64 {
+	la, lb := float64(utf8.RuneCountInString(a)), float64(utf8.RuneCountInString(b))
+	shortest := la
+	if lb < shortest {
+		shortest = lb
+	}
+
+	jaro := (shortest/la + shortest/lb + 1) / 3
+	return jaro + 0.4*(1-jaro)
+}
+
+// dateOfBirth is a date of birth, which may be only partially known: month
+// and day are 0 when unknown and years between `Year` and `ToYear` match
+// when only a range of years is known.
+type dateOfBirth struct {
+	Year   int
+	ToYear int
+	Month  time.Month
+	Day    int
+}
+
+var dateOfBirthLayouts = []struct {
+	layout string
+	month  bool
+	day    bool
+}{
+	{"2006-01-02", true, true},
+	{"02 Jan 2006", true, true},
+	{"2 Jan 2006", true, true},
+	{"January 2, 2006", true, true},
+	{"Jan 2006", true, false},
+	{"January 2006", true, false},
+}
+
+// parseDateOfBirth parses dates of birth in ISO 8601 and in the formats of
+// the OFAC SDN list: `05 Jan 1962`, `Jan 1962`, `1962`, `circa 1962` and
+// `1962 to 1964`.
+func parseDateOfBirth(value string) (dateOfBirth, bool) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSpace(strings.TrimPrefix(value, "circa"))
+	if value == "" {
+		return dateOfBirth{}, false
+	}
+
+	for _, l := range dateOfBirthLayouts {
+		t, err := time.Parse(l.layout, value)
+		if err != nil {
+			continue
+		}
+		dob := dateOfBirth{Year: t.Year(), ToYear: t.Year()}
+		if l.month {
+			dob.Month = t.Month()
+		}
+		if l.day {
+			dob.Day = t.Day()
+		}
+		return dob, true
+	}
+
+	years := strings.Split(value, " to ")
+	from, err := strconv.Atoi(strings.TrimSpace(years[0]))
+	if err != nil {
+		return dateOfBirth{}, false
+	}
+	to := from
+	if len(years) == 2 {
+		to, err = strconv.Atoi(strings.TrimSpace(years[1]))
+		if err != nil || to < from {
+			return dateOfBirth{}, false
+		}
+	}
+
+	return dateOfBirth{Year: from, ToYear: to}, true
+}
+
+// matches returns true when `d` and `other` can be the same date.
+func (d dateOfBirth) matches(other dateOfBirth) bool {
+	if d.ToYear < other.Year || other.ToYear < d.Year {
+		return false
+	}
+	if d.Month != 0 && other.Month != 0 && d.Month != other.Month {
+		return false
+	}
+	if d.Day != 0 && other.Day != 0 && d.Day != other.Day {
+		return false
+	}
+	return true
+}
+
+func (d dateOfBirth) matchesAny(dates []dateOfBirth) bool {
+	for _, other := range dates {
+		if d.matches(other) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func clamp(score float64) float64 {
+	if score < 0 {
+		return 0
+	}
+	if score > 1 {
+		return 1
+	}
+	return score
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
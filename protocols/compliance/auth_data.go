@@ -20,7 +20,8 @@ func (d AuthData) Attachment() (attachment Attachment, err error) {
 	return
 }
 
-// Validate checks if fields are of required form:
+// Validate checks if fields are of required form. Encrypted attachments must
+// be decrypted before being validated.
 //  * `Sender` field is valid address
 //  * `Tx` is valid and it's memo_hash equals sha256 hash of attachment preimage
 //  * `Attachment` is valid JSON
@@ -31,20 +32,22 @@ func (d AuthData) Validate() error {
 		return err
 	}
 
-	// Check if Tx is a valid transaction
-	var tx xdr.Transaction
-	err = xdr.SafeUnmarshalBase64(d.Tx, &tx)
-	if err != nil {
-		return errors.New("Tx is invalid")
+	if d.EncryptedAttachment != "" {
+		return errors.New("Attachment is encrypted")
 	}
 
-	if tx.Memo.Hash == nil {
-		return errors.New("Memo.Hash is nil")
+	if d.AttachmentJSON == "" {
+		return errors.New("attachment: non zero value required")
+	}
+
+	memoHash, err := d.memoHash()
+	if err != nil {
+		return err
 	}
 
 	// Check if Memo.Hash is sha256 hash of attachment preimage
 	attachmentPreimageHashBytes := d.AttachmentPreimageHash()
-	memoBytes := [32]byte(*tx.Memo.Hash)
+	memoBytes := [32]byte(*memoHash)
 	if attachmentPreimageHashBytes != memoBytes {
 		return errors.New("Attachment preimage hash does not equal Memo.Hash in Tx")
 	}
@@ -52,6 +55,44 @@ func (d AuthData) Validate() error {
 	return nil
 }
 
+// ValidateEncrypted checks the fields of data which attachment is encrypted
+// like Validate does, except the memo hash of `Tx` which can be checked only
+// once the attachment is decrypted.
+func (d AuthData) ValidateEncrypted() error {
+	valid, err := govalidator.ValidateStruct(d)
+
+	if !valid {
+		return err
+	}
+
+	if d.EncryptedAttachment == "" {
+		return errors.New("encrypted_attachment: non zero value required")
+	}
+
+	if d.AttachmentJSON != "" {
+		return errors.New("attachment must be empty when encrypted")
+	}
+
+	_, err = d.memoHash()
+	return err
+}
+
+// memoHash returns the memo hash of `Tx`.
+func (d AuthData) memoHash() (*xdr.Hash, error) {
+	// Check if Tx is a valid transaction
+	var tx xdr.Transaction
+	err := xdr.SafeUnmarshalBase64(d.Tx, &tx)
+	if err != nil {
+		return nil, errors.New("Tx is invalid")
+	}
+
+	if tx.Memo.Hash == nil {
+		return nil, errors.New("Memo.Hash is nil")
+	}
+
+	return tx.Memo.Hash, nil
+}
+
 // AttachmentPreimageHash returns sha-256 hash of memo preimage.
 func (d AuthData) AttachmentPreimageHash() [32]byte {
 	return sha256.Sum256([]byte(d.AttachmentJSON))
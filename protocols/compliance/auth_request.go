@@ -15,11 +15,13 @@ import (
 func (r *AuthRequest) Populate(request *http.Request) *AuthRequest {
 	r.DataJSON = request.PostFormValue("data")
 	r.Signature = request.PostFormValue("sig")
+	r.Encryption = request.PostFormValue("encryption")
 	return r
 }
 
 // Validate is using govalidator to check if fields are valid and also
-// runs Validate method on authData.
+// runs Validate method on authData, or ValidateEncrypted when the attachment
+// is encrypted.
 // This method only performs data validation. You should also call
 // VerifySignature to confirm that signature is valid.
 func (r *AuthRequest) Validate() error {
@@ -36,7 +38,14 @@ func (r *AuthRequest) Validate() error {
 	}
 
 	// Validate DataJSON
-	err = authData.Validate()
+	switch r.Encryption {
+	case "":
+		err = authData.Validate()
+	case EncryptionNaclBox:
+		err = authData.ValidateEncrypted()
+	default:
+		return errors.New("Unsupported encryption: " + r.Encryption)
+	}
 	if err != nil {
 		return errors.New("Invalid Data: " + err.Error())
 	}
@@ -85,8 +94,12 @@ func (r *AuthRequest) Data() (data AuthData, err error) {
 
 // ToURLValues returns AuthData encoded as url.Values.
 func (r *AuthRequest) ToURLValues() url.Values {
-	return url.Values{
+	values := url.Values{
 		"data": []string{r.DataJSON},
 		"sig":  []string{r.Signature},
 	}
+	if r.Encryption != "" {
+		values.Set("encryption", r.Encryption)
+	}
+	return values
 }
@@ -0,0 +1,124 @@
+package compliance
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/stellar/go/support/errors"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// EncryptionNaclBox is the value of AuthRequest.Encryption when the
+// attachment is encrypted using NaCl box (curve25519, xsalsa20 and poly1305).
+const EncryptionNaclBox = "nacl_box"
+
+const (
+	encryptionKeyLength   = 32
+	encryptionNonceLength = 24
+)
+
+// GenerateEncryptionKey generates a new random key pair used to encrypt
+// attachments. Both keys are hex encoded: the public key should be published
+// as ENCRYPTION_KEY in stellar.toml and the secret key kept by the receiving
+// compliance server.
+func GenerateEncryptionKey() (publicKey, secretKey string, err error) {
+	public, secret, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", errors.Wrap(err, "Error generating key")
+	}
+	return hex.EncodeToString(public[:]), hex.EncodeToString(secret[:]), nil
+}
+
+// ParseEncryptionKey decodes a hex encoded encryption key, public or secret.
+func ParseEncryptionKey(key string) (*[32]byte, error) {
+	bytes, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, errors.New("Encryption key is not hex encoded")
+	}
+	if len(bytes) != encryptionKeyLength {
+		return nil, errors.New("Encryption key must be 32 bytes long")
+	}
+
+	var parsed [32]byte
+	copy(parsed[:], bytes)
+	return &parsed, nil
+}
+
+// EncryptionPublicKey returns the hex encoded public key of a hex encoded
+// secret encryption key.
+func EncryptionPublicKey(secretKey string) (string, error) {
+	secret, err := ParseEncryptionKey(secretKey)
+	if err != nil {
+		return "", err
+	}
+
+	var public [32]byte
+	curve25519.ScalarBaseMult(&public, secret)
+	return hex.EncodeToString(public[:]), nil
+}
+
+// Encrypt encrypts the attachment to `publicKey`, the ENCRYPTION_KEY of the
+// receiver, moving it from `AttachmentJSON` to `EncryptedAttachment`. The
+// attachment is sealed with a new ephemeral key pair: `EncryptedAttachment`
+// is the base64 encoded ephemeral public key (32 bytes), nonce (24 bytes) and
+// box.
+//
+// The memo hash of the transaction is still the hash of the plain attachment
+// so the receiver checks it once the attachment is decrypted.
+func (d *AuthData) Encrypt(publicKey *[32]byte) error {
+	if d.AttachmentJSON == "" {
+		return errors.New("No attachment to encrypt")
+	}
+
+	ephemeralPublic, ephemeralSecret, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return errors.Wrap(err, "Error generating ephemeral key")
+	}
+
+	var nonce [encryptionNonceLength]byte
+	_, err = rand.Read(nonce[:])
+	if err != nil {
+		return errors.Wrap(err, "Error generating nonce")
+	}
+
+	sealed := append(ephemeralPublic[:], nonce[:]...)
+	sealed = box.Seal(sealed, []byte(d.AttachmentJSON), &nonce, publicKey, ephemeralSecret)
+
+	d.EncryptedAttachment = base64.StdEncoding.EncodeToString(sealed)
+	d.AttachmentJSON = ""
+	return nil
+}
+
+// Decrypt decrypts `EncryptedAttachment` using `secretKey`, the secret key of
+// the published ENCRYPTION_KEY, and moves it to `AttachmentJSON`. Call
+// Validate afterwards to check the attachment matches the transaction memo.
+func (d *AuthData) Decrypt(secretKey *[32]byte) error {
+	if d.EncryptedAttachment == "" {
+		return errors.New("Attachment is not encrypted")
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(d.EncryptedAttachment)
+	if err != nil {
+		return errors.New("Encrypted attachment is not base64 encoded")
+	}
+
+	if len(sealed) < encryptionKeyLength+encryptionNonceLength+box.Overhead {
+		return errors.New("Encrypted attachment is too short")
+	}
+
+	var ephemeralPublic [encryptionKeyLength]byte
+	var nonce [encryptionNonceLength]byte
+	copy(ephemeralPublic[:], sealed[:encryptionKeyLength])
+	copy(nonce[:], sealed[encryptionKeyLength:encryptionKeyLength+encryptionNonceLength])
+
+	attachment, ok := box.Open(nil, sealed[encryptionKeyLength+encryptionNonceLength:], &nonce, &ephemeralPublic, secretKey)
+	if !ok {
+		return errors.New("Cannot decrypt attachment")
+	}
+
+	d.AttachmentJSON = string(attachment)
+	d.EncryptedAttachment = ""
+	return nil
+}
@@ -0,0 +1,107 @@
+package compliance
+
+import (
+	"testing"
+
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAuthData(t *testing.T) *AuthData {
+	attachment := Attachment{
+		Transaction: Transaction{
+			SenderInfo: map[string]string{
+				"first_name": "Bartek",
+			},
+			Route: "jed*stellar.org",
+		},
+	}
+	attachment.GenerateNonce()
+
+	attachHash, err := attachment.Hash()
+	require.NoError(t, err)
+	attachMarshalled, err := attachment.Marshal()
+	require.NoError(t, err)
+
+	txBuilder, err := build.Transaction(
+		build.SourceAccount{"GAW77Z6GPWXSODJOMF5L5BMX6VMYGEJRKUNBC2CZ725JTQZORK74HQQD"},
+		build.Sequence{0},
+		build.TestNetwork,
+		build.MemoHash{attachHash},
+		build.Payment(
+			build.Destination{"GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JZVEE"},
+			build.CreditAmount{"USD", "GAMVF7G4GJC4A7JMFJWLUAEIBFQD5RT3DCB5DC5TJDEKQBBACQ4JZVEE", "20"},
+		),
+	)
+	require.NoError(t, err)
+
+	txB64, err := xdr.MarshalBase64(txBuilder.TX)
+	require.NoError(t, err)
+
+	return &AuthData{
+		Sender:         "bartek*stellar.org",
+		Tx:             txB64,
+		AttachmentJSON: string(attachMarshalled),
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	publicKey, secretKey, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+
+	derived, err := EncryptionPublicKey(secretKey)
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, derived)
+
+	public, err := ParseEncryptionKey(publicKey)
+	require.NoError(t, err)
+	secret, err := ParseEncryptionKey(secretKey)
+	require.NoError(t, err)
+
+	authData := testAuthData(t)
+	attachmentJSON := authData.AttachmentJSON
+
+	require.NoError(t, authData.Encrypt(public))
+	assert.Equal(t, "", authData.AttachmentJSON)
+	assert.NotEqual(t, "", authData.EncryptedAttachment)
+	assert.NotContains(t, authData.EncryptedAttachment, "Bartek")
+
+	assert.EqualError(t, authData.Validate(), "Attachment is encrypted")
+	assert.NoError(t, authData.ValidateEncrypted())
+
+	dataJSON, err := authData.Marshal()
+	require.NoError(t, err)
+
+	authRequest := &AuthRequest{DataJSON: string(dataJSON), Signature: "test", Encryption: EncryptionNaclBox}
+	assert.NoError(t, authRequest.Validate())
+	assert.Equal(t, EncryptionNaclBox, authRequest.ToURLValues().Get("encryption"))
+
+	authRequest.Encryption = "rot13"
+	assert.EqualError(t, authRequest.Validate(), "Unsupported encryption: rot13")
+
+	authRequest.Encryption = ""
+	assert.EqualError(t, authRequest.Validate(), "Invalid Data: Attachment is encrypted")
+
+	// other keys can't decrypt the attachment
+	_, otherSecretKey, err := GenerateEncryptionKey()
+	require.NoError(t, err)
+	otherSecret, err := ParseEncryptionKey(otherSecretKey)
+	require.NoError(t, err)
+	encrypted := *authData
+	assert.EqualError(t, encrypted.Decrypt(otherSecret), "Cannot decrypt attachment")
+
+	require.NoError(t, authData.Decrypt(secret))
+	assert.Equal(t, attachmentJSON, authData.AttachmentJSON)
+	assert.Equal(t, "", authData.EncryptedAttachment)
+	assert.NoError(t, authData.Validate())
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	_, err := ParseEncryptionKey("not hex")
+	assert.EqualError(t, err, "Encryption key is not hex encoded")
+
+	_, err = ParseEncryptionKey("abcd")
+	assert.EqualError(t, err, "Encryption key must be 32 bytes long")
+}
@@ -20,6 +20,9 @@ type AuthRequest struct {
 	DataJSON string `name:"data" valid:"required,json"`
 	// Signature of sending FI
 	Signature string `name:"sig" valid:"required,base64"`
+	// Encryption of the attachment in DataJSON, empty when the attachment is
+	// not encrypted. The only supported value is EncryptionNaclBox.
+	Encryption string `name:"encryption" valid:"optional"`
 }
 
 // AuthData represents how AuthRequest.Data field looks like.
@@ -31,7 +34,10 @@ type AuthData struct {
 	// The transaction that the sender would like to send in XDR format. This transaction is unsigned.
 	Tx string `json:"tx" valid:"required,base64"`
 	// The full text of the attachment the hash of this attachment is included in the transaction.
-	AttachmentJSON string `json:"attachment" valid:"required,json"`
+	// Empty when the attachment is encrypted.
+	AttachmentJSON string `json:"attachment" valid:"optional,json"`
+	// The attachment encrypted to the ENCRYPTION_KEY of the receiver, see AuthData.Encrypt.
+	EncryptedAttachment string `json:"encrypted_attachment,omitempty" valid:"optional,base64"`
 }
 
 // AuthResponse represents response sent by auth server
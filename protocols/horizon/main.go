@@ -15,6 +15,7 @@ import (
 	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/support/render/problem"
 	"github.com/stellar/go/xdr"
 )
 
@@ -104,6 +105,23 @@ func (this *Account) GetData(key string) ([]byte, error) {
 	return base64.StdEncoding.DecodeString(this.Data[key])
 }
 
+// AccountBatchItem is the result of the lookup of one account of a batch.
+// Either Account or Problem is set, depending on Status.
+type AccountBatchItem struct {
+	ID      string     `json:"id"`
+	Status  int        `json:"status"`
+	Account *Account   `json:"account,omitempty"`
+	Problem *problem.P `json:"problem,omitempty"`
+}
+
+// AccountBatch is the collection of results returned by the account batch
+// lookup endpoint, in the order of the requested accounts.
+type AccountBatch struct {
+	Embedded struct {
+		Records []AccountBatchItem `json:"records"`
+	} `json:"_embedded"`
+}
+
 // AccountFlags represents the state of an account's flags
 type AccountFlags struct {
 	AuthRequired  bool `json:"auth_required"`
@@ -129,10 +147,17 @@ type AssetStat struct {
 	} `json:"_links"`
 
 	base.Asset
-	PT          string       `json:"paging_token"`
-	Amount      string       `json:"amount"`
-	NumAccounts int32        `json:"num_accounts"`
-	Flags       AccountFlags `json:"flags"`
+	PT                        string       `json:"paging_token"`
+	Amount                    string       `json:"amount"`
+	NumAccounts               int32        `json:"num_accounts"`
+	NumAuthorizedTrustlines   int32        `json:"num_authorized_trustlines"`
+	NumUnauthorizedTrustlines int32        `json:"num_unauthorized_trustlines"`
+	NumOffers                 int32        `json:"num_offers"`
+	Volume24h                 string       `json:"volume_24h"`
+	TradeCount24h             int32        `json:"trade_count_24h"`
+	LastPrice                 string       `json:"last_price,omitempty"`
+	Flags                     AccountFlags `json:"flags"`
+	HomeDomain                string       `json:"home_domain,omitempty"`
 }
 
 // PagingToken implementation for hal.Pageable
@@ -151,6 +176,31 @@ type Balance struct {
 	base.Asset
 }
 
+// Health represents the health of a horizon instance and of the services it
+// depends on.
+type Health struct {
+	// Status is "ok" when the instance is healthy (or ready), "unavailable"
+	// otherwise.
+	Status string `json:"status"`
+	// Ingest is true if the instance is configured to ingest.
+	Ingest bool `json:"ingest"`
+	// IngestLeader is true if the instance is the one currently ingesting,
+	// ingesting instances electing a single leader among them.
+	IngestLeader        bool          `json:"ingest_leader"`
+	CoreLatestLedger    int32         `json:"core_latest_ledger"`
+	HistoryLatestLedger int32         `json:"history_latest_ledger"`
+	IngestionLag        int32         `json:"ingestion_lag"`
+	Checks              []HealthCheck `json:"checks"`
+}
+
+// HealthCheck is the result of checking one of the services horizon depends
+// on.
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
 // HistoryAccount is a simple resource, used for the account collection actions.
 // It provides only the "TotalOrderID" of the account and its account id.
 type HistoryAccount struct {
@@ -215,10 +265,48 @@ func (this Offer) PagingToken() string {
 
 // OrderBookSummary represents a snapshot summary of a given order book
 type OrderBookSummary struct {
-	Bids    []PriceLevel `json:"bids"`
-	Asks    []PriceLevel `json:"asks"`
-	Selling Asset        `json:"base"`
-	Buying  Asset        `json:"counter"`
+	Bids    []PriceLevel     `json:"bids"`
+	Asks    []PriceLevel     `json:"asks"`
+	Selling Asset            `json:"base"`
+	Buying  Asset            `json:"counter"`
+	Spread  *OrderBookSpread `json:"spread,omitempty"`
+}
+
+// OrderBookDiff represents the price levels of an order book that changed
+// between two ledgers.  Price levels that were removed have a zero amount.
+type OrderBookDiff struct {
+	Bids    []PriceLevel     `json:"bids"`
+	Asks    []PriceLevel     `json:"asks"`
+	Selling Asset            `json:"base"`
+	Buying  Asset            `json:"counter"`
+	Spread  *OrderBookSpread `json:"spread,omitempty"`
+}
+
+// OrderBookSpread represents the best bid and ask of an order book, along with
+// the spread and midprice derived from them.  Fields are empty when a side of
+// the order book has no offers.
+type OrderBookSpread struct {
+	BestBid  string `json:"best_bid,omitempty"`
+	BestAsk  string `json:"best_ask,omitempty"`
+	Spread   string `json:"spread,omitempty"`
+	MidPrice string `json:"mid_price,omitempty"`
+}
+
+// OrderBookTicker is a compact summary of the top of an order book.
+type OrderBookTicker struct {
+	Selling Asset `json:"base"`
+	Buying  Asset `json:"counter"`
+	OrderBookSpread
+	BestBidAmount string `json:"best_bid_amount,omitempty"`
+	BestAskAmount string `json:"best_ask_amount,omitempty"`
+}
+
+// OrderBookTickers is the collection of tickers returned by the order book
+// ticker endpoint, in the order of the requested pairs.
+type OrderBookTickers struct {
+	Embedded struct {
+		Records []OrderBookTicker `json:"records"`
+	} `json:"_embedded"`
 }
 
 // Path represents a single payment path.
@@ -244,9 +332,10 @@ type Price base.Price
 
 // PriceLevel represents an aggregation of offers that share a given price
 type PriceLevel struct {
-	PriceR Price  `json:"price_r"`
-	Price  string `json:"price"`
-	Amount string `json:"amount"`
+	PriceR     Price  `json:"price_r"`
+	Price      string `json:"price"`
+	Amount     string `json:"amount"`
+	Cumulative string `json:"cumulative,omitempty"`
 }
 
 // Root is the initial map of links into the api.
@@ -271,6 +360,20 @@ type Root struct {
 	NetworkPassphrase            string `json:"network_passphrase"`
 	CurrentProtocolVersion       int32  `json:"current_protocol_version"`
 	CoreSupportedProtocolVersion int32  `json:"core_supported_protocol_version"`
+
+	CoreSubmissionBackends []CoreBackend `json:"core_submission_backends"`
+}
+
+// CoreBackend represents the health of a stellar-core instance horizon submits
+// transactions to.
+type CoreBackend struct {
+	URL       string     `json:"url"`
+	Healthy   bool       `json:"healthy"`
+	State     string     `json:"state,omitempty"`
+	Ledger    int32      `json:"ledger,omitempty"`
+	LedgerAge int64      `json:"ledger_age,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
 }
 
 // Signer represents one of an account's signers.
@@ -364,6 +467,22 @@ func (res TradeAggregation) PagingToken() string {
 	return string(res.Timestamp)
 }
 
+// TradeAggregationSeries represents the trade aggregations of a single asset
+// pair, as returned by the trade aggregations batch endpoint.
+type TradeAggregationSeries struct {
+	Base    Asset              `json:"base"`
+	Counter Asset              `json:"counter"`
+	Records []TradeAggregation `json:"records"`
+}
+
+// TradeAggregationBatch is the collection of series returned by the trade
+// aggregations batch endpoint, in the order of the requested pairs.
+type TradeAggregationBatch struct {
+	Embedded struct {
+		Records []TradeAggregationSeries `json:"records"`
+	} `json:"_embedded"`
+}
+
 // Transaction represents a single, successful transaction
 type Transaction struct {
 	Links struct {
@@ -418,6 +537,23 @@ func (res Transaction) PagingToken() string {
 	return res.PT
 }
 
+// TransactionBatchItem is the result of the lookup of one transaction of a
+// batch.  Either Transaction or Problem is set, depending on Status.
+type TransactionBatchItem struct {
+	ID          string       `json:"id"`
+	Status      int          `json:"status"`
+	Transaction *Transaction `json:"transaction,omitempty"`
+	Problem     *problem.P   `json:"problem,omitempty"`
+}
+
+// TransactionBatch is the collection of results returned by the transaction
+// batch lookup endpoint, in the order of the requested transactions.
+type TransactionBatch struct {
+	Embedded struct {
+		Records []TransactionBatchItem `json:"records"`
+	} `json:"_embedded"`
+}
+
 // TransactionResultCodes represent a summary of result codes returned from
 // a single xdr TransactionResult
 type TransactionResultCodes struct {
@@ -451,6 +587,62 @@ func (resp TransactionSuccess) TransactionSuccessToString() (s string) {
 	return
 }
 
+// Webhook represents a registered webhook, along with the filters that
+// determine which operations and effects are delivered to it.  Secret is only
+// populated in the response to the request that creates the webhook.
+type Webhook struct {
+	Links struct {
+		Self        hal.Link `json:"self"`
+		Replay      hal.Link `json:"replay"`
+		DeadLetters hal.Link `json:"dead_letters"`
+	} `json:"_links"`
+
+	ID             string    `json:"id"`
+	PT             string    `json:"paging_token"`
+	URL            string    `json:"url"`
+	Secret         string    `json:"secret,omitempty"`
+	Account        string    `json:"account,omitempty"`
+	AssetType      string    `json:"asset_type,omitempty"`
+	AssetCode      string    `json:"asset_code,omitempty"`
+	AssetIssuer    string    `json:"asset_issuer,omitempty"`
+	OperationTypes []string  `json:"operation_types"`
+	LastLedger     int32     `json:"last_ledger"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (res Webhook) PagingToken() string {
+	return res.PT
+}
+
+// WebhookDeadLetter represents a webhook delivery that was abandoned after
+// reaching the maximum number of attempts.
+type WebhookDeadLetter struct {
+	ID         string          `json:"id"`
+	PT         string          `json:"paging_token"`
+	WebhookID  string          `json:"webhook_id"`
+	EventToken string          `json:"event_paging_token"`
+	Payload    json.RawMessage `json:"payload"`
+	Attempts   int32           `json:"attempts"`
+	LastError  string          `json:"last_error"`
+	CreatedAt  time.Time       `json:"created_at"`
+	FailedAt   time.Time       `json:"failed_at"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (res WebhookDeadLetter) PagingToken() string {
+	return res.PT
+}
+
+// WebhookReplay is the response to a webhook replay request.  The events of
+// the ledgers from FromLedger to ToLedger are enqueued in the background.
+type WebhookReplay struct {
+	WebhookID  string `json:"webhook_id"`
+	Cursor     string `json:"cursor"`
+	FromLedger int32  `json:"from_ledger"`
+	ToLedger   int32  `json:"to_ledger"`
+}
+
 // KeyTypeFromAddress converts the version byte of the provided strkey encoded
 // value (for example an account id or a signer key) and returns the appropriate
 // horizon-specific type name.
@@ -483,6 +675,24 @@ type AccountData struct {
 	Value string `json:"value"`
 }
 
+// AccountDataEntry is a data entry of an account, as listed by the
+// `/accounts/{account_id}/data` endpoint.
+type AccountDataEntry struct {
+	Links struct {
+		Self    hal.Link `json:"self"`
+		History hal.Link `json:"history"`
+	} `json:"_links"`
+
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	PT    string `json:"paging_token"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (res AccountDataEntry) PagingToken() string {
+	return res.PT
+}
+
 // EffectsPage contains page of effects returned by Horizon.
 type EffectsPage struct {
 	Embedded struct {
@@ -0,0 +1,48 @@
+package callbacks
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/stellar/go/services/bridge/internal/db"
+)
+
+// SendPaymentStatus notifies the final status of a payment sent using
+// /payment with an `id` when `callbacks.payment_status` is set: successful
+// payments are sent to `callbacks.receive` and failed ones to
+// `callbacks.error`.  Failed notifications are queued like receive callbacks.
+func (s *Sender) SendPaymentStatus(transaction *db.SentTransaction) error {
+	if !s.config.Callbacks.PaymentStatus || !transaction.PaymentID.Valid {
+		return nil
+	}
+
+	var callbackURL string
+	switch transaction.Status {
+	case db.SentTransactionStatusSuccess:
+		callbackURL = s.config.Callbacks.Receive
+	case db.SentTransactionStatusFailure:
+		callbackURL = s.config.Callbacks.Error
+	}
+	if callbackURL == "" {
+		return nil
+	}
+
+	form := url.Values{
+		"type":           {string(db.QueuedCallbackTypePaymentStatus)},
+		"id":             {transaction.PaymentID.String},
+		"status":         {string(transaction.Status)},
+		"transaction_id": {transaction.TransactionID},
+		"source":         {transaction.Source},
+	}
+	if transaction.Ledger != nil {
+		form.Set("ledger", strconv.FormatInt(int64(*transaction.Ledger), 10))
+	}
+	if transaction.ResultXdr != nil {
+		form.Set("result_xdr", *transaction.ResultXdr)
+	}
+	if transaction.ResultCodes != nil {
+		form.Set("result_codes", *transaction.ResultCodes)
+	}
+
+	return s.Send(db.QueuedCallbackTypePaymentStatus, transaction.PaymentID.String, callbackURL, form)
+}
@@ -0,0 +1,306 @@
+package callbacks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stellar/go/services/bridge/internal/config"
+	"github.com/stellar/go/services/bridge/internal/db"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+)
+
+// DefaultMaxAttempts is the number of times a callback is sent before being
+// given up on, unless `callbacks.max_attempts` is set.
+const DefaultMaxAttempts = 10
+
+const (
+	// callbackTimeout is the timeout of a single callback request.
+	callbackTimeout = 60 * time.Second
+	// retryInterval is the delay before the first retry of a failed callback.
+	// The delay doubles after every failed attempt.
+	retryInterval = 10 * time.Second
+	// maxRetryInterval is the maximum delay between two attempts.
+	maxRetryInterval = time.Hour
+	// queueInterval is the delay between two polls of the queue when no
+	// callback is due.
+	queueInterval = time.Second
+	// queueBatchSize is the number of due callbacks loaded at once.
+	queueBatchSize = 50
+)
+
+// ErrQueued is returned when a callback failed and was queued to be sent
+// again later.
+var ErrQueued = errors.New("Callback queued")
+
+// HTTP represents an http client that a sender can use to make HTTP requests.
+type HTTP interface {
+	Do(req *http.Request) (resp *http.Response, err error)
+}
+
+// Sender sends callbacks signed with `mac_key`.  Failed callbacks are queued
+// in the database and sent again with an exponential backoff.
+type Sender struct {
+	client   HTTP
+	config   *config.Config
+	database db.Database
+	log      *logrus.Entry
+	now      func() time.Time
+}
+
+// NewSender creates a new Sender
+func NewSender(config *config.Config, database db.Database, now func() time.Time) *Sender {
+	return &Sender{
+		client: &http.Client{
+			Timeout: callbackTimeout,
+		},
+		config:   config,
+		database: database,
+		now:      now,
+		log: logrus.WithFields(logrus.Fields{
+			"service": "CallbackSender",
+		}),
+	}
+}
+
+// Run starts sending the queued callbacks as they become due.
+func (s *Sender) Run() {
+	go s.processQueue()
+}
+
+// PostForm sends a form to `url`.  When mac_key is set, the request is signed
+// with a MAC of the body.
+func (s *Sender) PostForm(url string, form url.Values) (*http.Response, error) {
+	return s.post(url, form.Encode())
+}
+
+// Send sends a callback and, if it fails, queues it to be sent again later,
+// in which case ErrQueued is returned.  `reference` identifies the object the
+// callback is about, a callback already queued for the same reference is
+// replaced.
+func (s *Sender) Send(callbackType db.QueuedCallbackType, reference, url string, form url.Values) error {
+	body := form.Encode()
+
+	cause := s.send(url, body)
+	if cause == nil {
+		return nil
+	}
+
+	log := s.log.WithFields(logrus.Fields{"type": callbackType, "reference": reference})
+	log.WithFields(logrus.Fields{"err": cause}).Error("Error sending callback")
+
+	err := s.queue(callbackType, reference, url, body, cause)
+	if err != nil {
+		log.WithFields(logrus.Fields{"err": err}).Error("Error queueing callback")
+		return cause
+	}
+
+	return ErrQueued
+}
+
+// send posts `body` to `url` and returns an error unless the callback responds
+// with `200 OK`.
+func (s *Sender) send(url, body string) error {
+	resp, err := s.post(url, body)
+	if err != nil {
+		return errors.Wrap(err, "Error sending request to callback")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		respBody, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrap(err, "Error reading callback response")
+		}
+
+		s.log.WithFields(logrus.Fields{
+			"url":    url,
+			"status": resp.StatusCode,
+			"body":   string(respBody),
+		}).Error("Error response from callback")
+		return errors.Errorf("Error response from callback: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *Sender) post(url string, strbody string) (*http.Response, error) {
+	req, err := http.NewRequest("POST", url, strings.NewReader(strbody))
+	if err != nil {
+		return nil, errors.Wrap(err, "configure http request failed")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if s.config.MACKey != "" {
+		var rawMAC []byte
+		rawMAC, err = getMAC(s.config.MACKey, []byte(strbody))
+		if err != nil {
+			return nil, errors.Wrap(err, "getMAC failed")
+		}
+
+		encMAC := base64.StdEncoding.EncodeToString(rawMAC)
+		req.Header.Set("X_PAYLOAD_MAC", encMAC)
+		req.Header.Set("X-Payload-Mac", encMAC)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "http request errored")
+	}
+
+	return resp, nil
+}
+
+func getMAC(key string, raw []byte) ([]byte, error) {
+	rawkey, err := strkey.Decode(strkey.VersionByteSeed, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid MAC key")
+	}
+
+	macer := hmac.New(sha256.New, rawkey)
+	macer.Write(raw)
+	return macer.Sum(nil), nil
+}
+
+// queue queues a callback which failed with `cause` for its first retry.
+func (s *Sender) queue(callbackType db.QueuedCallbackType, reference, url, body string, cause error) error {
+	now := s.now()
+
+	callback, err := s.database.GetQueuedCallbackByReference(callbackType, reference)
+	if err != nil {
+		return err
+	}
+
+	if callback == nil {
+		callback = &db.QueuedCallback{
+			Type:      callbackType,
+			Reference: reference,
+			CreatedAt: now,
+		}
+	}
+
+	callback.URL = url
+	callback.Body = body
+	callback.Status = db.QueuedCallbackStatusPending
+	callback.Attempts = 1
+	callback.NextAttemptAt = now.Add(backoff(callback.Attempts))
+	callback.LastError = cause.Error()
+	callback.UpdatedAt = now
+
+	if callback.ID == 0 {
+		return s.database.InsertQueuedCallback(callback)
+	}
+	return s.database.UpdateQueuedCallback(callback)
+}
+
+func (s *Sender) processQueue() {
+	for {
+		callbacks, err := s.database.GetDueQueuedCallbacks(s.now(), queueBatchSize)
+		if err != nil {
+			s.log.WithFields(logrus.Fields{"err": err}).Error("Error loading queued callbacks")
+		}
+
+		for _, callback := range callbacks {
+			s.retry(callback)
+		}
+
+		if len(callbacks) < queueBatchSize {
+			time.Sleep(queueInterval)
+		}
+	}
+}
+
+// retry sends a queued callback again.  Callbacks failing more than
+// `callbacks.max_attempts` times are moved to the dead state and are only sent
+// again when retried by an admin.
+func (s *Sender) retry(callback *db.QueuedCallback) {
+	log := s.log.WithFields(logrus.Fields{
+		"callback":  callback.ID,
+		"type":      callback.Type,
+		"reference": callback.Reference,
+	})
+
+	err := s.send(callback.URL, callback.Body)
+
+	callback.Attempts++
+	callback.UpdatedAt = s.now()
+
+	switch {
+	case err == nil:
+		log.Info("Queued callback delivered")
+		callback.Status = db.QueuedCallbackStatusDelivered
+		callback.LastError = ""
+	case callback.Attempts >= s.maxAttempts():
+		log.WithFields(logrus.Fields{"err": err}).Error("Queued callback failed too many times")
+		callback.Status = db.QueuedCallbackStatusDead
+		callback.LastError = err.Error()
+	default:
+		log.WithFields(logrus.Fields{"err": err}).Warn("Queued callback failed")
+		callback.NextAttemptAt = callback.UpdatedAt.Add(backoff(callback.Attempts))
+		callback.LastError = err.Error()
+	}
+
+	// The callback may have been cancelled while it was sent.
+	updated, err := s.database.UpdatePendingQueuedCallback(callback)
+	if err != nil {
+		log.WithFields(logrus.Fields{"err": err}).Error("Error updating queued callback")
+		return
+	}
+	if !updated {
+		log.Info("Queued callback is not pending anymore")
+		return
+	}
+
+	if callback.Type == db.QueuedCallbackTypeReceive && callback.Status != db.QueuedCallbackStatusPending {
+		s.updateReceivedPaymentStatus(callback)
+	}
+}
+
+// updateReceivedPaymentStatus sets the status of the payment of a receive
+// callback which was delivered or given up on.
+func (s *Sender) updateReceivedPaymentStatus(callback *db.QueuedCallback) {
+	payment, err := s.database.GetReceivedPaymentByOperationID(callback.Reference)
+	if err != nil || payment == nil {
+		s.log.WithFields(logrus.Fields{"id": callback.Reference, "err": err}).Error("Error loading received payment")
+		return
+	}
+
+	if callback.Status == db.QueuedCallbackStatusDelivered {
+		payment.Status = "Success"
+	} else {
+		payment.Status = "Receive callback failed: " + callback.LastError
+	}
+	payment.ProcessedAt = s.now()
+
+	err = s.database.UpdateReceivedPayment(payment)
+	if err != nil {
+		s.log.WithFields(logrus.Fields{"id": callback.Reference, "err": err}).Error("Error updating payment")
+	}
+}
+
+func (s *Sender) maxAttempts() int {
+	if s.config.Callbacks.MaxAttempts > 0 {
+		return s.config.Callbacks.MaxAttempts
+	}
+	return DefaultMaxAttempts
+}
+
+// backoff returns the delay before the attempt following the `attempts`-th
+// one.
+func backoff(attempts int) time.Duration {
+	delay := retryInterval
+	for i := 1; i < attempts && delay < maxRetryInterval; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryInterval {
+		delay = maxRetryInterval
+	}
+	return delay
+}
@@ -0,0 +1,28 @@
+package callbacks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{9, 2560 * time.Second},
+		// the delay is capped at maxRetryInterval
+		{10, time.Hour},
+		{11, time.Hour},
+		{100, time.Hour},
+		{1000000, time.Hour},
+	} {
+		assert.Equal(t, tc.expected, backoff(tc.attempts), "attempts: %d", tc.attempts)
+	}
+}
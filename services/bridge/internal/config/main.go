@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"regexp"
 
+	"github.com/stellar/go/amount"
 	"github.com/stellar/go/keypair"
 )
 
@@ -22,26 +23,49 @@ type Config struct {
 	Database          *Database `valid:"optional"`
 	Accounts          Accounts  `valid:"optional" toml:"accounts"`
 	Callbacks         Callbacks `valid:"optional" toml:"callbacks"`
+	Quotes            Quotes    `valid:"optional" toml:"quotes"`
 }
 
 // Asset represents credit asset
 type Asset struct {
 	Code   string `valid:"required"`
 	Issuer string `valid:"optional"`
+	// ReceivingAccountIDs restricts the receiving accounts accepting the asset.
+	// When empty, the asset is accepted by all receiving accounts.
+	ReceivingAccountIDs []string `valid:"optional" toml:"receiving_account_ids"`
 }
 
 // Accounts contains values of `accounts` config group
 type Accounts struct {
-	AuthorizingSeed    string `valid:"optional" toml:"authorizing_seed"`
-	BaseSeed           string `valid:"optional" toml:"base_seed"`
-	IssuingAccountID   string `valid:"optional" toml:"issuing_account_id"`
-	ReceivingAccountID string `valid:"optional" toml:"receiving_account_id"`
+	AuthorizingSeed        string   `valid:"optional" toml:"authorizing_seed"`
+	BaseSeed               string   `valid:"optional" toml:"base_seed"`
+	IssuingAccountID       string   `valid:"optional" toml:"issuing_account_id"`
+	ReceivingAccountID     string   `valid:"optional" toml:"receiving_account_id"`
+	ReceivingAccountIDs    []string `valid:"optional" toml:"receiving_account_ids"`
+	ChannelSeeds           []string `valid:"optional" toml:"channel_seeds"`
+	ChannelCount           int      `valid:"optional" toml:"channel_count"`
+	ChannelStartingBalance string   `valid:"optional" toml:"channel_starting_balance"`
 }
 
 // Callbacks contains values of `callbacks` config group
 type Callbacks struct {
 	Receive string `valid:"optional"`
 	Error   string `valid:"optional"`
+	// MaxAttempts is the number of times a receive callback is sent before
+	// being given up on.
+	MaxAttempts int `valid:"optional" toml:"max_attempts"`
+	// PaymentStatus enables notifying the final status of payments sent
+	// using /payment with an `id`.
+	PaymentStatus bool `valid:"optional" toml:"payment_status"`
+}
+
+// Quotes contains values of `quotes` config group
+type Quotes struct {
+	// Slippage is the fraction of the source amount added to the send_max of
+	// quotes, 0.01 by default.
+	Slippage *float64 `valid:"optional" toml:"slippage"`
+	// TTL is the number of seconds a quote can be used for, 60 by default.
+	TTL int `valid:"optional" toml:"ttl"`
 }
 
 // Database contains values of `database` config group
@@ -98,6 +122,14 @@ func (c *Config) Validate() (err error) {
 		if !matched {
 			return errors.New("Invalid asset code: " + asset.Code)
 		}
+
+		for _, accountID := range asset.ReceivingAccountIDs {
+			_, err = keypair.Parse(accountID)
+			if err != nil {
+				err = errors.New("Receiving account is invalid for " + asset.Code)
+				return
+			}
+		}
 	}
 
 	var dbURL *url.URL
@@ -116,6 +148,8 @@ func (c *Config) Validate() (err error) {
 		c.Database.URL = dbURL.String()
 	case "postgres":
 		break
+	case "sqlite3":
+		break
 	case "":
 		// Allow to start gateway server with a single endpoint: /payment
 		break
@@ -140,6 +174,39 @@ func (c *Config) Validate() (err error) {
 		}
 	}
 
+	for _, seed := range c.Accounts.ChannelSeeds {
+		var kp keypair.KP
+		kp, err = keypair.Parse(seed)
+		if err != nil {
+			err = errors.New("accounts.channel_seeds is invalid")
+			return
+		}
+		if _, ok := kp.(*keypair.Full); !ok {
+			err = errors.New("accounts.channel_seeds must only contain secret seeds")
+			return
+		}
+	}
+
+	// The maximum number of channels is checked by the submitter when the
+	// channels are created.
+	if c.Accounts.ChannelCount < 0 {
+		err = errors.New("accounts.channel_count must not be negative")
+		return
+	}
+
+	if c.Accounts.ChannelCount > 0 && c.Accounts.BaseSeed == "" {
+		err = errors.New("accounts.channel_count requires accounts.base_seed")
+		return
+	}
+
+	if c.Accounts.ChannelStartingBalance != "" {
+		_, err = amount.Parse(c.Accounts.ChannelStartingBalance)
+		if err != nil {
+			err = errors.New("accounts.channel_starting_balance is invalid")
+			return
+		}
+	}
+
 	if c.Accounts.IssuingAccountID != "" {
 		_, err = keypair.Parse(c.Accounts.IssuingAccountID)
 		if err != nil {
@@ -156,6 +223,14 @@ func (c *Config) Validate() (err error) {
 		}
 	}
 
+	for _, accountID := range c.Accounts.ReceivingAccountIDs {
+		_, err = keypair.Parse(accountID)
+		if err != nil {
+			err = errors.New("accounts.receiving_account_ids is invalid")
+			return
+		}
+	}
+
 	if c.Callbacks.Receive != "" {
 		_, err = url.Parse(c.Callbacks.Receive)
 		if err != nil {
@@ -164,6 +239,21 @@ func (c *Config) Validate() (err error) {
 		}
 	}
 
+	if c.Callbacks.MaxAttempts < 0 {
+		err = errors.New("callbacks.max_attempts is invalid")
+		return
+	}
+
+	if c.Quotes.Slippage != nil && (*c.Quotes.Slippage < 0 || *c.Quotes.Slippage > 1) {
+		err = errors.New("quotes.slippage must be between 0 and 1")
+		return
+	}
+
+	if c.Quotes.TTL < 0 {
+		err = errors.New("quotes.ttl is invalid")
+		return
+	}
+
 	if c.Callbacks.Error != "" {
 		_, err = url.Parse(c.Callbacks.Error)
 		if err != nil {
@@ -174,3 +264,27 @@ func (c *Config) Validate() (err error) {
 
 	return
 }
+
+// ReceivingAccounts returns the IDs of all the accounts the bridge server
+// listens to for received payments: accounts.receiving_account_id,
+// accounts.receiving_account_ids and the receiving accounts of assets.
+func (c *Config) ReceivingAccounts() []string {
+	var accountIDs []string
+	seen := map[string]bool{}
+	add := func(ids ...string) {
+		for _, id := range ids {
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			accountIDs = append(accountIDs, id)
+		}
+	}
+
+	add(c.Accounts.ReceivingAccountID)
+	add(c.Accounts.ReceivingAccountIDs...)
+	for _, asset := range c.Assets {
+		add(asset.ReceivingAccountIDs...)
+	}
+	return accountIDs
+}
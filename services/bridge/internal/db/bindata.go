@@ -5,6 +5,12 @@
 // migrations/02_payment_id.sql
 // migrations/03_transaction_id.sql
 // migrations/04_table_names.sql
+// migrations/05_listener_cursor.sql
+// migrations/06_queued_callback.sql
+// migrations/07_sent_transaction_result_codes.sql
+// migrations/08_quote.sql
+// migrations_mysql/01_init.sql
+// migrations_sqlite3/01_init.sql
 // DO NOT EDIT!
 
 package db
@@ -72,7 +78,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x58\xdb\x6e\xe2\x48\x13\xbe\xfe\x79\x8a\xba\x23\xd1\x6f\x18\xc8\x42\x26\x03\xca\x85\x07\x1c\x0d\x5a\x30\x19\x30\x3b\x13\x69\x25\xab\xb1\x0b\xa7\x15\xbb\xed\x74\xb7\x73\xd8\xa7\x5f\xb5\x0d\xc1\x27\x0e\x49\xd8\x4b\x77\x57\xd7\xe9\xab\xfa\xaa\xa0\xd1\xa8\x35\x1a\x70\x1b\x0a\xe9\x71\x9c\xff\x1c\x83\x4b\x24\x59\x12\x81\xe0\xc6\x41\x54\x6b\x34\x6a\xea\x7e\x18\x07\x11\xba\xb0\xe2\x61\xb0\x15\x78\x42\x2e\x68\xc8\xe0\x5b\xf3\xb2\xd9\xce\x48\x2d\x5f\x21\xf2\x6c\xf5\xbc\x20\x52\x9b\x1b\x16\x08\x49\x24\x06\xc8\xa4\x2d\x69\x80\x61\x2c\xe1\x1a\x5a\xfd\xe4\xca\x0f\x9d\x87\xf2\x29\x75\x7d\xb4\x29\xb3\x25\x27\x4c\x10\x47\xd2\x90\xd9\x02\x85\xd2\x5b\x16\x76\x7c\xaa\x54\x23\x73\x42\x97\x32\x0f\xae\xa1\xbe\xb0\x6e\xae\xea\xfd\x8d\x6d\xe6\x12\xee\xda\x4e\xc8\x56\x21\x0f\x28\xf3\x6c\x21\x39\x65\x9e\x80\x6b\x08\xd9\x5a\xc7\x3d\x3a\x0f\xf6\x2a\x66\xa9\xad\x65\xe8\x52\x54\xf7\x2b\xe2\x0b\xcc\x99\x09\x28\xb3\x03\x14\x82\x78\x89\xc0\x33\xe1\x8c\x32\x2f\x15\xe1\xe1\xb3\x2d\xd0\x89\x39\x95\xaf\x4a\xf9\x6a\xd5\x57\xa9\x54\x79\x32\x49\x80\x3d\x88\xfc\xc8\x13\x8f\x7e\x1f\xac\xd7\x08\x7b\x60\xfc\xb6\x0c\x73\x3e\x9a\x9a\x7d\x98\x3b\xf7\x18\x90\x1e\x34\xfa\x30\x7d\x66\xc8\x7b\x90\xe0\x30\x98\x19\xba\x65\x6c\x05\x61\x74\x03\xe6\xd4\x02\xe3\xf7\x68\x6e\xcd\x37\xfa\xe0\xd7\xc8\xfa\x01\xf3\xc1\x0f\x63\xa2\x2b\x1c\x1c\x22\x89\x1f\x7a\xfd\x5a\xde\xfa\x56\x4b\xc1\x8f\xc1\x74\x32\x31\x4c\x6b\xb7\x17\xe9\x3d\x4c\xcd\xb2\x0e\x18\xcd\xa1\x7e\x3b\xfe\x12\x79\xaa\x92\x22\x1e\x3a\xe8\xc6\x9c\xf8\xe0\x13\xe6\xc5\xc4\xc3\xba\x72\x23\x41\x02\x09\x77\xee\xed\x88\xc8\x7b\xb8\x86\x28\x5e\xfa\xd4\xd1\xf2\xee\x2a\x31\x17\x57\x24\xf6\xa5\x2d\xc9\xd2\x47\x11\x11\x07\x15\xa2\xf5\xc2\xed\x33\x95\xf7\x76\x48\xdd\x0c\x48\xb9\x58\xbd\x90\x47\x76\x40\x3d\x4e\x14\xa0\x62\x13\xa9\xa5\x7f\x1f\x1b\xdb\x38\x53\x27\xde\x82\x5d\x12\x2e\xf1\x21\x9b\xf8\x44\xbe\xa8\x0c\xce\x6a\x00\x00\xd4\x05\x89\x2f\x32\xc1\xc3\x5c\x8c\xc7\x5a\x72\x4a\xa2\xc8\xa7\xe8\xda\x44\x82\xaa\x54\x21\x49\x10\x81\xf2\x36\xf9\x84\x7f\x42\x86\xb5\x73\x95\x12\x7d\x6c\x19\xb3\x1d\x06\xa6\xbf\x4c\x75\x37\x5d\x7b\x54\x88\x8d\xa3\x83\xf4\x09\x5d\x3b\x22\xaf\xaa\xab\x3e\x17\x5c\x51\xdb\x36\xba\x25\xf5\x28\x2b\xc6\x17\x46\x98\x7a\x69\x53\x17\x9c\x7b\xc2\x89\x23\x91\xc3\x13\xe1\xaf\x94\x79\x67\x17\xdd\xee\x79\xe1\x45\x52\x13\x42\x54\xe5\x44\xf5\xf1\x5b\x5a\x8a\xcf\x88\xa7\x7a\x55\x86\x0f\xc8\x8e\x33\xa4\x68\x26\x16\xc7\xc9\x66\x99\xa5\x32\x90\xcb\xce\x39\x0c\x8d\x1b\x7d\x31\xb6\xa0\x6e\x7e\xd1\xeb\xbd\x5e\x49\xa8\x0c\x64\x29\x99\xc7\x21\xb9\x96\xb6\xa9\x6b\x0b\x7c\xdc\xe0\x39\x37\x7e\x2e\x0c\x73\xf0\x1e\x48\x37\x4f\x76\x68\x4e\x42\x9f\x5b\xfa\xcc\x4a\x29\xa3\x9d\x1c\x8c\xcc\xc1\xcc\x48\x1a\xfc\xfb\xdd\xfa\xc8\x9c\xc2\x64\x64\xfe\xa5\x8f\x17\xc6\xdb\xb7\xfe\x7b\xfb\x3d\xd0\x07\x3f\x0c\x68\xef\x0a\x3f\x6f\xf5\x24\x49\x48\x94\x0c\xe1\xfb\xdd\x31\xd9\x48\x7d\x3a\x90\x8c\x37\x8d\x25\xd4\x9a\xd4\x2d\x72\xa7\x48\xa6\xd7\xb6\x68\x3e\xd7\x73\x45\x6d\xdb\x9e\xa3\x4c\xa2\x87\xfc\x23\xd5\x7a\x64\x33\xb4\x5b\x25\xd1\x30\xe6\x0e\x56\x88\x76\x2f\x4b\xa2\xf1\x32\xa0\x52\xbe\xb7\x97\x45\xec\x38\x88\xee\xc1\x67\xa9\xb4\x8f\xae\x4a\x41\x4a\x3f\xe9\x11\xb2\x27\xf4\xc3\x08\xed\x17\x97\x57\xb1\x2e\x47\xa1\xa6\x82\xba\xdd\xd1\xfe\x9b\x5e\x56\x6f\x2a\x5a\x79\xc3\x39\x9b\x02\xf9\xa0\x9a\x32\x23\x94\xa0\xde\xdf\x0c\x4a\x3c\x8f\xf6\xa9\x18\xa1\x5a\xf3\x7f\xcd\x08\xd5\x56\x4f\x92\x84\xcf\x30\xc2\x1e\xb7\x12\x46\x28\xa2\x56\xc1\x08\x25\xaa\xa7\xee\xc6\xc3\x75\x91\x1c\xef\x57\x9a\xab\xa9\x39\x2e\x73\x11\xa4\x12\x83\xe9\x78\x31\x31\x15\x43\xa8\x2d\x68\x53\x85\x0c\x5f\xe4\x13\xf1\xcf\xea\xd5\x0c\x57\xef\xf5\x38\x7a\x8e\x4f\x84\x38\x3f\x44\x68\x27\x72\xbf\xa4\xf6\x28\xf7\xab\xe1\xa8\x76\x7f\x48\x24\x81\x55\xc8\x8f\x58\xf4\x60\xa8\x5b\xfa\x51\xbd\x32\xbd\xbd\x2b\x6f\x79\xd4\xd5\x32\xeb\xdc\x39\xdc\xcc\xa6\x13\x10\xd2\xa5\xac\x5f\x6b\xb5\x6d\xca\xa8\x6c\x8a\x47\xff\x7f\x17\xad\xf6\x55\xa3\xd5\x69\x5c\x74\xa1\x7d\xd5\xbb\xe8\xf4\x3a\x9d\x66\xb7\xfb\xf5\xdb\x55\xfb\xff\xad\x8b\x5a\xeb\xc2\xde\xa2\xb2\x5b\xfe\x6b\xfb\xb2\xd3\x4d\xe4\xff\xb0\xf3\xa9\xd8\xf3\xe6\xea\xeb\xb7\xf4\x4d\x27\x5d\x97\x6d\x46\x02\x14\xbb\x1f\x5c\xb5\x3b\x4a\xfc\xef\xe6\xae\x6c\xee\x5d\x2d\xdf\x97\xce\xf2\x5e\xa9\xf2\x99\x5d\x1f\xb5\xdc\x6a\xa8\xe5\x36\x3e\x6d\x3d\xc0\xb4\xc2\xec\xcb\xa3\x90\x09\xe4\x5d\xbb\xc4\xdc\x38\xaa\xba\xe7\xc6\xd8\x18\x58\x99\x9f\x28\x4d\x81\x7b\xfb\x4d\x83\xb6\x96\xfe\x1c\xd9\x5d\xb0\x7b\x37\x89\xf7\xa5\xb8\xbc\x46\xa8\xa4\xe6\x13\xb6\x4d\x64\x3a\xe6\xb5\xdc\x0c\xd7\x72\xa3\x59\x5b\x8f\x5e\x2d\x37\x6f\xb5\xcc\x74\xd5\x32\x23\xf2\x00\x14\x47\x92\xf8\xa7\xa1\xd8\xc1\x1d\x95\x50\x54\x52\x46\xf1\xdb\x8e\x1e\xf0\x75\xfb\xd3\xd8\x9c\x5b\x33\x7d\x64\x7e\x8c\x0e\x0b\xaa\x93\x49\xa9\x0f\x87\x19\xb5\x95\xd6\xe1\x76\x36\x9a\xe8\xb3\x3b\xf8\xd3\xb8\x53\xa0\x1e\x66\xef\x4c\x21\xc6\x8c\x3e\xc6\x78\xa2\x00\x8a\x86\xaa\x22\x28\xd9\x86\x85\x39\xfa\xb9\x30\xe0\x2c\x53\x2c\x07\xc7\x67\xb1\xa5\xb2\x4c\x61\x9f\x0e\x91\xa2\xdd\xaa\x80\x0e\xb9\xf2\x16\x5f\xf6\xe2\xfd\x11\x9e\xb0\xce\x3e\x12\xd5\xc7\x0a\xad\xd8\x6e\x27\x0c\xe2\x98\x5a\xab\x32\xbf\x27\x88\x5d\x7f\x72\x82\x13\x06\x91\x8f\x12\x13\x4f\xfe\x0d\x00\x00\xff\xff\x7e\x47\x0b\xb3\x11\x15\x00\x00")
+var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc5\x1a\xdb\x52\xa3\x48\xf4\x79\xfd\x8a\x7e\x8b\xd6\xb6\x4e\xe2\x26\xea\x68\xed\x43\xc6\xe0\x4e\x6a\x23\x71\x0c\xd9\x19\xab\xb6\x8a\xea\x40\x27\x52\x12\xc0\x6e\xf0\xb2\x5f\xbf\xa7\x81\x04\x68\x3a\x81\x18\xa6\xc6\x07\x15\xfa\xf4\xb9\xdf\x93\xe3\xe3\x83\xe3\x63\x74\xe7\xf3\x70\xc1\xe8\xe4\xdb\x08\xd9\x24\x24\x33\xc2\x29\xb2\xa3\x65\x00\x67\x07\xe2\x7c\x00\xff\x53\x1b\xcd\x99\xbf\xcc\x00\x5e\x28\xe3\x8e\xef\xa1\xcf\x27\x67\x27\x9d\x1c\xd4\xec\x1d\x05\x0b\x53\x5c\x97\x40\x0e\x26\x9a\x81\x78\x48\x42\xba\xa4\x5e\x68\x86\xce\x92\xfa\x51\x88\xfe\x44\xed\xab\xf8\xc8\xf5\xad\xa7\xf2\x5b\xc7\x76\xa9\xe9\x78\x66\xc8\x88\xc7\x89\x15\x02\x3e\x93\x53\x2e\xf0\x96\x81\x2d\xd7\x11\xa8\xa9\x67\xf9\xb6\xe3\x2d\xe0\xa0\x35\x35\x6e\x2e\x5a\x57\x2b\xda\x9e\x4d\x98\x6d\x5a\xbe\x37\xf7\xd9\x12\x20\x4c\x1e\x32\xf8\xc3\x01\xd2\xf7\x52\x1c\x8f\x14\xf8\x98\x47\x5e\x42\x6b\x06\x98\xa8\x38\x9f\x13\x97\xd3\x02\x19\x40\x60\x2e\x81\x15\xb2\x88\x01\x5e\x09\xf3\x00\x57\x02\xc2\xfc\x57\x60\xd3\x8a\x98\x13\xbe\x0b\xe4\xf3\xf9\x95\x50\xa5\xd0\x93\x4e\x96\xf4\x12\x05\x6e\xb0\xe0\xcf\xee\x15\x32\xde\x03\x78\xd4\x7e\x18\x9a\x3e\x19\x8e\xf5\x2b\x34\x01\x0e\x96\xe4\x12\x1d\x5f\xa1\xf1\xab\x47\xd9\x25\x8a\xed\x70\x7d\xaf\xf5\x0d\x2d\x03\x44\xc3\x1b\xa4\x8f\x0d\x78\x31\x9c\x18\x93\x15\x3e\xf4\x7d\x68\x7c\x45\x93\xeb\xaf\xda\x6d\x5f\xd8\xc1\x02\x73\xb9\x3e\x30\x55\xa4\x9e\x61\x91\xf8\xb8\x1e\xdf\xde\x6a\xba\xb1\x99\x8b\xe4\x1c\xc1\xcd\x12\x0e\x34\x9c\xa0\xd6\xdd\xe8\x53\xb0\x10\x9e\x14\x30\xdf\xa2\x76\xc4\x88\x8b\x5c\xe2\x2d\x22\xd0\x52\x4b\xb0\x11\x5b\x82\x12\x66\x3d\x9a\x01\x09\x1f\x41\x39\x41\x34\x73\x1d\x0b\x17\xd9\x15\x60\x36\x9d\x93\xc8\x05\x57\x21\x33\x97\xf2\x80\x58\x54\x58\xb4\x25\x9d\xbe\x3a\xe1\xa3\xe9\x3b\x76\xce\x48\x05\x59\x17\x3e\x0b\xc0\x56\x0b\x46\x84\x41\xf9\x4a\x52\xa3\xff\x65\xa4\x65\x72\x26\x4c\xac\x85\x9d\x11\x16\xd2\xa7\xbc\xe2\x63\x78\x19\x19\x3a\x3c\x40\xf0\xe3\xd8\x28\xa4\x6f\x61\x6c\x0f\x7d\x3a\x1a\xe1\xf8\x2d\x09\x02\x70\x14\xdb\x24\x21\x12\x9e\x0a\xee\x07\x31\x21\xb8\x8d\x1f\xd1\x7f\xbe\x47\x0f\x8e\x84\x4a\xfa\x23\x43\xbb\xdf\x40\x60\xfc\x5d\x17\x67\xe3\x94\x23\x49\x36\xd7\xe1\x21\x05\x86\x4d\xf0\x34\xee\xb3\xfd\x64\x93\x90\xa5\xb2\x11\xcb\xf2\x23\xf0\x76\x90\xd1\x7a\x24\x0c\x42\x90\x32\xf4\x42\xd8\x3b\xf8\xfa\x61\xef\xec\x48\x12\x3a\x20\x0b\x11\x57\xa1\xff\x44\x3d\xc5\x85\xd3\x5e\x4f\xbe\x11\x05\x90\x53\x54\x6a\x12\xa1\xbd\xd6\xd4\xfa\x52\x59\x65\x32\xdf\xdb\x55\xf6\x1c\xd1\x08\xa8\x59\xc4\x75\x67\xc4\x7a\xda\x4f\x65\x12\xb2\xcc\x1d\x66\x0e\xa8\x41\x76\x88\x10\x28\xa9\x74\xd2\x96\x55\xc2\xe8\x9c\x32\xc8\x62\xb4\xa6\x06\x99\xab\xf2\x3f\x48\x5d\xef\xaa\xf7\x22\x07\x47\xbc\x16\x23\x24\x84\x6c\x1d\x84\x1c\x81\x30\x74\x01\xa0\x03\xed\xa6\x3f\x1d\x19\xa8\x2d\x01\x7a\x40\xc6\x4c\xa1\xeb\x5a\x32\xb9\xe9\x12\x0e\x19\x9b\x31\x30\x5c\xcc\xeb\x8a\x42\xab\x75\x79\xa9\x60\xde\x62\x74\x17\x6f\x69\xc8\xc5\x64\x3b\xef\xe4\x62\x10\x3a\x50\x0b\x9e\x57\x9e\x36\xd1\xbe\x4d\x35\xfd\x7a\x17\x67\x5b\x5d\xd9\x80\x39\x96\x71\x62\xf4\xef\x8d\x24\xfd\x77\xe2\x17\x43\x1d\x6e\xc7\xc9\xfa\xcb\x43\xfa\x4a\x1f\xa3\xdb\xa1\xfe\x4f\x7f\x34\xd5\xd6\xcf\xfd\x1f\xd9\xf3\x75\x1f\x0a\x07\xea\x54\x48\x9f\x52\x6d\x44\x09\x31\x92\x01\x70\x58\x47\x1b\x09\x4f\x15\xca\x58\x63\x94\xcf\x4f\x1c\x5b\x2e\x83\xcf\x91\x1f\xd2\x7d\x33\x00\xa0\xc8\xe2\xbe\x1c\x54\x67\x5d\x39\xa8\xb8\x1f\x31\x8b\x9a\x69\x62\xad\x95\x55\x6d\xf0\x59\xc7\x8b\x4b\x42\xbd\x94\x90\xbb\xf0\x51\x42\x26\x59\x6e\xb8\x56\x16\x89\x70\x4e\x43\xe8\xab\x6c\x55\xc6\xea\x9c\x1e\x15\xa2\xba\x04\xa1\x44\xe6\x70\x1e\x01\x88\x9a\xe9\x5d\xd0\x71\xea\x41\xe4\x37\xc9\x60\x0e\x63\x73\x5c\xa6\x4e\x51\x5f\xe7\x31\x17\x4b\xf2\x56\x0b\x38\x6e\xb5\x9a\x4a\xa7\xf4\x2d\x70\x18\xe5\xfb\xa4\x53\x11\x34\xdb\xf3\x07\xa3\x16\x75\x5e\x80\xb5\x80\xbc\x8b\x81\x61\xbf\x30\x95\xb1\x55\x54\x6a\x3f\xa0\x49\x03\xa6\x6e\x7b\x14\x01\x17\xb7\xbb\xe0\x12\x3b\xaa\x72\xf7\x76\x69\x73\xf5\x2e\xc3\xe6\x87\xa6\x8d\xc9\x69\xed\xa5\xfa\xa7\xbe\xca\x51\xcb\xe6\x2b\x29\xb3\x9e\x25\x53\xe8\x06\xcb\xa1\x1a\xf3\xcf\x2e\x87\x6a\xaa\x8d\x28\x61\x9f\x72\xb8\x85\xad\xb8\x1c\xca\x56\x53\xd4\x43\x1e\x0f\xe6\x99\xd3\xec\x17\x73\x32\xb6\x2c\xe6\x56\xdd\xe4\x07\xbc\xb5\x66\x30\x74\xda\xea\xaa\x5b\xab\x08\xf2\x68\xb6\x74\xc2\x9d\xd3\x22\x8f\x2c\x8b\x52\xbb\xf2\x5a\xda\xf7\x52\x5b\xa8\x20\x49\x3f\x69\x5e\xf5\x5e\xa8\x0b\xa9\xc7\x7c\xb3\x99\x2a\x59\x43\xce\x15\x03\xaf\x38\xdd\x10\xfe\xab\x58\x16\x77\x14\xa1\xbc\xca\x39\x2b\x07\xd9\x0b\x4d\xca\x8d\xa8\xa9\x3c\xe6\xb6\x9c\x28\x4a\x1e\xb0\x3d\x46\x04\x78\xd1\x09\x9a\x4a\x14\x6a\xcc\x3f\x3b\x51\xa8\xa9\x36\xa2\x84\x7d\x12\xc5\x16\xb6\xe2\x44\x21\x5b\x4d\xd9\x38\x17\xe7\x21\x80\x48\x19\x4c\x5d\xa7\x3e\x5b\x89\xaa\xc6\xfa\xa8\xd4\xb0\xa3\x04\xe0\x7a\x3c\x9a\xde\xea\x22\x6d\x88\xad\xcf\xca\x35\xc5\xc0\xf9\x42\xdc\xc3\x96\x7a\x0a\x80\x42\xc6\xe8\xc2\x82\xe1\x92\x1f\xc9\xcc\x97\xca\x57\x33\xdc\x97\xd0\xd6\x62\x5f\x9d\xb5\xb7\xb1\x5f\x0a\xaa\x66\xd8\x2f\xa1\xad\xc5\xbe\xda\x97\xd4\xec\x0f\x48\x48\xd0\x1c\xe6\xfc\xea\xbd\x1c\x1a\xf4\x8d\x7e\xad\x40\x1f\xdf\x3d\x94\x97\x72\x8e\x8d\x73\xdb\xb7\x23\x74\x73\x3f\xbe\x85\x5a\x61\x3b\xde\xd5\x41\xbb\x63\x3a\x9e\x13\x9e\xf0\x67\xf7\xb7\xd3\x76\xe7\xe2\xb8\xdd\x3d\x3e\xed\xa1\xce\xc5\xe5\x69\xf7\xb2\xdb\x3d\xe9\xf5\xce\x3f\x5f\x74\x7e\x6f\x9f\x1e\xb4\x4f\xcd\xcc\x2a\x9b\xe1\xcf\x3b\x67\xdd\x5e\x0c\xff\x87\x59\x54\xc5\x96\x3b\x17\xe7\x9f\x93\x3b\xdd\x64\xbb\x69\x7a\xa0\x13\xbe\xf9\xc2\x45\xa7\x1b\x83\xf7\x4c\x69\xdb\x55\x7d\xe5\xcc\x94\x07\xe1\xca\x2b\xe7\xa6\xec\x0d\x66\x3e\xdb\x57\x23\xb8\x30\xe3\x16\xbf\x12\xf0\xdf\x93\x4d\xde\xb1\x6d\x4d\xb7\x9b\x77\x94\x76\x74\xc2\x3b\xc4\x2a\x0e\x67\x7b\x36\x2c\x76\x68\x38\xde\x98\xe1\xb4\xa9\xc0\xeb\xe5\x17\x96\xb7\x5b\x38\xb7\xb4\xc2\xb9\x11\x0a\xe7\x16\x4d\x45\xa7\xcb\xc9\xb9\xd3\x76\x04\x62\xae\x8e\x9c\x13\x6d\xa4\x5d\x1b\xb9\x05\xfa\x09\xcc\xa6\xdb\xb2\x23\x46\x1d\x9c\x2c\xcb\x37\xc7\x67\x79\x39\xb2\xab\xde\xe3\xcd\x88\xd0\x76\x71\xe3\x81\xf3\x5b\x06\xac\x5a\x55\x60\xc5\x1e\x02\xe7\x96\x0c\xb8\xb0\x23\xc0\xf2\x88\x8f\xcb\x13\x3a\x2e\x0e\xd8\x78\x3d\x3d\xe3\x78\x34\x2e\x5a\x31\x9b\x6f\x37\x5a\x51\xd2\xd5\xd6\x61\x75\x37\xb5\x95\x27\x55\xa1\xc1\xfc\x40\x8a\x0b\xc3\x26\x2e\xcc\x90\x99\xf7\x16\x53\x51\x85\x3b\xd6\x9c\x4e\xf6\x76\xc7\x0d\xd5\xae\x8e\x3b\x6e\x9d\x4d\x76\x53\x71\x79\x30\x89\x53\x42\x41\x61\x99\x22\x13\xc7\xc1\x85\xa9\x00\x17\x9a\x7d\x9c\x36\xf3\xb8\xd0\xc1\xe3\x5c\xbf\x8e\x73\x4d\x37\x2e\x74\xce\x15\x86\xa9\xd9\x0d\xee\x6d\x98\x0d\x75\x5c\x69\x18\x65\xf9\x96\x9f\xcd\xe0\x89\xbe\x67\x9f\x2a\xea\x13\xe3\xbe\x3f\xd4\x3f\xd6\x9a\x48\xa8\xe3\x96\xbb\x3f\x18\xe4\xd0\x2a\xa9\xa3\xbb\xfb\xe1\x6d\xff\xfe\x01\xfd\xad\x3d\x08\x13\x97\x44\x90\x3f\x38\x92\x9e\x9b\x14\x41\x42\xad\x12\x41\x45\xbd\x28\x42\xf6\x51\xdc\x51\x55\x43\x2e\xe7\xfd\x06\x45\x91\x50\xab\x44\x51\x51\xaf\xb4\x46\x95\x08\xa2\x64\x9b\xeb\x8a\x6d\xfe\x52\x81\xca\xbc\xa0\xa9\x3e\x84\x60\x44\x87\x52\x67\x71\xa4\xfc\xcc\x21\xf9\xdd\xac\x55\x00\xa1\x9a\xf5\x15\xa5\x4a\x0b\x94\xf2\x62\x2e\x4d\x47\x9e\x03\x4a\x68\x88\x59\x99\x90\x8a\xef\x12\xed\xb5\x86\xb3\x93\xea\xd1\x4e\x2e\x38\xf9\x3a\xda\xa0\x0b\xc9\x74\x55\x02\x55\xb1\xb2\x96\x2f\x7f\xb0\xbb\x84\xc1\xaf\x95\xea\x63\x8e\x26\x97\x9f\x06\x85\xa8\xe3\x6b\x2a\xf2\xbb\xe6\x2b\x33\xe9\x17\x4c\x69\x56\x58\x49\x31\xd4\x07\xda\x8f\x1d\x56\x57\x31\x7c\x4d\x1a\xe2\xdb\x38\x72\xf6\x9c\x4e\x86\xfa\x5f\x68\x16\x32\x0a\x3d\xf8\xaa\x95\x91\xee\xe5\x44\xda\xf4\x15\x30\x64\xf9\xcb\xc0\xa5\x90\x5a\x04\x6f\xff\x03\xf6\xef\xb3\x73\x2f\x26\x00\x00")
 
 func latestSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -87,7 +93,7 @@ func latestSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "latest.sql", size: 5393, mode: os.FileMode(420), modTime: time.Unix(1530096833, 0)}
+	info := bindataFileInfo{name: "latest.sql", size: 9775, mode: os.FileMode(420), modTime: time.Unix(1792364927, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -172,6 +178,126 @@ func migrations04_table_namesSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations05_listener_cursorSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x8f\xb1\x0e\x82\x30\x18\x84\xf7\x3e\xc5\x3f\x42\x94\xc5\x04\x17\x26\xd4\x0e\x46\x04\xd2\xc0\xc0\xd4\x34\xa5\x81\x46\x69\x49\xf9\x91\xe8\xd3\x4b\x74\xd0\x10\x6e\xbc\xbb\x2f\xb9\x0b\x02\xd8\x74\xba\x71\x02\x15\x94\x3d\x39\x32\x1a\x17\x14\x8a\xf8\x90\x50\xb8\xeb\x01\x95\x51\x8e\xcb\xd1\x0d\xd6\x81\x47\x60\x96\x90\xd2\x8e\x06\xb9\xae\x41\xb6\xc2\x09\x89\xca\xc1\x43\xb8\xa7\x36\x8d\x17\xee\x7d\x48\xb3\x02\xd2\x32\x49\xb6\x9f\x7a\x2f\x9a\x39\xe0\x68\x6f\xca\xac\x00\xbb\x30\x5c\x12\x63\x5f\xcf\x6b\x6a\x2e\x10\x50\x77\x6a\x40\xd1\xf5\x30\x69\x6c\xed\xf8\x75\xe0\x65\x8d\x5a\x40\x39\x3b\x5f\x63\x56\xc1\x85\x56\xe0\xfd\x26\xfa\xc4\x8f\x08\x09\xfe\x4e\x9e\xec\x64\xc8\x89\x65\xf9\xfa\xc9\x88\xbc\x01\x84\x10\x25\xb9\x12\x01\x00\x00")
+
+func migrations05_listener_cursorSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations05_listener_cursorSql,
+		"migrations/05_listener_cursor.sql",
+	)
+}
+
+func migrations05_listener_cursorSql() (*asset, error) {
+	bytes, err := migrations05_listener_cursorSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/05_listener_cursor.sql", size: 274, mode: os.FileMode(420), modTime: time.Unix(1792364283, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations06_queued_callbackSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x92\x4b\x4f\x84\x30\x14\x85\xf7\xfc\x8a\xbb\x1b\x88\x43\x32\x31\x99\xd5\xac\x50\x6a\x42\x44\x18\x09\x24\xce\x8a\x14\xb8\x32\x8d\xbc\x2c\xb7\x8e\xf8\xeb\x65\xac\xe3\x03\x59\x38\xdd\x34\x39\xfd\xda\x73\x73\x7a\x6c\x1b\x2e\x6a\x51\x4a\x4e\x08\x49\x67\x5c\x47\xcc\x89\x19\xc4\xce\x95\xcf\xe0\x59\xa1\xc2\x22\xcd\x79\x55\x65\x3c\x7f\x02\xd3\x80\x71\x89\x02\x32\x51\xf6\x28\x05\xaf\x96\x1f\x0a\x0d\x1d\x42\xbe\xe7\x92\xe7\x84\x12\x5e\xb8\x1c\x44\x53\x9a\x97\x2b\x0b\x82\x30\x86\x20\xf1\x7d\x0d\x4a\x7c\x44\x89\x4d\x3e\x4b\xaf\xd7\x53\x5c\xc9\x0a\x08\x5f\x69\x22\x67\x6d\x31\xcc\xe9\x3d\x71\x52\xfd\xbf\x06\xe1\x44\x58\x77\xd4\x83\x68\x08\xcb\x11\x3d\x1d\x83\xcb\x6e\x9c\xc4\x8f\x61\xa5\xc1\x66\xb4\x49\x3f\xe9\x71\x07\x12\x35\x8e\x3e\x75\x07\x07\x41\xfb\x56\x69\x05\xde\xda\x06\x27\x16\x15\xef\x29\x45\x29\x5b\xf9\x7b\xd6\x2f\x87\xc5\x42\x83\xb9\xc4\x31\xfc\xe2\xbc\xd7\x55\x57\x9c\x7f\x69\x1b\x79\x77\x4e\xb4\x83\x5b\xb6\x03\x53\x14\x96\x56\x93\xc0\xbb\x4f\x18\x98\xc7\x5f\x5c\x7e\x7f\x91\x65\x58\x1b\xe3\xd4\x07\x2f\x70\xd9\xc3\xb4\x0f\xa9\x0e\x3c\x9d\x66\x14\x06\x7f\x9b\xa3\xd1\xe5\x34\xcf\xa3\x85\xfd\xa3\x81\x6e\x7b\x68\x0c\x37\x0a\xb7\xf3\x0d\xdc\x18\xef\x6b\x20\x0f\x9d\xaf\x02\x00\x00")
+
+func migrations06_queued_callbackSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations06_queued_callbackSql,
+		"migrations/06_queued_callback.sql",
+	)
+}
+
+func migrations06_queued_callbackSql() (*asset, error) {
+	bytes, err := migrations06_queued_callbackSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/06_queued_callback.sql", size: 687, mode: os.FileMode(420), modTime: time.Unix(1792364552, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations07_sent_transaction_result_codesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd3\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4e\xcd\x2b\x89\x2f\x29\x4a\xcc\x2b\x4e\x4c\x2e\xc9\xcc\xcf\x53\x70\x74\x71\x51\x28\x4a\x2d\x2e\xcd\x29\x89\x4f\xce\x4f\x49\x2d\x56\x28\x49\xad\x28\x51\x70\x71\x75\x73\x0c\xf5\x09\x51\xf0\x0b\xf5\xf1\xb1\xe6\xe2\xd2\x45\x32\xd0\x25\xbf\x3c\x0f\xbf\x91\x2e\x41\xfe\x01\x28\x66\x5a\x73\x01\x00\xc1\xf0\x0c\x93\x92\x00\x00\x00")
+
+func migrations07_sent_transaction_result_codesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations07_sent_transaction_result_codesSql,
+		"migrations/07_sent_transaction_result_codes.sql",
+	)
+}
+
+func migrations07_sent_transaction_result_codesSql() (*asset, error) {
+	bytes, err := migrations07_sent_transaction_result_codesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/07_sent_transaction_result_codes.sql", size: 146, mode: os.FileMode(420), modTime: time.Unix(1792364753, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations08_quoteSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x93\x31\x6f\x83\x30\x10\x85\x77\xff\x8a\xdb\x02\x6a\x19\x1a\x95\x2c\x99\x68\xa1\x52\x55\x9a\x44\x08\x86\x4c\xc8\x32\xa7\xe0\x01\x9b\xda\x47\x43\xfb\xeb\x6b\xa5\x95\x8a\x08\x8d\x52\x6e\x7c\x7a\x9f\xde\x9d\xcf\x17\x04\x70\xd3\xc8\x83\xe1\x84\x50\xb4\xec\x31\x4b\xa2\x3c\x81\x3c\x7a\x48\x13\x78\xeb\xb4\x53\x3d\x06\xae\x64\x05\xa2\xe6\x86\x0b\x42\x03\xef\xdc\x7c\x48\x75\xf0\x56\xf7\x3e\x6c\xb6\x39\x6c\x8a\x34\xbd\x3d\xd9\xac\xee\x8c\xc0\x92\x0b\xa1\x3b\x45\x13\x48\xb8\x1a\x23\x15\x5a\x92\x8a\x93\xd4\x6a\xc2\xbf\x0c\xc3\x0b\xc0\xdc\xa0\x92\x37\x7f\x60\xe7\x23\x71\x6b\x91\x4a\xa1\x2b\x9c\xb0\xdf\x2d\x7f\xed\x10\x27\x4f\x51\x91\xe6\xb0\x58\x0c\x49\x69\x6d\xe7\x88\xcb\x1d\x9e\xb1\x16\x55\x55\xce\x8f\x1e\xe0\x33\xf3\x7f\x16\x79\xfd\x3b\x9d\x22\x1b\xde\x5f\x65\x6e\x39\xd5\x40\xd8\xd3\x48\x17\x06\xdd\x4f\x74\xad\x13\x90\x6c\xdc\xc2\x78\xd3\xc2\x51\x52\xad\xbb\x6f\x05\x3e\xb5\xc2\x11\x84\x7d\x2b\x0d\xda\xff\x41\xbb\xec\xf9\x35\xca\xf6\xf0\x92\xec\xc1\x93\x95\xcf\xfc\x35\x63\xc1\xe0\x1a\x62\x7d\x54\x2c\xce\xb6\xbb\xe1\x35\xac\xd9\x17\x54\xcc\x7d\x40\x31\x03\x00\x00")
+
+func migrations08_quoteSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations08_quoteSql,
+		"migrations/08_quote.sql",
+	)
+}
+
+func migrations08_quoteSql() (*asset, error) {
+	bytes, err := migrations08_quoteSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/08_quote.sql", size: 817, mode: os.FileMode(420), modTime: time.Unix(1792364927, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_mysql01_initSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x56\x4d\x73\xda\x30\x10\xbd\xfb\x57\xe8\x86\x3d\x85\x69\xca\x94\x5c\x38\x39\xc5\x9d\x61\x4a\x4c\xca\xd8\x87\x9c\x34\x42\xde\x12\x0d\xfe\xaa\x24\x53\xf8\xf7\x95\x0d\xc6\x96\xb0\x9d\x90\xa3\x77\x9f\xf6\xe3\xed\xd3\xca\x93\x09\xfa\x92\xb0\x1d\x27\x12\x50\x98\x5b\x3f\x36\x9e\x1b\x78\x28\x70\x9f\x56\x1e\xe2\x40\x81\x1d\x20\xc2\x39\x39\x25\x90\x4a\x64\x5b\x08\xb1\x08\x6d\xd9\x8e\xa9\x2f\x7f\x1d\x20\x3f\x5c\xad\x90\x1b\x06\x6b\xbc\xf4\xd5\xd9\x67\xcf\x0f\xc6\x0a\x94\xe5\xa0\x22\xb2\x2c\xc5\x0a\x7e\x20\x9c\xbe\x11\x6e\x4f\x67\x33\xe7\x7a\xa8\x44\xe5\x3c\xa3\x20\x84\x4a\x40\x24\x8a\x54\x05\x92\x25\x60\x3f\x1a\x20\xa2\x92\xed\xb0\xcc\xf6\x90\xf6\x87\x12\x92\xc8\x42\xf4\xfb\x25\x27\xa9\x20\xd4\x2c\xe9\xf1\xbb\x83\x16\xde\x4f\x37\x5c\x05\x68\xe4\x7f\x75\x47\x25\xf6\x65\xb3\x7c\x76\x37\xaf\xe8\x97\xf7\x8a\x6c\x16\x39\xa5\x2d\xf4\x97\xbf\x43\xaf\x32\x99\xac\xe0\x76\xb3\x78\x0f\x27\x64\xb7\x2d\x8e\xe5\xcc\x2d\x9d\x57\x51\x9e\x6a\x55\x54\xf3\xfa\x0e\xa9\x03\x3d\x0c\x50\xf1\xed\xc1\x70\x67\x05\xa7\x70\x75\xcf\x0c\xba\x45\xb1\x4d\x98\x94\xc3\x33\x11\x05\xa5\x00\xd1\x2d\xa8\xe6\xb2\x06\xc6\x10\xed\x80\xd7\x82\x31\xbd\x90\x1e\x20\x56\x5c\xe1\x63\xc4\x91\x84\xa3\xd4\x92\x70\x10\x45\x2c\x2b\x9f\x36\x56\x33\x4a\x3d\x06\x53\x69\x26\xee\x12\x8f\x66\x11\x88\x2a\xdb\x07\x66\xdd\xc4\xc6\x45\xca\xfe\x16\x80\xec\xc6\xd4\x31\xd9\x98\x09\x09\x29\x70\x4c\x0b\x2e\x32\x5e\x0d\x96\x50\x9a\x15\x7a\x7d\xb3\x4f\x69\xbc\xc8\x4b\xaa\x07\x07\xa3\xb5\xd3\x24\xee\xa8\x54\xf5\x52\xa8\x58\x94\xc4\xf1\x96\xd0\xfd\xc7\xaf\xb6\x3c\xe5\x8d\x7a\xa6\x86\xb8\x38\xfc\x01\x0e\x69\x4b\x5f\xb7\x5d\xf0\xf8\x76\xd6\xdb\x2c\x3a\xdd\x5a\xcd\x4b\x6d\x24\x23\x4a\xa6\x49\x2e\x85\x7e\x6f\xea\xb1\x3f\x94\x90\x54\x85\xc4\x17\xdc\x10\x6f\x31\x11\x12\x03\xe7\x59\x87\x0e\x29\x87\xf7\x68\xbf\x77\x32\xb7\x42\x33\xc6\x81\x4b\x96\xf1\x95\xcd\xf3\x56\x29\x6d\xe3\x86\xe2\x2a\x44\xd7\xd9\x33\x6d\xd8\xec\xdd\x3e\xdb\xc7\x26\x29\x9d\xe2\xc8\xd4\x73\x70\x91\x44\xef\x9e\xa9\x16\x09\xbe\xa8\xac\x57\xdb\xea\xb6\x49\x96\x56\xdb\xb0\x5f\x14\x2d\xd0\x3d\x01\x31\x49\x34\xa8\x59\x22\x51\x8f\xcb\xf9\xc6\x37\xeb\x70\xea\xdc\x6a\x65\x34\x6a\xd0\x4c\x88\x02\x78\x67\x76\x03\xaf\xd6\xb8\x1a\xf9\x5d\x29\x5a\x47\xee\xc8\x73\x21\x7a\xb8\xd7\x2a\x74\x42\x8e\xbd\x80\x9c\xc8\xb7\x4f\xa9\x1b\x8e\x39\x53\xcb\xf3\x1e\x75\x57\x92\x9a\xb4\x7e\x2d\x16\xd9\xbf\xd4\x5a\x6c\xd6\x2f\x6d\x85\xcd\x75\x8b\xa6\x62\xcd\x67\xac\x55\xcd\x67\x3e\xa6\x9a\xd3\x7c\xab\xe7\xd6\x7f\x15\xa0\x12\xec\xf0\x08\x00\x00")
+
+func migrations_mysql01_initSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_mysql01_initSql,
+		"migrations_mysql/01_init.sql",
+	)
+}
+
+func migrations_mysql01_initSql() (*asset, error) {
+	bytes, err := migrations_mysql01_initSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_mysql/01_init.sql", size: 2288, mode: os.FileMode(420), modTime: time.Unix(1792365290, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations_sqlite301_initSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x56\x4d\x8f\xda\x30\x10\xbd\xe7\x57\xf8\x06\xa8\xa0\x6e\x51\xd9\x0b\xa7\xb4\xa4\x12\x2a\x1b\xb6\x28\x91\xba\x27\xcb\x38\x53\xd6\xda\xc4\x4e\x6d\x87\xc2\xbf\xaf\x43\x02\x38\x0e\xc9\x2e\x57\xe6\x79\x3e\xde\x7b\x33\x61\x32\x41\x9f\x32\xb6\x93\x44\x03\x8a\x73\xef\xfb\x26\xf0\xa3\x00\x45\xfe\xb7\x55\x80\x24\x50\x60\x7b\x48\x70\x4e\x8e\x19\x70\x8d\x86\x1e\x42\x2c\x41\x8c\x6b\xd8\x81\x44\xcf\x9b\xe5\x93\xbf\x79\x41\x3f\x83\x17\xe4\xc7\xd1\x7a\x19\x9a\xe7\x4f\x41\x18\x8d\x0d\x4e\xe4\x60\x92\x32\xc1\xb1\x79\xb1\x27\x92\xbe\x12\x39\x9c\xce\x66\x23\x14\xae\x23\x14\xc6\xab\x15\x8a\xc3\xe5\xaf\x38\x28\xc1\xb9\x14\x14\x94\x32\xa5\x88\x46\x89\xe9\x45\xb3\x0c\x2e\xc0\x13\x82\xec\x18\xdf\x61\x2d\xde\x80\xdf\x4e\x57\xa2\x94\x26\xba\x50\xdd\x71\x2d\x09\x57\x84\xba\x6d\x3d\x7e\x1d\xa1\x45\xf0\xc3\x8f\x57\x11\x1a\x84\x9f\xfd\x81\x37\x9a\x7b\x4d\x2e\x94\x99\x1f\x5b\xcf\xef\xe2\xa2\xa7\x6c\x4f\xf7\x5f\x1e\x9c\xb0\x28\x24\x85\x4b\x78\xf6\xe8\x84\x8b\x6d\xc6\xb4\xee\xe1\x50\x15\x94\x02\x24\x0e\xe2\x3c\xf8\x19\x95\x42\x52\x0e\xb4\x65\x86\x70\xdd\x8a\x02\xdf\x43\x6a\xb4\xc5\x87\x44\x22\x0d\x07\xdd\xa8\x20\x41\x15\xa9\x3e\xc5\x1a\x1a\xb8\x59\x6a\x43\xb5\xac\x61\xe3\x2c\x7b\xd4\x69\xa9\x48\x40\x55\x45\x6d\x60\x5b\xac\x94\x29\x0d\x1c\x24\xa6\x85\x54\x42\x9e\xb4\x22\x94\x8a\xa2\x59\xd2\x66\xd0\x16\xf0\xe3\x7e\x2b\xf2\x92\xc6\xdb\x8c\xb7\xdb\xfa\x5b\x40\x61\xc0\x94\xa4\xe9\x96\xd0\xb7\xfb\x2c\x74\xcc\xaf\xd2\x4f\x1d\x67\x48\xf8\x03\x12\xb8\x65\x8e\x76\xa7\x32\x6d\xcb\xb5\x15\xc9\xb1\xfd\xab\xbb\x44\x4e\x31\x62\x3c\x96\xe5\x5a\x5d\xfa\xbe\x50\x78\x16\xe5\xa1\x84\x71\x93\x16\xd7\xd8\x4e\x47\xa6\x44\x69\x0c\x52\x0a\xc7\x4b\xd7\x6d\x1c\x94\x30\x2a\x81\xf4\x19\xbb\x4f\x86\x32\x5e\x39\x09\x0d\x4b\x12\xc7\x57\xb6\x46\xb6\x44\xcb\x70\x11\xfc\x76\x25\xc2\x15\x17\xd8\x1d\x66\x1d\xb6\xc5\xac\xa0\x63\x77\xf0\x1b\x2e\x10\xe6\xd6\xd6\xda\xdf\x3a\x05\xae\x15\xab\xbd\xc7\xb5\x81\x3b\xf7\xdf\xec\x86\x66\xfc\x74\x72\xbb\x6d\x60\x81\xee\x49\x88\x49\xd6\x80\xba\x97\x8b\x98\xdb\x5d\xed\xe7\xf5\x7a\x4d\x47\x5d\x72\x56\x68\xa6\x54\x01\xf2\xf6\x32\x36\xf1\xe6\xf6\x1a\x6d\xef\x2a\x61\x3d\xb9\xa3\x4e\x4d\x74\xff\xac\xa7\xd4\x19\x39\x74\x02\x72\xa2\x5f\xdb\x5b\xf5\x9e\x87\xe1\x90\x33\x73\xe7\xba\x4f\xc9\xc4\xfa\x52\x2f\xc4\x3f\xee\x2d\x36\xeb\x67\xdb\x53\xf3\xe6\x2f\x0d\x7b\x36\x62\xce\x79\x6c\xc4\xdc\xef\x5c\x23\xe8\xfe\x21\x98\x7b\xff\x01\x65\x87\x21\x52\x3f\x08\x00\x00")
+
+func migrations_sqlite301_initSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations_sqlite301_initSql,
+		"migrations_sqlite3/01_init.sql",
+	)
+}
+
+func migrations_sqlite301_initSql() (*asset, error) {
+	bytes, err := migrations_sqlite301_initSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations_sqlite3/01_init.sql", size: 2111, mode: os.FileMode(420), modTime: time.Unix(1792365290, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -224,11 +350,17 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"latest.sql":                       latestSql,
-	"migrations/01_init.sql":           migrations01_initSql,
-	"migrations/02_payment_id.sql":     migrations02_payment_idSql,
-	"migrations/03_transaction_id.sql": migrations03_transaction_idSql,
-	"migrations/04_table_names.sql":    migrations04_table_namesSql,
+	"latest.sql":                                      latestSql,
+	"migrations/01_init.sql":                          migrations01_initSql,
+	"migrations/02_payment_id.sql":                    migrations02_payment_idSql,
+	"migrations/03_transaction_id.sql":                migrations03_transaction_idSql,
+	"migrations/04_table_names.sql":                   migrations04_table_namesSql,
+	"migrations/05_listener_cursor.sql":               migrations05_listener_cursorSql,
+	"migrations/06_queued_callback.sql":               migrations06_queued_callbackSql,
+	"migrations/07_sent_transaction_result_codes.sql": migrations07_sent_transaction_result_codesSql,
+	"migrations/08_quote.sql":                         migrations08_quoteSql,
+	"migrations_mysql/01_init.sql":                    migrations_mysql01_initSql,
+	"migrations_sqlite3/01_init.sql":                  migrations_sqlite301_initSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -274,10 +406,20 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"latest.sql": &bintree{latestSql, map[string]*bintree{}},
 	"migrations": &bintree{nil, map[string]*bintree{
-		"01_init.sql":           &bintree{migrations01_initSql, map[string]*bintree{}},
-		"02_payment_id.sql":     &bintree{migrations02_payment_idSql, map[string]*bintree{}},
-		"03_transaction_id.sql": &bintree{migrations03_transaction_idSql, map[string]*bintree{}},
-		"04_table_names.sql":    &bintree{migrations04_table_namesSql, map[string]*bintree{}},
+		"01_init.sql":                          &bintree{migrations01_initSql, map[string]*bintree{}},
+		"02_payment_id.sql":                    &bintree{migrations02_payment_idSql, map[string]*bintree{}},
+		"03_transaction_id.sql":                &bintree{migrations03_transaction_idSql, map[string]*bintree{}},
+		"04_table_names.sql":                   &bintree{migrations04_table_namesSql, map[string]*bintree{}},
+		"05_listener_cursor.sql":               &bintree{migrations05_listener_cursorSql, map[string]*bintree{}},
+		"06_queued_callback.sql":               &bintree{migrations06_queued_callbackSql, map[string]*bintree{}},
+		"07_sent_transaction_result_codes.sql": &bintree{migrations07_sent_transaction_result_codesSql, map[string]*bintree{}},
+		"08_quote.sql":                         &bintree{migrations08_quoteSql, map[string]*bintree{}},
+	}},
+	"migrations_mysql": &bintree{nil, map[string]*bintree{
+		"01_init.sql": &bintree{migrations_mysql01_initSql, map[string]*bintree{}},
+	}},
+	"migrations_sqlite3": &bintree{nil, map[string]*bintree{
+		"01_init.sql": &bintree{migrations_sqlite301_initSql, map[string]*bintree{}},
 	}},
 }}
 
@@ -46,6 +46,85 @@ CREATE TABLE gorp_migrations (
 
 ALTER TABLE gorp_migrations OWNER TO bartek;
 
+--
+-- Name: listener_cursor; Type: TABLE; Schema: public; Owner: bartek
+--
+
+CREATE TABLE listener_cursor (
+    account_id character varying(56) NOT NULL,
+    paging_token character varying(255) NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE listener_cursor OWNER TO bartek;
+
+--
+-- Name: queued_callback; Type: TABLE; Schema: public; Owner: bartek
+--
+
+CREATE TABLE queued_callback (
+    id bigint NOT NULL,
+    type character varying(20) NOT NULL,
+    reference character varying(255) NOT NULL,
+    url text NOT NULL,
+    body text NOT NULL,
+    status character varying(20) NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp without time zone NOT NULL,
+    last_error text DEFAULT ''::text NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE queued_callback OWNER TO bartek;
+
+--
+-- Name: queued_callback_id_seq; Type: SEQUENCE; Schema: public; Owner: bartek
+--
+
+CREATE SEQUENCE queued_callback_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER TABLE queued_callback_id_seq OWNER TO bartek;
+
+--
+-- Name: queued_callback_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: bartek
+--
+
+ALTER SEQUENCE queued_callback_id_seq OWNED BY queued_callback.id;
+
+
+--
+-- Name: quote; Type: TABLE; Schema: public; Owner: bartek
+--
+
+CREATE TABLE quote (
+    id character varying(64) NOT NULL,
+    source_account character varying(56) NOT NULL,
+    destination character varying(255) NOT NULL,
+    destination_account character varying(56) NOT NULL,
+    destination_amount character varying(64) NOT NULL,
+    asset_code character varying(12) DEFAULT ''::character varying NOT NULL,
+    asset_issuer character varying(56) DEFAULT ''::character varying NOT NULL,
+    send_asset_code character varying(12) DEFAULT ''::character varying NOT NULL,
+    send_asset_issuer character varying(56) DEFAULT ''::character varying NOT NULL,
+    source_amount character varying(64) NOT NULL,
+    send_max character varying(64) NOT NULL,
+    path text NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    expires_at timestamp without time zone NOT NULL
+);
+
+
+ALTER TABLE quote OWNER TO bartek;
+
 --
 -- Name: received_payment; Type: TABLE; Schema: public; Owner: bartek
 --
@@ -97,7 +176,8 @@ CREATE TABLE sent_transaction (
     ledger bigint,
     envelope_xdr text NOT NULL,
     result_xdr character varying(255) DEFAULT NULL::character varying,
-    payment_id character varying(255) DEFAULT NULL::character varying
+    payment_id character varying(255) DEFAULT NULL::character varying,
+    result_codes text
 );
 
 
@@ -124,6 +204,13 @@ ALTER TABLE senttransaction_id_seq OWNER TO bartek;
 ALTER SEQUENCE senttransaction_id_seq OWNED BY sent_transaction.id;
 
 
+--
+-- Name: queued_callback id; Type: DEFAULT; Schema: public; Owner: bartek
+--
+
+ALTER TABLE ONLY queued_callback ALTER COLUMN id SET DEFAULT nextval('queued_callback_id_seq'::regclass);
+
+
 --
 -- Name: received_payment id; Type: DEFAULT; Schema: public; Owner: bartek
 --
@@ -147,6 +234,33 @@ COPY gorp_migrations (id, applied_at) FROM stdin;
 02_payment_id.sql	2018-04-25 18:24:44.571645+02
 03_transaction_id.sql	2018-04-25 18:24:44.578795+02
 04_table_names.sql	2018-04-25 18:24:44.5814+02
+05_listener_cursor.sql	2018-04-25 18:24:44.5814+02
+06_queued_callback.sql	2018-04-25 18:24:44.5814+02
+07_sent_transaction_result_codes.sql	2018-04-25 18:24:44.5814+02
+08_quote.sql	2018-04-25 18:24:44.5814+02
+\.
+
+
+--
+-- Data for Name: queued_callback; Type: TABLE DATA; Schema: public; Owner: bartek
+--
+
+COPY queued_callback (id, type, reference, url, body, status, attempts, next_attempt_at, last_error, created_at, updated_at) FROM stdin;
+\.
+
+
+--
+-- Name: queued_callback_id_seq; Type: SEQUENCE SET; Schema: public; Owner: bartek
+--
+
+SELECT pg_catalog.setval('queued_callback_id_seq', 1, false);
+
+
+--
+-- Data for Name: quote; Type: TABLE DATA; Schema: public; Owner: bartek
+--
+
+COPY quote (id, source_account, destination, destination_account, destination_amount, asset_code, asset_issuer, send_asset_code, send_asset_issuer, source_amount, send_max, path, created_at, expires_at) FROM stdin;
 \.
 
 
@@ -169,7 +283,7 @@ SELECT pg_catalog.setval('receivedpayment_id_seq', 1, false);
 -- Data for Name: sent_transaction; Type: TABLE DATA; Schema: public; Owner: bartek
 --
 
-COPY sent_transaction (id, transaction_id, status, source, submitted_at, succeeded_at, ledger, envelope_xdr, result_xdr, payment_id) FROM stdin;
+COPY sent_transaction (id, transaction_id, status, source, submitted_at, succeeded_at, ledger, envelope_xdr, result_xdr, payment_id, result_codes) FROM stdin;
 \.
 
 
@@ -188,6 +302,38 @@ ALTER TABLE ONLY gorp_migrations
     ADD CONSTRAINT gorp_migrations_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: listener_cursor listener_cursor_pkey; Type: CONSTRAINT; Schema: public; Owner: bartek
+--
+
+ALTER TABLE ONLY listener_cursor
+    ADD CONSTRAINT listener_cursor_pkey PRIMARY KEY (account_id);
+
+
+--
+-- Name: queued_callback queued_callback_pkey; Type: CONSTRAINT; Schema: public; Owner: bartek
+--
+
+ALTER TABLE ONLY queued_callback
+    ADD CONSTRAINT queued_callback_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: queued_callback queued_callback_type_reference_key; Type: CONSTRAINT; Schema: public; Owner: bartek
+--
+
+ALTER TABLE ONLY queued_callback
+    ADD CONSTRAINT queued_callback_type_reference_key UNIQUE (type, reference);
+
+
+--
+-- Name: quote quote_pkey; Type: CONSTRAINT; Schema: public; Owner: bartek
+--
+
+ALTER TABLE ONLY quote
+    ADD CONSTRAINT quote_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: sent_transaction payment_id_unique; Type: CONSTRAINT; Schema: public; Owner: bartek
 --
@@ -220,6 +366,13 @@ ALTER TABLE ONLY sent_transaction
     ADD CONSTRAINT senttransaction_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: queued_callback_status_next_attempt_at; Type: INDEX; Schema: public; Owner: bartek
+--
+
+CREATE INDEX queued_callback_status_next_attempt_at ON queued_callback USING btree (status, next_attempt_at);
+
+
 --
 -- PostgreSQL database dump complete
 --
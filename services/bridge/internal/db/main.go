@@ -5,20 +5,37 @@ import (
 	"time"
 
 	migrate "github.com/rubenv/sql-migrate"
-	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
 )
 
 //go:generate go-bindata -ignore .+\.go$ -pkg db -o bindata.go ./...
 
-// Migrations represents all of the schema migration
+// Migrations represents all of the schema migration of postgres databases
 var Migrations migrate.MigrationSource = &migrate.AssetMigrationSource{
 	Asset:    Asset,
 	AssetDir: AssetDir,
 	Dir:      "migrations",
 }
 
+// MysqlMigrations represents all of the schema migration of mysql databases
+var MysqlMigrations migrate.MigrationSource = &migrate.AssetMigrationSource{
+	Asset:    Asset,
+	AssetDir: AssetDir,
+	Dir:      "migrations_mysql",
+}
+
+// SqliteMigrations represents all of the schema migration of sqlite3 databases
+var SqliteMigrations migrate.MigrationSource = &migrate.AssetMigrationSource{
+	Asset:    Asset,
+	AssetDir: AssetDir,
+	Dir:      "migrations_sqlite3",
+}
+
 type Database interface {
 	GetLastCursorValue() (cursor *string, err error)
+	GetListenerCursor(accountID string) (*ListenerCursor, error)
+	GetListenerCursors() ([]*ListenerCursor, error)
+	SaveListenerCursor(cursor *ListenerCursor) error
 
 	InsertReceivedPayment(payment *ReceivedPayment) error
 	UpdateReceivedPayment(payment *ReceivedPayment) error
@@ -26,14 +43,47 @@ type Database interface {
 	GetReceivedPaymentByOperationID(operationID string) (*ReceivedPayment, error)
 	GetReceivedPayments(page, limit uint64) ([]*ReceivedPayment, error)
 
+	InsertQueuedCallback(callback *QueuedCallback) error
+	UpdateQueuedCallback(callback *QueuedCallback) error
+	UpdatePendingQueuedCallback(callback *QueuedCallback) (bool, error)
+	GetQueuedCallbackByID(id int64) (*QueuedCallback, error)
+	GetQueuedCallbackByReference(callbackType QueuedCallbackType, reference string) (*QueuedCallback, error)
+	GetQueuedCallbacks(status QueuedCallbackStatus, page, limit uint64) ([]*QueuedCallback, error)
+	GetDueQueuedCallbacks(now time.Time, limit uint64) ([]*QueuedCallback, error)
+
+	InsertQuote(quote *Quote) error
+	GetQuoteByID(id string) (*Quote, error)
+
 	InsertSentTransaction(transaction *SentTransaction) error
 	UpdateSentTransaction(transaction *SentTransaction) error
 	GetSentTransactionByPaymentID(paymentID string) (*SentTransaction, error)
 	GetSentTransactions(page, limit uint64) ([]*SentTransaction, error)
 }
 
-type PostgresDatabase struct {
-	session *db.Session
+// SQLDatabase is a Database stored in one of the supported SQL databases
+type SQLDatabase interface {
+	Database
+	Open(dsn string) error
+	GetDB() *sql.DB
+	// Dialect returns the name of the sql driver used by the database
+	Dialect() string
+	// Migrations returns the schema migrations of the database
+	Migrations() migrate.MigrationSource
+}
+
+// NewDatabase returns a new, not opened, database of the given type: mysql,
+// postgres or sqlite3
+func NewDatabase(databaseType string) (SQLDatabase, error) {
+	switch databaseType {
+	case "mysql":
+		return &MysqlDatabase{}, nil
+	case "postgres":
+		return &PostgresDatabase{}, nil
+	case "sqlite3":
+		return &SqliteDatabase{}, nil
+	default:
+		return nil, errors.New("Invalid database type: " + databaseType)
+	}
 }
 
 // ReceivedPayment represents payment received by the gateway server
@@ -46,6 +96,76 @@ type ReceivedPayment struct {
 	TransactionID string    `db:"transaction_id" json:"transaction_id"`
 }
 
+// ListenerCursor represents the paging token of the last payment of a receiving
+// account processed by the payment listener
+type ListenerCursor struct {
+	AccountID   string    `db:"account_id" json:"account_id"`
+	PagingToken string    `db:"paging_token" json:"paging_token"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// QueuedCallbackType type represents the kind of a queued callback
+type QueuedCallbackType string
+
+const (
+	// QueuedCallbackTypeReceive is a type of callbacks sent to `callbacks.receive`.
+	// The reference of receive callbacks is the ID of the received operation.
+	QueuedCallbackTypeReceive QueuedCallbackType = "receive"
+	// QueuedCallbackTypePaymentStatus is a type of callbacks sent when the status
+	// of a payment sent using /payment changes. The reference of payment status
+	// callbacks is the ID of the payment.
+	QueuedCallbackTypePaymentStatus QueuedCallbackType = "payment_status"
+)
+
+// QueuedCallbackStatus type represents queued callback status
+type QueuedCallbackStatus string
+
+const (
+	// QueuedCallbackStatusPending is a status indicating that callback will be sent at NextAttemptAt
+	QueuedCallbackStatusPending QueuedCallbackStatus = "pending"
+	// QueuedCallbackStatusDelivered is a status indicating that callback has been successfully sent
+	QueuedCallbackStatusDelivered QueuedCallbackStatus = "delivered"
+	// QueuedCallbackStatusDead is a status indicating that callback failed too many times and will not be sent again
+	QueuedCallbackStatusDead QueuedCallbackStatus = "dead"
+	// QueuedCallbackStatusCancelled is a status indicating that callback has been cancelled by an admin
+	QueuedCallbackStatusCancelled QueuedCallbackStatus = "cancelled"
+)
+
+// QueuedCallback represents a callback request which failed and is retried
+// by the bridge server
+type QueuedCallback struct {
+	ID            int64                `db:"id" json:"id"`
+	Type          QueuedCallbackType   `db:"type" json:"type"`
+	Reference     string               `db:"reference" json:"reference"`
+	URL           string               `db:"url" json:"url"`
+	Body          string               `db:"body" json:"body"`
+	Status        QueuedCallbackStatus `db:"status" json:"status"` // pending/delivered/dead/cancelled
+	Attempts      int                  `db:"attempts" json:"attempts"`
+	NextAttemptAt time.Time            `db:"next_attempt_at" json:"next_attempt_at"`
+	LastError     string               `db:"last_error" json:"last_error"`
+	CreatedAt     time.Time            `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time            `db:"updated_at" json:"updated_at"`
+}
+
+// Quote represents a path payment quote returned by /quote. The path and
+// send_max of a quote are reused by /payment requests referencing it.
+type Quote struct {
+	ID                 string    `db:"id" json:"id"`
+	SourceAccount      string    `db:"source_account" json:"source_account"`
+	Destination        string    `db:"destination" json:"destination"`
+	DestinationAccount string    `db:"destination_account" json:"destination_account"`
+	DestinationAmount  string    `db:"destination_amount" json:"destination_amount"`
+	AssetCode          string    `db:"asset_code" json:"asset_code"`
+	AssetIssuer        string    `db:"asset_issuer" json:"asset_issuer"`
+	SendAssetCode      string    `db:"send_asset_code" json:"send_asset_code"`
+	SendAssetIssuer    string    `db:"send_asset_issuer" json:"send_asset_issuer"`
+	SourceAmount       string    `db:"source_amount" json:"source_amount"`
+	SendMax            string    `db:"send_max" json:"send_max"`
+	Path               string    `db:"path" json:"path"` // JSON encoded assets of the path
+	CreatedAt          time.Time `db:"created_at" json:"created_at"`
+	ExpiresAt          time.Time `db:"expires_at" json:"expires_at"`
+}
+
 // SentTransactionStatus type represents sent transaction status
 type SentTransactionStatus string
 
@@ -70,4 +190,5 @@ type SentTransaction struct {
 	Ledger        *int32                `db:"ledger" json:"ledger"`
 	EnvelopeXdr   string                `db:"envelope_xdr" json:"envelope_xdr"`
 	ResultXdr     *string               `db:"result_xdr" json:"result_xdr"`
+	ResultCodes   *string               `db:"result_codes" json:"result_codes"` // JSON encoded result codes of failed transactions
 }
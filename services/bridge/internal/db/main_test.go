@@ -0,0 +1,306 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/support/db/dbtest"
+	"github.com/stellar/go/support/db/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresDatabase(t *testing.T) {
+	tdb := dbtest.Postgres(t)
+	defer tdb.Close()
+
+	testDatabase(t, &PostgresDatabase{}, tdb.DSN)
+}
+
+func TestMysqlDatabase(t *testing.T) {
+	tdb := dbtest.Mysql(t)
+	defer tdb.Close()
+
+	testDatabase(t, &MysqlDatabase{}, tdb.DSN+"?parseTime=true")
+}
+
+func TestNewDatabase(t *testing.T) {
+	for databaseType, expected := range map[string]SQLDatabase{
+		"mysql":    &MysqlDatabase{},
+		"postgres": &PostgresDatabase{},
+		"sqlite3":  &SqliteDatabase{},
+	} {
+		database, err := NewDatabase(databaseType)
+		if assert.NoError(t, err) {
+			assert.IsType(t, expected, database)
+			assert.Equal(t, databaseType, database.Dialect())
+		}
+	}
+
+	_, err := NewDatabase("oracle")
+	assert.Error(t, err)
+}
+
+// testDatabase runs the Database test suite against `database`, migrated
+// from scratch using its own migrations
+func testDatabase(t *testing.T, database SQLDatabase, dsn string) {
+	require.NoError(t, database.Open(dsn))
+	defer database.GetDB().Close()
+
+	applied, err := schema.MigrateDialect(database.GetDB(), database.Dialect(), database.Migrations(), schema.MigrateUp, 0)
+	require.NoError(t, err)
+	require.True(t, applied > 0)
+
+	// Times are stored without time zone and, depending on the database,
+	// without sub-second precision.
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("SentTransaction", func(t *testing.T) {
+		transaction := &SentTransaction{
+			PaymentID:     sql.NullString{String: "payment", Valid: true},
+			TransactionID: "a41e1bfb8b1fcadd3fa5d8d9f6b20cbbd4bc9de4b1ee55a1edc6d05adb2d4a1c",
+			Status:        SentTransactionStatusSending,
+			Source:        "GBIUXI4S27PSL6TTJCJMPYDCF3K6AW2MYORFRTC7QBFE6NNEGVOQK46H",
+			SubmittedAt:   now,
+			EnvelopeXdr:   "envelope",
+		}
+		require.NoError(t, database.InsertSentTransaction(transaction))
+		assert.NotEqual(t, int64(0), transaction.ID)
+
+		other := &SentTransaction{
+			TransactionID: "f2e6e0a1bc35b4a1fef4c4d4b8a1ccd1ee9b31f1c2e4c7e1a3a0a3b7e1f6c2d0",
+			Status:        SentTransactionStatusSending,
+			Source:        "GBIUXI4S27PSL6TTJCJMPYDCF3K6AW2MYORFRTC7QBFE6NNEGVOQK46H",
+			SubmittedAt:   now,
+			EnvelopeXdr:   "envelope",
+		}
+		require.NoError(t, database.InsertSentTransaction(other))
+		assert.True(t, other.ID > transaction.ID)
+
+		ledger := int32(100)
+		resultCodes := `{"transaction":"tx_failed"}`
+		transaction.Status = SentTransactionStatusFailure
+		transaction.Ledger = &ledger
+		transaction.ResultCodes = &resultCodes
+		require.NoError(t, database.UpdateSentTransaction(transaction))
+
+		found, err := database.GetSentTransactionByPaymentID("payment")
+		require.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, transaction.ID, found.ID)
+			assert.Equal(t, SentTransactionStatusFailure, found.Status)
+			assert.Equal(t, &ledger, found.Ledger)
+			assert.Equal(t, &resultCodes, found.ResultCodes)
+			assert.True(t, now.Equal(found.SubmittedAt))
+		}
+
+		found, err = database.GetSentTransactionByPaymentID("missing")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		transactions, err := database.GetSentTransactions(1, 10)
+		require.NoError(t, err)
+		if assert.Len(t, transactions, 2) {
+			assert.Equal(t, other.ID, transactions[0].ID)
+			assert.False(t, transactions[0].PaymentID.Valid)
+		}
+
+		transactions, err = database.GetSentTransactions(2, 1)
+		require.NoError(t, err)
+		if assert.Len(t, transactions, 1) {
+			assert.Equal(t, transaction.ID, transactions[0].ID)
+		}
+	})
+
+	t.Run("ReceivedPayment", func(t *testing.T) {
+		cursor, err := database.GetLastCursorValue()
+		require.NoError(t, err)
+		assert.Nil(t, cursor)
+
+		payment := &ReceivedPayment{
+			OperationID:   "12884905985",
+			ProcessedAt:   now,
+			PagingToken:   "12884905985",
+			Status:        "Processing...",
+			TransactionID: "a41e1bfb8b1fcadd3fa5d8d9f6b20cbbd4bc9de4b1ee55a1edc6d05adb2d4a1c",
+		}
+		require.NoError(t, database.InsertReceivedPayment(payment))
+		assert.NotEqual(t, int64(0), payment.ID)
+
+		payment.Status = "Success"
+		require.NoError(t, database.UpdateReceivedPayment(payment))
+
+		found, err := database.GetReceivedPaymentByID(payment.ID)
+		require.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, "12884905985", found.OperationID)
+			assert.Equal(t, "Success", found.Status)
+			assert.True(t, now.Equal(found.ProcessedAt))
+		}
+
+		found, err = database.GetReceivedPaymentByOperationID("missing")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		payments, err := database.GetReceivedPayments(1, 10)
+		require.NoError(t, err)
+		assert.Len(t, payments, 1)
+
+		cursor, err = database.GetLastCursorValue()
+		require.NoError(t, err)
+		if assert.NotNil(t, cursor) {
+			assert.Equal(t, "12884905985", *cursor)
+		}
+	})
+
+	t.Run("ListenerCursor", func(t *testing.T) {
+		first := "GBIUXI4S27PSL6TTJCJMPYDCF3K6AW2MYORFRTC7QBFE6NNEGVOQK46H"
+		second := "GASZUHRFAFIZX5LR4WNHBWUXJBZNBEWCHFTR4XZHPF5TMVM5XUZBP5DT"
+
+		cursor, err := database.GetListenerCursor(first)
+		require.NoError(t, err)
+		assert.Nil(t, cursor)
+
+		require.NoError(t, database.SaveListenerCursor(&ListenerCursor{AccountID: first, PagingToken: "1", UpdatedAt: now}))
+		require.NoError(t, database.SaveListenerCursor(&ListenerCursor{AccountID: second, PagingToken: "2", UpdatedAt: now}))
+		// saving again updates the cursor
+		require.NoError(t, database.SaveListenerCursor(&ListenerCursor{AccountID: first, PagingToken: "3", UpdatedAt: now.Add(time.Minute)}))
+
+		cursor, err = database.GetListenerCursor(first)
+		require.NoError(t, err)
+		if assert.NotNil(t, cursor) {
+			assert.Equal(t, "3", cursor.PagingToken)
+			assert.True(t, now.Add(time.Minute).Equal(cursor.UpdatedAt))
+		}
+
+		cursors, err := database.GetListenerCursors()
+		require.NoError(t, err)
+		if assert.Len(t, cursors, 2) {
+			assert.Equal(t, second, cursors[0].AccountID)
+			assert.Equal(t, first, cursors[1].AccountID)
+		}
+	})
+
+	t.Run("QueuedCallback", func(t *testing.T) {
+		due := &QueuedCallback{
+			Type:          QueuedCallbackTypeReceive,
+			Reference:     "12884905985",
+			URL:           "http://localhost/receive",
+			Body:          "id=12884905985",
+			Status:        QueuedCallbackStatusPending,
+			Attempts:      1,
+			NextAttemptAt: now.Add(-time.Minute),
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		require.NoError(t, database.InsertQueuedCallback(due))
+		assert.NotEqual(t, int64(0), due.ID)
+
+		later := &QueuedCallback{
+			Type:          QueuedCallbackTypePaymentStatus,
+			Reference:     "12884905985",
+			URL:           "http://localhost/receive",
+			Body:          "id=payment",
+			Status:        QueuedCallbackStatusPending,
+			NextAttemptAt: now.Add(time.Hour),
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		require.NoError(t, database.InsertQueuedCallback(later))
+
+		callbacks, err := database.GetDueQueuedCallbacks(now, 10)
+		require.NoError(t, err)
+		if assert.Len(t, callbacks, 1) {
+			assert.Equal(t, due.ID, callbacks[0].ID)
+		}
+
+		due.Status = QueuedCallbackStatusDelivered
+		due.LastError = "Error sending callback"
+		require.NoError(t, database.UpdateQueuedCallback(due))
+
+		found, err := database.GetQueuedCallbackByID(due.ID)
+		require.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, QueuedCallbackStatusDelivered, found.Status)
+			assert.Equal(t, "Error sending callback", found.LastError)
+			assert.True(t, now.Add(-time.Minute).Equal(found.NextAttemptAt))
+		}
+
+		found, err = database.GetQueuedCallbackByReference(QueuedCallbackTypePaymentStatus, "12884905985")
+		require.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, later.ID, found.ID)
+		}
+
+		// only pending callbacks are updated by UpdatePendingQueuedCallback
+		due.Status = QueuedCallbackStatusDead
+		updated, err := database.UpdatePendingQueuedCallback(due)
+		require.NoError(t, err)
+		assert.False(t, updated)
+
+		found, err = database.GetQueuedCallbackByID(due.ID)
+		require.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, QueuedCallbackStatusDelivered, found.Status)
+		}
+
+		later.Attempts = 1
+		updated, err = database.UpdatePendingQueuedCallback(later)
+		require.NoError(t, err)
+		assert.True(t, updated)
+
+		found, err = database.GetQueuedCallbackByReference(QueuedCallbackTypeReceive, "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+
+		callbacks, err = database.GetQueuedCallbacks(QueuedCallbackStatusPending, 1, 10)
+		require.NoError(t, err)
+		if assert.Len(t, callbacks, 1) {
+			assert.Equal(t, later.ID, callbacks[0].ID)
+		}
+
+		callbacks, err = database.GetQueuedCallbacks("", 1, 10)
+		require.NoError(t, err)
+		assert.Len(t, callbacks, 2)
+
+		callbacks, err = database.GetDueQueuedCallbacks(now, 10)
+		require.NoError(t, err)
+		assert.Len(t, callbacks, 0)
+	})
+
+	t.Run("Quote", func(t *testing.T) {
+		quote := &Quote{
+			ID:                 "5e0a6bc0b6e44c6a0e5f2cd4a1f7f8e1",
+			SourceAccount:      "GBIUXI4S27PSL6TTJCJMPYDCF3K6AW2MYORFRTC7QBFE6NNEGVOQK46H",
+			Destination:        "bob*stellar.org",
+			DestinationAccount: "GASZUHRFAFIZX5LR4WNHBWUXJBZNBEWCHFTR4XZHPF5TMVM5XUZBP5DT",
+			DestinationAmount:  "10",
+			AssetCode:          "USD",
+			AssetIssuer:        "GASZUHRFAFIZX5LR4WNHBWUXJBZNBEWCHFTR4XZHPF5TMVM5XUZBP5DT",
+			SourceAmount:       "20",
+			SendMax:            "20.2",
+			Path:               "[]",
+			CreatedAt:          now,
+			ExpiresAt:          now.Add(time.Minute),
+		}
+		require.NoError(t, database.InsertQuote(quote))
+
+		found, err := database.GetQuoteByID(quote.ID)
+		require.NoError(t, err)
+		if assert.NotNil(t, found) {
+			assert.Equal(t, "bob*stellar.org", found.Destination)
+			assert.Equal(t, "", found.SendAssetCode)
+			assert.Equal(t, "20.2", found.SendMax)
+			assert.True(t, now.Add(time.Minute).Equal(found.ExpiresAt))
+		}
+
+		found, err = database.GetQuoteByID("missing")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	applied, err = schema.MigrateDialect(database.GetDB(), database.Dialect(), database.Migrations(), schema.MigrateDown, 0)
+	require.NoError(t, err)
+	assert.True(t, applied > 0)
+}
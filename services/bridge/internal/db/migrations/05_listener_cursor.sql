@@ -0,0 +1,10 @@
+-- +migrate Up
+CREATE TABLE listener_cursor (
+    account_id character varying(56) NOT NULL,
+    paging_token character varying(255) NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    PRIMARY KEY (account_id)
+);
+
+-- +migrate Down
+DROP TABLE listener_cursor;
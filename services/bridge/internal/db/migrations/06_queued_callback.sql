@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE queued_callback (
+    id bigserial,
+    type character varying(20) NOT NULL,
+    reference character varying(255) NOT NULL,
+    url text NOT NULL,
+    body text NOT NULL,
+    status character varying(20) NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamp without time zone NOT NULL,
+    last_error text NOT NULL DEFAULT '',
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (type, reference)
+);
+
+CREATE INDEX queued_callback_status_next_attempt_at ON queued_callback (status, next_attempt_at);
+
+-- +migrate Down
+DROP TABLE queued_callback;
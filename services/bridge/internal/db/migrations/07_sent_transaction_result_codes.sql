@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE sent_transaction ADD result_codes text DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE sent_transaction DROP result_codes;
@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE quote (
+    id character varying(64) NOT NULL,
+    source_account character varying(56) NOT NULL,
+    destination character varying(255) NOT NULL,
+    destination_account character varying(56) NOT NULL,
+    destination_amount character varying(64) NOT NULL,
+    asset_code character varying(12) NOT NULL DEFAULT '',
+    asset_issuer character varying(56) NOT NULL DEFAULT '',
+    send_asset_code character varying(12) NOT NULL DEFAULT '',
+    send_asset_issuer character varying(56) NOT NULL DEFAULT '',
+    source_amount character varying(64) NOT NULL,
+    send_max character varying(64) NOT NULL,
+    path text NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    expires_at timestamp without time zone NOT NULL,
+    PRIMARY KEY (id)
+);
+
+-- +migrate Down
+DROP TABLE quote;
@@ -0,0 +1,76 @@
+-- +migrate Up
+CREATE TABLE received_payment (
+  id bigint NOT NULL AUTO_INCREMENT,
+  operation_id varchar(255) NOT NULL,
+  processed_at datetime(6) NOT NULL,
+  paging_token varchar(255) NOT NULL,
+  status varchar(255) NOT NULL,
+  transaction_id varchar(64) DEFAULT 'N/A',
+  PRIMARY KEY (id),
+  UNIQUE KEY received_payment_operation_id_key (operation_id)
+);
+
+CREATE TABLE sent_transaction (
+  id int NOT NULL AUTO_INCREMENT,
+  transaction_id varchar(64) NOT NULL,
+  status varchar(10) NOT NULL,
+  source varchar(56) NOT NULL,
+  submitted_at datetime(6) NOT NULL,
+  succeeded_at datetime(6) DEFAULT NULL,
+  ledger bigint DEFAULT NULL,
+  envelope_xdr text NOT NULL,
+  result_xdr varchar(255) DEFAULT NULL,
+  payment_id varchar(255) DEFAULT NULL,
+  result_codes text,
+  PRIMARY KEY (id),
+  UNIQUE KEY payment_id_unique (payment_id)
+);
+
+CREATE TABLE listener_cursor (
+  account_id varchar(56) NOT NULL,
+  paging_token varchar(255) NOT NULL,
+  updated_at datetime(6) NOT NULL,
+  PRIMARY KEY (account_id)
+);
+
+CREATE TABLE queued_callback (
+  id bigint NOT NULL AUTO_INCREMENT,
+  type varchar(20) NOT NULL,
+  reference varchar(255) NOT NULL,
+  url text NOT NULL,
+  body text NOT NULL,
+  status varchar(20) NOT NULL,
+  attempts int NOT NULL DEFAULT 0,
+  next_attempt_at datetime(6) NOT NULL,
+  last_error text NOT NULL,
+  created_at datetime(6) NOT NULL,
+  updated_at datetime(6) NOT NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY queued_callback_type_reference_key (type, reference),
+  KEY queued_callback_status_next_attempt_at (status, next_attempt_at)
+);
+
+CREATE TABLE quote (
+  id varchar(64) NOT NULL,
+  source_account varchar(56) NOT NULL,
+  destination varchar(255) NOT NULL,
+  destination_account varchar(56) NOT NULL,
+  destination_amount varchar(64) NOT NULL,
+  asset_code varchar(12) NOT NULL DEFAULT '',
+  asset_issuer varchar(56) NOT NULL DEFAULT '',
+  send_asset_code varchar(12) NOT NULL DEFAULT '',
+  send_asset_issuer varchar(56) NOT NULL DEFAULT '',
+  source_amount varchar(64) NOT NULL,
+  send_max varchar(64) NOT NULL,
+  path text NOT NULL,
+  created_at datetime(6) NOT NULL,
+  expires_at datetime(6) NOT NULL,
+  PRIMARY KEY (id)
+);
+
+-- +migrate Down
+DROP TABLE quote;
+DROP TABLE queued_callback;
+DROP TABLE listener_cursor;
+DROP TABLE sent_transaction;
+DROP TABLE received_payment;
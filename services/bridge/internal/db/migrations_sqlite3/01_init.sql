@@ -0,0 +1,70 @@
+-- +migrate Up
+CREATE TABLE received_payment (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  operation_id varchar(255) NOT NULL UNIQUE,
+  processed_at datetime NOT NULL,
+  paging_token varchar(255) NOT NULL,
+  status varchar(255) NOT NULL,
+  transaction_id varchar(64) DEFAULT 'N/A'
+);
+
+CREATE TABLE sent_transaction (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  transaction_id varchar(64) NOT NULL,
+  status varchar(10) NOT NULL,
+  source varchar(56) NOT NULL,
+  submitted_at datetime NOT NULL,
+  succeeded_at datetime DEFAULT NULL,
+  ledger bigint DEFAULT NULL,
+  envelope_xdr text NOT NULL,
+  result_xdr varchar(255) DEFAULT NULL,
+  payment_id varchar(255) DEFAULT NULL UNIQUE,
+  result_codes text DEFAULT NULL
+);
+
+CREATE TABLE listener_cursor (
+  account_id varchar(56) NOT NULL PRIMARY KEY,
+  paging_token varchar(255) NOT NULL,
+  updated_at datetime NOT NULL
+);
+
+CREATE TABLE queued_callback (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  type varchar(20) NOT NULL,
+  reference varchar(255) NOT NULL,
+  url text NOT NULL,
+  body text NOT NULL,
+  status varchar(20) NOT NULL,
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt_at datetime NOT NULL,
+  last_error text NOT NULL DEFAULT '',
+  created_at datetime NOT NULL,
+  updated_at datetime NOT NULL,
+  UNIQUE (type, reference)
+);
+
+CREATE INDEX queued_callback_status_next_attempt_at ON queued_callback (status, next_attempt_at);
+
+CREATE TABLE quote (
+  id varchar(64) NOT NULL PRIMARY KEY,
+  source_account varchar(56) NOT NULL,
+  destination varchar(255) NOT NULL,
+  destination_account varchar(56) NOT NULL,
+  destination_amount varchar(64) NOT NULL,
+  asset_code varchar(12) NOT NULL DEFAULT '',
+  asset_issuer varchar(56) NOT NULL DEFAULT '',
+  send_asset_code varchar(12) NOT NULL DEFAULT '',
+  send_asset_issuer varchar(56) NOT NULL DEFAULT '',
+  source_amount varchar(64) NOT NULL,
+  send_max varchar(64) NOT NULL,
+  path text NOT NULL,
+  created_at datetime NOT NULL,
+  expires_at datetime NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE quote;
+DROP TABLE queued_callback;
+DROP TABLE listener_cursor;
+DROP TABLE sent_transaction;
+DROP TABLE received_payment;
@@ -0,0 +1,49 @@
+package db
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+)
+
+// MysqlDatabase is a Database stored in mysql. The `parseTime=true` parameter
+// must be set in the dsn.
+type MysqlDatabase struct {
+	sqlDatabase
+}
+
+// Open connects to the database at `dsn`
+func (d *MysqlDatabase) Open(dsn string) error {
+	var err error
+	d.session, err = db.Open("mysql", dsn)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Dialect returns the name of the sql driver used by the database
+func (d *MysqlDatabase) Dialect() string {
+	return "mysql"
+}
+
+// Migrations returns the schema migrations of the database
+func (d *MysqlDatabase) Migrations() migrate.MigrationSource {
+	return MysqlMigrations
+}
+
+// SaveListenerCursor inserts or updates the listener cursor of a receiving
+// account
+func (d *MysqlDatabase) SaveListenerCursor(cursor *ListenerCursor) error {
+	_, err := d.session.ExecRaw(
+		`INSERT INTO listener_cursor (account_id, paging_token, updated_at) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE paging_token = VALUES(paging_token), updated_at = VALUES(updated_at)`,
+		cursor.AccountID, cursor.PagingToken, cursor.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "Error saving listener cursor")
+	}
+
+	return nil
+}
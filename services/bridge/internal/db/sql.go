@@ -0,0 +1,455 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	listenerCursorTableName  = "listener_cursor"
+	queuedCallbackTableName  = "queued_callback"
+	quoteTableName           = "quote"
+	receivedPaymentTableName = "received_payment"
+	sentTransactionTableName = "sent_transaction"
+)
+
+// sqlDatabase implements the parts of Database which are common to all the
+// supported SQL databases
+type sqlDatabase struct {
+	session *db.Session
+}
+
+// GetDB returns the underlying database connection
+func (d *sqlDatabase) GetDB() *sql.DB {
+	if d.session == nil {
+		return nil
+	}
+
+	return d.session.DB.DB
+}
+
+func (d *sqlDatabase) getTable(name string, session *db.Session) *db.Table {
+	if session == nil {
+		session = d.session
+	}
+
+	return &db.Table{
+		Name:    name,
+		Session: session,
+	}
+}
+
+// InsertSentTransaction inserts anew transaction into DB. After successful insert ID
+// field on `transaction` will updated to ID of a new row.
+func (d *sqlDatabase) InsertSentTransaction(transaction *SentTransaction) error {
+	sentTransactionTable := d.getTable(sentTransactionTableName, nil)
+	_, err := sentTransactionTable.Insert(transaction).IgnoreCols("id").Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error inserting sent transaction")
+	}
+
+	newTransaction, err := d.GetSentTransactionByHash(transaction.TransactionID)
+	if err != nil {
+		return errors.Wrap(err, "Error getting new transaction")
+	}
+
+	transaction.ID = newTransaction.ID
+	return nil
+}
+
+func (d *sqlDatabase) UpdateSentTransaction(transaction *SentTransaction) error {
+	if transaction.ID == 0 {
+		return errors.New("ID equals 0")
+	}
+
+	sentTransactionTable := d.getTable(sentTransactionTableName, nil)
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	_, err := sentTransactionTable.Update(nil, map[string]interface{}{"id": transaction.ID}).
+		SetStruct(transaction, []string{"id"}).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error updating sent transaction")
+	}
+
+	return nil
+}
+
+// InsertReceivedPayment inserts a new payment into DB. After successful insert ID
+// field on `payment` will updated to ID of a new row.
+func (d *sqlDatabase) InsertReceivedPayment(payment *ReceivedPayment) error {
+	receivedPaymentTable := d.getTable(receivedPaymentTableName, nil)
+	_, err := receivedPaymentTable.Insert(payment).IgnoreCols("id").Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error inserting received payment")
+	}
+
+	newPayment, err := d.GetReceivedPaymentByOperationID(payment.OperationID)
+	if err != nil {
+		return errors.Wrap(err, "Error getting new operation")
+	}
+
+	payment.ID = newPayment.ID
+	return nil
+}
+
+func (d *sqlDatabase) UpdateReceivedPayment(payment *ReceivedPayment) error {
+	if payment.ID == 0 {
+		return errors.New("ID equals 0")
+	}
+
+	receivedPaymentTable := d.getTable(receivedPaymentTableName, nil)
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	_, err := receivedPaymentTable.Update(nil, map[string]interface{}{"id": payment.ID}).
+		SetStruct(payment, []string{"id"}).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error updating received payment")
+	}
+
+	return nil
+}
+
+// GetLastCursorValue returns last cursor value from a DB
+func (d *sqlDatabase) GetLastCursorValue() (cursor *string, err error) {
+	receivedPayment, err := d.getLastReceivedPayment()
+	if err != nil {
+		return nil, err
+	} else if receivedPayment == nil {
+		return nil, nil
+	} else {
+		return &receivedPayment.PagingToken, nil
+	}
+}
+
+// GetListenerCursor returns the listener cursor of a receiving account or nil
+// if the account has no cursor yet
+func (d *sqlDatabase) GetListenerCursor(accountID string) (*ListenerCursor, error) {
+	listenerCursorTable := d.getTable(listenerCursorTableName, nil)
+	var cursor ListenerCursor
+	err := listenerCursorTable.Get(&cursor, map[string]interface{}{"account_id": accountID}).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting listener cursor")
+		}
+	}
+
+	return &cursor, nil
+}
+
+// GetListenerCursors returns the listener cursors of all receiving accounts
+func (d *sqlDatabase) GetListenerCursors() ([]*ListenerCursor, error) {
+	listenerCursorTable := d.getTable(listenerCursorTableName, nil)
+	cursors := []*ListenerCursor{}
+
+	err := listenerCursorTable.Select(&cursors, "1=1").OrderBy("account_id asc").Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return cursors, nil
+		default:
+			return cursors, errors.Wrap(err, "Error getting listener cursors")
+		}
+	}
+
+	return cursors, nil
+}
+
+// InsertQueuedCallback inserts a new callback into DB. After successful insert ID
+// field on `callback` will updated to ID of a new row.
+func (d *sqlDatabase) InsertQueuedCallback(callback *QueuedCallback) error {
+	queuedCallbackTable := d.getTable(queuedCallbackTableName, nil)
+	_, err := queuedCallbackTable.Insert(callback).IgnoreCols("id").Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error inserting queued callback")
+	}
+
+	newCallback, err := d.GetQueuedCallbackByReference(callback.Type, callback.Reference)
+	if err != nil {
+		return errors.Wrap(err, "Error getting new callback")
+	}
+
+	callback.ID = newCallback.ID
+	return nil
+}
+
+func (d *sqlDatabase) UpdateQueuedCallback(callback *QueuedCallback) error {
+	if callback.ID == 0 {
+		return errors.New("ID equals 0")
+	}
+
+	queuedCallbackTable := d.getTable(queuedCallbackTableName, nil)
+	_, err := queuedCallbackTable.Update(nil, map[string]interface{}{"id": callback.ID}).
+		SetStruct(callback, []string{"id"}).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error updating queued callback")
+	}
+
+	return nil
+}
+
+// UpdatePendingQueuedCallback updates a callback only while it is pending, so
+// that a callback cancelled (or retried) by an admin in the meantime is left
+// untouched.  It returns false when the callback was not updated.
+func (d *sqlDatabase) UpdatePendingQueuedCallback(callback *QueuedCallback) (bool, error) {
+	if callback.ID == 0 {
+		return false, errors.New("ID equals 0")
+	}
+
+	queuedCallbackTable := d.getTable(queuedCallbackTableName, nil)
+	result, err := queuedCallbackTable.Update(nil, map[string]interface{}{
+		"id":     callback.ID,
+		"status": QueuedCallbackStatusPending,
+	}).
+		SetStruct(callback, []string{"id"}).
+		Exec()
+	if err != nil {
+		return false, errors.Wrap(err, "Error updating queued callback")
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "Error getting updated rows")
+	}
+
+	return updated > 0, nil
+}
+
+// GetQueuedCallbackByID returns queued callback by id
+func (d *sqlDatabase) GetQueuedCallbackByID(id int64) (*QueuedCallback, error) {
+	return d.getQueuedCallback(map[string]interface{}{"id": id})
+}
+
+// GetQueuedCallbackByReference returns queued callback by type and reference
+func (d *sqlDatabase) GetQueuedCallbackByReference(callbackType QueuedCallbackType, reference string) (*QueuedCallback, error) {
+	return d.getQueuedCallback(map[string]interface{}{"type": callbackType, "reference": reference})
+}
+
+func (d *sqlDatabase) getQueuedCallback(params map[string]interface{}) (*QueuedCallback, error) {
+	queuedCallbackTable := d.getTable(queuedCallbackTableName, nil)
+	var callback QueuedCallback
+	err := queuedCallbackTable.Get(&callback, params).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting queued callback")
+		}
+	}
+
+	return &callback, nil
+}
+
+// GetQueuedCallbacks returns queued callbacks, all of them or only the ones
+// with the given status
+func (d *sqlDatabase) GetQueuedCallbacks(status QueuedCallbackStatus, page, limit uint64) ([]*QueuedCallback, error) {
+	queuedCallbackTable := d.getTable(queuedCallbackTableName, nil)
+	callbacks := []*QueuedCallback{}
+
+	if page == 0 {
+		page = 1
+	}
+
+	offset := (page - 1) * limit
+
+	var pred interface{} = "1=1"
+	if status != "" {
+		pred = map[string]interface{}{"status": status}
+	}
+
+	err := queuedCallbackTable.Select(&callbacks, pred).Limit(limit).Offset(offset).OrderBy("id desc").Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return callbacks, nil
+		default:
+			return callbacks, errors.Wrap(err, "Error getting queued callbacks")
+		}
+	}
+
+	return callbacks, nil
+}
+
+// GetDueQueuedCallbacks returns pending callbacks which should be sent at
+// `now`, the most overdue first
+func (d *sqlDatabase) GetDueQueuedCallbacks(now time.Time, limit uint64) ([]*QueuedCallback, error) {
+	queuedCallbackTable := d.getTable(queuedCallbackTableName, nil)
+	callbacks := []*QueuedCallback{}
+
+	err := queuedCallbackTable.Select(&callbacks, "status = ? AND next_attempt_at <= ?", QueuedCallbackStatusPending, now).
+		Limit(limit).
+		OrderBy("next_attempt_at asc").
+		Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return callbacks, nil
+		default:
+			return callbacks, errors.Wrap(err, "Error getting due queued callbacks")
+		}
+	}
+
+	return callbacks, nil
+}
+
+// InsertQuote inserts a new quote into DB
+func (d *sqlDatabase) InsertQuote(quote *Quote) error {
+	quoteTable := d.getTable(quoteTableName, nil)
+	_, err := quoteTable.Insert(quote).Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error inserting quote")
+	}
+
+	return nil
+}
+
+// GetQuoteByID returns quote by id
+func (d *sqlDatabase) GetQuoteByID(id string) (*Quote, error) {
+	quoteTable := d.getTable(quoteTableName, nil)
+	var quote Quote
+	err := quoteTable.Get(&quote, map[string]interface{}{"id": id}).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting quote")
+		}
+	}
+
+	return &quote, nil
+}
+
+// GetSentTransactionByPaymentID returns sent transaction searching by payment ID
+func (d *sqlDatabase) GetSentTransactionByPaymentID(paymentID string) (*SentTransaction, error) {
+	sentTransactionTable := d.getTable(sentTransactionTableName, nil)
+	var transaction SentTransaction
+	err := sentTransactionTable.Get(&transaction, map[string]interface{}{"payment_id": paymentID}).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting sent transaction by payment ID")
+		}
+	}
+
+	return &transaction, nil
+}
+
+// GetSentTransactionByHash returns sent transaction searching by hash
+func (d *sqlDatabase) GetSentTransactionByHash(hash string) (*SentTransaction, error) {
+	sentTransactionTable := d.getTable(sentTransactionTableName, nil)
+	var transaction SentTransaction
+	err := sentTransactionTable.Get(&transaction, map[string]interface{}{"transaction_id": hash}).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting sent transaction by hash")
+		}
+	}
+
+	return &transaction, nil
+}
+
+// GetReceivedPaymentByID returns received payment by id
+func (d *sqlDatabase) GetReceivedPaymentByID(id int64) (*ReceivedPayment, error) {
+	return d.getReceivedPayment(map[string]interface{}{"id": id})
+}
+
+// GetReceivedPaymentByOperationID returns received payment by operation_id
+func (d *sqlDatabase) GetReceivedPaymentByOperationID(operationID string) (*ReceivedPayment, error) {
+	return d.getReceivedPayment(map[string]interface{}{"operation_id": operationID})
+}
+
+func (d *sqlDatabase) getReceivedPayment(params map[string]interface{}) (*ReceivedPayment, error) {
+	receivedPaymentTable := d.getTable(receivedPaymentTableName, nil)
+	var receivedPayment ReceivedPayment
+	err := receivedPaymentTable.Get(&receivedPayment, params).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting received payment transaction by operation ID")
+		}
+	}
+
+	return &receivedPayment, nil
+}
+
+// GetReceivedPayments returns received payments
+func (d *sqlDatabase) GetReceivedPayments(page, limit uint64) ([]*ReceivedPayment, error) {
+	receivedPaymentTable := d.getTable(receivedPaymentTableName, nil)
+	payments := []*ReceivedPayment{}
+
+	if page == 0 {
+		page = 1
+	}
+
+	offset := (page - 1) * limit
+
+	err := receivedPaymentTable.Select(&payments, "1=1").Limit(limit).Offset(offset).OrderBy("id desc").Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return payments, nil
+		default:
+			return payments, errors.Wrap(err, "Error getting received payments")
+		}
+	}
+
+	return payments, nil
+}
+
+// GetSentTransactions returns received payments
+func (d *sqlDatabase) GetSentTransactions(page, limit uint64) ([]*SentTransaction, error) {
+	sentTransactionTable := d.getTable(sentTransactionTableName, nil)
+	transactions := []*SentTransaction{}
+
+	if page == 0 {
+		page = 1
+	}
+
+	offset := (page - 1) * limit
+
+	err := sentTransactionTable.Select(&transactions, "1=1").Limit(limit).Offset(offset).OrderBy("id desc").Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return transactions, nil
+		default:
+			return transactions, errors.Wrap(err, "Error getting sent transactions")
+		}
+	}
+
+	return transactions, nil
+}
+
+// getLastReceivedPayment returns the last received payment
+func (d *sqlDatabase) getLastReceivedPayment() (*ReceivedPayment, error) {
+	receivedPaymentTable := d.getTable(receivedPaymentTableName, nil)
+	// TODO: `1=1`. We should be able to get row without WHERE clause.
+	// When it's set to nil: `pq: syntax error at or near "ORDER""`
+	var receivedPayment ReceivedPayment
+	err := receivedPaymentTable.Get(&receivedPayment, "1=1").OrderBy("id DESC").Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting last received payment")
+		}
+	}
+
+	return &receivedPayment, nil
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+)
+
+// SqliteDatabase is a Database stored in a sqlite3 file. It is only available
+// in binaries built with cgo.
+type SqliteDatabase struct {
+	sqlDatabase
+}
+
+// Open connects to the database at `dsn`
+func (d *SqliteDatabase) Open(dsn string) error {
+	var err error
+	d.session, err = db.Open("sqlite3", dsn)
+	if err != nil {
+		return err
+	}
+
+	// sqlite3 allows a single writer at a time: concurrent writes from the
+	// listener, the callback queue and requests would fail with "database is
+	// locked" errors.
+	d.session.DB.SetMaxOpenConns(1)
+	return nil
+}
+
+// Dialect returns the name of the sql driver used by the database
+func (d *SqliteDatabase) Dialect() string {
+	return "sqlite3"
+}
+
+// Migrations returns the schema migrations of the database
+func (d *SqliteDatabase) Migrations() migrate.MigrationSource {
+	return SqliteMigrations
+}
+
+// SaveListenerCursor inserts or updates the listener cursor of a receiving
+// account
+func (d *SqliteDatabase) SaveListenerCursor(cursor *ListenerCursor) error {
+	_, err := d.session.ExecRaw(
+		`INSERT INTO listener_cursor (account_id, paging_token, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (account_id) DO UPDATE SET paging_token = excluded.paging_token, updated_at = excluded.updated_at`,
+		cursor.AccountID, cursor.PagingToken, cursor.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "Error saving listener cursor")
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+// +build cgo
+
+package db
+
+import (
+	"testing"
+
+	"github.com/stellar/go/support/db/dbtest"
+)
+
+func TestSqliteDatabase(t *testing.T) {
+	tdb := dbtest.Sqlite(t)
+	defer tdb.Close()
+
+	testDatabase(t, &SqliteDatabase{}, tdb.DSN)
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	log "github.com/sirupsen/logrus"
+	"github.com/stellar/go/services/bridge/internal/db"
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/http/helpers"
+)
+
+// AdminCallbacks implements /admin/callbacks endpoint
+func (rh *RequestHandler) AdminCallbacks(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	status := db.QueuedCallbackStatus(r.URL.Query().Get("status"))
+	limit := 10
+
+	switch status {
+	case "", db.QueuedCallbackStatusPending, db.QueuedCallbackStatusDelivered, db.QueuedCallbackStatusDead, db.QueuedCallbackStatusCancelled:
+	default:
+		helpers.Write(w, helpers.NewInvalidParameterError("status", "Unknown status"))
+		return
+	}
+
+	callbacks, err := rh.Database.GetQueuedCallbacks(status, uint64(page), uint64(limit))
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error loading QueuedCallbacks")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(callbacks)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "callbacks": callbacks}).Error("Error encoding QueuedCallbacks")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+}
+
+// AdminCallback implements /admin/callbacks/{id} endpoint
+func (rh *RequestHandler) AdminCallback(w http.ResponseWriter, r *http.Request) {
+	callback, ok := rh.loadQueuedCallback(w, r)
+	if !ok {
+		return
+	}
+
+	rh.writeQueuedCallback(w, callback)
+}
+
+// AdminRetryCallback implements /admin/callbacks/{id}/retry endpoint. The
+// callback is sent again as soon as possible with a new set of attempts.
+func (rh *RequestHandler) AdminRetryCallback(w http.ResponseWriter, r *http.Request) {
+	callback, ok := rh.loadQueuedCallback(w, r)
+	if !ok {
+		return
+	}
+
+	if callback.Status == db.QueuedCallbackStatusDelivered {
+		helpers.Write(w, helpers.NewInvalidParameterError("id", "Callback already delivered"))
+		return
+	}
+
+	now := time.Now()
+	callback.Status = db.QueuedCallbackStatusPending
+	callback.Attempts = 0
+	callback.NextAttemptAt = now
+	callback.UpdatedAt = now
+
+	rh.updateQueuedCallback(w, callback)
+}
+
+// AdminCancelCallback implements /admin/callbacks/{id}/cancel endpoint
+func (rh *RequestHandler) AdminCancelCallback(w http.ResponseWriter, r *http.Request) {
+	callback, ok := rh.loadQueuedCallback(w, r)
+	if !ok {
+		return
+	}
+
+	if callback.Status == db.QueuedCallbackStatusDelivered {
+		helpers.Write(w, helpers.NewInvalidParameterError("id", "Callback already delivered"))
+		return
+	}
+
+	callback.Status = db.QueuedCallbackStatusCancelled
+	callback.UpdatedAt = time.Now()
+
+	rh.updateQueuedCallback(w, callback)
+}
+
+// loadQueuedCallback loads the callback of the `id` URL param. It writes an
+// error response and returns false if the callback cannot be loaded.
+func (rh *RequestHandler) loadQueuedCallback(w http.ResponseWriter, r *http.Request) (*db.QueuedCallback, bool) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	callback, err := rh.Database.GetQueuedCallbackByID(id)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting QueuedCallback")
+		helpers.Write(w, helpers.InternalServerError)
+		return nil, false
+	}
+
+	if callback == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+
+	return callback, true
+}
+
+func (rh *RequestHandler) updateQueuedCallback(w http.ResponseWriter, callback *db.QueuedCallback) {
+	err := rh.Database.UpdateQueuedCallback(callback)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error updating QueuedCallback")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+
+	rh.writeQueuedCallback(w, callback)
+}
+
+func (rh *RequestHandler) writeQueuedCallback(w http.ResponseWriter, callback *db.QueuedCallback) {
+	encoder := json.NewEncoder(w)
+	err := encoder.Encode(callback)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "callback": callback}).Error("Error encoding QueuedCallback")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+}
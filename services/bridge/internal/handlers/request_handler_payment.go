@@ -13,6 +13,7 @@ import (
 	"github.com/stellar/go/clients/horizon"
 	"github.com/stellar/go/protocols/compliance"
 	"github.com/stellar/go/protocols/federation"
+	"github.com/stellar/go/services/bridge/internal/db"
 	shared "github.com/stellar/go/services/internal/bridge-compliance-shared"
 	"github.com/stellar/go/services/internal/bridge-compliance-shared/http/helpers"
 	"github.com/stellar/go/services/internal/bridge-compliance-shared/protocols/bridge"
@@ -46,6 +47,35 @@ func (rh *RequestHandler) Payment(w http.ResponseWriter, r *http.Request) {
 		request.Source = rh.Config.Accounts.BaseSeed
 	}
 
+	// Payments are idempotent by ID: the result of a payment which was already
+	// sent is returned instead of sending it again.
+	if request.ID != "" {
+		sentTransaction, err := rh.Database.GetSentTransactionByPaymentID(request.ID)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error getting sent transaction")
+			helpers.Write(w, helpers.InternalServerError)
+			return
+		}
+
+		if sentTransaction != nil {
+			rh.sentPayment(w, sentTransaction)
+			return
+		}
+	}
+
+	if request.QuoteID != "" {
+		errorResponse, err := rh.applyQuote(request)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error applying quote")
+			helpers.Write(w, helpers.InternalServerError)
+			return
+		}
+		if errorResponse != nil {
+			helpers.Write(w, errorResponse)
+			return
+		}
+	}
+
 	// Will use compliance if compliance server is connected and:
 	// * User passed extra memo OR
 	// * User explicitly wants to use compliance protocol
@@ -129,29 +159,8 @@ func (rh *RequestHandler) complianceProtocolPayment(w http.ResponseWriter, reque
 
 func (rh *RequestHandler) standardPayment(w http.ResponseWriter, request *bridge.PaymentRequest) {
 	var paymentID *string
-
 	if request.ID != "" {
-		sentTransaction, err := rh.Database.GetSentTransactionByPaymentID(request.ID)
-		if err != nil {
-			log.WithFields(log.Fields{"err": err}).Error("Error getting sent transaction")
-			helpers.Write(w, helpers.InternalServerError)
-			return
-		}
-
-		if sentTransaction == nil {
-			paymentID = &request.ID
-		} else {
-			log.WithFields(log.Fields{"paymentID": request.ID, "tx": sentTransaction.EnvelopeXdr}).Info("Transaction with given ID already exists, resubmitting...")
-			submitResponse, err := rh.Horizon.SubmitTransaction(sentTransaction.EnvelopeXdr)
-			if err != nil {
-				log.WithFields(log.Fields{"error": err}).Error("Error submitting transaction")
-				helpers.Write(w, helpers.InternalServerError)
-				return
-			}
-
-			rh.handleTransactionSubmitResponse(w, submitResponse, err)
-			return
-		}
+		paymentID = &request.ID
 	}
 
 	destinationObject := &federation.NameResponse{}
@@ -288,6 +297,62 @@ func (rh *RequestHandler) standardPayment(w http.ResponseWriter, request *bridge
 	rh.handleTransactionSubmitResponse(w, submitResponse, err)
 }
 
+// sentPayment responds with the result of a payment which was already sent.
+// Payments which status is unknown are resubmitted with the same envelope.
+func (rh *RequestHandler) sentPayment(w http.ResponseWriter, sentTransaction *db.SentTransaction) {
+	log.WithFields(log.Fields{"paymentID": sentTransaction.PaymentID.String, "status": sentTransaction.Status}).Info("Transaction with given ID already exists")
+
+	switch sentTransaction.Status {
+	case db.SentTransactionStatusSuccess:
+		response := horizon.TransactionSuccess{
+			Hash: sentTransaction.TransactionID,
+			Env:  sentTransaction.EnvelopeXdr,
+		}
+		if sentTransaction.Ledger != nil {
+			response.Ledger = *sentTransaction.Ledger
+		}
+		if sentTransaction.ResultXdr != nil {
+			response.Result = *sentTransaction.ResultXdr
+		}
+		rh.handleTransactionSubmitResponse(w, response, nil)
+	case db.SentTransactionStatusFailure:
+		rh.handleTransactionSubmitResponse(w, horizon.TransactionSuccess{}, transactionFailedError(sentTransaction))
+	default:
+		submitResponse, err := rh.TransactionSubmitter.ResubmitTransaction(sentTransaction)
+		rh.handleTransactionSubmitResponse(w, submitResponse, err)
+	}
+}
+
+// transactionFailedError rebuilds the horizon error returned when a sent
+// transaction failed.
+func transactionFailedError(sentTransaction *db.SentTransaction) *horizon.Error {
+	extras := map[string]json.RawMessage{}
+	encode := func(key string, value interface{}) {
+		raw, err := json.Marshal(value)
+		if err == nil {
+			extras[key] = raw
+		}
+	}
+
+	encode("envelope_xdr", sentTransaction.EnvelopeXdr)
+	if sentTransaction.ResultXdr != nil {
+		encode("result_xdr", *sentTransaction.ResultXdr)
+	}
+	if sentTransaction.ResultCodes != nil {
+		extras["result_codes"] = json.RawMessage(*sentTransaction.ResultCodes)
+	}
+
+	return &horizon.Error{
+		Problem: horizon.Problem{
+			Type:   "https://stellar.org/horizon-errors/transaction_failed",
+			Title:  "Transaction Failed",
+			Status: http.StatusBadRequest,
+			Detail: "The transaction failed when submitted to the stellar network.",
+			Extras: extras,
+		},
+	}
+}
+
 func (rh *RequestHandler) handleTransactionSubmitResponse(w http.ResponseWriter, submitResponse horizon.TransactionSuccess, err error) {
 	jsonEncoder := json.NewEncoder(w)
 
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	log "github.com/sirupsen/logrus"
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/http/helpers"
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/protocols/bridge"
+)
+
+// PaymentStatus implements /payment/{id} endpoint
+func (rh *RequestHandler) PaymentStatus(w http.ResponseWriter, r *http.Request) {
+	sentTransaction, err := rh.Database.GetSentTransactionByPaymentID(chi.URLParam(r, "id"))
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error getting sent transaction")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+
+	if sentTransaction == nil {
+		helpers.Write(w, bridge.PaymentNotFound)
+		return
+	}
+
+	response := &bridge.PaymentStatusResponse{
+		ID:            sentTransaction.PaymentID.String,
+		Status:        string(sentTransaction.Status),
+		TransactionID: sentTransaction.TransactionID,
+		Source:        sentTransaction.Source,
+		SubmittedAt:   sentTransaction.SubmittedAt,
+		SucceededAt:   sentTransaction.SucceededAt,
+		Ledger:        sentTransaction.Ledger,
+		EnvelopeXdr:   sentTransaction.EnvelopeXdr,
+		ResultXdr:     sentTransaction.ResultXdr,
+	}
+
+	if sentTransaction.ResultCodes != nil {
+		var codes bridge.PaymentResultCodes
+		err = json.Unmarshal([]byte(*sentTransaction.ResultCodes), &codes)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error decoding result codes")
+		} else {
+			response.ResultCodes = &codes
+		}
+	}
+
+	helpers.Write(w, response)
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/services/bridge/internal/config"
+	"github.com/stellar/go/services/bridge/internal/db"
+	"github.com/stellar/go/services/bridge/internal/submitter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// paymentDatabase returns the stored sent transaction of a payment.
+type paymentDatabase struct {
+	db.Database
+	sentTransaction *db.SentTransaction
+}
+
+func (d *paymentDatabase) GetSentTransactionByPaymentID(paymentID string) (*db.SentTransaction, error) {
+	if d.sentTransaction == nil || d.sentTransaction.PaymentID.String != paymentID {
+		return nil, nil
+	}
+	return d.sentTransaction, nil
+}
+
+// resubmitter records the transactions resubmitted by the handler.
+type resubmitter struct {
+	submitter.TransactionSubmitterInterface
+	resubmitted []*db.SentTransaction
+}
+
+func (s *resubmitter) ResubmitTransaction(transaction *db.SentTransaction) (horizon.TransactionSuccess, error) {
+	s.resubmitted = append(s.resubmitted, transaction)
+	return horizon.TransactionSuccess{Hash: transaction.TransactionID, Ledger: 12}, nil
+}
+
+func TestPaymentReplaysSentTransaction(t *testing.T) {
+	const (
+		seed     = "SDOTALIMPAM2IV65IOZA7KZL7XWZI5BODFXTRVLIHLQZQCKK57PH5F3H"
+		envelope = "AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAACgAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAEAAAAAO2C/AO45YBD3tHVFO1R3A0MekP8JR6nN1A9eWidyItUAAAABVVNEAAAAAABi/B0L0JGythwN1lY0aypo19NHxvLCyO5tBEcCVvwF9w3gtrOnZAAAAAAAAAAAAAFW/AX3AAAAQAaT9l0o2ESDAnhABxgcQUAH7T9KQYc2a0CS6r8j82dEXkBNmsxvZzd9UwhyXa/vXMjZeWiFh5vjT4nowQ7ArAM="
+	)
+	result := "AAAAAAAAAGT/////AAAAAQAAAAAAAAAB////+wAAAAA="
+	resultCodes := `{"transaction":"tx_failed","operations":["op_no_destination"]}`
+	ledger := int32(10)
+
+	for _, tc := range []struct {
+		name            string
+		sentTransaction *db.SentTransaction
+		status          int
+		body            map[string]interface{}
+		resubmitted     bool
+	}{
+		{
+			name: "success",
+			sentTransaction: &db.SentTransaction{
+				TransactionID: "abc",
+				Status:        db.SentTransactionStatusSuccess,
+				Ledger:        &ledger,
+				EnvelopeXdr:   envelope,
+				ResultXdr:     &result,
+			},
+			status: http.StatusOK,
+			body: map[string]interface{}{
+				"hash":         "abc",
+				"ledger":       float64(10),
+				"envelope_xdr": envelope,
+				"result_xdr":   result,
+			},
+		},
+		{
+			name: "failure",
+			sentTransaction: &db.SentTransaction{
+				TransactionID: "abc",
+				Status:        db.SentTransactionStatusFailure,
+				EnvelopeXdr:   envelope,
+				ResultXdr:     &result,
+				ResultCodes:   &resultCodes,
+			},
+			status: http.StatusBadRequest,
+			body: map[string]interface{}{
+				"type":   "https://stellar.org/horizon-errors/transaction_failed",
+				"title":  "Transaction Failed",
+				"status": float64(http.StatusBadRequest),
+				"extras": map[string]interface{}{
+					"envelope_xdr": envelope,
+					"result_xdr":   result,
+					"result_codes": map[string]interface{}{
+						"transaction": "tx_failed",
+						"operations":  []interface{}{"op_no_destination"},
+					},
+				},
+			},
+		},
+		{
+			name: "sending",
+			sentTransaction: &db.SentTransaction{
+				TransactionID: "abc",
+				Status:        db.SentTransactionStatusSending,
+				EnvelopeXdr:   envelope,
+			},
+			status: http.StatusOK,
+			body: map[string]interface{}{
+				"hash":   "abc",
+				"ledger": float64(12),
+			},
+			resubmitted: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.sentTransaction.PaymentID = sql.NullString{String: "payment-1", Valid: true}
+			ts := &resubmitter{}
+			rh := &RequestHandler{
+				Config:               &config.Config{},
+				Database:             &paymentDatabase{sentTransaction: tc.sentTransaction},
+				TransactionSubmitter: ts,
+			}
+
+			form := url.Values{
+				"id":          {"payment-1"},
+				"source":      {seed},
+				"destination": {"GBIUXI4S27PSL6TTJCJMPYDCF3K6AW2MYORFRTC7QBFE6NNEGVOQK46H"},
+				"amount":      {"20"},
+			}
+			r := httptest.NewRequest("POST", "/payment", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			rh.Payment(w, r)
+
+			assert.Equal(t, tc.status, w.Code)
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			for key, expected := range tc.body {
+				assert.Equal(t, expected, body[key], key)
+			}
+
+			if tc.resubmitted {
+				assert.Equal(t, []*db.SentTransaction{tc.sentTransaction}, ts.resubmitted)
+			} else {
+				assert.Empty(t, ts.resubmitted)
+			}
+		})
+	}
+}
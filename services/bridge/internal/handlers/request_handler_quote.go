@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stellar/go/address"
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/services/bridge/internal/db"
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/http/helpers"
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/protocols"
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/protocols/bridge"
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	// defaultQuoteSlippage is the fraction of the source amount added to the
+	// send_max of quotes when `quotes.slippage` is not set.
+	defaultQuoteSlippage = 0.01
+	// defaultQuoteTTL is the time a quote can be used for when `quotes.ttl` is
+	// not set.
+	defaultQuoteTTL = 60 * time.Second
+	// maxQuotes is the maximum number of quotes returned by a single request.
+	maxQuotes = 10
+)
+
+// Quote implements /quote endpoint
+func (rh *RequestHandler) Quote(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.QuoteRequest{}
+	err := helpers.FromRequest(r, request)
+	if err != nil {
+		log.Error(err.Error())
+		helpers.Write(w, helpers.InvalidParameterError)
+		return
+	}
+
+	err = helpers.Validate(request)
+	if err != nil {
+		switch err := err.(type) {
+		case *helpers.ErrorResponse:
+			helpers.Write(w, err)
+		default:
+			log.Error(err)
+			helpers.Write(w, helpers.InternalServerError)
+		}
+		return
+	}
+
+	source := request.Source
+	if source == "" {
+		if rh.Config.Accounts.BaseSeed == "" {
+			helpers.Write(w, helpers.NewMissingParameter("source"))
+			return
+		}
+		source = keypair.MustParse(rh.Config.Accounts.BaseSeed).Address()
+	}
+
+	destinationAccount := request.Destination
+	if _, _, err = address.Split(request.Destination); err == nil {
+		destinationObject, err := rh.FederationResolver.LookupByAddress(request.Destination)
+		if err != nil {
+			log.WithFields(log.Fields{"destination": request.Destination, "err": err}).Print("Cannot resolve address")
+			helpers.Write(w, bridge.PaymentCannotResolveDestination)
+			return
+		}
+		destinationAccount = destinationObject.AccountID
+	}
+
+	slippage := rh.quoteSlippage()
+	if request.Slippage != "" {
+		slippage, _ = strconv.ParseFloat(request.Slippage, 64)
+	}
+
+	destinationAsset := horizon.Asset{Type: "native"}
+	if request.AssetCode != "" && request.AssetIssuer != "" {
+		destinationAsset = horizon.Asset{
+			Type:   assetType(request.AssetCode),
+			Code:   request.AssetCode,
+			Issuer: request.AssetIssuer,
+		}
+	}
+
+	paths, err := rh.Horizon.LoadPaths(source, destinationAccount, destinationAsset, request.Amount)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error loading paths")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+
+	var records []horizon.Path
+	for _, path := range paths.Embedded.Records {
+		if request.SendAssetCode != "" &&
+			(path.SourceAssetCode != request.SendAssetCode || path.SourceAssetIssuer != request.SendAssetIssuer) {
+			continue
+		}
+		records = append(records, path)
+	}
+
+	if len(records) == 0 {
+		helpers.Write(w, bridge.QuoteNoPath)
+		return
+	}
+
+	// The cheapest paths first
+	sort.SliceStable(records, func(i, j int) bool {
+		a, _ := amount.ParseInt64(records[i].SourceAmount)
+		b, _ := amount.ParseInt64(records[j].SourceAmount)
+		return a < b
+	})
+	if len(records) > maxQuotes {
+		records = records[:maxQuotes]
+	}
+
+	now := time.Now()
+	response := &bridge.QuoteResponse{}
+	for _, path := range records {
+		quote, err := rh.newQuote(source, request, destinationAccount, path, slippage, now)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error creating quote")
+			helpers.Write(w, helpers.InternalServerError)
+			return
+		}
+		response.Quotes = append(response.Quotes, quote)
+	}
+
+	helpers.Write(w, response)
+}
+
+// newQuote saves the quote of `path` and returns it.
+func (rh *RequestHandler) newQuote(
+	source string,
+	request *bridge.QuoteRequest,
+	destinationAccount string,
+	path horizon.Path,
+	slippage float64,
+	now time.Time,
+) (bridge.Quote, error) {
+	sourceAmount, err := amount.ParseInt64(path.SourceAmount)
+	if err != nil {
+		return bridge.Quote{}, errors.Wrap(err, "Invalid source amount")
+	}
+	sendMax := amount.StringFromInt64(int64(math.Ceil(float64(sourceAmount) * (1 + slippage))))
+
+	assets := []protocols.Asset{}
+	for _, asset := range path.Path {
+		if asset.Type == "native" {
+			assets = append(assets, protocols.Asset{})
+		} else {
+			assets = append(assets, protocols.Asset{asset.Code, asset.Issuer})
+		}
+	}
+	rawPath, err := json.Marshal(assets)
+	if err != nil {
+		return bridge.Quote{}, errors.Wrap(err, "Error encoding path")
+	}
+
+	rawID := make([]byte, 16)
+	_, err = rand.Read(rawID)
+	if err != nil {
+		return bridge.Quote{}, errors.Wrap(err, "Error generating quote ID")
+	}
+
+	quote := &db.Quote{
+		ID:                 hex.EncodeToString(rawID),
+		SourceAccount:      source,
+		Destination:        request.Destination,
+		DestinationAccount: destinationAccount,
+		DestinationAmount:  request.Amount,
+		AssetCode:          request.AssetCode,
+		AssetIssuer:        request.AssetIssuer,
+		SendAssetCode:      path.SourceAssetCode,
+		SendAssetIssuer:    path.SourceAssetIssuer,
+		SourceAmount:       path.SourceAmount,
+		SendMax:            sendMax,
+		Path:               string(rawPath),
+		CreatedAt:          now,
+		ExpiresAt:          now.Add(rh.quoteTTL()),
+	}
+
+	err = rh.Database.InsertQuote(quote)
+	if err != nil {
+		return bridge.Quote{}, err
+	}
+
+	return bridge.Quote{
+		ID:                quote.ID,
+		SendAssetCode:     quote.SendAssetCode,
+		SendAssetIssuer:   quote.SendAssetIssuer,
+		SourceAmount:      quote.SourceAmount,
+		SendMax:           quote.SendMax,
+		Path:              assets,
+		DestinationAmount: path.DestinationAmount,
+		ExpiresAt:         quote.ExpiresAt,
+	}, nil
+}
+
+// applyQuote sets the path and send_max of the quote referenced by a payment
+// request. It returns an error response if the quote cannot be used.
+func (rh *RequestHandler) applyQuote(request *bridge.PaymentRequest) (*helpers.ErrorResponse, error) {
+	quote, err := rh.Database.GetQuoteByID(request.QuoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote == nil {
+		return bridge.QuoteNotFound, nil
+	}
+
+	if time.Now().After(quote.ExpiresAt) {
+		return bridge.QuoteExpired, nil
+	}
+
+	kp, err := keypair.Parse(request.Source)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid source")
+	}
+
+	if kp.Address() != quote.SourceAccount ||
+		request.Destination != quote.Destination ||
+		request.AssetCode != quote.AssetCode ||
+		request.AssetIssuer != quote.AssetIssuer {
+		return bridge.QuoteMismatch, nil
+	}
+
+	destinationAmount, err := amount.ParseInt64(request.Amount)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid amount")
+	}
+	quoteAmount, err := amount.ParseInt64(quote.DestinationAmount)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid quote amount")
+	}
+	if destinationAmount != quoteAmount {
+		return bridge.QuoteMismatch, nil
+	}
+
+	var path []protocols.Asset
+	err = json.Unmarshal([]byte(quote.Path), &path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error decoding quote path")
+	}
+
+	request.SendMax = quote.SendMax
+	request.SendAssetCode = quote.SendAssetCode
+	request.SendAssetIssuer = quote.SendAssetIssuer
+	request.Path = path
+	return nil, nil
+}
+
+func (rh *RequestHandler) quoteSlippage() float64 {
+	if rh.Config.Quotes.Slippage != nil {
+		return *rh.Config.Quotes.Slippage
+	}
+	return defaultQuoteSlippage
+}
+
+func (rh *RequestHandler) quoteTTL() time.Duration {
+	if rh.Config.Quotes.TTL > 0 {
+		return time.Duration(rh.Config.Quotes.TTL) * time.Second
+	}
+	return defaultQuoteTTL
+}
+
+// assetType returns the type of the credit asset with the given code.
+func assetType(code string) string {
+	if len(code) <= 4 {
+		return "credit_alphanum4"
+	}
+	return "credit_alphanum12"
+}
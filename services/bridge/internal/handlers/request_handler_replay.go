@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/http/helpers"
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/protocols/bridge"
+)
+
+// AdminReplay implements /admin/replay endpoint
+func (rh *RequestHandler) AdminReplay(w http.ResponseWriter, r *http.Request) {
+	request := &bridge.ReplayRequest{}
+	err := helpers.FromRequest(r, request)
+	if err != nil {
+		log.Error(err.Error())
+		helpers.Write(w, helpers.InvalidParameterError)
+		return
+	}
+
+	err = helpers.Validate(request)
+	if err != nil {
+		switch err := err.(type) {
+		case *helpers.ErrorResponse:
+			helpers.Write(w, err)
+		default:
+			log.Error(err)
+			helpers.Write(w, helpers.InternalServerError)
+		}
+		return
+	}
+
+	// The replay runs in the background, its results are logged.
+	endCursor, err := rh.PaymentListener.StartReplay(request.AccountID, request.Cursor, request.EndCursor)
+	response := &bridge.ReplayResponse{
+		Status:    "ok",
+		Message:   "Replay started",
+		Cursor:    request.Cursor,
+		EndCursor: endCursor,
+	}
+	if err != nil {
+		response.Status = "error"
+		response.Message = err.Error()
+	}
+
+	helpers.Write(w, response)
+}
@@ -2,55 +2,58 @@ package listener
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strings"
+	"strconv"
+	"sync"
 	"time"
 
-	"encoding/base64"
-
 	"github.com/sirupsen/logrus"
 	"github.com/stellar/go/clients/horizon"
 	"github.com/stellar/go/protocols/compliance"
+	"github.com/stellar/go/services/bridge/internal/callbacks"
 	"github.com/stellar/go/services/bridge/internal/config"
 	"github.com/stellar/go/services/bridge/internal/db"
 	callback "github.com/stellar/go/services/internal/bridge-compliance-shared/protocols/compliance"
-	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/support/errors"
 )
 
-// PaymentListener is listening for a new payments received by ReceivingAccount
+// PaymentListener is listening for a new payments received by the receiving
+// accounts
 type PaymentListener struct {
-	client   HTTP
-	config   *config.Config
-	database db.Database
-	horizon  horizon.ClientInterface
-	log      *logrus.Entry
-	now      func() time.Time
+	callbacks *callbacks.Sender
+	config    *config.Config
+	database  db.Database
+	horizon   horizon.ClientInterface
+	log       *logrus.Entry
+	now       func() time.Time
+
+	// accountLocks serialize the processing of the payments of every
+	// receiving account between the listener and replays.
+	accountLocks map[string]*sync.Mutex
+	// replaying is the set of accounts being replayed, guarded by lock.
+	replaying map[string]bool
+	lock      *sync.Mutex
 }
 
-// HTTP represents an http client that a payment listener can use to make HTTP
-// requests.
-type HTTP interface {
-	Do(req *http.Request) (resp *http.Response, err error)
-}
+// ErrReplayInProgress is returned when a replay of the account is already
+// running.
+var ErrReplayInProgress = errors.New("A replay of the account is already running")
 
-const callbackTimeout = 60 * time.Second
+// replayPageLimit is the number of payments loaded at once when replaying.
+const replayPageLimit = 200
 
 // NewPaymentListener creates a new PaymentListener
 func NewPaymentListener(
 	config *config.Config,
 	database db.Database,
 	horizon horizon.ClientInterface,
+	callbacks *callbacks.Sender,
 	now func() time.Time,
 ) (pl PaymentListener, err error) {
-	pl.client = &http.Client{
-		Timeout: callbackTimeout,
-	}
+	pl.callbacks = callbacks
 	pl.config = config
 	pl.database = database
 	pl.horizon = horizon
@@ -58,49 +61,200 @@ func NewPaymentListener(
 	pl.log = logrus.WithFields(logrus.Fields{
 		"service": "PaymentListener",
 	})
+	pl.accountLocks = make(map[string]*sync.Mutex)
+	for _, accountID := range config.ReceivingAccounts() {
+		pl.accountLocks[accountID] = &sync.Mutex{}
+	}
+	pl.replaying = make(map[string]bool)
+	pl.lock = &sync.Mutex{}
 	return
 }
 
-// Listen starts listening for new payments
+// Listen starts listening for new payments received by every receiving
+// account.  Each account is streamed from its own cursor, which is persisted
+// after every payment.
 func (pl *PaymentListener) Listen() (err error) {
-	accountID := pl.config.Accounts.ReceivingAccountID
+	accountIDs := pl.config.ReceivingAccounts()
+
+	for _, accountID := range accountIDs {
+		_, err = pl.horizon.LoadAccount(accountID)
+		if err != nil {
+			return errors.Wrap(err, "Error loading receiving account "+accountID)
+		}
+	}
+
+	for _, accountID := range accountIDs {
+		go pl.listen(accountID)
+	}
 
-	_, err = pl.horizon.LoadAccount(accountID)
+	return
+}
+
+func (pl *PaymentListener) listen(accountID string) {
+	for {
+		cursor, err := pl.loadCursor(accountID)
+		if err != nil {
+			pl.log.WithFields(logrus.Fields{"accountId": accountID, "error": err}).Error("Could not load last cursor from the DB")
+			return
+		}
+
+		pl.log.WithFields(logrus.Fields{
+			"accountId": accountID,
+			"cursor":    cursor,
+		}).Info("Started listening for new payments")
+
+		err = pl.horizon.StreamPayments(context.Background(), accountID, &cursor, func(payment horizon.Payment) {
+			pl.accountLocks[accountID].Lock()
+			defer pl.accountLocks[accountID].Unlock()
+			pl.onPayment(accountID, payment)
+			pl.saveCursor(accountID, payment.PagingToken)
+		})
+		if err != nil {
+			pl.log.Error("Error while streaming: ", err)
+			pl.log.Info("Sleeping...")
+			time.Sleep(10 * time.Second)
+		}
+	}
+}
+
+// loadCursor returns the cursor `accountID` is streamed from.  Accounts
+// without a persisted cursor are streamed from `now`, except for
+// accounts.receiving_account_id which resumes from the last received payment
+// saved before cursors were persisted per account.
+func (pl *PaymentListener) loadCursor(accountID string) (horizon.Cursor, error) {
+	listenerCursor, err := pl.database.GetListenerCursor(accountID)
 	if err != nil {
-		return
+		return "", err
+	}
+	if listenerCursor != nil {
+		return horizon.Cursor(listenerCursor.PagingToken), nil
 	}
 
+	if accountID == pl.config.Accounts.ReceivingAccountID {
+		cursorValue, err := pl.database.GetLastCursorValue()
+		if err != nil {
+			return "", err
+		}
+		if cursorValue != nil {
+			return horizon.Cursor(*cursorValue), nil
+		}
+	}
+
+	// If no last cursor saved set it to: `now`
+	return horizon.Cursor("now"), nil
+}
+
+func (pl *PaymentListener) saveCursor(accountID, pagingToken string) {
+	err := pl.database.SaveListenerCursor(&db.ListenerCursor{
+		AccountID:   accountID,
+		PagingToken: pagingToken,
+		UpdatedAt:   pl.now(),
+	})
+	if err != nil {
+		pl.log.WithFields(logrus.Fields{"accountId": accountID, "err": err}).Error("Error saving listener cursor")
+	}
+}
+
+// StartReplay processes again in the background the payments of `accountID`
+// after `cursor` and up to `endCursor`, or up to the persisted cursor of the
+// account when `endCursor` is empty.  Payments are processed one at a time
+// with the payments streamed by the listener, and payments which were already
+// processed are skipped, so a replay only catches up with payments missed by
+// the listener.  The persisted cursor is left untouched.  It returns the end
+// cursor of the replay.
+func (pl *PaymentListener) StartReplay(accountID, cursor, endCursor string) (string, error) {
+	if pl.config == nil || !pl.isReceivingAccount(accountID) {
+		return "", errors.New("Not a receiving account")
+	}
+
+	from, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil {
+		return "", errors.New("Invalid cursor")
+	}
+
+	if endCursor == "" {
+		listenerCursor, err := pl.database.GetListenerCursor(accountID)
+		if err != nil {
+			return "", errors.Wrap(err, "Error loading listener cursor")
+		}
+		if listenerCursor == nil {
+			return "", errors.New("No end_cursor given and no cursor persisted for the account")
+		}
+		endCursor = listenerCursor.PagingToken
+	}
+
+	to, err := strconv.ParseInt(endCursor, 10, 64)
+	if err != nil {
+		return "", errors.New("Invalid end_cursor")
+	}
+	if to <= from {
+		return "", errors.New("end_cursor must be after cursor")
+	}
+
+	pl.lock.Lock()
+	defer pl.lock.Unlock()
+	if pl.replaying[accountID] {
+		return "", ErrReplayInProgress
+	}
+	pl.replaying[accountID] = true
+
 	go func() {
-		for {
-			cursorValue, err := pl.database.GetLastCursorValue()
-			if err != nil {
-				pl.log.WithFields(logrus.Fields{"error": err}).Error("Could not load last cursor from the DB")
+		defer func() {
+			pl.lock.Lock()
+			delete(pl.replaying, accountID)
+			pl.lock.Unlock()
+		}()
+		pl.replay(accountID, cursor, to)
+	}()
+
+	return endCursor, nil
+}
+
+// replay processes the payments of `accountID` after `cursor` whose paging
+// token is not after `end`.
+func (pl *PaymentListener) replay(accountID, cursor string, end int64) {
+	log := pl.log.WithFields(logrus.Fields{"accountId": accountID, "cursor": cursor, "endCursor": end})
+	log.Info("Replaying payments")
+
+	var processed, skipped int
+	for {
+		page, err := pl.horizon.LoadAccountPayments(
+			accountID,
+			horizon.Cursor(cursor),
+			horizon.OrderAsc,
+			horizon.Limit(replayPageLimit),
+		)
+		if err != nil {
+			log.WithFields(logrus.Fields{"err": err, "processed": processed, "skipped": skipped, "last": cursor}).Error("Error loading payments to replay")
+			return
+		}
+
+		for _, payment := range page.Embedded.Records {
+			token, _ := strconv.ParseInt(payment.PagingToken, 10, 64)
+			if token > end {
+				log.WithFields(logrus.Fields{"processed": processed, "skipped": skipped}).Info("Replay finished")
 				return
 			}
 
-			var cursor horizon.Cursor
-			if cursorValue != nil {
-				cursor = horizon.Cursor(*cursorValue)
+			if pl.replayPayment(accountID, payment) {
+				processed++
 			} else {
-				// If no last cursor saved set it to: `now`
-				cursor = horizon.Cursor("now")
+				skipped++
 			}
+			cursor = payment.PagingToken
+		}
 
-			pl.log.WithFields(logrus.Fields{
-				"accountId": accountID,
-				"cursor":    cursor,
-			}).Info("Started listening for new payments")
-
-			err = pl.horizon.StreamPayments(context.Background(), accountID, &cursor, pl.onPayment)
-			if err != nil {
-				pl.log.Error("Error while streaming: ", err)
-				pl.log.Info("Sleeping...")
-				time.Sleep(10 * time.Second)
-			}
+		if len(page.Embedded.Records) < replayPageLimit {
+			log.WithFields(logrus.Fields{"processed": processed, "skipped": skipped}).Info("Replay finished")
+			return
 		}
-	}()
+	}
+}
 
-	return
+func (pl *PaymentListener) replayPayment(accountID string, payment horizon.Payment) bool {
+	pl.accountLocks[accountID].Lock()
+	defer pl.accountLocks[accountID].Unlock()
+	return pl.onPayment(accountID, payment)
 }
 
 func (pl *PaymentListener) ReprocessPayment(payment horizon.Payment, force bool) error {
@@ -143,18 +297,28 @@ func (pl *PaymentListener) ReprocessPayment(payment horizon.Payment, force bool)
 	return pl.database.UpdateReceivedPayment(existingPayment)
 }
 
-func (pl *PaymentListener) onPayment(payment horizon.Payment) {
-	pl.log.WithFields(logrus.Fields{"id": payment.ID}).Info("New received payment")
+// onPayment processes an operation streamed for `accountID`.  It returns false
+// when the operation was skipped because it was already processed or was sent
+// by the account.
+func (pl *PaymentListener) onPayment(accountID string, payment horizon.Payment) bool {
+	pl.log.WithFields(logrus.Fields{"id": payment.ID, "accountId": accountID}).Info("New received payment")
+
+	// Operations sent by a receiving account are also streamed for the
+	// account receiving them, which may be listened to as well.
+	if isPaymentOperation(payment) && !isReceivedBy(accountID, payment) {
+		pl.log.WithFields(logrus.Fields{"id": payment.ID}).Info("Operation sent not received")
+		return false
+	}
 
 	existingPayment, err := pl.database.GetReceivedPaymentByOperationID(payment.ID)
 	if err != nil {
 		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error checking if receive payment exists")
-		return
+		return false
 	}
 
 	if existingPayment != nil {
 		pl.log.WithFields(logrus.Fields{"id": payment.ID}).Info("Payment already exists")
-		return
+		return false
 	}
 
 	dbPayment := &db.ReceivedPayment{
@@ -167,10 +331,11 @@ func (pl *PaymentListener) onPayment(payment horizon.Payment) {
 
 	err = pl.database.InsertReceivedPayment(dbPayment)
 	if err != nil {
-		return
+		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error inserting payment")
+		return false
 	}
 
-	process, status := pl.shouldProcessPayment(payment)
+	process, status := pl.shouldProcessPayment(accountID, payment)
 	if !process {
 		dbPayment.Status = status
 		pl.log.Info(status)
@@ -189,14 +354,14 @@ func (pl *PaymentListener) onPayment(payment horizon.Payment) {
 	err = pl.database.UpdateReceivedPayment(dbPayment)
 	if err != nil {
 		pl.log.WithFields(logrus.Fields{"err": err}).Error("Error updating payment")
-		return
 	}
+	return true
 }
 
 // shouldProcessPayment returns false and text status if payment should not be processed
 // (ex. asset is different than allowed assets).
-func (pl *PaymentListener) shouldProcessPayment(payment horizon.Payment) (bool, string) {
-	if payment.Type != "payment" && payment.Type != "path_payment" && payment.Type != "account_merge" {
+func (pl *PaymentListener) shouldProcessPayment(accountID string, payment horizon.Payment) (bool, string) {
+	if !isPaymentOperation(payment) {
 		return false, "Not a payment operation"
 	}
 
@@ -204,17 +369,41 @@ func (pl *PaymentListener) shouldProcessPayment(payment horizon.Payment) (bool,
 		payment.AssetType = "native"
 	}
 
-	if payment.To != pl.config.Accounts.ReceivingAccountID && payment.Into != pl.config.Accounts.ReceivingAccountID {
+	if !isReceivedBy(accountID, payment) {
 		return false, "Operation sent not received"
 	}
 
-	if !pl.isAssetAllowed(payment.AssetType, payment.AssetCode, payment.AssetIssuer) {
+	if !pl.isAssetAllowed(accountID, payment.AssetType, payment.AssetCode, payment.AssetIssuer) {
 		return false, "Asset not allowed"
 	}
 
 	return true, ""
 }
 
+// isPaymentOperation returns true for the operations bringing value to their
+// destination: payments, path payments and account merges.
+func isPaymentOperation(payment horizon.Payment) bool {
+	return payment.Type == "payment" || payment.Type == "path_payment" || payment.Type == "account_merge"
+}
+
+// isReceivedBy returns true if `accountID` is the destination of the payment
+// operation.
+func isReceivedBy(accountID string, payment horizon.Payment) bool {
+	if payment.Type == "account_merge" {
+		return payment.Into == accountID
+	}
+	return payment.To == accountID
+}
+
+func (pl *PaymentListener) isReceivingAccount(accountID string) bool {
+	for _, id := range pl.config.ReceivingAccounts() {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
+}
+
 func (pl *PaymentListener) process(payment horizon.Payment) error {
 	if payment.Type == "account_merge" {
 		payment.AssetType = "native"
@@ -244,7 +433,7 @@ func (pl *PaymentListener) process(payment horizon.Payment) error {
 
 		pl.log.WithFields(logrus.Fields{"url": complianceRequestURL, "body": complianceRequestBody}).Info("Sending request to compliance server")
 		var resp *http.Response
-		resp, err = pl.postForm(complianceRequestURL, complianceRequestBody)
+		resp, err = pl.callbacks.PostForm(complianceRequestURL, complianceRequestBody)
 		if err != nil {
 			return errors.Wrap(err, "Error sending request to compliance server")
 		}
@@ -286,44 +475,33 @@ func (pl *PaymentListener) process(payment horizon.Payment) error {
 		route = payment.Memo.Value
 	}
 
-	resp, err := pl.postForm(
-		pl.config.Callbacks.Receive,
-		url.Values{
-			"id":             {payment.ID},
-			"from":           {payment.From},
-			"route":          {route},
-			"amount":         {payment.Amount},
-			"asset_code":     {payment.AssetCode},
-			"asset_issuer":   {payment.AssetIssuer},
-			"memo_type":      {payment.Memo.Type},
-			"memo":           {payment.Memo.Value},
-			"data":           {receiveResponse.Data},
-			"transaction_id": {payment.TransactionHash},
-		},
-	)
-	if err != nil {
-		return errors.Wrap(err, "Error sending request to receive callback")
+	form := url.Values{
+		"id":                  {payment.ID},
+		"type":                {payment.Type},
+		"from":                {payment.From},
+		"to":                  {payment.To},
+		"route":               {route},
+		"amount":              {payment.Amount},
+		"asset_code":          {payment.AssetCode},
+		"asset_issuer":        {payment.AssetIssuer},
+		"source_amount":       {payment.SourceAmount},
+		"source_asset_code":   {payment.SourceAssetCode},
+		"source_asset_issuer": {payment.SourceAssetIssuer},
+		"memo_type":           {payment.Memo.Type},
+		"memo":                {payment.Memo.Value},
+		"data":                {receiveResponse.Data},
+		"transaction_id":      {payment.TransactionHash},
 	}
 
-	if resp.StatusCode != 200 {
-		defer resp.Body.Close()
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return errors.Wrap(err, "Error reading receive callback response")
-		}
-
-		pl.log.WithFields(logrus.Fields{
-			"status": resp.StatusCode,
-			"body":   string(body),
-		}).Error("Error response from receive callback")
-		return errors.New("Error response from receive callback")
-	}
-
-	return nil
+	return pl.callbacks.Send(db.QueuedCallbackTypeReceive, payment.ID, pl.config.Callbacks.Receive, form)
 }
 
-func (pl *PaymentListener) isAssetAllowed(asset_type string, code string, issuer string) bool {
+func (pl *PaymentListener) isAssetAllowed(accountID string, asset_type string, code string, issuer string) bool {
 	for _, asset := range pl.config.Assets {
+		if !isAssetReceivedBy(asset, accountID) {
+			continue
+		}
+
 		if asset.Code == code && asset.Issuer == issuer {
 			return true
 		}
@@ -336,47 +514,15 @@ func (pl *PaymentListener) isAssetAllowed(asset_type string, code string, issuer
 	return false
 }
 
-func (pl *PaymentListener) postForm(
-	url string,
-	form url.Values,
-) (*http.Response, error) {
-
-	strbody := form.Encode()
-
-	req, err := http.NewRequest("POST", url, strings.NewReader(strbody))
-	if err != nil {
-		return nil, errors.Wrap(err, "configure http request failed")
+// isAssetReceivedBy returns true if `asset` can be received by `accountID`.
+func isAssetReceivedBy(asset config.Asset, accountID string) bool {
+	if len(asset.ReceivingAccountIDs) == 0 {
+		return true
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	if pl.config.MACKey != "" {
-		var rawMAC []byte
-		rawMAC, err = pl.getMAC(pl.config.MACKey, []byte(strbody))
-		if err != nil {
-			return nil, errors.Wrap(err, "getMAC failed")
+	for _, id := range asset.ReceivingAccountIDs {
+		if id == accountID {
+			return true
 		}
-
-		encMAC := base64.StdEncoding.EncodeToString(rawMAC)
-		req.Header.Set("X_PAYLOAD_MAC", encMAC)
-		req.Header.Set("X-Payload-Mac", encMAC)
 	}
-
-	resp, err := pl.client.Do(req)
-	if err != nil {
-		return nil, errors.Wrap(err, "http request errored")
-	}
-
-	return resp, nil
-}
-
-func (pl *PaymentListener) getMAC(key string, raw []byte) ([]byte, error) {
-
-	rawkey, err := strkey.Decode(strkey.VersionByteSeed, pl.config.MACKey)
-	if err != nil {
-		return nil, errors.Wrap(err, "invalid MAC key")
-	}
-
-	macer := hmac.New(sha256.New, rawkey)
-	macer.Write(raw)
-	return macer.Sum(nil), nil
+	return false
 }
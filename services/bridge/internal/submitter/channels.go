@@ -0,0 +1,181 @@
+package submitter
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+)
+
+// MaxChannels is the maximum number of channel accounts created from the base
+// account, which is the maximum number of operations in a transaction.
+const MaxChannels = 100
+
+// DefaultChannelStartingBalance is the starting balance of channel accounts
+// created from the base account, in lumens.  It covers the minimum balance of
+// a channel along with the fees of many transactions.
+const DefaultChannelStartingBalance = "5"
+
+// channelBorrowTimeout is the maximum time a submission waits for a channel
+// to be available.
+const channelBorrowTimeout = 30 * time.Second
+
+// ErrNoChannelAvailable is returned when no channel account is available to
+// submit a transaction.
+var ErrNoChannelAvailable = errors.New("No channel account available")
+
+// ChannelPool is a pool of channel accounts.  Channels are used as the source
+// of transactions, so that transactions of the same account can be submitted
+// concurrently: each channel has its own sequence number and is only used by
+// one transaction at a time.
+type ChannelPool struct {
+	channels chan *Account
+	size     int
+	timeout  time.Duration
+}
+
+// NewChannelPool creates a pool of the provided channel accounts.
+func NewChannelPool(accounts []*Account) *ChannelPool {
+	pool := &ChannelPool{
+		channels: make(chan *Account, len(accounts)),
+		size:     len(accounts),
+		timeout:  channelBorrowTimeout,
+	}
+	for _, account := range accounts {
+		pool.channels <- account
+	}
+	return pool
+}
+
+// Size returns the number of channels of the pool.
+func (p *ChannelPool) Size() int {
+	return p.size
+}
+
+// Borrow takes a channel out of the pool, waiting for one to be returned if
+// all the channels are in use.  Channels must be returned using Return.
+func (p *ChannelPool) Borrow() (*Account, error) {
+	select {
+	case channel := <-p.channels:
+		return channel, nil
+	case <-time.After(p.timeout):
+		return nil, ErrNoChannelAvailable
+	}
+}
+
+// Return puts a channel borrowed using Borrow back into the pool.
+func (p *ChannelPool) Return(channel *Account) {
+	p.channels <- channel
+}
+
+// InitChannels loads the channel accounts of the provided seeds along with
+// `count` channels derived from the base account.  Derived channels that do
+// not exist yet are created and funded with `startingBalance` lumens by the
+// base account.  Transactions built by SubmitTransaction are then submitted
+// using the channels as source.
+func (ts *TransactionSubmitter) InitChannels(baseSeed string, seeds []string, count int, startingBalance string) error {
+	if count > 0 {
+		derived, err := ts.createChannels(baseSeed, count, startingBalance)
+		if err != nil {
+			return errors.Wrap(err, "Error creating channels")
+		}
+		seeds = append(seeds, derived...)
+	}
+
+	var accounts []*Account
+	for _, seed := range seeds {
+		account, err := ts.LoadAccount(seed)
+		if err != nil {
+			return errors.Wrap(err, "Error loading a channel")
+		}
+		accounts = append(accounts, account)
+	}
+
+	if len(accounts) > 0 {
+		ts.Channels = NewChannelPool(accounts)
+	}
+	return nil
+}
+
+// createChannels creates the missing channels derived from the base account
+// and returns the seeds of all of them.
+func (ts *TransactionSubmitter) createChannels(baseSeed string, count int, startingBalance string) ([]string, error) {
+	if count > MaxChannels {
+		return nil, errors.Errorf("At most %d channels can be created", MaxChannels)
+	}
+	if startingBalance == "" {
+		startingBalance = DefaultChannelStartingBalance
+	}
+
+	var (
+		seeds     []string
+		mutators  = []build.TransactionMutator{build.SourceAccount{baseSeed}, ts.Network}
+		operation = 0
+	)
+	for i := 0; i < count; i++ {
+		channel, err := DeriveChannel(baseSeed, i)
+		if err != nil {
+			return nil, err
+		}
+		seeds = append(seeds, channel.Seed())
+
+		_, err = ts.Horizon.LoadAccount(channel.Address())
+		if err == nil {
+			continue
+		}
+		if herr, ok := err.(*horizon.Error); !ok || herr.Problem.Status != http.StatusNotFound {
+			return nil, errors.Wrap(err, "Error loading a channel")
+		}
+
+		ts.log.WithFields(logrus.Fields{"channel": channel.Address()}).Info("Creating channel")
+		mutators = append(mutators, build.CreateAccount(
+			build.Destination{channel.Address()},
+			build.NativeAmount{startingBalance},
+		))
+		operation++
+	}
+
+	if operation == 0 {
+		return seeds, nil
+	}
+
+	txBuilder, err := build.Transaction(mutators...)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error building a transaction")
+	}
+
+	_, err = ts.SignAndSubmitRawTransaction(nil, baseSeed, txBuilder.TX)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error submitting a transaction")
+	}
+	return seeds, nil
+}
+
+// DeriveChannel returns the keypair of the channel at `index` derived from the
+// base account seed.  Derived channels are the same across restarts, so that
+// channels are only created once.
+func DeriveChannel(baseSeed string, index int) (*keypair.Full, error) {
+	rawSeed, err := strkey.Decode(strkey.VersionByteSeed, baseSeed)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid base seed")
+	}
+
+	var i [4]byte
+	binary.BigEndian.PutUint32(i[:], uint32(index))
+
+	h := sha256.New()
+	h.Write(rawSeed)
+	h.Write([]byte("channel"))
+	h.Write(i[:])
+
+	var channelSeed [32]byte
+	copy(channelSeed[:], h.Sum(nil))
+	return keypair.FromRawSeed(channelSeed)
+}
@@ -0,0 +1,88 @@
+package submitter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBaseSeed = "SDOTALIMPAM2IV65IOZA7KZL7XWZI5BODFXTRVLIHLQZQCKK57PH5F3H"
+
+func TestDeriveChannel(t *testing.T) {
+	other, err := keypair.Random()
+	require.NoError(t, err)
+	otherSeed := other.Seed()
+
+	for _, tc := range []struct {
+		name      string
+		seedA     string
+		indexA    int
+		seedB     string
+		indexB    int
+		identical bool
+	}{
+		{"same seed and index", testBaseSeed, 0, testBaseSeed, 0, true},
+		{"same seed and large index", testBaseSeed, MaxChannels - 1, testBaseSeed, MaxChannels - 1, true},
+		{"different index", testBaseSeed, 0, testBaseSeed, 1, false},
+		{"different seed", testBaseSeed, 0, otherSeed, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := DeriveChannel(tc.seedA, tc.indexA)
+			require.NoError(t, err)
+			b, err := DeriveChannel(tc.seedB, tc.indexB)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.identical, a.Seed() == b.Seed())
+			assert.NotEqual(t, tc.seedA, a.Seed())
+		})
+	}
+
+	// channels must stay the same across releases, or restarted servers would
+	// create new ones
+	channel, err := DeriveChannel(testBaseSeed, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "GBCO5Q56ROTST27YQRST7RQ7NNVAUSFOIQETPZDYUJFO5YZCXSHJ5XP4", channel.Address())
+
+	for _, seed := range []string{"", "GBIUXI4S27PSL6TTJCJMPYDCF3K6AW2MYORFRTC7QBFE6NNEGVOQK46H", "not a seed"} {
+		_, err := DeriveChannel(seed, 0)
+		assert.Error(t, err, seed)
+	}
+}
+
+func TestChannelPool(t *testing.T) {
+	first := &Account{Seed: "first"}
+	second := &Account{Seed: "second"}
+	pool := NewChannelPool([]*Account{first, second})
+	pool.timeout = 10 * time.Millisecond
+	assert.Equal(t, 2, pool.Size())
+
+	a, err := pool.Borrow()
+	require.NoError(t, err)
+	b, err := pool.Borrow()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*Account{first, second}, []*Account{a, b})
+
+	// every channel is in use
+	_, err = pool.Borrow()
+	assert.Equal(t, ErrNoChannelAvailable, err)
+
+	// a returned channel can be borrowed again
+	pool.Return(a)
+	c, err := pool.Borrow()
+	require.NoError(t, err)
+	assert.Equal(t, a, c)
+
+	// a waiting borrow gets the channel returned before the timeout
+	pool.timeout = time.Second
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		pool.Return(b)
+	}()
+	c, err = pool.Borrow()
+	require.NoError(t, err)
+	assert.Equal(t, b, c)
+	assert.Equal(t, 2, pool.Size())
+}
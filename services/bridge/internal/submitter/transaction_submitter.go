@@ -3,6 +3,7 @@ package submitter
 import (
 	"database/sql"
 	"encoding/hex"
+	"encoding/json"
 	"strconv"
 	"sync"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/stellar/go/build"
 	"github.com/stellar/go/clients/horizon"
 	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/services/bridge/internal/callbacks"
 	"github.com/stellar/go/services/bridge/internal/db"
 	shared "github.com/stellar/go/services/internal/bridge-compliance-shared"
 	"github.com/stellar/go/support/errors"
@@ -21,6 +23,7 @@ import (
 type TransactionSubmitterInterface interface {
 	SubmitTransaction(paymentID *string, seed string, operation, memo interface{}) (response horizon.TransactionSuccess, err error)
 	SignAndSubmitRawTransaction(paymentID *string, seed string, tx *xdr.Transaction) (response horizon.TransactionSuccess, err error)
+	ResubmitTransaction(transaction *db.SentTransaction) (horizon.TransactionSuccess, error)
 }
 
 // TransactionSubmitter submits transactions to Stellar Network
@@ -30,6 +33,8 @@ type TransactionSubmitter struct {
 	AccountsMutex sync.Mutex
 	Database      db.Database
 	Network       build.Network
+	Channels      *ChannelPool      // sources of SubmitTransaction transactions, optional
+	Callbacks     *callbacks.Sender // notifies payment status changes, optional
 	log           *logrus.Entry
 	now           func() time.Time
 }
@@ -121,10 +126,17 @@ func (ts *TransactionSubmitter) SignAndSubmitRawTransaction(paymentID *string, s
 		return
 	}
 
-	account.Mutex.Lock()
-	account.SequenceNumber++
-	tx.SeqNum = xdr.SequenceNumber(account.SequenceNumber)
-	account.Mutex.Unlock()
+	return ts.signAndSubmit(paymentID, tx, account, account)
+}
+
+// signAndSubmit submits a transaction of `account` using `source` as the
+// source of the transaction, which provides the sequence number.  The
+// transaction is signed by both accounts.
+func (ts *TransactionSubmitter) signAndSubmit(paymentID *string, tx *xdr.Transaction, account, source *Account) (response horizon.TransactionSuccess, err error) {
+	source.Mutex.Lock()
+	source.SequenceNumber++
+	tx.SeqNum = xdr.SequenceNumber(source.SequenceNumber)
+	source.Mutex.Unlock()
 
 	hash, err := shared.TransactionHash(tx, ts.Network.Passphrase)
 	if err != nil {
@@ -132,15 +144,25 @@ func (ts *TransactionSubmitter) SignAndSubmitRawTransaction(paymentID *string, s
 		return
 	}
 
-	sig, err := account.Keypair.SignDecorated(hash[:])
-	if err != nil {
-		ts.log.WithFields(logrus.Fields{"err": err}).Error("Error signing a transaction")
-		return
+	signers := []*Account{source}
+	if account != source {
+		signers = append(signers, account)
+	}
+
+	var signatures []xdr.DecoratedSignature
+	for _, signer := range signers {
+		var sig xdr.DecoratedSignature
+		sig, err = signer.Keypair.SignDecorated(hash[:])
+		if err != nil {
+			ts.log.WithFields(logrus.Fields{"err": err}).Error("Error signing a transaction")
+			return
+		}
+		signatures = append(signatures, sig)
 	}
 
 	envelopeXdr := xdr.TransactionEnvelope{
 		Tx:         *tx,
-		Signatures: []xdr.DecoratedSignature{sig},
+		Signatures: signatures,
 	}
 
 	txeB64, err := xdr.MarshalBase64(envelopeXdr)
@@ -179,31 +201,18 @@ func (ts *TransactionSubmitter) SignAndSubmitRawTransaction(paymentID *string, s
 
 	ts.log.WithFields(logrus.Fields{"tx": txeB64}).Info("Submitting transaction")
 
-	var herr *horizon.Error
 	response, err = ts.Horizon.SubmitTransaction(txeB64)
-	if err == nil {
-		sentTransaction.Status = db.SentTransactionStatusSuccess
-		sentTransaction.Ledger = &response.Ledger
-		now := time.Now()
-		sentTransaction.SucceededAt = &now
-	} else {
-		var isHorizonError bool
-		herr, isHorizonError = err.(*horizon.Error)
-		if !isHorizonError {
-			ts.log.WithFields(logrus.Fields{"err": err}).Error("Error submitting transaction ", err)
-			return
-		}
-
-		var result string
-		result, err = herr.ResultString()
-		if err != nil {
-			result = errors.Wrap(err, "Error getting tx result").Error()
+	herr, isHorizonError := err.(*horizon.Error)
+	if err != nil && !isHorizonError {
+		ts.log.WithFields(logrus.Fields{"err": err}).Error("Error submitting transaction ", err)
+		if source != account {
+			// the channel may or may not have consumed its sequence number
+			ts.syncSequenceNumber(source)
 		}
-		sentTransaction.Status = db.SentTransactionStatusFailure
-		sentTransaction.ResultXdr = &result
+		return
 	}
 
-	err = ts.Database.UpdateSentTransaction(sentTransaction)
+	err = ts.saveResult(sentTransaction, response, herr)
 	if err != nil {
 		ts.log.WithFields(logrus.Fields{"err": err}).Error("Error updating sent transaction")
 		return
@@ -220,20 +229,89 @@ func (ts *TransactionSubmitter) SignAndSubmitRawTransaction(paymentID *string, s
 			return response, herr
 		}
 
-		account.Mutex.Lock()
-		ts.log.Print("Syncing sequence number for ", account.Keypair.Address())
-		var accountResponse horizon.Account
-		accountResponse, err = ts.Horizon.LoadAccount(account.Keypair.Address())
+		ts.syncSequenceNumber(source)
+		return response, herr
+	}
+	return
+}
+
+// ResubmitTransaction submits again the envelope of a transaction which
+// status is unknown, which is safe as the envelope can only be applied once.
+// The result is saved like the result of the first submission.
+func (ts *TransactionSubmitter) ResubmitTransaction(transaction *db.SentTransaction) (horizon.TransactionSuccess, error) {
+	ts.log.WithFields(logrus.Fields{"tx": transaction.EnvelopeXdr}).Info("Resubmitting transaction")
+
+	response, err := ts.Horizon.SubmitTransaction(transaction.EnvelopeXdr)
+	herr, isHorizonError := err.(*horizon.Error)
+	if err != nil && !isHorizonError {
+		return response, errors.Wrap(err, "Error submitting transaction")
+	}
+
+	err = ts.saveResult(transaction, response, herr)
+	if err != nil {
+		return response, errors.Wrap(err, "Error updating sent transaction")
+	}
+
+	if herr != nil {
+		return response, herr
+	}
+	return response, nil
+}
+
+// saveResult updates `transaction` with the result of its submission, which
+// failed when `herr` is not nil, and notifies the new status of the payment.
+func (ts *TransactionSubmitter) saveResult(transaction *db.SentTransaction, response horizon.TransactionSuccess, herr *horizon.Error) error {
+	if herr == nil {
+		transaction.Status = db.SentTransactionStatusSuccess
+		transaction.Ledger = &response.Ledger
+		now := ts.now()
+		transaction.SucceededAt = &now
+		transaction.ResultXdr = &response.Result
+	} else {
+		result, err := herr.ResultString()
 		if err != nil {
-			ts.log.Error("Error updating sequence number ", err)
-		} else {
-			account.SequenceNumber, _ = strconv.ParseUint(accountResponse.Sequence, 10, 64)
+			result = errors.Wrap(err, "Error getting tx result").Error()
+		}
+		transaction.Status = db.SentTransactionStatusFailure
+		transaction.ResultXdr = &result
+
+		codes, err := herr.ResultCodes()
+		if err == nil {
+			var rawCodes []byte
+			rawCodes, err = json.Marshal(codes)
+			if err == nil {
+				resultCodes := string(rawCodes)
+				transaction.ResultCodes = &resultCodes
+			}
 		}
-		account.Mutex.Unlock()
+	}
 
-		return response, herr
+	err := ts.Database.UpdateSentTransaction(transaction)
+	if err != nil {
+		return err
 	}
-	return
+
+	if ts.Callbacks != nil {
+		err = ts.Callbacks.SendPaymentStatus(transaction)
+		if err != nil && err != callbacks.ErrQueued {
+			ts.log.WithFields(logrus.Fields{"err": err}).Error("Error sending payment status callback")
+		}
+	}
+	return nil
+}
+
+// syncSequenceNumber reloads the sequence number of `account` from horizon.
+func (ts *TransactionSubmitter) syncSequenceNumber(account *Account) {
+	account.Mutex.Lock()
+	defer account.Mutex.Unlock()
+
+	ts.log.Print("Syncing sequence number for ", account.Keypair.Address())
+	accountResponse, err := ts.Horizon.LoadAccount(account.Keypair.Address())
+	if err != nil {
+		ts.log.Error("Error updating sequence number ", err)
+		return
+	}
+	account.SequenceNumber, _ = strconv.ParseUint(accountResponse.Sequence, 10, 64)
 }
 
 // SubmitTransaction builds and submits transaction to Stellar network
@@ -249,8 +327,17 @@ func (ts *TransactionSubmitter) SubmitTransaction(paymentID *string, seed string
 		return horizon.TransactionSuccess{}, errors.New("Cannot cast operationMutator to build.TransactionMutator")
 	}
 
+	source := account
+	if ts.Channels != nil {
+		source, err = ts.Channels.Borrow()
+		if err != nil {
+			return horizon.TransactionSuccess{}, errors.Wrap(err, "Error borrowing a channel")
+		}
+		defer ts.Channels.Return(source)
+	}
+
 	mutators := []build.TransactionMutator{
-		build.SourceAccount{account.Seed},
+		build.SourceAccount{source.Seed},
 		ts.Network,
 		operationMutator,
 	}
@@ -269,5 +356,20 @@ func (ts *TransactionSubmitter) SubmitTransaction(paymentID *string, seed string
 		return horizon.TransactionSuccess{}, errors.Wrap(err, "Error building a transaction")
 	}
 
-	return ts.SignAndSubmitRawTransaction(paymentID, seed, txBuilder.TX)
+	if source != account {
+		// The operations keep the account as their source, only the sequence
+		// number and the fee are provided by the channel.
+		var accountID xdr.AccountId
+		err = accountID.SetAddress(account.Keypair.Address())
+		if err != nil {
+			return horizon.TransactionSuccess{}, errors.Wrap(err, "Error setting the operations source")
+		}
+		for i := range txBuilder.TX.Operations {
+			if txBuilder.TX.Operations[i].SourceAccount == nil {
+				txBuilder.TX.Operations[i].SourceAccount = &accountID
+			}
+		}
+	}
+
+	return ts.signAndSubmit(paymentID, txBuilder.TX, account, source)
 }
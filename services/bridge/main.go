@@ -13,6 +13,7 @@ import (
 	"github.com/stellar/go/clients/federation"
 	"github.com/stellar/go/clients/horizon"
 	"github.com/stellar/go/clients/stellartoml"
+	"github.com/stellar/go/services/bridge/internal/callbacks"
 	"github.com/stellar/go/services/bridge/internal/config"
 	"github.com/stellar/go/services/bridge/internal/db"
 	"github.com/stellar/go/services/bridge/internal/handlers"
@@ -93,9 +94,14 @@ type App struct {
 func NewApp(config config.Config, migrateFlag bool, versionFlag bool, version string) (app *App, err error) {
 	var g inject.Graph
 
-	var database db.PostgresDatabase
+	var database db.SQLDatabase = &db.PostgresDatabase{}
 
 	if config.Database != nil {
+		database, err = db.NewDatabase(config.Database.Type)
+		if err != nil {
+			return
+		}
+
 		err = database.Open(config.Database.URL)
 		if err != nil {
 			err = fmt.Errorf("Cannot connect to a DB: %s", err)
@@ -105,9 +111,10 @@ func NewApp(config config.Config, migrateFlag bool, versionFlag bool, version st
 
 	if migrateFlag {
 		var migrationsApplied int
-		migrationsApplied, err = schema.Migrate(
+		migrationsApplied, err = schema.MigrateDialect(
 			database.GetDB(),
-			db.Migrations,
+			database.Dialect(),
+			database.Migrations(),
 			schema.MigrateUp,
 			0,
 		)
@@ -144,7 +151,7 @@ func NewApp(config config.Config, migrateFlag bool, versionFlag bool, version st
 	}
 
 	log.Print("Creating and initializing TransactionSubmitter")
-	ts := submitter.NewTransactionSubmitter(&h, &database, config.NetworkPassphrase, time.Now)
+	ts := submitter.NewTransactionSubmitter(&h, database, config.NetworkPassphrase, time.Now)
 	if err != nil {
 		return
 	}
@@ -169,18 +176,38 @@ func NewApp(config config.Config, migrateFlag bool, versionFlag bool, version st
 		}
 	}
 
+	if len(config.Accounts.ChannelSeeds) > 0 || config.Accounts.ChannelCount > 0 {
+		log.Print("Initializing channel accounts")
+		err = ts.InitChannels(
+			config.Accounts.BaseSeed,
+			config.Accounts.ChannelSeeds,
+			config.Accounts.ChannelCount,
+			config.Accounts.ChannelStartingBalance,
+		)
+		if err != nil {
+			return
+		}
+		log.Printf("Using %d channel accounts", ts.Channels.Size())
+	}
+
 	log.Print("TransactionSubmitter created")
 
+	callbackSender := callbacks.NewSender(&config, database, time.Now)
+	if config.Database != nil {
+		callbackSender.Run()
+		ts.Callbacks = callbackSender
+	}
+
 	log.Print("Creating and starting PaymentListener")
 
 	var paymentListener listener.PaymentListener
 
-	if config.Accounts.ReceivingAccountID == "" {
-		log.Warning("No accounts.receiving_account_id param. Skipping...")
+	if len(config.ReceivingAccounts()) == 0 {
+		log.Warning("No receiving accounts. Skipping...")
 	} else if config.Callbacks.Receive == "" {
 		log.Warning("No callbacks.receive param. Skipping...")
 	} else {
-		paymentListener, err = listener.NewPaymentListener(&config, &database, &h, time.Now)
+		paymentListener, err = listener.NewPaymentListener(&config, database, &h, callbackSender, time.Now)
 		if err != nil {
 			return
 		}
@@ -207,7 +234,7 @@ func NewApp(config config.Config, migrateFlag bool, versionFlag bool, version st
 		&inject.Object{Value: &stellartomlClient},
 		&inject.Object{Value: &federationClient},
 		&inject.Object{Value: &h},
-		&inject.Object{Value: &database},
+		&inject.Object{Value: database},
 		&inject.Object{Value: &ts},
 		&inject.Object{Value: &paymentListener},
 		&inject.Object{Value: &httpClientWithTimeout},
@@ -252,11 +279,18 @@ func (a *App) Serve() {
 	mux.Post("/builder", a.requestHandler.Builder)
 	mux.Post("/payment", a.requestHandler.Payment)
 	mux.Get("/payment", a.requestHandler.Payment)
+	mux.Get("/payment/{id}", a.requestHandler.PaymentStatus)
+	mux.Post("/quote", a.requestHandler.Quote)
 	mux.Post("/reprocess", a.requestHandler.Reprocess)
 
 	mux.Get("/admin/received-payments", a.requestHandler.AdminReceivedPayments)
 	mux.Get("/admin/received-payments/{id}", a.requestHandler.AdminReceivedPayment)
 	mux.Get("/admin/sent-transactions", a.requestHandler.AdminSentTransactions)
+	mux.Post("/admin/replay", a.requestHandler.AdminReplay)
+	mux.Get("/admin/callbacks", a.requestHandler.AdminCallbacks)
+	mux.Get("/admin/callbacks/{id}", a.requestHandler.AdminCallback)
+	mux.Post("/admin/callbacks/{id}/retry", a.requestHandler.AdminRetryCallback)
+	mux.Post("/admin/callbacks/{id}/cancel", a.requestHandler.AdminCancelCallback)
 
 	supportHttp.Run(supportHttp.Config{
 		ListenAddr: fmt.Sprintf(":%d", *a.config.Port),
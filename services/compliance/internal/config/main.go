@@ -5,21 +5,24 @@ import (
 	"net/url"
 
 	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/protocols/compliance"
 	"github.com/stellar/go/support/config"
 )
 
 // Config contains config params of the compliance server
 type Config struct {
-	ExternalPort      *int          `valid:"required" toml:"external_port"`
-	InternalPort      *int          `valid:"required" toml:"internal_port"`
-	LogFormat         string        `valid:"optional" toml:"log_format"`
-	NeedsAuth         bool          `valid:"optional" toml:"needs_auth"`
-	NetworkPassphrase string        `valid:"required" toml:"network_passphrase"`
-	Database          Database      `valid:"required"`
-	Keys              Keys          `valid:"required" toml:"keys"`
-	Callbacks         Callbacks     `valid:"optional" toml:"callbacks"`
-	TLS               *config.TLS   `valid:"optional"`
-	TxStatusAuth      *TxStatusAuth `valid:"optional" toml:"tx_status_auth"`
+	ExternalPort       *int          `valid:"required" toml:"external_port"`
+	InternalPort       *int          `valid:"required" toml:"internal_port"`
+	LogFormat          string        `valid:"optional" toml:"log_format"`
+	NeedsAuth          bool          `valid:"optional" toml:"needs_auth"`
+	EncryptAttachments bool          `valid:"optional" toml:"encrypt_attachments"`
+	NetworkPassphrase  string        `valid:"required" toml:"network_passphrase"`
+	Database           Database      `valid:"required"`
+	Keys               Keys          `valid:"required" toml:"keys"`
+	Callbacks          Callbacks     `valid:"optional" toml:"callbacks"`
+	TLS                *config.TLS   `valid:"optional"`
+	TxStatusAuth       *TxStatusAuth `valid:"optional" toml:"tx_status_auth"`
+	Audit              Audit         `valid:"optional" toml:"audit"`
 }
 
 type TxStatusAuth struct {
@@ -29,7 +32,15 @@ type TxStatusAuth struct {
 
 // Keys contains values of `keys` config group
 type Keys struct {
-	SigningSeed string `valid:"required" toml:"signing_seed"`
+	SigningSeed   string `valid:"required" toml:"signing_seed"`
+	EncryptionKey string `valid:"optional" toml:"encryption_key"`
+}
+
+// Audit contains values of `audit` config group
+type Audit struct {
+	// PersonalDataRetention is the number of days the personal data of auth
+	// requests is kept in the audit log. It is kept forever when 0.
+	PersonalDataRetention int `valid:"optional" toml:"personal_data_retention"`
 }
 
 // Callbacks contains values of `callbacks` config group
@@ -64,7 +75,7 @@ func (c *Config) Validate() (err error) {
 	}
 
 	if c.Keys.SigningSeed == "" {
-		err = errors.New("keys.signing_seed and keys.encryption_key params are required")
+		err = errors.New("keys.signing_seed param is required")
 		return
 	}
 
@@ -76,6 +87,19 @@ func (c *Config) Validate() (err error) {
 		}
 	}
 
+	if c.Keys.EncryptionKey != "" {
+		_, err = compliance.ParseEncryptionKey(c.Keys.EncryptionKey)
+		if err != nil {
+			err = errors.New("keys.encryption_key is invalid")
+			return
+		}
+	}
+
+	if c.Audit.PersonalDataRetention < 0 {
+		err = errors.New("audit.personal_data_retention must be positive")
+		return
+	}
+
 	var dbURL *url.URL
 	dbURL, err = url.Parse(c.Database.URL)
 	if err != nil {
@@ -4,6 +4,9 @@
 // migrations/01_init.sql
 // migrations/02_auth_data.sql
 // migrations/03_table_names.sql
+// migrations/04_audit_log.sql
+// migrations/05_allowlist_grants.sql
+// migrations/06_auth_data_created_at.sql
 // DO NOT EDIT!
 
 package db
@@ -151,6 +154,66 @@ func migrations03_table_namesSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations04_audit_logSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x92\x41\x6f\x82\x40\x10\x85\xef\xfc\x8a\xb9\x09\xa9\x26\x6d\x53\xbd\x78\xa2\x85\x26\xa6\x14\x0d\xc1\x44\x4f\x9b\x95\x9d\xd2\x49\x60\x21\xcb\x6a\xed\xbf\xef\x6a\xb0\xb2\x18\xd2\x1e\x77\xde\xb7\x6f\xe7\xed\xcc\x64\x02\x77\x25\xe5\x8a\x6b\x84\x75\xed\xbc\x24\xa1\x9f\x86\x90\xfa\xcf\x51\x08\x7c\x2f\x48\xb3\xa2\xca\xc1\x75\x00\x48\xc0\x8e\xf2\x06\x15\xf1\x62\x6c\xce\x0d\x4a\x81\x0a\x0e\x5c\x65\x9f\x5c\xb9\x8f\xd3\xa9\x07\xf1\x32\x85\x78\x1d\x45\x57\x9d\x89\xaa\xe4\x24\x87\x31\xad\xb8\x6c\x78\xa6\xa9\x92\xcc\x3c\x71\xe1\x66\x4f\x36\x26\x30\xa3\xc6\x30\xbf\xc0\xc3\xac\xe7\x73\x64\x8d\xe6\x7a\xdf\x0c\x12\x24\x3f\xaa\xbf\x98\xda\x34\x4d\x32\x37\xac\xc6\xdc\xa4\xbb\x68\x10\x84\xaf\xfe\x3a\x4a\xe1\xfe\x44\xa1\x52\x95\x02\x8d\x47\x7d\x0b\x8c\x46\x6d\x2a\x2a\x6c\xe2\x9c\x82\x6b\xde\x56\xdb\x8a\xc2\x0c\xe9\x80\x82\x71\x0d\x9a\x4a\x34\xfd\x95\xb5\x75\x47\xa1\x30\xdf\x73\x43\x9c\x55\x23\xaf\x92\xc5\xbb\x9f\x6c\xe1\x2d\xdc\x82\x4b\xc2\x73\xbc\xb9\x73\x19\xe3\x22\x0e\xc2\xcd\x75\x8c\x6c\xf7\xcd\xba\xef\x2d\xe3\xee\x88\x3b\x8a\xb1\x18\x76\xb0\xe7\x6a\x7b\x58\xda\x18\xfe\x6b\xd9\xdb\x01\xdb\xd3\x16\x4f\xe9\x26\x9d\x9d\x0d\xaa\x2f\xe9\x04\xc9\x72\xd5\xdf\xd9\xb9\xf3\x03\x10\xbe\xa5\x03\xdb\x02\x00\x00")
+
+func migrations04_audit_logSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations04_audit_logSql,
+		"migrations/04_audit_log.sql",
+	)
+}
+
+func migrations04_audit_logSql() (*asset, error) {
+	bytes, err := migrations04_audit_logSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/04_audit_log.sql", size: 731, mode: os.FileMode(420), modTime: time.Unix(1792366062, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations05_allowlist_grantsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa5\x92\xb1\x0e\x82\x30\x14\x45\x77\xbf\xe2\x6d\x68\x0c\x83\x26\xba\x38\xa1\xc5\xa9\x82\x21\x30\x93\xa7\x54\x6c\x42\x81\xb4\x45\xfd\x7c\x8d\x24\x62\x44\x10\x64\xea\xd2\x73\x9b\x73\xc0\x34\x61\x2a\x78\x2c\x51\x33\x08\xf2\x91\x45\x7d\xdb\x03\xdf\x5a\x53\x1b\x30\x49\xb2\x2b\x8b\xc2\x13\x07\x8b\x10\xd8\xb8\x34\xd8\x39\xc0\x6e\x39\x97\x4c\x85\xa8\x41\x73\xc1\x94\x46\x91\x83\x13\x50\xba\xea\x00\xa3\x52\x4c\x87\xc7\x2c\x62\x70\x41\x79\x3c\xa3\x1c\xcf\xe6\x13\x70\x5c\xff\x39\x01\xc4\xde\x5a\x01\xf5\xc1\x30\xba\xaf\x71\xa5\x0a\x26\x5f\x7b\x8b\xe5\xdf\x7b\x02\x6f\x21\x8a\xac\x48\x35\x1c\x78\xcc\x1f\x47\xe9\xf5\x15\x2d\xd4\xe3\xd1\xff\xbb\x7c\xe2\x43\xcb\x7c\xdf\x1b\xd2\xe6\x73\xb1\xb1\x8e\xf9\xf6\x0b\x91\xec\x9a\x36\x95\x26\x9e\xbb\xaf\xd7\x5a\x75\xb9\x5e\xd5\xe9\x71\xbd\x94\xef\x04\x54\x6e\x6d\x5f\xbb\x8f\x40\x0d\xf8\xa5\xd0\x00\xb4\x49\xd4\x90\x77\x8d\x3b\xa2\x95\x7a\xb0\xd9\x03\x00\x00")
+
+func migrations05_allowlist_grantsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations05_allowlist_grantsSql,
+		"migrations/05_allowlist_grants.sql",
+	)
+}
+
+func migrations05_allowlist_grantsSql() (*asset, error) {
+	bytes, err := migrations05_allowlist_grantsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/05_allowlist_grants.sql", size: 985, mode: os.FileMode(420), modTime: time.Unix(1792366212, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations06_auth_data_created_atSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd3\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2c\x2d\xc9\x88\x4f\x49\x2c\x49\x54\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x2e\x4a\x05\x2a\x4d\x89\x4f\x2c\x51\x28\xc9\xcc\x4d\x2d\x2e\x49\xcc\x2d\x50\xf0\x0b\xf5\xf1\xb1\xe6\xe2\xd2\x45\x32\xcb\x25\xbf\x3c\x0f\x87\x69\x2e\x41\xfe\x01\x98\xc6\x59\x73\x01\x00\xc2\x6f\x81\xc7\x8b\x00\x00\x00")
+
+func migrations06_auth_data_created_atSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations06_auth_data_created_atSql,
+		"migrations/06_auth_data_created_at.sql",
+	)
+}
+
+func migrations06_auth_data_created_atSql() (*asset, error) {
+	bytes, err := migrations06_auth_data_created_atSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/06_auth_data_created_at.sql", size: 139, mode: os.FileMode(420), modTime: time.Unix(1792367806, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -203,10 +266,13 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"latest.sql":                    latestSql,
-	"migrations/01_init.sql":        migrations01_initSql,
-	"migrations/02_auth_data.sql":   migrations02_auth_dataSql,
-	"migrations/03_table_names.sql": migrations03_table_namesSql,
+	"latest.sql":                             latestSql,
+	"migrations/01_init.sql":                 migrations01_initSql,
+	"migrations/02_auth_data.sql":            migrations02_auth_dataSql,
+	"migrations/03_table_names.sql":          migrations03_table_namesSql,
+	"migrations/04_audit_log.sql":            migrations04_audit_logSql,
+	"migrations/05_allowlist_grants.sql":     migrations05_allowlist_grantsSql,
+	"migrations/06_auth_data_created_at.sql": migrations06_auth_data_created_atSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -252,9 +318,12 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"latest.sql": &bintree{latestSql, map[string]*bintree{}},
 	"migrations": &bintree{nil, map[string]*bintree{
-		"01_init.sql":        &bintree{migrations01_initSql, map[string]*bintree{}},
-		"02_auth_data.sql":   &bintree{migrations02_auth_dataSql, map[string]*bintree{}},
-		"03_table_names.sql": &bintree{migrations03_table_namesSql, map[string]*bintree{}},
+		"01_init.sql":                 &bintree{migrations01_initSql, map[string]*bintree{}},
+		"02_auth_data.sql":            &bintree{migrations02_auth_dataSql, map[string]*bintree{}},
+		"03_table_names.sql":          &bintree{migrations03_table_namesSql, map[string]*bintree{}},
+		"04_audit_log.sql":            &bintree{migrations04_audit_logSql, map[string]*bintree{}},
+		"05_allowlist_grants.sql":     &bintree{migrations05_allowlist_grantsSql, map[string]*bintree{}},
+		"06_auth_data_created_at.sql": &bintree{migrations06_auth_data_created_atSql, map[string]*bintree{}},
 	}},
 }}
 
@@ -20,16 +20,27 @@ type Database interface {
 	InsertAuthorizedTransaction(transaction *AuthorizedTransaction) error
 	GetAuthorizedTransactionByMemo(memo string) (*AuthorizedTransaction, error)
 
-	InsertAllowedFI(fi *AllowedFI) error
+	SaveAllowedFI(fi *AllowedFI) error
 	GetAllowedFIByDomain(domain string) (*AllowedFI, error)
+	GetAllowedFIs(includeExpired bool, now time.Time, page, limit uint64) ([]*AllowedFI, error)
 	DeleteAllowedFIByDomain(domain string) error
 
-	InsertAllowedUser(user *AllowedUser) error
+	SaveAllowedUser(user *AllowedUser) error
 	GetAllowedUserByDomainAndUserID(domain, userID string) (*AllowedUser, error)
+	GetAllowedUsers(domain string, includeExpired bool, now time.Time, page, limit uint64) ([]*AllowedUser, error)
 	DeleteAllowedUserByDomainAndUserID(domain, userID string) error
 
+	ImportAllowed(fis []*AllowedFI, users []*AllowedUser) error
+
 	InsertAuthData(authData *AuthData) error
 	GetAuthData(requestID string) (*AuthData, error)
+
+	InsertAuditLogEntry(entry *AuditLogEntry) error
+	GetAuditLogEntryByID(id int64) (*AuditLogEntry, error)
+	GetAuditLogEntries(filter AuditLogFilter, page, limit uint64) ([]*AuditLogEntry, error)
+	RedactAuditLogEntries(receivedBefore, now time.Time) (int64, error)
+	RedactAuthorizedTransactions(authorizedBefore time.Time) (int64, error)
+	RedactAuthData(createdBefore time.Time) (int64, error)
 }
 
 type PostgresDatabase struct {
@@ -43,16 +54,30 @@ type AllowedFI struct {
 	Domain    string    `db:"domain"`
 	PublicKey string    `db:"public_key"`
 	AllowedAt time.Time `db:"allowed_at"`
+	// ExpiresAt is the time access expires, nil when it does not
+	ExpiresAt *time.Time `db:"expires_at"`
+	// AssetCode and AssetIssuer restrict access to payments of an asset
+	// when not empty, `XLM` without issuer being lumens
+	AssetCode   string `db:"asset_code"`
+	AssetIssuer string `db:"asset_issuer"`
+	// MaxAmount restricts access to payments of at most this amount, in
+	// stroops, when not nil
+	MaxAmount *int64 `db:"max_amount"`
 }
 
-// AllowedUser represents allowed user
+// AllowedUser represents allowed user. ExpiresAt, AssetCode, AssetIssuer and
+// MaxAmount are the same as in AllowedFI.
 type AllowedUser struct {
-	ID          int64     `db:"id"`
-	FiName      string    `db:"fi_name"`
-	FiDomain    string    `db:"fi_domain"`
-	FiPublicKey string    `db:"fi_public_key"`
-	UserID      string    `db:"user_id"`
-	AllowedAt   time.Time `db:"allowed_at"`
+	ID          int64      `db:"id"`
+	FiName      string     `db:"fi_name"`
+	FiDomain    string     `db:"fi_domain"`
+	FiPublicKey string     `db:"fi_public_key"`
+	UserID      string     `db:"user_id"`
+	AllowedAt   time.Time  `db:"allowed_at"`
+	ExpiresAt   *time.Time `db:"expires_at"`
+	AssetCode   string     `db:"asset_code"`
+	AssetIssuer string     `db:"asset_issuer"`
+	MaxAmount   *int64     `db:"max_amount"`
 }
 
 // AuthorizedTransaction represents authorized transaction
@@ -71,4 +96,44 @@ type AuthData struct {
 	RequestID string `db:"request_id"`
 	Domain    string `db:"domain"`
 	AuthData  string `db:"auth_data"`
+	// CreatedAt is nil for auth data sent before it was recorded
+	CreatedAt *time.Time `db:"created_at"`
+}
+
+// AuditLogEntry represents the decision on an auth request received by the
+// Auth endpoint
+type AuditLogEntry struct {
+	ID            int64  `db:"id"`
+	Sender        string `db:"sender"`
+	SenderDomain  string `db:"sender_domain"`
+	TransactionID string `db:"transaction_id"`
+	// Decision is `ok` when both TxStatus and InfoStatus are `ok`, otherwise
+	// it is `denied`, `error` or `pending`, in this order of precedence.
+	Decision   string `db:"decision"`
+	TxStatus   string `db:"tx_status"`
+	InfoStatus string `db:"info_status"`
+	Pending    int    `db:"pending"`
+	Error      string `db:"error"`
+	// Trail is the JSON array of the steps of the decision
+	Trail string `db:"trail"`
+	// Data is the JSON auth data, with the decrypted attachment. It contains
+	// personal data so it is nil once redacted.
+	Data       *string    `db:"data"`
+	ReceivedAt time.Time  `db:"received_at"`
+	RedactedAt *time.Time `db:"redacted_at"`
+}
+
+// AuditLogFilter filters audit log entries. Zero values do not filter.
+type AuditLogFilter struct {
+	// From and To filter entries by `received_at`, To is exclusive
+	From         time.Time
+	To           time.Time
+	SenderDomain string
+	Decision     string
+	// BeforeID filters entries with a lower ID, to page through entries
+	// without offsets
+	BeforeID int64
+	// Pending filters `pending` entries which were not decided later, by an
+	// auth request for the same transaction
+	Pending bool
 }
@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE audit_log (
+  id bigserial,
+  sender varchar(255) NOT NULL,
+  sender_domain varchar(255) NOT NULL,
+  transaction_id varchar(64) NOT NULL,
+  decision varchar(16) NOT NULL,
+  tx_status varchar(16) NOT NULL,
+  info_status varchar(16) NOT NULL,
+  pending integer NOT NULL DEFAULT 0,
+  error text NOT NULL DEFAULT '',
+  trail text NOT NULL,
+  data text NULL,
+  received_at timestamp NOT NULL,
+  redacted_at timestamp NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX audit_log_by_received_at ON audit_log (received_at);
+CREATE INDEX audit_log_by_sender_domain ON audit_log (sender_domain, received_at);
+CREATE INDEX audit_log_by_transaction_id ON audit_log (transaction_id);
+
+-- +migrate Down
+DROP TABLE audit_log;
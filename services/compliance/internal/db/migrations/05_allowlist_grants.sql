@@ -0,0 +1,21 @@
+-- +migrate Up
+ALTER TABLE allowed_fi ADD COLUMN expires_at timestamp NULL;
+ALTER TABLE allowed_fi ADD COLUMN asset_code varchar(12) NOT NULL DEFAULT '';
+ALTER TABLE allowed_fi ADD COLUMN asset_issuer varchar(56) NOT NULL DEFAULT '';
+ALTER TABLE allowed_fi ADD COLUMN max_amount bigint NULL;
+
+ALTER TABLE allowed_user ADD COLUMN expires_at timestamp NULL;
+ALTER TABLE allowed_user ADD COLUMN asset_code varchar(12) NOT NULL DEFAULT '';
+ALTER TABLE allowed_user ADD COLUMN asset_issuer varchar(56) NOT NULL DEFAULT '';
+ALTER TABLE allowed_user ADD COLUMN max_amount bigint NULL;
+
+-- +migrate Down
+ALTER TABLE allowed_fi DROP COLUMN expires_at;
+ALTER TABLE allowed_fi DROP COLUMN asset_code;
+ALTER TABLE allowed_fi DROP COLUMN asset_issuer;
+ALTER TABLE allowed_fi DROP COLUMN max_amount;
+
+ALTER TABLE allowed_user DROP COLUMN expires_at;
+ALTER TABLE allowed_user DROP COLUMN asset_code;
+ALTER TABLE allowed_user DROP COLUMN asset_issuer;
+ALTER TABLE allowed_user DROP COLUMN max_amount;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE auth_data ADD COLUMN created_at timestamp NULL;
+
+-- +migrate Down
+ALTER TABLE auth_data DROP COLUMN created_at;
@@ -2,7 +2,9 @@ package db
 
 import (
 	"database/sql"
+	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/stellar/go/support/db"
 	"github.com/stellar/go/support/errors"
 )
@@ -12,6 +14,7 @@ const (
 	allowedFITableName             = "allowed_fi"
 	allowedUserTableName           = "allowed_user"
 	authDataTableName              = "auth_data"
+	auditLogTableName              = "audit_log"
 )
 
 func (d *PostgresDatabase) Open(dsn string) error {
@@ -71,12 +74,29 @@ func (d *PostgresDatabase) GetAuthorizedTransactionByMemo(memo string) (*Authori
 	return &authorizedTransaction, nil
 }
 
-// InsertAllowedFI inserts a new allowed FI into DB.
-func (d *PostgresDatabase) InsertAllowedFI(fi *AllowedFI) error {
-	allowedFITable := d.getTable(allowedFITableName, nil)
-	_, err := allowedFITable.Insert(fi).IgnoreCols("id").Exec()
+// SaveAllowedFI inserts a new allowed FI into DB or updates the allowed FI
+// with the same domain.
+func (d *PostgresDatabase) SaveAllowedFI(fi *AllowedFI) error {
+	return d.saveAllowedFI(d.session, fi)
+}
+
+func (d *PostgresDatabase) saveAllowedFI(session *db.Session, fi *AllowedFI) error {
+	_, err := session.ExecRaw(
+		`INSERT INTO `+allowedFITableName+`
+			(name, domain, public_key, allowed_at, expires_at, asset_code, asset_issuer, max_amount)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (domain) DO UPDATE SET
+			name = EXCLUDED.name,
+			public_key = EXCLUDED.public_key,
+			allowed_at = EXCLUDED.allowed_at,
+			expires_at = EXCLUDED.expires_at,
+			asset_code = EXCLUDED.asset_code,
+			asset_issuer = EXCLUDED.asset_issuer,
+			max_amount = EXCLUDED.max_amount`,
+		fi.Name, fi.Domain, fi.PublicKey, fi.AllowedAt, fi.ExpiresAt, fi.AssetCode, fi.AssetIssuer, fi.MaxAmount,
+	)
 	if err != nil {
-		return errors.Wrap(err, "Error inserting allowed FI")
+		return errors.Wrap(err, "Error saving allowed FI")
 	}
 
 	return nil
@@ -99,6 +119,31 @@ func (d *PostgresDatabase) GetAllowedFIByDomain(domain string) (*AllowedFI, erro
 	return &allowedFI, nil
 }
 
+// GetAllowedFIs returns allowed FIs sorted by domain. Expired FIs are only
+// returned when `includeExpired` is true.
+func (d *PostgresDatabase) GetAllowedFIs(includeExpired bool, now time.Time, page, limit uint64) ([]*AllowedFI, error) {
+	allowedFITable := d.getTable(allowedFITableName, nil)
+	fis := []*AllowedFI{}
+
+	if page == 0 {
+		page = 1
+	}
+
+	offset := (page - 1) * limit
+
+	err := allowedFITable.Select(&fis, notExpired(includeExpired, now)).Limit(limit).Offset(offset).OrderBy("domain asc").Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return fis, nil
+		default:
+			return fis, errors.Wrap(err, "Error getting allowed FIs")
+		}
+	}
+
+	return fis, nil
+}
+
 // DeleteAllowedFIByDomain deletes allowed FI by a domain
 func (d *PostgresDatabase) DeleteAllowedFIByDomain(domain string) error {
 	allowedFITable := d.getTable(allowedFITableName, nil)
@@ -106,12 +151,29 @@ func (d *PostgresDatabase) DeleteAllowedFIByDomain(domain string) error {
 	return errors.Wrap(err, "Error removing allowed FI by domain")
 }
 
-// InsertAllowedUser inserts a new allowed user into DB.
-func (d *PostgresDatabase) InsertAllowedUser(user *AllowedUser) error {
-	allowedUserTable := d.getTable(allowedUserTableName, nil)
-	_, err := allowedUserTable.Insert(user).IgnoreCols("id").Exec()
+// SaveAllowedUser inserts a new allowed user into DB or updates the allowed
+// user with the same FI public key and user ID.
+func (d *PostgresDatabase) SaveAllowedUser(user *AllowedUser) error {
+	return d.saveAllowedUser(d.session, user)
+}
+
+func (d *PostgresDatabase) saveAllowedUser(session *db.Session, user *AllowedUser) error {
+	_, err := session.ExecRaw(
+		`INSERT INTO `+allowedUserTableName+`
+			(fi_name, fi_domain, fi_public_key, user_id, allowed_at, expires_at, asset_code, asset_issuer, max_amount)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (fi_public_key, user_id) DO UPDATE SET
+			fi_name = EXCLUDED.fi_name,
+			fi_domain = EXCLUDED.fi_domain,
+			allowed_at = EXCLUDED.allowed_at,
+			expires_at = EXCLUDED.expires_at,
+			asset_code = EXCLUDED.asset_code,
+			asset_issuer = EXCLUDED.asset_issuer,
+			max_amount = EXCLUDED.max_amount`,
+		user.FiName, user.FiDomain, user.FiPublicKey, user.UserID, user.AllowedAt, user.ExpiresAt, user.AssetCode, user.AssetIssuer, user.MaxAmount,
+	)
 	if err != nil {
-		return errors.Wrap(err, "Error inserting allowed user")
+		return errors.Wrap(err, "Error saving allowed user")
 	}
 
 	return nil
@@ -134,6 +196,37 @@ func (d *PostgresDatabase) GetAllowedUserByDomainAndUserID(domain, userID string
 	return &allowedUser, nil
 }
 
+// GetAllowedUsers returns allowed users sorted by domain and user ID,
+// filtered by domain when not empty. Expired users are only returned when
+// `includeExpired` is true.
+func (d *PostgresDatabase) GetAllowedUsers(domain string, includeExpired bool, now time.Time, page, limit uint64) ([]*AllowedUser, error) {
+	allowedUserTable := d.getTable(allowedUserTableName, nil)
+	users := []*AllowedUser{}
+
+	if page == 0 {
+		page = 1
+	}
+
+	offset := (page - 1) * limit
+
+	pred := sq.And{notExpired(includeExpired, now)}
+	if domain != "" {
+		pred = append(pred, sq.Eq{"fi_domain": domain})
+	}
+
+	err := allowedUserTable.Select(&users, pred).Limit(limit).Offset(offset).OrderBy("fi_domain asc, user_id asc").Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return users, nil
+		default:
+			return users, errors.Wrap(err, "Error getting allowed users")
+		}
+	}
+
+	return users, nil
+}
+
 // DeleteAllowedUserByDomainAndUserID deletes allowed user by domain and userID
 func (d *PostgresDatabase) DeleteAllowedUserByDomainAndUserID(domain, userID string) error {
 	allowedUserTable := d.getTable(allowedUserTableName, nil)
@@ -141,6 +234,49 @@ func (d *PostgresDatabase) DeleteAllowedUserByDomainAndUserID(domain, userID str
 	return errors.Wrap(err, "Error removing allowed user by domain and userID")
 }
 
+// ImportAllowed saves allowed FIs and users, like SaveAllowedFI and
+// SaveAllowedUser, in a single DB transaction so that either all or none of
+// them are saved.
+func (d *PostgresDatabase) ImportAllowed(fis []*AllowedFI, users []*AllowedUser) error {
+	session := d.session.Clone()
+
+	err := session.Begin()
+	if err != nil {
+		return errors.Wrap(err, "Error starting transaction")
+	}
+	defer session.Rollback()
+
+	for _, fi := range fis {
+		err = d.saveAllowedFI(session, fi)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, user := range users {
+		err = d.saveAllowedUser(session, user)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = session.Commit()
+	if err != nil {
+		return errors.Wrap(err, "Error committing transaction")
+	}
+
+	return nil
+}
+
+// notExpired returns the predicate of allowed FIs and users which are not
+// expired at `now`, or of all of them when `includeExpired` is true.
+func notExpired(includeExpired bool, now time.Time) sq.Sqlizer {
+	if includeExpired {
+		return sq.Expr("1=1")
+	}
+	return sq.Or{sq.Eq{"expires_at": nil}, sq.Gt{"expires_at": now}}
+}
+
 // InsertAuthData inserts a new auth data into DB.
 func (d *PostgresDatabase) InsertAuthData(authData *AuthData) error {
 	authDataTable := d.getTable(authDataTableName, nil)
@@ -168,3 +304,164 @@ func (d *PostgresDatabase) GetAuthData(requestID string) (*AuthData, error) {
 
 	return &authData, nil
 }
+
+// InsertAuditLogEntry inserts a new audit log entry into DB.
+func (d *PostgresDatabase) InsertAuditLogEntry(entry *AuditLogEntry) error {
+	auditLogTable := d.getTable(auditLogTableName, nil)
+	_, err := auditLogTable.Insert(entry).IgnoreCols("id").Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error inserting audit log entry")
+	}
+
+	return nil
+}
+
+// GetAuditLogEntryByID returns audit log entry by ID
+func (d *PostgresDatabase) GetAuditLogEntryByID(id int64) (*AuditLogEntry, error) {
+	auditLogTable := d.getTable(auditLogTableName, nil)
+	var entry AuditLogEntry
+	err := auditLogTable.Get(&entry, map[string]interface{}{"id": id}).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting audit log entry by ID")
+		}
+	}
+
+	return &entry, nil
+}
+
+// GetAuditLogEntries returns audit log entries matching `filter`, the most
+// recent first
+func (d *PostgresDatabase) GetAuditLogEntries(filter AuditLogFilter, page, limit uint64) ([]*AuditLogEntry, error) {
+	auditLogTable := d.getTable(auditLogTableName, nil)
+	entries := []*AuditLogEntry{}
+
+	if page == 0 {
+		page = 1
+	}
+
+	offset := (page - 1) * limit
+
+	pred := sq.And{}
+	if !filter.From.IsZero() {
+		pred = append(pred, sq.GtOrEq{"received_at": filter.From})
+	}
+	if !filter.To.IsZero() {
+		pred = append(pred, sq.Lt{"received_at": filter.To})
+	}
+	if filter.SenderDomain != "" {
+		pred = append(pred, sq.Eq{"sender_domain": filter.SenderDomain})
+	}
+	if filter.BeforeID > 0 {
+		pred = append(pred, sq.Lt{"id": filter.BeforeID})
+	}
+	if filter.Decision != "" {
+		pred = append(pred, sq.Eq{"decision": filter.Decision})
+	}
+	if filter.Pending {
+		pred = append(pred, sq.Eq{"decision": "pending"}, sq.Expr(
+			"NOT EXISTS (SELECT 1 FROM "+auditLogTableName+" later WHERE later.transaction_id = "+
+				auditLogTableName+".transaction_id AND later.id > "+auditLogTableName+".id AND later.decision != 'pending')",
+		))
+	}
+
+	var where interface{} = "1=1"
+	if len(pred) > 0 {
+		where = pred
+	}
+
+	err := auditLogTable.Select(&entries, where).Limit(limit).Offset(offset).OrderBy("id desc").Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return entries, nil
+		default:
+			return entries, errors.Wrap(err, "Error getting audit log entries")
+		}
+	}
+
+	return entries, nil
+}
+
+// RedactAuditLogEntries removes the personal data of the audit log entries
+// received before `receivedBefore`, the auth data and the callback responses
+// of the trail, and returns the number of redacted entries
+func (d *PostgresDatabase) RedactAuditLogEntries(receivedBefore, now time.Time) (int64, error) {
+	query := sq.Update(auditLogTableName).
+		Set("data", nil).
+		Set("trail", sq.Expr(redactedTrail)).
+		Set("redacted_at", now).
+		Where(sq.And{
+			sq.Lt{"received_at": receivedBefore},
+			sq.Eq{"redacted_at": nil},
+		})
+
+	result, err := d.session.Exec(query)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error redacting audit log entries")
+	}
+
+	redacted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "Error redacting audit log entries")
+	}
+
+	return redacted, nil
+}
+
+// redactedTrail is the trail of an audit log entry without the callback
+// responses of its steps
+const redactedTrail = `(
+	SELECT COALESCE(jsonb_agg(step - 'response' ORDER BY position), '[]')::text
+	FROM jsonb_array_elements(trail::jsonb) WITH ORDINALITY AS steps(step, position)
+)`
+
+// RedactAuthorizedTransactions removes the auth data, which contains personal
+// data, of the transactions authorized before `authorizedBefore` and returns
+// the number of redacted transactions
+func (d *PostgresDatabase) RedactAuthorizedTransactions(authorizedBefore time.Time) (int64, error) {
+	query := sq.Update(authorizedTransactionTableName).
+		Set("data", "").
+		Where(sq.And{
+			sq.Lt{"authorized_at": authorizedBefore},
+			sq.NotEq{"data": ""},
+		})
+
+	result, err := d.session.Exec(query)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error redacting authorized transactions")
+	}
+
+	redacted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "Error redacting authorized transactions")
+	}
+
+	return redacted, nil
+}
+
+// RedactAuthData removes the auth data sent before `createdBefore` and returns
+// the number of redacted auth data
+func (d *PostgresDatabase) RedactAuthData(createdBefore time.Time) (int64, error) {
+	query := sq.Update(authDataTableName).
+		Set("auth_data", "").
+		Where(sq.And{
+			sq.Lt{"created_at": createdBefore},
+			sq.NotEq{"auth_data": ""},
+		})
+
+	result, err := d.session.Exec(query)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error redacting auth data")
+	}
+
+	redacted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "Error redacting auth data")
+	}
+
+	return redacted, nil
+}
@@ -5,38 +5,24 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/stellar/go/services/compliance/internal/db"
 	"github.com/stellar/go/services/internal/bridge-compliance-shared/http/helpers"
 )
 
-// HandlerAllowAccess implements /allow_access endpoint
+// HandlerAllowAccess implements /allow_access endpoint. Access previously
+// allowed to the same FI or user is replaced.
 func (rh *RequestHandler) HandlerAllowAccess(w http.ResponseWriter, r *http.Request) {
-	name := r.PostFormValue("name")
-	domain := r.PostFormValue("domain")
-	publicKey := r.PostFormValue("public_key")
-	userID := r.PostFormValue("user_id")
-
-	// TODO check params
+	fi, user, errorResponse := allowedFromValues(r.PostFormValue, time.Now().UTC())
+	if errorResponse != nil {
+		helpers.Write(w, errorResponse)
+		return
+	}
 
 	var err error
 
-	if userID != "" {
-		entity := &db.AllowedUser{
-			FiName:      name,
-			FiDomain:    domain,
-			FiPublicKey: publicKey,
-			UserID:      userID,
-			AllowedAt:   time.Now(),
-		}
-		err = rh.Database.InsertAllowedUser(entity)
+	if user != nil {
+		err = rh.Database.SaveAllowedUser(user)
 	} else {
-		entity := &db.AllowedFI{
-			Name:      name,
-			Domain:    domain,
-			PublicKey: publicKey,
-			AllowedAt: time.Now(),
-		}
-		err = rh.Database.InsertAllowedFI(entity)
+		err = rh.Database.SaveAllowedFI(fi)
 	}
 
 	if err != nil {
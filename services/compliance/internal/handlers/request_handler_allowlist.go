@@ -0,0 +1,411 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	baseAmount "github.com/stellar/go/amount"
+	"github.com/stellar/go/services/compliance/internal/db"
+	shared "github.com/stellar/go/services/internal/bridge-compliance-shared"
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/http/helpers"
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	allowlistDefaultLimit = 10
+	allowlistMaxLimit     = 100
+	// nativeAssetCode is the asset code of lumens in access scopes
+	nativeAssetCode = "XLM"
+)
+
+// accessGrant is the expiry and the scopes of an allowed FI or user.
+type accessGrant struct {
+	ExpiresAt   *time.Time
+	AssetCode   string
+	AssetIssuer string
+	MaxAmount   *int64
+}
+
+// allowlistPayment is the asset and amount received by a payment operation of
+// a transaction.
+type allowlistPayment struct {
+	Amount      xdr.Int64
+	AssetType   string
+	AssetCode   string
+	AssetIssuer string
+}
+
+// firstPayment returns the first payment operation of `tx`, ok is false when
+// it is neither a payment nor a path payment.
+func firstPayment(tx xdr.Transaction) (payment allowlistPayment, ok bool) {
+	if len(tx.Operations) == 0 {
+		return
+	}
+
+	return operationPayment(tx.Operations[0])
+}
+
+// transactionPayments returns the payment operations of `tx`, ok is false
+// when `tx` has no operations or any of them is neither a payment nor a path
+// payment.
+func transactionPayments(tx xdr.Transaction) (payments []allowlistPayment, ok bool) {
+	if len(tx.Operations) == 0 {
+		return nil, false
+	}
+
+	for _, operation := range tx.Operations {
+		payment, ok := operationPayment(operation)
+		if !ok {
+			return nil, false
+		}
+		payments = append(payments, payment)
+	}
+
+	return payments, true
+}
+
+// operationPayment returns the payment of `operation`, ok is false when it is
+// neither a payment nor a path payment.
+func operationPayment(operation xdr.Operation) (payment allowlistPayment, ok bool) {
+	operationBody := operation.Body
+	switch operationBody.Type {
+	case xdr.OperationTypePayment:
+		payment.Amount = operationBody.PaymentOp.Amount
+		operationBody.PaymentOp.Asset.Extract(&payment.AssetType, &payment.AssetCode, &payment.AssetIssuer)
+	case xdr.OperationTypePathPayment:
+		payment.Amount = operationBody.PathPaymentOp.DestAmount
+		operationBody.PathPaymentOp.DestAsset.Extract(&payment.AssetType, &payment.AssetCode, &payment.AssetIssuer)
+	default:
+		return
+	}
+
+	return payment, true
+}
+
+// check returns why the grant does not allow access to the data of a
+// transaction made of `payments` at `now`, or an empty string when it does.
+// Scopes apply to every payment and the max amount to their total.
+// `isPayment` is false when the transaction contains other operations.
+func (g accessGrant) check(now time.Time, payments []allowlistPayment, isPayment bool) string {
+	if g.ExpiresAt != nil && !now.Before(*g.ExpiresAt) {
+		return "expired at " + g.ExpiresAt.Format(time.RFC3339)
+	}
+
+	if g.AssetCode == "" && g.MaxAmount == nil {
+		return ""
+	}
+
+	if !isPayment {
+		return "restricted to payments"
+	}
+
+	var total int64
+	for _, payment := range payments {
+		if g.AssetCode != "" {
+			assetCode, assetIssuer := payment.AssetCode, payment.AssetIssuer
+			if payment.AssetType == "native" {
+				assetCode = nativeAssetCode
+			}
+			if assetCode != g.AssetCode || assetIssuer != g.AssetIssuer {
+				return "restricted to " + g.asset()
+			}
+		}
+
+		if g.MaxAmount != nil {
+			// Amounts are positive, checking each one keeps the total from
+			// overflowing
+			total += int64(payment.Amount)
+			if int64(payment.Amount) > *g.MaxAmount || total > *g.MaxAmount {
+				return "restricted to amounts up to " + baseAmount.StringFromInt64(*g.MaxAmount)
+			}
+		}
+	}
+
+	return ""
+}
+
+func (g accessGrant) asset() string {
+	if g.AssetIssuer == "" {
+		return g.AssetCode
+	}
+	return g.AssetCode + ":" + g.AssetIssuer
+}
+
+// accessGrantFromValues reads and validates the `expires_at`, `asset_code`,
+// `asset_issuer` and `max_amount` params using `get`.
+func accessGrantFromValues(get func(key string) string, now time.Time) (accessGrant, *helpers.ErrorResponse) {
+	grant := accessGrant{
+		AssetCode:   get("asset_code"),
+		AssetIssuer: get("asset_issuer"),
+	}
+
+	if value := get("expires_at"); value != "" {
+		expiresAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return grant, helpers.NewInvalidParameterError("expires_at", "Invalid RFC 3339 time")
+		}
+		if !expiresAt.After(now) {
+			return grant, helpers.NewInvalidParameterError("expires_at", "Must be in the future")
+		}
+		expiresAt = expiresAt.UTC()
+		grant.ExpiresAt = &expiresAt
+	}
+
+	if grant.AssetIssuer != "" && !shared.IsValidAccountID(grant.AssetIssuer) {
+		return grant, helpers.NewInvalidParameterError("asset_issuer", "Invalid account ID")
+	}
+
+	if grant.AssetIssuer != "" && grant.AssetCode == "" {
+		return grant, helpers.NewMissingParameter("asset_code")
+	}
+
+	if grant.AssetCode != "" && grant.AssetCode != nativeAssetCode && grant.AssetIssuer == "" {
+		return grant, helpers.NewMissingParameter("asset_issuer")
+	}
+
+	if grant.AssetCode != "" && !shared.IsValidAssetCode(grant.AssetCode) {
+		return grant, helpers.NewInvalidParameterError("asset_code", "Invalid asset code")
+	}
+
+	if value := get("max_amount"); value != "" {
+		maxAmount, err := baseAmount.Parse(value)
+		if err != nil || maxAmount <= 0 {
+			return grant, helpers.NewInvalidParameterError("max_amount", "Invalid amount")
+		}
+		amount := int64(maxAmount)
+		grant.MaxAmount = &amount
+	}
+
+	return grant, nil
+}
+
+// allowedFromValues reads and validates the params of /allow_access using
+// `get` and returns the allowed FI, or the allowed user when `user_id` is set.
+func allowedFromValues(get func(key string) string, now time.Time) (*db.AllowedFI, *db.AllowedUser, *helpers.ErrorResponse) {
+	name := get("name")
+	domain := get("domain")
+	publicKey := get("public_key")
+	userID := get("user_id")
+
+	if name == "" {
+		return nil, nil, helpers.NewMissingParameter("name")
+	}
+
+	if domain == "" {
+		return nil, nil, helpers.NewMissingParameter("domain")
+	}
+
+	if !shared.IsValidAccountID(publicKey) {
+		return nil, nil, helpers.NewInvalidParameterError("public_key", "Invalid account ID")
+	}
+
+	grant, errorResponse := accessGrantFromValues(get, now)
+	if errorResponse != nil {
+		return nil, nil, errorResponse
+	}
+
+	if userID != "" {
+		return nil, &db.AllowedUser{
+			FiName:      name,
+			FiDomain:    domain,
+			FiPublicKey: publicKey,
+			UserID:      userID,
+			AllowedAt:   now,
+			ExpiresAt:   grant.ExpiresAt,
+			AssetCode:   grant.AssetCode,
+			AssetIssuer: grant.AssetIssuer,
+			MaxAmount:   grant.MaxAmount,
+		}, nil
+	}
+
+	return &db.AllowedFI{
+		Name:        name,
+		Domain:      domain,
+		PublicKey:   publicKey,
+		AllowedAt:   now,
+		ExpiresAt:   grant.ExpiresAt,
+		AssetCode:   grant.AssetCode,
+		AssetIssuer: grant.AssetIssuer,
+		MaxAmount:   grant.MaxAmount,
+	}, nil, nil
+}
+
+// allowedResponse represents an allowed FI or user returned by /allowed_fis
+// and /allowed_users.
+type allowedResponse struct {
+	Name        string     `json:"name"`
+	Domain      string     `json:"domain"`
+	PublicKey   string     `json:"public_key"`
+	UserID      string     `json:"user_id,omitempty"`
+	AllowedAt   time.Time  `json:"allowed_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Expired     bool       `json:"expired"`
+	AssetCode   string     `json:"asset_code,omitempty"`
+	AssetIssuer string     `json:"asset_issuer,omitempty"`
+	MaxAmount   string     `json:"max_amount,omitempty"`
+}
+
+func newAllowedResponse(name, domain, publicKey, userID string, allowedAt time.Time, grant accessGrant, now time.Time) allowedResponse {
+	response := allowedResponse{
+		Name:        name,
+		Domain:      domain,
+		PublicKey:   publicKey,
+		UserID:      userID,
+		AllowedAt:   allowedAt,
+		ExpiresAt:   grant.ExpiresAt,
+		Expired:     grant.ExpiresAt != nil && !now.Before(*grant.ExpiresAt),
+		AssetCode:   grant.AssetCode,
+		AssetIssuer: grant.AssetIssuer,
+	}
+	if grant.MaxAmount != nil {
+		response.MaxAmount = baseAmount.StringFromInt64(*grant.MaxAmount)
+	}
+	return response
+}
+
+func allowlistPage(r *http.Request) (page, limit uint64, includeExpired bool) {
+	page, _ = strconv.ParseUint(r.URL.Query().Get("page"), 10, 64)
+	limit, _ = strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+	if limit == 0 {
+		limit = allowlistDefaultLimit
+	}
+	if limit > allowlistMaxLimit {
+		limit = allowlistMaxLimit
+	}
+	includeExpired, _ = strconv.ParseBool(r.URL.Query().Get("include_expired"))
+	return
+}
+
+// HandlerAllowedFIs implements /allowed_fis endpoint
+func (rh *RequestHandler) HandlerAllowedFIs(w http.ResponseWriter, r *http.Request) {
+	page, limit, includeExpired := allowlistPage(r)
+	now := time.Now().UTC()
+
+	fis, err := rh.Database.GetAllowedFIs(includeExpired, now, page, limit)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error loading allowed FIs")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+
+	response := []allowedResponse{}
+	for _, fi := range fis {
+		grant := accessGrant{fi.ExpiresAt, fi.AssetCode, fi.AssetIssuer, fi.MaxAmount}
+		response = append(response, newAllowedResponse(fi.Name, fi.Domain, fi.PublicKey, "", fi.AllowedAt, grant, now))
+	}
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(response)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error encoding allowed FIs")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+}
+
+// HandlerAllowedUsers implements /allowed_users endpoint
+func (rh *RequestHandler) HandlerAllowedUsers(w http.ResponseWriter, r *http.Request) {
+	page, limit, includeExpired := allowlistPage(r)
+	now := time.Now().UTC()
+
+	users, err := rh.Database.GetAllowedUsers(r.URL.Query().Get("domain"), includeExpired, now, page, limit)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error loading allowed users")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+
+	response := []allowedResponse{}
+	for _, user := range users {
+		grant := accessGrant{user.ExpiresAt, user.AssetCode, user.AssetIssuer, user.MaxAmount}
+		response = append(response, newAllowedResponse(user.FiName, user.FiDomain, user.FiPublicKey, user.UserID, user.AllowedAt, grant, now))
+	}
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(response)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error encoding allowed users")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+}
+
+// HandlerImportAccess implements /allow_access/import endpoint. The body is
+// CSV with a header row naming the params of /allow_access, each row allowing
+// an FI or a user. Rows are all saved or, when one is invalid, none of them
+// and the error data contains the invalid `row`, starting at 1.
+func (rh *RequestHandler) HandlerImportAccess(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+	reader := csv.NewReader(r.Body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		helpers.Write(w, helpers.NewInvalidParameterError("body", "Missing CSV header"))
+		return
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	var fis []*db.AllowedFI
+	var users []*db.AllowedUser
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errorResponse := helpers.NewInvalidParameterError("body", err.Error())
+			errorResponse.Data["row"] = row
+			helpers.Write(w, errorResponse)
+			return
+		}
+
+		get := func(key string) string {
+			i, ok := columns[key]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		fi, user, errorResponse := allowedFromValues(get, now)
+		if errorResponse != nil {
+			errorResponse.Data["row"] = row
+			helpers.Write(w, errorResponse)
+			return
+		}
+
+		if fi != nil {
+			fis = append(fis, fi)
+		} else {
+			users = append(users, user)
+		}
+	}
+
+	err = rh.Database.ImportAllowed(fis, users)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Warn("Error importing allowed FIs and users")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+
+	log.WithFields(log.Fields{"fis": len(fis), "users": len(users)}).Info("Imported allowed FIs and users")
+
+	encoder := json.NewEncoder(w)
+	encoder.Encode(struct {
+		FIs   int `json:"fis"`
+		Users int `json:"users"`
+	}{len(fis), len(users)})
+}
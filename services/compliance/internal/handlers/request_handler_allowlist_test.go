@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+const testIssuer = "GBIUXI4S27PSL6TTJCJMPYDCF3K6AW2MYORFRTC7QBFE6NNEGVOQK46H"
+
+func paymentOperation(asset xdr.Asset, amount xdr.Int64) xdr.Operation {
+	return xdr.Operation{Body: xdr.OperationBody{
+		Type:      xdr.OperationTypePayment,
+		PaymentOp: &xdr.PaymentOp{Asset: asset, Amount: amount},
+	}}
+}
+
+func TestAccessGrantCheckMultipleOperations(t *testing.T) {
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	usd := xdr.MustNewCreditAsset("USD", testIssuer)
+	eur := xdr.MustNewCreditAsset("EUR", testIssuer)
+	maxAmount := int64(100 * 10000000)
+	grant := accessGrant{AssetCode: "USD", AssetIssuer: testIssuer, MaxAmount: &maxAmount}
+
+	check := func(operations ...xdr.Operation) string {
+		payments, isPayment := transactionPayments(xdr.Transaction{Operations: operations})
+		return grant.check(now, payments, isPayment)
+	}
+
+	assert.Equal(t, "", check(paymentOperation(usd, 60*10000000)))
+	assert.Equal(t, "", check(paymentOperation(usd, 60*10000000), paymentOperation(usd, 40*10000000)))
+
+	// every payment must be of the asset of the grant
+	assert.Equal(t, "restricted to USD:"+testIssuer, check(paymentOperation(usd, 10000000), paymentOperation(eur, 10000000)))
+
+	// the max amount applies to the total of the payments
+	assert.Equal(t, "restricted to amounts up to 100.0000000", check(paymentOperation(usd, 60*10000000), paymentOperation(usd, 60*10000000)))
+
+	// every operation must be a payment
+	createAccount := xdr.Operation{Body: xdr.OperationBody{
+		Type:            xdr.OperationTypeCreateAccount,
+		CreateAccountOp: &xdr.CreateAccountOp{},
+	}}
+	assert.Equal(t, "restricted to payments", check(paymentOperation(usd, 10000000), createAccount))
+
+	// unscoped grants allow any transaction
+	assert.Equal(t, "", accessGrant{}.check(now, nil, false))
+}
@@ -0,0 +1,383 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	log "github.com/sirupsen/logrus"
+	"github.com/stellar/go/address"
+	"github.com/stellar/go/protocols/compliance"
+	"github.com/stellar/go/services/compliance/internal/db"
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/http/helpers"
+)
+
+const (
+	auditLogDefaultLimit = 10
+	auditLogMaxLimit     = 100
+	// auditLogMaxResponse is the maximum length of callback responses stored
+	// in the decision trail
+	auditLogMaxResponse = 1000
+)
+
+// auditStep is a step of the decision on an auth request
+type auditStep struct {
+	Name       string                `json:"name"`
+	Status     compliance.AuthStatus `json:"status"`
+	HTTPStatus int                   `json:"http_status,omitempty"`
+	Note       string                `json:"note,omitempty"`
+	Response   string                `json:"response,omitempty"`
+	At         time.Time             `json:"at"`
+}
+
+// auditTrail is the list of steps of the decision on an auth request, stored
+// in the audit log
+type auditTrail []auditStep
+
+func (t *auditTrail) add(name string, status compliance.AuthStatus, note string) {
+	*t = append(*t, auditStep{Name: name, Status: status, Note: note, At: time.Now().UTC()})
+}
+
+// addCallback adds the step of a callback with its truncated response.
+// Callback responses may contain personal data so they are removed from the
+// trail when the entry is redacted.
+func (t *auditTrail) addCallback(name string, status compliance.AuthStatus, httpStatus int, response string) {
+	if len(response) > auditLogMaxResponse {
+		response = response[:auditLogMaxResponse]
+	}
+	*t = append(*t, auditStep{Name: name, Status: status, HTTPStatus: httpStatus, Response: response, At: time.Now().UTC()})
+}
+
+// failure returns the reason of the failure of a trail ending with an error
+// step.
+func (t auditTrail) failure() string {
+	if len(t) == 0 || t[len(t)-1].Status != compliance.AuthStatusError {
+		return ""
+	}
+
+	step := t[len(t)-1]
+	if step.Note == "" && step.HTTPStatus != 0 {
+		return fmt.Sprintf("Unexpected %s response: HTTP %d", step.Name, step.HTTPStatus)
+	}
+	return step.Note
+}
+
+// authDecision returns the decision on an auth request, following the
+// precedence of the HTTP status of the Auth endpoint response.
+func authDecision(response compliance.AuthResponse) compliance.AuthStatus {
+	switch {
+	case response.TxStatus == compliance.AuthStatusOk && response.InfoStatus == compliance.AuthStatusOk:
+		return compliance.AuthStatusOk
+	case response.TxStatus == compliance.AuthStatusDenied || response.InfoStatus == compliance.AuthStatusDenied:
+		return compliance.AuthStatusDenied
+	case response.TxStatus == compliance.AuthStatusError || response.InfoStatus == compliance.AuthStatusError:
+		return compliance.AuthStatusError
+	default:
+		return compliance.AuthStatusPending
+	}
+}
+
+// recordAuthDecision stores the decision on an auth request in the audit log.
+// Requests which could not be answered are recorded with an `error` decision.
+func (rh *RequestHandler) recordAuthDecision(
+	authData compliance.AuthData,
+	dataJSON string,
+	transactionID string,
+	decision compliance.AuthStatus,
+	response compliance.AuthResponse,
+	trail auditTrail,
+) error {
+	trailJSON, err := json.Marshal(trail)
+	if err != nil {
+		return err
+	}
+
+	_, domain, err := address.Split(authData.Sender)
+	if err != nil {
+		return err
+	}
+
+	entry := &db.AuditLogEntry{
+		Sender:        authData.Sender,
+		SenderDomain:  domain,
+		TransactionID: transactionID,
+		Decision:      string(decision),
+		TxStatus:      string(response.TxStatus),
+		InfoStatus:    string(response.InfoStatus),
+		Pending:       response.Pending,
+		Error:         response.Error,
+		Trail:         string(trailJSON),
+		Data:          &dataJSON,
+		ReceivedAt:    time.Now().UTC(),
+	}
+	return rh.Database.InsertAuditLogEntry(entry)
+}
+
+// auditLogEntryResponse represents an audit log entry returned by the audit
+// endpoints. Trail and Data are only returned by /audit/{id}.
+type auditLogEntryResponse struct {
+	ID            int64           `json:"id"`
+	Sender        string          `json:"sender"`
+	SenderDomain  string          `json:"sender_domain"`
+	TransactionID string          `json:"transaction_id"`
+	Decision      string          `json:"decision"`
+	TxStatus      string          `json:"tx_status"`
+	InfoStatus    string          `json:"info_status"`
+	Pending       int             `json:"pending,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	ReceivedAt    time.Time       `json:"received_at"`
+	RedactedAt    *time.Time      `json:"redacted_at,omitempty"`
+	Trail         json.RawMessage `json:"trail,omitempty"`
+	Data          json.RawMessage `json:"data,omitempty"`
+}
+
+func newAuditLogEntryResponse(entry *db.AuditLogEntry) auditLogEntryResponse {
+	return auditLogEntryResponse{
+		ID:            entry.ID,
+		Sender:        entry.Sender,
+		SenderDomain:  entry.SenderDomain,
+		TransactionID: entry.TransactionID,
+		Decision:      entry.Decision,
+		TxStatus:      entry.TxStatus,
+		InfoStatus:    entry.InfoStatus,
+		Pending:       entry.Pending,
+		Error:         entry.Error,
+		ReceivedAt:    entry.ReceivedAt,
+		RedactedAt:    entry.RedactedAt,
+	}
+}
+
+// HandlerAudit implements /audit endpoint
+func (rh *RequestHandler) HandlerAudit(w http.ResponseWriter, r *http.Request) {
+	filter, errorResponse := auditLogFilterFromRequest(r)
+	if errorResponse != nil {
+		helpers.Write(w, errorResponse)
+		return
+	}
+
+	page, _ := strconv.ParseUint(r.URL.Query().Get("page"), 10, 64)
+	limit, _ := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+	if limit == 0 {
+		limit = auditLogDefaultLimit
+	}
+	if limit > auditLogMaxLimit {
+		limit = auditLogMaxLimit
+	}
+
+	entries, err := rh.Database.GetAuditLogEntries(filter, page, limit)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error loading audit log entries")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+
+	response := []auditLogEntryResponse{}
+	for _, entry := range entries {
+		response = append(response, newAuditLogEntryResponse(entry))
+	}
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(response)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error encoding audit log entries")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+}
+
+// HandlerAuditEntry implements /audit/{id} endpoint
+func (rh *RequestHandler) HandlerAuditEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		helpers.Write(w, helpers.NewInvalidParameterError("id", "Invalid ID"))
+		return
+	}
+
+	entry, err := rh.Database.GetAuditLogEntryByID(id)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error loading audit log entry")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+
+	if entry == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	response := newAuditLogEntryResponse(entry)
+	response.Trail = json.RawMessage(entry.Trail)
+	if entry.Data != nil {
+		response.Data = json.RawMessage(*entry.Data)
+	}
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(response)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error encoding audit log entry")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+}
+
+// HandlerAuditExport implements /audit/export endpoint. It returns all the
+// entries matching the filters of /audit as CSV.
+func (rh *RequestHandler) HandlerAuditExport(w http.ResponseWriter, r *http.Request) {
+	filter, errorResponse := auditLogFilterFromRequest(r)
+	if errorResponse != nil {
+		helpers.Write(w, errorResponse)
+		return
+	}
+
+	// Load the first page before writing headers so errors can be returned.
+	// Next pages are loaded after the last exported entry so entries added
+	// during the export do not shift pages.
+	entries, err := rh.Database.GetAuditLogEntries(filter, 1, auditLogMaxLimit)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error loading audit log entries")
+		helpers.Write(w, helpers.InternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="audit_log.csv"`)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{
+		"id", "received_at", "sender", "sender_domain", "transaction_id", "decision",
+		"tx_status", "info_status", "pending", "error", "sender_info", "trail", "redacted_at",
+	})
+
+	for {
+		for _, entry := range entries {
+			writer.Write(auditLogEntryRecord(entry))
+		}
+
+		if len(entries) < auditLogMaxLimit {
+			break
+		}
+
+		filter.BeforeID = entries[len(entries)-1].ID
+		entries, err = rh.Database.GetAuditLogEntries(filter, 1, auditLogMaxLimit)
+		if err != nil {
+			// Headers are sent already, the export ends early
+			log.WithFields(log.Fields{"err": err}).Error("Error loading audit log entries")
+			break
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("Error writing audit log CSV")
+	}
+}
+
+// auditLogEntryRecord returns the CSV record of an audit log entry. The
+// sender info is read from the attachment, unless the entry is redacted.
+func auditLogEntryRecord(entry *db.AuditLogEntry) []string {
+	var senderInfo, redactedAt string
+
+	if entry.Data != nil {
+		var authData compliance.AuthData
+		err := json.Unmarshal([]byte(*entry.Data), &authData)
+		if err == nil {
+			attachment, err := authData.Attachment()
+			if err == nil && len(attachment.Transaction.SenderInfo) > 0 {
+				info, _ := json.Marshal(attachment.Transaction.SenderInfo)
+				senderInfo = string(info)
+			}
+		}
+	}
+
+	if entry.RedactedAt != nil {
+		redactedAt = entry.RedactedAt.Format(time.RFC3339)
+	}
+
+	return []string{
+		strconv.FormatInt(entry.ID, 10),
+		entry.ReceivedAt.Format(time.RFC3339),
+		entry.Sender,
+		entry.SenderDomain,
+		entry.TransactionID,
+		entry.Decision,
+		entry.TxStatus,
+		entry.InfoStatus,
+		strconv.Itoa(entry.Pending),
+		entry.Error,
+		senderInfo,
+		entry.Trail,
+		redactedAt,
+	}
+}
+
+// auditLogFilterFromRequest reads the filters of the audit endpoints:
+// `from` and `to` (RFC 3339 or YYYY-MM-DD dates, `to` inclusive),
+// `sender_domain`, `decision`, `before_id` and `pending`.
+func auditLogFilterFromRequest(r *http.Request) (db.AuditLogFilter, *helpers.ErrorResponse) {
+	query := r.URL.Query()
+	filter := db.AuditLogFilter{
+		SenderDomain: query.Get("sender_domain"),
+		Decision:     query.Get("decision"),
+	}
+
+	var ok bool
+	if value := query.Get("from"); value != "" {
+		filter.From, ok = parseAuditLogTime(value, false)
+		if !ok {
+			return filter, helpers.NewInvalidParameterError("from", "Invalid date")
+		}
+	}
+
+	if value := query.Get("to"); value != "" {
+		filter.To, ok = parseAuditLogTime(value, true)
+		if !ok {
+			return filter, helpers.NewInvalidParameterError("to", "Invalid date")
+		}
+	}
+
+	switch compliance.AuthStatus(filter.Decision) {
+	case "", compliance.AuthStatusOk, compliance.AuthStatusDenied, compliance.AuthStatusError, compliance.AuthStatusPending:
+	default:
+		return filter, helpers.NewInvalidParameterError("decision", "Unknown decision")
+	}
+
+	if value := query.Get("before_id"); value != "" {
+		var err error
+		filter.BeforeID, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || filter.BeforeID <= 0 {
+			return filter, helpers.NewInvalidParameterError("before_id", "Invalid ID")
+		}
+	}
+
+	if value := query.Get("pending"); value != "" {
+		var err error
+		filter.Pending, err = strconv.ParseBool(value)
+		if err != nil {
+			return filter, helpers.NewInvalidParameterError("pending", "Invalid boolean")
+		}
+	}
+
+	return filter, nil
+}
+
+// parseAuditLogTime parses RFC 3339 times and YYYY-MM-DD dates in UTC. When
+// `end` is true dates are parsed as the end of the day.
+func parseAuditLogTime(value string, end bool) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t.UTC(), true
+	}
+
+	t, err = time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, true
+}
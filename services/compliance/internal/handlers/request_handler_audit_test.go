@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/protocols/compliance"
+	"github.com/stellar/go/services/compliance/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthDecision(t *testing.T) {
+	for _, tc := range []struct {
+		tx, info compliance.AuthStatus
+		expected compliance.AuthStatus
+	}{
+		{compliance.AuthStatusOk, compliance.AuthStatusOk, compliance.AuthStatusOk},
+		{compliance.AuthStatusOk, compliance.AuthStatusDenied, compliance.AuthStatusDenied},
+		{compliance.AuthStatusDenied, compliance.AuthStatusOk, compliance.AuthStatusDenied},
+		{compliance.AuthStatusDenied, compliance.AuthStatusError, compliance.AuthStatusDenied},
+		{compliance.AuthStatusPending, compliance.AuthStatusDenied, compliance.AuthStatusDenied},
+		{compliance.AuthStatusError, compliance.AuthStatusOk, compliance.AuthStatusError},
+		{compliance.AuthStatusPending, compliance.AuthStatusError, compliance.AuthStatusError},
+		{compliance.AuthStatusPending, compliance.AuthStatusOk, compliance.AuthStatusPending},
+		{compliance.AuthStatusOk, compliance.AuthStatusPending, compliance.AuthStatusPending},
+		{compliance.AuthStatusOk, "", compliance.AuthStatusPending},
+	} {
+		response := compliance.AuthResponse{TxStatus: tc.tx, InfoStatus: tc.info}
+		assert.Equal(t, tc.expected, authDecision(response), "tx_status: %s, info_status: %s", tc.tx, tc.info)
+	}
+}
+
+func TestAuditTrail(t *testing.T) {
+	trail := auditTrail{}
+	assert.Equal(t, "", trail.failure())
+
+	trail.add("signature", compliance.AuthStatusOk, "Signed")
+	assert.Equal(t, "", trail.failure())
+
+	// callback responses are truncated
+	trail.addCallback("sanctions", compliance.AuthStatusOk, http.StatusOK, strings.Repeat("a", auditLogMaxResponse+1))
+	assert.Len(t, trail[1].Response, auditLogMaxResponse)
+	assert.Equal(t, "", trail.failure())
+
+	trail.addCallback("fetch_info", compliance.AuthStatusError, http.StatusBadGateway, "down")
+	assert.Equal(t, "Unexpected fetch_info response: HTTP 502", trail.failure())
+
+	trail.add("ask_user", compliance.AuthStatusError, "Error sending request to ask_user server")
+	assert.Equal(t, "Error sending request to ask_user server", trail.failure())
+}
+
+func TestParseAuditLogTime(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		end      bool
+		expected time.Time
+		ok       bool
+	}{
+		{"2019-03-04T05:06:07Z", false, time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC), true},
+		{"2019-03-04T05:06:07+02:00", false, time.Date(2019, 3, 4, 3, 6, 7, 0, time.UTC), true},
+		// times are not moved to the end of the day
+		{"2019-03-04T05:06:07Z", true, time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC), true},
+		{"2019-03-04", false, time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC), true},
+		// `to` dates include the whole day
+		{"2019-03-04", true, time.Date(2019, 3, 5, 0, 0, 0, 0, time.UTC), true},
+		{"2019-12-31", true, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"", false, time.Time{}, false},
+		{"2019-13-01", false, time.Time{}, false},
+		{"04/03/2019", true, time.Time{}, false},
+	} {
+		parsed, ok := parseAuditLogTime(tc.value, tc.end)
+		assert.Equal(t, tc.ok, ok, tc.value)
+		assert.True(t, tc.expected.Equal(parsed), "%s: %s", tc.value, parsed)
+	}
+}
+
+func TestAuditLogFilterFromRequest(t *testing.T) {
+	for _, tc := range []struct {
+		query    string
+		expected db.AuditLogFilter
+		invalid  string
+	}{
+		{query: "", expected: db.AuditLogFilter{}},
+		{
+			query: "from=2019-03-04&to=2019-03-05&sender_domain=stellar.org&decision=denied&before_id=42&pending=true",
+			expected: db.AuditLogFilter{
+				From:         time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC),
+				To:           time.Date(2019, 3, 6, 0, 0, 0, 0, time.UTC),
+				SenderDomain: "stellar.org",
+				Decision:     "denied",
+				BeforeID:     42,
+				Pending:      true,
+			},
+		},
+		{query: "pending=false", expected: db.AuditLogFilter{}},
+		{query: "from=yesterday", invalid: "from"},
+		{query: "to=2019-02-30", invalid: "to"},
+		{query: "decision=maybe", invalid: "decision"},
+		{query: "before_id=0", invalid: "before_id"},
+		{query: "before_id=-1", invalid: "before_id"},
+		{query: "before_id=abc", invalid: "before_id"},
+		{query: "pending=sometimes", invalid: "pending"},
+	} {
+		r := httptest.NewRequest("GET", "/audit?"+tc.query, nil)
+		filter, errorResponse := auditLogFilterFromRequest(r)
+		if tc.invalid != "" {
+			if assert.NotNil(t, errorResponse, tc.query) {
+				assert.Equal(t, tc.invalid, errorResponse.Data["name"], tc.query)
+			}
+			continue
+		}
+
+		assert.Nil(t, errorResponse, tc.query)
+		assert.Equal(t, tc.expected, filter, tc.query)
+	}
+}
+
+// auditLogDatabase serves audit log entries from memory, the most recent
+// first, and records the filters of the loaded pages.
+type auditLogDatabase struct {
+	db.Database
+	entries []*db.AuditLogEntry
+	filters []db.AuditLogFilter
+	// onLoad is called after every load
+	onLoad func(d *auditLogDatabase)
+}
+
+func (d *auditLogDatabase) GetAuditLogEntries(filter db.AuditLogFilter, page, limit uint64) ([]*db.AuditLogEntry, error) {
+	d.filters = append(d.filters, filter)
+
+	var entries []*db.AuditLogEntry
+	for i := len(d.entries) - 1; i >= 0; i-- {
+		entry := d.entries[i]
+		if filter.BeforeID > 0 && entry.ID >= filter.BeforeID {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	offset := (page - 1) * limit
+	if offset > uint64(len(entries)) {
+		offset = uint64(len(entries))
+	}
+	entries = entries[offset:]
+	if uint64(len(entries)) > limit {
+		entries = entries[:limit]
+	}
+
+	if d.onLoad != nil {
+		d.onLoad(d)
+	}
+	return entries, nil
+}
+
+func (d *auditLogDatabase) add(count int) {
+	for i := 0; i < count; i++ {
+		id := int64(len(d.entries) + 1)
+		d.entries = append(d.entries, &db.AuditLogEntry{
+			ID:           id,
+			Sender:       "alice*stellar.org",
+			SenderDomain: "stellar.org",
+			Decision:     string(compliance.AuthStatusOk),
+			Trail:        "[]",
+			ReceivedAt:   time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC).Add(time.Duration(id) * time.Minute),
+		})
+	}
+}
+
+func TestHandlerAuditExportPaging(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		entries  int
+		beforeID []int64
+	}{
+		{"empty", 0, []int64{0}},
+		{"single page", 42, []int64{0}},
+		{"full pages", 2 * auditLogMaxLimit, []int64{0, 101, 1}},
+		{"partial last page", 250, []int64{0, 151, 51}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			database := &auditLogDatabase{}
+			database.add(tc.entries)
+			// entries added during the export are not exported and do
+			// not shift the pages
+			database.onLoad = func(d *auditLogDatabase) { d.add(3) }
+
+			rh := &RequestHandler{Database: database}
+			r := httptest.NewRequest("GET", "/audit/export?sender_domain=stellar.org", nil)
+			w := httptest.NewRecorder()
+			rh.HandlerAuditExport(w, r)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+
+			records, err := csv.NewReader(w.Body).ReadAll()
+			require.NoError(t, err)
+			require.Len(t, records, tc.entries+1)
+			assert.Equal(t, "id", records[0][0])
+			for i, record := range records[1:] {
+				assert.Equal(t, strconv.Itoa(tc.entries-i), record[0])
+			}
+
+			var beforeID []int64
+			for _, filter := range database.filters {
+				assert.Equal(t, "stellar.org", filter.SenderDomain)
+				beforeID = append(beforeID, filter.BeforeID)
+			}
+			assert.Equal(t, tc.beforeID, beforeID)
+		})
+	}
+}
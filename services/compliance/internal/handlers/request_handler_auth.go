@@ -26,8 +26,9 @@ import (
 // HandlerAuth implements authorize endpoint
 func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 	authreq := &compliance.AuthRequest{
-		DataJSON:  r.PostFormValue("data"),
-		Signature: r.PostFormValue("sig"),
+		DataJSON:   r.PostFormValue("data"),
+		Signature:  r.PostFormValue("sig"),
+		Encryption: r.PostFormValue("encryption"),
 	}
 
 	log.WithFields(log.Fields{"data": authreq.DataJSON, "sig": authreq.Signature}).Info("HandlerAuth")
@@ -83,6 +84,48 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Decrypt attachment. Data is stored decrypted so it can be read by
+	// the bridge server when the payment is received.
+	dataJSON := authreq.DataJSON
+	if authreq.Encryption != "" {
+		if rh.Config.Keys.EncryptionKey == "" {
+			log.WithFields(log.Fields{"encryption": authreq.Encryption}).Warn("Received encrypted attachment but keys.encryption_key is not set")
+			httpHelpers.Write(w, httpHelpers.NewInvalidParameterError("encryption", "Encryption is not supported."))
+			return
+		}
+
+		var encryptionKey *[32]byte
+		encryptionKey, err = compliance.ParseEncryptionKey(rh.Config.Keys.EncryptionKey)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Invalid keys.encryption_key")
+			httpHelpers.Write(w, httpHelpers.InternalServerError)
+			return
+		}
+
+		err = authData.Decrypt(encryptionKey)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err, "sender": authData.Sender}).Warn("Error decrypting attachment")
+			httpHelpers.Write(w, httpHelpers.NewInvalidParameterError("data.encrypted_attachment", err.Error()))
+			return
+		}
+
+		err = authData.Validate()
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Info(err.Error())
+			httpHelpers.Write(w, httpHelpers.NewInvalidParameterError("data", err.Error()))
+			return
+		}
+
+		var data []byte
+		data, err = authData.Marshal()
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error marshalling decrypted data")
+			httpHelpers.Write(w, httpHelpers.InternalServerError)
+			return
+		}
+		dataJSON = string(data)
+	}
+
 	b64r := base64.NewDecoder(base64.StdEncoding, strings.NewReader(authData.Tx))
 	var tx xdr.Transaction
 	_, err = xdr.Unmarshal(b64r, &tx)
@@ -144,9 +187,31 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 
 	response := compliance.AuthResponse{}
 
+	// Decision trail, stored in the audit log
+	trail := auditTrail{}
+	trail.add("signature", compliance.AuthStatusOk, "Signed by "+senderStellarToml.SigningKey)
+	if authreq.Encryption != "" {
+		trail.add("decryption", compliance.AuthStatusOk, "Attachment decrypted using "+authreq.Encryption)
+	}
+
+	// Requests failing from now on are recorded with an `error` decision and
+	// the steps taken so far
+	recorded := false
+	defer func() {
+		if recorded {
+			return
+		}
+		response.Error = trail.failure()
+		err := rh.recordAuthDecision(authData, dataJSON, hex.EncodeToString(transactionHash[:]), compliance.AuthStatusError, response, trail)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err, "transaction_id": hex.EncodeToString(transactionHash[:])}).Error("Error persisting audit log entry")
+		}
+	}()
+
 	// Sanctions check
 	if rh.Config.Callbacks.Sanctions == "" {
 		response.TxStatus = compliance.AuthStatusOk
+		trail.add("sanctions", compliance.AuthStatusOk, "No sanctions callback")
 	} else {
 		var senderInfo []byte
 		senderInfo, err = json.Marshal(attachment.Transaction.SenderInfo)
@@ -166,6 +231,7 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 				"sanctions": rh.Config.Callbacks.Sanctions,
 				"err":       err,
 			}).Error("Error sending request to sanctions server")
+			trail.add("sanctions", compliance.AuthStatusError, "Error sending request to sanctions server")
 			httpHelpers.Write(w, httpHelpers.InternalServerError)
 			return
 		}
@@ -175,6 +241,7 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 		body, err = ioutil.ReadAll(resp.Body)
 		if err != nil {
 			log.Error("Error reading sanctions server response")
+			trail.add("sanctions", compliance.AuthStatusError, "Error reading sanctions server response")
 			httpHelpers.Write(w, httpHelpers.InternalServerError)
 			return
 		}
@@ -213,9 +280,12 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 				"status": resp.StatusCode,
 				"body":   string(body),
 			}).Error("Error response from sanctions server")
+			trail.addCallback("sanctions", compliance.AuthStatusError, resp.StatusCode, string(body))
 			httpHelpers.Write(w, httpHelpers.InternalServerError)
 			return
 		}
+
+		trail.addCallback("sanctions", response.TxStatus, resp.StatusCode, string(body))
 	}
 
 	// User info
@@ -229,45 +299,65 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 				log.WithFields(log.Fields{
 					"sender": authData.Sender,
 				}).Warn("Invalid stellar address")
+				trail.add("allowed_fi", compliance.AuthStatusError, "Invalid sender address")
 				httpHelpers.Write(w, httpHelpers.InternalServerError)
 				return
 			}
 
+			// Access can be restricted by time, asset and amount
+			payments, isPayment := transactionPayments(tx)
+			now := time.Now().UTC()
+
 			allowedFi, err2 := rh.Database.GetAllowedFIByDomain(tokens[1])
 			if err2 != nil {
 				log.WithFields(log.Fields{"err": err2}).Error("Error getting AllowedFi from DB")
+				trail.add("allowed_fi", compliance.AuthStatusError, "Error getting allowed FI")
 				httpHelpers.Write(w, httpHelpers.InternalServerError)
 				return
 			}
 
+			if allowedFi != nil {
+				grant := accessGrant{allowedFi.ExpiresAt, allowedFi.AssetCode, allowedFi.AssetIssuer, allowedFi.MaxAmount}
+				if reason := grant.check(now, payments, isPayment); reason != "" {
+					trail.add("allowed_fi", compliance.AuthStatusDenied, "FI access "+reason)
+					allowedFi = nil
+				}
+			}
+
 			if allowedFi == nil {
 				// FI not found check AllowedUser
 				allowedUser, err2 := rh.Database.GetAllowedUserByDomainAndUserID(tokens[1], tokens[0])
 				if err2 != nil {
 					log.WithFields(log.Fields{"err": err2}).Error("Error getting AllowedUser from DB")
+					trail.add("allowed_user", compliance.AuthStatusError, "Error getting allowed user")
 					httpHelpers.Write(w, httpHelpers.InternalServerError)
 					return
 				}
 
+				if allowedUser != nil {
+					grant := accessGrant{allowedUser.ExpiresAt, allowedUser.AssetCode, allowedUser.AssetIssuer, allowedUser.MaxAmount}
+					if reason := grant.check(now, payments, isPayment); reason != "" {
+						trail.add("allowed_user", compliance.AuthStatusDenied, "User access "+reason)
+						allowedUser = nil
+					}
+				}
+
 				if allowedUser != nil {
 					response.InfoStatus = compliance.AuthStatusOk
+					trail.add("allowed_user", response.InfoStatus, "User allowed at "+allowedUser.AllowedAt.Format(time.RFC3339))
+				} else {
+					trail.add("allowed_user", response.InfoStatus, "Neither FI nor user allowed")
 				}
 			} else {
 				response.InfoStatus = compliance.AuthStatusOk
+				trail.add("allowed_fi", response.InfoStatus, "FI allowed at "+allowedFi.AllowedAt.Format(time.RFC3339))
 			}
 		} else {
 			// Ask user
-			var amount, assetType, assetCode, assetIssuer string
-
-			if len(tx.Operations) > 0 {
-				operationBody := tx.Operations[0].Body
-				if operationBody.Type == xdr.OperationTypePayment {
-					amount = baseAmount.String(operationBody.PaymentOp.Amount)
-					operationBody.PaymentOp.Asset.Extract(&assetType, &assetCode, &assetIssuer)
-				} else if operationBody.Type == xdr.OperationTypePathPayment {
-					amount = baseAmount.String(operationBody.PathPaymentOp.DestAmount)
-					operationBody.PathPaymentOp.DestAsset.Extract(&assetType, &assetCode, &assetIssuer)
-				}
+			var amount string
+			payment, isPayment := firstPayment(tx)
+			if isPayment {
+				amount = baseAmount.String(payment.Amount)
 			}
 
 			var senderInfo []byte
@@ -283,8 +373,8 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 				rh.Config.Callbacks.AskUser,
 				url.Values{
 					"amount":       {amount},
-					"asset_code":   {assetCode},
-					"asset_issuer": {assetIssuer},
+					"asset_code":   {payment.AssetCode},
+					"asset_issuer": {payment.AssetIssuer},
 					"sender":       {string(senderInfo)},
 					"note":         {attachment.Transaction.Note},
 				},
@@ -294,6 +384,7 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 					"ask_user": rh.Config.Callbacks.AskUser,
 					"err":      err,
 				}).Error("Error sending request to ask_user server")
+				trail.add("ask_user", compliance.AuthStatusError, "Error sending request to ask_user server")
 				httpHelpers.Write(w, httpHelpers.InternalServerError)
 				return
 			}
@@ -303,6 +394,7 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 			body, err = ioutil.ReadAll(resp.Body)
 			if err != nil {
 				log.Error("Error reading ask_user server response")
+				trail.add("ask_user", compliance.AuthStatusError, "Error reading ask_user server response")
 				httpHelpers.Write(w, httpHelpers.InternalServerError)
 				return
 			}
@@ -341,9 +433,12 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 					"status": resp.StatusCode,
 					"body":   string(body),
 				}).Error("Error response from ask_user server")
+				trail.addCallback("ask_user", compliance.AuthStatusError, resp.StatusCode, string(body))
 				httpHelpers.Write(w, httpHelpers.InternalServerError)
 				return
 			}
+
+			trail.addCallback("ask_user", response.InfoStatus, resp.StatusCode, string(body))
 		}
 
 		if response.InfoStatus == compliance.AuthStatusOk {
@@ -359,6 +454,7 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 					"fetch_info": rh.Config.Callbacks.FetchInfo,
 					"err":        err,
 				}).Error("Error sending request to fetch_info server")
+				trail.add("fetch_info", compliance.AuthStatusError, "Error sending request to fetch_info server")
 				httpHelpers.Write(w, httpHelpers.InternalServerError)
 				return
 			}
@@ -371,6 +467,7 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 					"fetch_info": rh.Config.Callbacks.FetchInfo,
 					"err":        err,
 				}).Error("Error reading fetch_info server response")
+				trail.add("fetch_info", compliance.AuthStatusError, "Error reading fetch_info server response")
 				httpHelpers.Write(w, httpHelpers.InternalServerError)
 				return
 			}
@@ -381,14 +478,25 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 					"status":     resp.StatusCode,
 					"body":       string(body),
 				}).Error("Error response from fetch_info server")
+				trail.addCallback("fetch_info", compliance.AuthStatusError, resp.StatusCode, string(body))
 				httpHelpers.Write(w, httpHelpers.InternalServerError)
 				return
 			}
 
 			response.DestInfo = string(body)
+			trail.addCallback("fetch_info", response.InfoStatus, resp.StatusCode, string(body))
 		}
 	} else {
 		response.InfoStatus = compliance.AuthStatusOk
+		trail.add("need_info", response.InfoStatus, "Receiver info not requested")
+	}
+
+	// The decision is returned even when it cannot be recorded, the audit
+	// log does not hold up auth requests
+	recorded = true
+	err = rh.recordAuthDecision(authData, dataJSON, hex.EncodeToString(transactionHash[:]), authDecision(response), response, trail)
+	if err != nil {
+		log.WithFields(log.Fields{"err": err, "transaction_id": hex.EncodeToString(transactionHash[:])}).Error("Error persisting audit log entry")
 	}
 
 	if response.TxStatus == compliance.AuthStatusOk && response.InfoStatus == compliance.AuthStatusOk {
@@ -398,7 +506,7 @@ func (rh *RequestHandler) HandlerAuth(w http.ResponseWriter, r *http.Request) {
 			Memo:           base64.StdEncoding.EncodeToString(memoBytes[:]),
 			TransactionXdr: authData.Tx,
 			AuthorizedAt:   time.Now(),
-			Data:           authreq.DataJSON,
+			Data:           dataJSON,
 		}
 		err = rh.Database.InsertAuthorizedTransaction(authorizedTransaction)
 		if err != nil {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stellar/go/address"
@@ -48,6 +49,12 @@ func (rh *RequestHandler) HandlerSend(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if authDataEntity != nil {
+		// Auth data is removed after `audit.personal_data_retention` days
+		if authDataEntity.AuthData == "" {
+			helpers.Write(w, helpers.NewInvalidParameterError("id", "Auth data of this request was removed."))
+			return
+		}
+
 		var stellarToml *stellartoml.Response
 		stellarToml, err = rh.StellarTomlResolver.GetStellarToml(authDataEntity.Domain)
 		if err != nil {
@@ -276,6 +283,26 @@ func (rh *RequestHandler) HandlerSend(w http.ResponseWriter, r *http.Request) {
 		AttachmentJSON: string(attachmentJSON),
 	}
 
+	// Encrypt the attachment when enabled and the receiver publishes a
+	// curve25519 encryption key. ENCRYPTION_KEY is also used for other kinds
+	// of keys (ex. PGP), the attachment is sent in plaintext to them.
+	if rh.Config.EncryptAttachments && stellarToml.EncryptionKey != "" {
+		encryptionKey, keyErr := compliance.ParseEncryptionKey(stellarToml.EncryptionKey)
+		if keyErr != nil {
+			log.WithFields(log.Fields{
+				"destination": request.Destination,
+				"err":         keyErr,
+			}).Info("ENCRYPTION_KEY in stellar.toml is not a curve25519 key, sending plaintext attachment")
+		} else {
+			err = authData.Encrypt(encryptionKey)
+			if err != nil {
+				log.WithFields(log.Fields{"err": err}).Error("Error encrypting attachment")
+				helpers.Write(w, helpers.InternalServerError)
+				return
+			}
+		}
+	}
+
 	data, err := authData.Marshal()
 	if err != nil {
 		log.Error("Error mashaling authData")
@@ -283,10 +310,12 @@ func (rh *RequestHandler) HandlerSend(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	createdAt := time.Now().UTC()
 	authDataEntity = &db.AuthData{
 		RequestID: request.ID,
 		Domain:    domain,
 		AuthData:  string(data),
+		CreatedAt: &createdAt,
 	}
 	err = rh.Database.InsertAuthData(authDataEntity)
 	if err != nil {
@@ -318,6 +347,9 @@ func (rh *RequestHandler) sendAuthData(w http.ResponseWriter, authServer string,
 		DataJSON:  string(data),
 		Signature: sig,
 	}
+	if authData.EncryptedAttachment != "" {
+		authRequest.Encryption = compliance.EncryptionNaclBox
+	}
 	resp, err := rh.Client.PostForm(
 		authServer,
 		authRequest.ToURLValues(),
@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stellar/go/clients/federation"
 	"github.com/stellar/go/clients/stellartoml"
+	"github.com/stellar/go/protocols/compliance"
 	"github.com/stellar/go/services/compliance/internal/config"
 	"github.com/stellar/go/services/compliance/internal/crypto"
 	"github.com/stellar/go/services/compliance/internal/db"
@@ -29,6 +30,7 @@ var rootCmd *cobra.Command
 var migrateFlag bool
 var configFile string
 var versionFlag bool
+var encryptionKeyFlag bool
 var version = "N/A"
 
 func main() {
@@ -47,9 +49,22 @@ func init() {
 	rootCmd.Flags().BoolVarP(&migrateFlag, "migrate-db", "", false, "migrate DB to the newest schema version")
 	rootCmd.Flags().StringVarP(&configFile, "config", "c", "compliance.cfg", "path to config file")
 	rootCmd.Flags().BoolVarP(&versionFlag, "version", "v", false, "displays compliance server version")
+	rootCmd.Flags().BoolVarP(&encryptionKeyFlag, "generate-encryption-key", "", false, "generates a new key pair to encrypt attachments")
 }
 
 func run(cmd *cobra.Command, args []string) {
+	if encryptionKeyFlag {
+		publicKey, secretKey, err := compliance.GenerateEncryptionKey()
+		if err != nil {
+			log.Fatal(err.Error())
+			return
+		}
+
+		fmt.Println("ENCRYPTION_KEY (stellar.toml):", publicKey)
+		fmt.Println("keys.encryption_key (compliance.cfg):", secretKey)
+		return
+	}
+
 	var cfg config.Config
 
 	err := supportConfig.Read(configFile, &cfg)
@@ -203,7 +218,17 @@ func (a *App) Serve() {
 	internal.Post("/send", a.requestHandler.HandlerSend)
 	internal.Post("/receive", a.requestHandler.HandlerReceive)
 	internal.Post("/allow_access", a.requestHandler.HandlerAllowAccess)
+	internal.Post("/allow_access/import", a.requestHandler.HandlerImportAccess)
 	internal.Post("/remove_access", a.requestHandler.HandlerRemoveAccess)
+	internal.Get("/allowed_fis", a.requestHandler.HandlerAllowedFIs)
+	internal.Get("/allowed_users", a.requestHandler.HandlerAllowedUsers)
+	internal.Get("/audit", a.requestHandler.HandlerAudit)
+	internal.Get("/audit/export", a.requestHandler.HandlerAuditExport)
+	internal.Get("/audit/{id}", a.requestHandler.HandlerAuditEntry)
+
+	if a.config.Audit.PersonalDataRetention > 0 {
+		go a.redactPersonalData()
+	}
 
 	supportHttp.Run(supportHttp.Config{
 		ListenAddr: fmt.Sprintf(":%d", *a.config.InternalPort),
@@ -213,3 +238,39 @@ func (a *App) Serve() {
 		},
 	})
 }
+
+// redactPersonalData removes, every hour, the auth data older than
+// `audit.personal_data_retention` days: from the audit log, from authorized
+// transactions and from sent auth requests.
+func (a *App) redactPersonalData() {
+	retention := time.Duration(a.config.Audit.PersonalDataRetention) * 24 * time.Hour
+	database := a.requestHandler.Database
+
+	for {
+		now := time.Now().UTC()
+		before := now.Add(-retention)
+
+		redacted, err := database.RedactAuditLogEntries(before, now)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error redacting audit log")
+		} else if redacted > 0 {
+			log.WithFields(log.Fields{"redacted": redacted}).Info("Redacted personal data from audit log")
+		}
+
+		redacted, err = database.RedactAuthorizedTransactions(before)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error redacting authorized transactions")
+		} else if redacted > 0 {
+			log.WithFields(log.Fields{"redacted": redacted}).Info("Redacted personal data from authorized transactions")
+		}
+
+		redacted, err = database.RedactAuthData(before)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("Error redacting sent auth data")
+		} else if redacted > 0 {
+			log.WithFields(log.Fields{"redacted": redacted}).Info("Redacted personal data from sent auth data")
+		}
+
+		time.Sleep(time.Hour)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/stellar/go/services/horizon/internal/db2/schema"
 	"github.com/stellar/go/services/horizon/internal/ingest"
+	"github.com/stellar/go/services/horizon/internal/verify"
 	"github.com/stellar/go/support/db"
 	hlog "github.com/stellar/go/support/log"
 )
@@ -184,6 +185,59 @@ var dbReapCmd = &cobra.Command{
 	},
 }
 
+var dbVerifyOpts struct {
+	accounts uint64
+	assets   uint64
+	ledgers  int32
+}
+
+var dbVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "verifies a sample of stellar-core state against the horizon database",
+	Long:  "verify recomputes the balances of randomly sampled accounts from their effects and compares them, along with asset stats and the hash chain of the latest ledgers, with the stellar-core database. It exits with a non-zero status when mismatches are found.",
+	Run: func(cmd *cobra.Command, args []string) {
+		initConfig()
+
+		hdb, err := db.Open("postgres", config.DatabaseURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		cdb, err := db.Open("postgres", config.StellarCoreDatabaseURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		v := verify.New(0, hdb, cdb)
+		v.AccountSampleSize = dbVerifyOpts.accounts
+		v.AssetSampleSize = dbVerifyOpts.assets
+		v.LedgerWindow = dbVerifyOpts.ledgers
+		v.CheckNativeBalances = config.IngestFailedTransactions
+
+		result, err := v.Run()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, m := range result.Mismatches {
+			fmt.Printf("%s: %s: expected %s, got %s\n", m.Check, m.Subject, m.Expected, m.Actual)
+		}
+		fmt.Printf(
+			"Verified ledger %d: %d ledgers, %d accounts, %d trustlines, %d assets, %d mismatches\n",
+			result.Ledger,
+			result.LedgersChecked,
+			result.AccountsChecked,
+			result.TrustlinesChecked,
+			result.AssetsChecked,
+			len(result.Mismatches),
+		)
+
+		if len(result.Mismatches) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
 var dbRebaseCmd = &cobra.Command{
 	Use:   "rebase",
 	Short: "rebases clears the horizon db and ingests the latest ledger segment from stellar-core",
@@ -274,8 +328,14 @@ func init() {
 		dbReapCmd,
 		dbReingestCmd,
 		dbRebaseCmd,
+		dbVerifyCmd,
 	)
 	dbReingestCmd.AddCommand(dbReingestRangeCmd, dbReingestOutdatedCmd)
+
+	flags := dbVerifyCmd.Flags()
+	flags.Uint64Var(&dbVerifyOpts.accounts, "accounts", 100, "number of randomly sampled accounts to verify")
+	flags.Uint64Var(&dbVerifyOpts.assets, "assets", 20, "number of randomly sampled assets to verify")
+	flags.Int32Var(&dbVerifyOpts.ledgers, "ledgers", 1000, "number of latest ledgers whose hash chain is verified")
 }
 
 func ingestSystem(ingestConfig ingest.Config) *ingest.System {
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"log"
+	"regexp"
+
+	"github.com/spf13/cobra"
+	"github.com/stellar/go/services/horizon/internal/export"
+	"github.com/stellar/go/support/db"
+	hlog "github.com/stellar/go/support/log"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [command]",
+	Short: "commands to export horizon and stellar-core data",
+}
+
+var exportStateOpts struct {
+	ledger        int32
+	format        string
+	asset         string
+	accountPrefix string
+	output        string
+}
+
+// accountPrefixPattern matches the beginning of a strkey encoded account id.
+var accountPrefixPattern = regexp.MustCompile(`^G[A-Z2-7]{0,55}$`)
+
+var exportStateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "exports the current ledger state",
+	Long:  "exports the accounts, trustlines, offers and data entries stored in the stellar-core database at the latest ledger, one file per kind of entry",
+	Run: func(cmd *cobra.Command, args []string) {
+		initConfig()
+
+		format, err := export.ParseFormat(exportStateOpts.format)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		state := export.State{
+			Ledger: exportStateOpts.ledger,
+			Format: format,
+			Dir:    exportStateOpts.output,
+		}
+
+		if exportStateOpts.asset != "" {
+			asset, err := export.ParseAsset(exportStateOpts.asset)
+			if err != nil {
+				log.Fatal(err)
+			}
+			state.Filter.Asset = &asset
+		}
+
+		if exportStateOpts.accountPrefix != "" {
+			if !accountPrefixPattern.MatchString(exportStateOpts.accountPrefix) {
+				log.Fatalf("Invalid account prefix: %s", exportStateOpts.accountPrefix)
+			}
+			state.Filter.AccountPrefix = exportStateOpts.accountPrefix
+		}
+
+		state.CoreDB, err = db.Open("postgres", config.StellarCoreDatabaseURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		result, err := state.Run()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for path, count := range result.Files {
+			hlog.WithField("ledger", result.Ledger).
+				WithField("rows", count).
+				Infof("export: wrote %s", path)
+		}
+	},
+}
+
+var exportHistoryOpts struct {
+	from      int32
+	to        int32
+	tables    string
+	format    string
+	chunkSize int32
+	output    string
+}
+
+var exportHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "exports history in ledger order",
+	Long:  "exports the transactions, operations, effects and trades stored in the horizon database, in ledger order, one file per table per chunk of ledgers. An interrupted export resumes after the last exported chunk when run again with the same options and output directory.",
+	Run: func(cmd *cobra.Command, args []string) {
+		initConfig()
+
+		format, err := export.ParseFormat(exportHistoryOpts.format)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		tables, err := export.ParseTables(exportHistoryOpts.tables)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if exportHistoryOpts.chunkSize <= 0 {
+			log.Fatalf("Invalid chunk size: %d", exportHistoryOpts.chunkSize)
+		}
+
+		h := export.History{
+			From:      exportHistoryOpts.from,
+			To:        exportHistoryOpts.to,
+			Tables:    tables,
+			Format:    format,
+			ChunkSize: exportHistoryOpts.chunkSize,
+			Dir:       exportHistoryOpts.output,
+		}
+
+		h.HorizonDB, err = db.Open("postgres", config.DatabaseURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		result, err := h.Run()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if result.From > result.To {
+			hlog.Infof("export: ledgers up to %d were already exported", result.To)
+			return
+		}
+
+		for path, count := range result.Files {
+			hlog.WithField("rows", count).Infof("export: wrote %s", path)
+		}
+		hlog.Infof("export: exported ledgers %d to %d", result.From, result.To)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportStateCmd)
+	exportCmd.AddCommand(exportHistoryCmd)
+
+	flags := exportStateCmd.Flags()
+	flags.Int32Var(&exportStateOpts.ledger, "ledger", 0, "ledger to export the state at, must be the latest ledger closed by stellar-core (0 exports the latest ledger)")
+	flags.StringVar(&exportStateOpts.format, "format", string(export.FormatJSONL), "output format: jsonl or csv")
+	flags.StringVar(&exportStateOpts.asset, "asset", "", "only export trustlines and offers of this asset (native or code:issuer)")
+	flags.StringVar(&exportStateOpts.accountPrefix, "account-prefix", "", "only export entries of accounts whose address starts with this prefix")
+	flags.StringVar(&exportStateOpts.output, "output", ".", "directory to write the exported files to")
+
+	flags = exportHistoryCmd.Flags()
+	flags.Int32Var(&exportHistoryOpts.from, "from", 0, "first ledger to export (0 exports from the oldest ledger in the horizon database)")
+	flags.Int32Var(&exportHistoryOpts.to, "to", 0, "last ledger to export (0 exports up to the latest ingested ledger)")
+	flags.StringVar(&exportHistoryOpts.tables, "tables", "transactions,operations,effects,trades", "comma separated list of tables to export: transactions, operations, effects and trades")
+	flags.StringVar(&exportHistoryOpts.format, "format", string(export.FormatJSONL), "output format: jsonl or csv")
+	flags.Int32Var(&exportHistoryOpts.chunkSize, "chunk-size", export.DefaultChunkSize, "number of ledgers exported to every file")
+	flags.StringVar(&exportHistoryOpts.output, "output", ".", "directory to write the exported files to")
+}
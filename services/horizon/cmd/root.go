@@ -5,6 +5,7 @@ import (
 	"go/types"
 	stdLog "log"
 	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -57,6 +58,18 @@ func checkMigrations() {
 	}
 }
 
+// setCommaSeparatedValue sets the []string config key of `co` from a comma
+// separated list, ignoring empty entries.
+func setCommaSeparatedValue(co *support.ConfigOption) {
+	var values []string
+	for _, v := range strings.Split(viper.GetString(co.Name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	*(co.ConfigKey.(*[]string)) = values
+}
+
 // configOpts defines the complete flag configuration for horizon
 // Add a new entry here to connect a new field in the horizon.Config struct
 var configOpts = []*support.ConfigOption{
@@ -68,6 +81,14 @@ var configOpts = []*support.ConfigOption{
 		Required:  true,
 		Usage:     "horizon postgres database to connect with",
 	},
+	&support.ConfigOption{
+		Name:           "db-replica-urls",
+		EnvVar:         "DATABASE_REPLICA_URLS",
+		ConfigKey:      &config.DatabaseReplicaURLs,
+		OptType:        types.String,
+		CustomSetValue: setCommaSeparatedValue,
+		Usage:          "comma separated list of read-only replicas of the horizon postgres database history queries are routed to when they are up to date",
+	},
 	&support.ConfigOption{
 		Name:      "stellar-core-db-url",
 		EnvVar:    "STELLAR_CORE_DATABASE_URL",
@@ -229,6 +250,13 @@ var configOpts = []*support.ConfigOption{
 		FlagDefault: false,
 		Usage:       "causes this horizon process to ingest failed transactions data",
 	},
+	&support.ConfigOption{
+		Name:        "ingest-leader-election",
+		ConfigKey:   &config.IngestLeaderElection,
+		OptType:     types.Bool,
+		FlagDefault: false,
+		Usage:       "causes ingesting horizon processes sharing a database to elect a single ingestion leader, so that only one of them ingests at a time",
+	},
 	&support.ConfigOption{
 		Name:        "history-retention-count",
 		ConfigKey:   &config.HistoryRetentionCount,
@@ -257,6 +285,48 @@ var configOpts = []*support.ConfigOption{
 		FlagDefault: false,
 		Usage:       "enables asset stats during the ingestion and expose `/assets` endpoint, Enabling it has a negative impact on CPU",
 	},
+	&support.ConfigOption{
+		Name:        "enable-webhooks",
+		ConfigKey:   &config.EnableWebhooks,
+		OptType:     types.Bool,
+		FlagDefault: false,
+		Usage:       "exposes the `/webhooks` endpoints and, on ingesting instances, delivers matching operations and effects to registered webhooks",
+	},
+	&support.ConfigOption{
+		Name:      "webhooks-admin-token",
+		ConfigKey: &config.WebhooksAdminToken,
+		OptType:   types.String,
+		Usage:     "bearer token required by the `/webhooks` endpoints, must be set when webhooks are enabled",
+	},
+	&support.ConfigOption{
+		Name:           "stellar-core-submit-urls",
+		ConfigKey:      &config.StellarCoreSubmitURLs,
+		OptType:        types.String,
+		CustomSetValue: setCommaSeparatedValue,
+		Usage:          "comma separated list of additional stellar-core instances (for http commands) transactions are submitted to when the one at stellar-core-url is unhealthy",
+	},
+	&support.ConfigOption{
+		Name:        "submit-broadcast",
+		ConfigKey:   &config.SubmitBroadcast,
+		OptType:     types.Bool,
+		FlagDefault: false,
+		Usage:       "submits transactions to every configured stellar-core instance at once instead of the healthiest one",
+	},
+	&support.ConfigOption{
+		Name:           "verify-interval",
+		ConfigKey:      &config.VerifyInterval,
+		OptType:        types.Int,
+		FlagDefault:    0,
+		CustomSetValue: support.SetDuration,
+		Usage:          "defines how often (in seconds) a sample of stellar-core state is verified against the ingested history, 0 disables verification",
+	},
+	&support.ConfigOption{
+		Name:        "verify-sample-size",
+		ConfigKey:   &config.VerifySampleSize,
+		OptType:     types.Uint,
+		FlagDefault: uint(100),
+		Usage:       "number of accounts verified against the ingested history per verification run",
+	},
 }
 
 func init() {
@@ -289,6 +359,10 @@ func initConfig() {
 	validateBothOrNeither("tls-cert", "tls-key")
 	validateBothOrNeither("rate-limit-redis-key", "redis-url")
 
+	if config.EnableWebhooks && config.WebhooksAdminToken == "" {
+		stdLog.Fatal("Invalid config: enable-webhooks requires webhooks-admin-token to be configured")
+	}
+
 	// Configure log file
 	if config.LogFile != "" {
 		logFile, err := os.OpenFile(config.LogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
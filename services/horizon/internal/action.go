@@ -2,6 +2,7 @@ package horizon
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/stellar/go/services/horizon/internal/toid"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/log"
+	"github.com/stellar/go/xdr"
 )
 
 // Action is the "base type" for all actions in horizon.  It provides
@@ -43,10 +45,15 @@ func (action *Action) CoreQ() *core.Q {
 }
 
 // HistoryQ provides access to queries that access the history portion of
-// horizon's database.
+// horizon's database.  Queries of read-only (GET and HEAD) requests are routed
+// to an up to date replica of the database when one is configured.
 func (action *Action) HistoryQ() *history.Q {
 	if action.hq == nil {
-		action.hq = &history.Q{Session: action.App.HorizonSession(action.R.Context())}
+		if action.R.Method == http.MethodGet || action.R.Method == http.MethodHead {
+			action.hq = &history.Q{Session: action.App.HorizonReadSession(action.R.Context())}
+		} else {
+			action.hq = &history.Q{Session: action.App.HorizonSession(action.R.Context())}
+		}
 	}
 
 	return action.hq
@@ -155,6 +162,49 @@ func (action *Action) baseURL() *url.URL {
 	return httpx.BaseURL(action.R.Context())
 }
 
+// GetAssetPairs retrieves a comma separated list of `base/counter` asset pairs
+// from the request, where an asset is either `native` or `code:issuer`.  At
+// least one and at most `max` pairs must be provided.
+func (action *Action) GetAssetPairs(name string, max int) (bases []xdr.Asset, counters []xdr.Asset) {
+	pairs := action.GetString(name)
+	if action.Err != nil {
+		return
+	}
+
+	if pairs == "" {
+		action.SetInvalidField(name, errors.New("at least one pair is required"))
+		return
+	}
+
+	for _, pair := range strings.Split(pairs, ",") {
+		parts := strings.Split(pair, "/")
+		if len(parts) != 2 {
+			action.SetInvalidField(name, fmt.Errorf("invalid pair: %s", pair))
+			return
+		}
+
+		base, err := parsePairAsset(parts[0])
+		if err != nil {
+			action.SetInvalidField(name, errors.Wrapf(err, "invalid pair: %s", pair))
+			return
+		}
+
+		counter, err := parsePairAsset(parts[1])
+		if err != nil {
+			action.SetInvalidField(name, errors.Wrapf(err, "invalid pair: %s", pair))
+			return
+		}
+
+		bases = append(bases, base)
+		counters = append(counters, counter)
+	}
+
+	if len(bases) > max {
+		action.SetInvalidField(name, fmt.Errorf("at most %d pairs are allowed", max))
+	}
+	return
+}
+
 // getAccountInfo returns the information about an account based on the provided param.
 // The expected param here is the account id, which has a string type.
 func (w *web) getAccountInfo(ctx context.Context, param interface{}) (interface{}, error) {
@@ -195,3 +245,25 @@ func (w *web) streamTransactionByAccount(ctx context.Context, s *sse.Stream, par
 
 	return actions.StreamTransactionByAccount(ctx, s, &history.Q{horizonSession}, tp.AccountFilter, tp.IncludeFailed, tp.PagingParams)
 }
+
+// parsePairAsset parses an asset in the `native` or `code:issuer` format.
+func parsePairAsset(s string) (xdr.Asset, error) {
+	if s == "native" {
+		return xdr.MustNewNativeAsset(), nil
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[0]) > 12 {
+		return xdr.Asset{}, fmt.Errorf("invalid asset: %s", s)
+	}
+
+	var issuer xdr.AccountId
+	err := issuer.SetAddress(parts[1])
+	if err != nil {
+		return xdr.Asset{}, fmt.Errorf("invalid asset issuer: %s", parts[1])
+	}
+
+	var asset xdr.Asset
+	err = asset.SetCredit(parts[0], issuer)
+	return asset, err
+}
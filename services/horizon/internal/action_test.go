@@ -14,6 +14,9 @@ import (
 	"github.com/stellar/go/services/horizon/internal/test"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetAccountInfo(t *testing.T) {
@@ -69,3 +72,18 @@ func TestGetTransactionPageByAccount(t *testing.T) {
 	tt.Assert.NoError(err)
 	tt.Assert.Equal(3, len(pageVal.Embedded.Records))
 }
+
+func TestParsePairAsset(t *testing.T) {
+	asset, err := parsePairAsset("native")
+	require.NoError(t, err)
+	assert.Equal(t, xdr.AssetTypeAssetTypeNative, asset.Type)
+
+	asset, err = parsePairAsset("USD:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	require.NoError(t, err)
+	assert.Equal(t, "credit_alphanum4/USD/GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4", asset.String())
+
+	for _, invalid := range []string{"", "USD", "USD:", ":GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4", "USD:GBAD"} {
+		_, err = parsePairAsset(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -23,6 +23,7 @@ type AssetsAction struct {
 	Action
 	AssetCode    string
 	AssetIssuer  string
+	Sort         string
 	PagingParams db2.PageQuery
 	Records      []assets.AssetStatsR
 	Page         hal.Page
@@ -55,6 +56,17 @@ func (action *AssetsAction) loadParams() {
 		}
 		action.AssetIssuer = issuerAccount.Address()
 	}
+
+	action.Sort = action.GetString("sort")
+	switch action.Sort {
+	case "", assets.SortByAsset, assets.SortByHolders, assets.SortByVolume:
+	default:
+		action.SetInvalidField("sort", fmt.Errorf(
+			"must be one of: %s, %s, %s", assets.SortByAsset, assets.SortByHolders, assets.SortByVolume,
+		))
+		return
+	}
+
 	action.PagingParams = action.GetPageQuery(actions.DisableCursorValidation)
 }
 
@@ -62,6 +74,7 @@ func (action *AssetsAction) loadRecords() {
 	sql, err := assets.AssetStatsQ{
 		AssetCode:   &action.AssetCode,
 		AssetIssuer: &action.AssetIssuer,
+		Sort:        action.Sort,
 		PageQuery:   &action.PagingParams,
 	}.GetSQL()
 	if err != nil {
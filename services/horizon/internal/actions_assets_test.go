@@ -28,9 +28,11 @@ func TestAssetsActions(t *testing.T) {
 			Code:   "BTC",
 			Issuer: "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4",
 		},
-		PT:          "BTC_GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4_credit_alphanum4",
-		Amount:      "100.9876000",
-		NumAccounts: 1,
+		PT:                      "BTC_GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4_credit_alphanum4",
+		Amount:                  "100.9876000",
+		NumAccounts:             1,
+		NumAuthorizedTrustlines: 1,
+		Volume24h:               "0.0000000",
 		Flags: horizon.AccountFlags{
 			AuthRequired:  true,
 			AuthRevocable: false,
@@ -43,9 +45,11 @@ func TestAssetsActions(t *testing.T) {
 			Code:   "SCOT",
 			Issuer: "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU",
 		},
-		PT:          "SCOT_GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU_credit_alphanum4",
-		Amount:      "1000.0000000",
-		NumAccounts: 1,
+		PT:                      "SCOT_GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU_credit_alphanum4",
+		Amount:                  "1000.0000000",
+		NumAccounts:             1,
+		NumAuthorizedTrustlines: 1,
+		Volume24h:               "0.0000000",
 		Flags: horizon.AccountFlags{
 			AuthRequired:  false,
 			AuthRevocable: true,
@@ -58,9 +62,11 @@ func TestAssetsActions(t *testing.T) {
 			Code:   "USD",
 			Issuer: "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4",
 		},
-		PT:          "USD_GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4_credit_alphanum4",
-		Amount:      "300001.0434000",
-		NumAccounts: 2,
+		PT:                      "USD_GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4_credit_alphanum4",
+		Amount:                  "300001.0434000",
+		NumAccounts:             2,
+		NumAuthorizedTrustlines: 2,
+		Volume24h:               "0.0000000",
 		Flags: horizon.AccountFlags{
 			AuthRequired:  true,
 			AuthRevocable: false,
@@ -258,6 +264,51 @@ func TestAssetsActions(t *testing.T) {
 	}
 }
 
+func TestAssetsActionsSort(t *testing.T) {
+	ht := StartHTTPTest(t, "ingest_asset_stats")
+	defer ht.Finish()
+
+	// Ugly but saves us time needed to change each `StartHTTPTest` occurence.
+	appConfig := NewTestConfig()
+	appConfig.EnableAssetStats = true
+
+	ht.App = NewApp(appConfig)
+	ht.RH = test.NewRequestHelper(ht.App.web.router)
+
+	w := ht.Get("/assets?sort=holders&order=desc&limit=2")
+	ht.Assert.Equal(200, w.Code)
+	ht.Assert.PageOf(2, w.Body)
+
+	records := []horizon.AssetStat{}
+	links := ht.UnmarshalPage(w.Body, &records)
+	ht.Assert.Equal("USD", records[0].Code)
+	ht.Assert.Equal(
+		"0000000002_USD_GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4_credit_alphanum4",
+		records[0].PT,
+	)
+	ht.Assert.Equal("SCOT", records[1].Code)
+
+	// the next page continues from the last asset using the same sort
+	ht.Assert.EqualUrlStrings(
+		"http://localhost/assets?order=desc&limit=2&cursor="+records[1].PT+"&sort=holders",
+		links.Next.Href,
+	)
+	w = ht.Get("/assets?sort=holders&order=desc&limit=2&cursor=" + records[1].PT)
+	ht.Assert.Equal(200, w.Code)
+	records = []horizon.AssetStat{}
+	ht.UnmarshalPage(w.Body, &records)
+	if ht.Assert.Equal(1, len(records)) {
+		ht.Assert.Equal("BTC", records[0].Code)
+	}
+
+	w = ht.Get("/assets?sort=volume")
+	ht.Assert.Equal(200, w.Code)
+	ht.Assert.PageOf(3, w.Body)
+
+	w = ht.Get("/assets?sort=name")
+	ht.Assert.Equal(400, w.Code)
+}
+
 func TestInvalidAssetCode(t *testing.T) {
 	ht := StartHTTPTest(t, "ingest_asset_stats")
 	defer ht.Finish()
@@ -0,0 +1,279 @@
+package horizon
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/horizon/internal/actions"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/resourceadapter"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/support/render/problem"
+)
+
+// maxBatchLookups is the maximum number of resources a single batch lookup
+// can include.
+const maxBatchLookups = 50
+
+// Interface verification
+var _ actions.JSONer = (*AccountBatchAction)(nil)
+
+// AccountBatchAction renders many accounts at once.  Accounts are provided in
+// the `id` parameter, which can be repeated and contain a comma separated list
+// of addresses.  Every account is rendered along with its own status, and
+// accounts that are invalid or do not exist are rendered as problems instead
+// of failing the whole request.
+type AccountBatchAction struct {
+	Action
+	IDs        []string
+	Accounts   map[string]core.Account
+	Data       map[string][]core.AccountData
+	Signers    map[string][]core.Signer
+	Trustlines map[string][]core.Trustline
+	Resource   horizon.AccountBatch
+}
+
+// JSON is a method for actions.JSON
+func (action *AccountBatchAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadResource,
+		func() { hal.Render(action.W, action.Resource) },
+	)
+	return action.Err
+}
+
+func (action *AccountBatchAction) loadParams() {
+	action.IDs = action.GetBatchValues("id", maxBatchLookups)
+}
+
+func (action *AccountBatchAction) loadRecords() {
+	var addys []string
+	for _, id := range action.IDs {
+		if validAccountID(id) {
+			addys = append(addys, id)
+		}
+	}
+	if len(addys) == 0 {
+		return
+	}
+
+	cq := action.CoreQ()
+
+	var accounts []core.Account
+	action.Err = cq.AccountsByAddresses(&accounts, addys)
+	if action.Err != nil {
+		return
+	}
+	action.Accounts = map[string]core.Account{}
+	for _, account := range accounts {
+		action.Accounts[account.Accountid] = account
+	}
+
+	var data []core.AccountData
+	action.Err = cq.AllDataByAddresses(&data, addys)
+	if action.Err != nil {
+		return
+	}
+	action.Data = map[string][]core.AccountData{}
+	for _, d := range data {
+		action.Data[d.Accountid] = append(action.Data[d.Accountid], d)
+	}
+
+	var signers []core.Signer
+	action.Err = cq.SignersByAddresses(&signers, addys)
+	if action.Err != nil {
+		return
+	}
+	action.Signers = map[string][]core.Signer{}
+	for _, signer := range signers {
+		action.Signers[signer.Accountid] = append(action.Signers[signer.Accountid], signer)
+	}
+
+	var trustlines []core.Trustline
+	action.Err = cq.TrustlinesByAddresses(&trustlines, addys)
+	if action.Err != nil {
+		return
+	}
+	action.Trustlines = map[string][]core.Trustline{}
+	for _, tl := range trustlines {
+		action.Trustlines[tl.Accountid] = append(action.Trustlines[tl.Accountid], tl)
+	}
+}
+
+func (action *AccountBatchAction) loadResource() {
+	records := make([]horizon.AccountBatchItem, len(action.IDs))
+	for i, id := range action.IDs {
+		records[i].ID = id
+
+		if !validAccountID(id) {
+			records[i].Problem = batchProblem(problem.MakeInvalidFieldProblem(
+				"id", fmt.Errorf("invalid account id: %s", id),
+			))
+			records[i].Status = records[i].Problem.Status
+			continue
+		}
+
+		account, ok := action.Accounts[id]
+		if !ok {
+			records[i].Problem = batchProblem(&problem.NotFound)
+			records[i].Status = records[i].Problem.Status
+			continue
+		}
+
+		var resource horizon.Account
+		action.Err = resourceadapter.PopulateAccount(
+			action.R.Context(),
+			&resource,
+			account,
+			action.Data[id],
+			action.Signers[id],
+			action.Trustlines[id],
+		)
+		if action.Err != nil {
+			return
+		}
+		records[i].Status = http.StatusOK
+		records[i].Account = &resource
+	}
+
+	action.Resource.Embedded.Records = records
+}
+
+// Interface verification
+var _ actions.JSONer = (*TransactionBatchAction)(nil)
+
+// TransactionBatchAction renders many transactions at once.  Transactions are
+// provided in the `hash` parameter, which can be repeated and contain a comma
+// separated list of hashes.  Every transaction is rendered along with its own
+// status, and transactions that are invalid or unknown are rendered as
+// problems instead of failing the whole request.
+type TransactionBatchAction struct {
+	Action
+	Hashes   []string
+	Records  map[string]history.Transaction
+	Resource horizon.TransactionBatch
+}
+
+// JSON is a method for actions.JSON
+func (action *TransactionBatchAction) JSON() error {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadResource,
+		func() { hal.Render(action.W, action.Resource) },
+	)
+	return action.Err
+}
+
+func (action *TransactionBatchAction) loadParams() {
+	action.Hashes = action.GetBatchValues("hash", maxBatchLookups)
+}
+
+func (action *TransactionBatchAction) loadRecords() {
+	var hashes []string
+	for _, hash := range action.Hashes {
+		if validTransactionHash(hash) {
+			hashes = append(hashes, strings.ToLower(hash))
+		}
+	}
+	if len(hashes) == 0 {
+		return
+	}
+
+	var records []history.Transaction
+	action.Err = action.HistoryQ().TransactionsByHashes(&records, hashes)
+	if action.Err != nil {
+		return
+	}
+
+	action.Records = map[string]history.Transaction{}
+	for _, record := range records {
+		action.Records[record.TransactionHash] = record
+	}
+}
+
+func (action *TransactionBatchAction) loadResource() {
+	records := make([]horizon.TransactionBatchItem, len(action.Hashes))
+	for i, hash := range action.Hashes {
+		records[i].ID = hash
+
+		if !validTransactionHash(hash) {
+			records[i].Problem = batchProblem(problem.MakeInvalidFieldProblem(
+				"hash", fmt.Errorf("invalid transaction hash: %s", hash),
+			))
+			records[i].Status = records[i].Problem.Status
+			continue
+		}
+
+		record, ok := action.Records[strings.ToLower(hash)]
+		if !ok {
+			records[i].Problem = batchProblem(&problem.NotFound)
+			records[i].Status = records[i].Problem.Status
+			continue
+		}
+
+		var resource horizon.Transaction
+		resourceadapter.PopulateTransaction(action.R.Context(), &resource, record)
+		records[i].Status = http.StatusOK
+		records[i].Transaction = &resource
+	}
+
+	action.Resource.Embedded.Records = records
+}
+
+// GetBatchValues returns the values of the `name` query parameter, which can
+// be repeated and contain comma separated lists, in order and without
+// duplicates.  At least one and at most `max` values are allowed.
+func (action *Action) GetBatchValues(name string, max int) (values []string) {
+	if action.Err != nil {
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, param := range action.R.URL.Query()[name] {
+		for _, value := range strings.Split(param, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" || seen[value] {
+				continue
+			}
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+
+	if len(values) == 0 {
+		action.SetInvalidField(name, errors.New("at least one value is required"))
+		return
+	}
+	if len(values) > max {
+		action.SetInvalidField(name, fmt.Errorf("at most %d values are allowed", max))
+	}
+	return
+}
+
+// batchProblem returns a copy of `p` suitable for rendering inside a batch
+// lookup result.
+func batchProblem(p *problem.P) *problem.P {
+	result := *p
+	problem.Inflate(&result)
+	return &result
+}
+
+func validAccountID(id string) bool {
+	_, err := strkey.Decode(strkey.VersionByteAccountID, id)
+	return err == nil
+}
+
+func validTransactionHash(hash string) bool {
+	decoded, err := hex.DecodeString(hash)
+	return err == nil && len(decoded) == 32
+}
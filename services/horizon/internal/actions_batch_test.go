@@ -0,0 +1,104 @@
+package horizon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/protocols/horizon"
+)
+
+func TestAccountBatchAction(t *testing.T) {
+	ht := StartHTTPTest(t, "allow_trust")
+	defer ht.Finish()
+
+	existing := "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+	missing := "GDBAPLDCAEJV6LSEDFEAUDAVFYSNFRUYZ4X75YYJJMMX5KFVUOHX46SQ"
+
+	w := ht.Get("/accounts?id=" + existing + "," + missing + "&id=invalid&id=" + existing)
+	if ht.Assert.Equal(200, w.Code) {
+		var result horizon.AccountBatch
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+
+		// duplicates are removed and the requested order is kept
+		records := result.Embedded.Records
+		ht.Require.Len(records, 3)
+
+		ht.Assert.Equal(existing, records[0].ID)
+		ht.Assert.Equal(200, records[0].Status)
+		ht.Assert.Nil(records[0].Problem)
+		if ht.Assert.NotNil(records[0].Account) {
+			ht.Assert.Equal(existing, records[0].Account.ID)
+			ht.Assert.Equal("8589934593", records[0].Account.Sequence)
+			ht.Assert.True(len(records[0].Account.Balances) > 1)
+			ht.Assert.NotEmpty(records[0].Account.Signers)
+		}
+
+		ht.Assert.Equal(missing, records[1].ID)
+		ht.Assert.Equal(404, records[1].Status)
+		ht.Assert.Nil(records[1].Account)
+		if ht.Assert.NotNil(records[1].Problem) {
+			ht.Assert.Equal("https://stellar.org/horizon-errors/not_found", records[1].Problem.Type)
+		}
+
+		ht.Assert.Equal("invalid", records[2].ID)
+		ht.Assert.Equal(400, records[2].Status)
+		if ht.Assert.NotNil(records[2].Problem) {
+			ht.Assert.Equal("id", records[2].Problem.Extras["invalid_field"])
+		}
+	}
+
+	// without ids, /accounts is not found as before batch lookups
+	w = ht.Get("/accounts")
+	ht.Assert.Equal(404, w.Code)
+
+	// the batch must not be empty
+	w = ht.Get("/accounts?id=")
+	ht.Assert.Equal(400, w.Code)
+
+	// nor too large
+	var ids []string
+	for i := 0; i <= maxBatchLookups; i++ {
+		ids = append(ids, fmt.Sprintf("G%d", i))
+	}
+	w = ht.Get("/accounts?id=" + strings.Join(ids, ","))
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestTransactionBatchAction(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	existing := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	missing := "0000000000000000000000000000000000000000000000000000000000000000"
+
+	q := url.Values{"hash": []string{strings.ToUpper(existing), missing, "not_real"}}
+	w := ht.GetWithParams("/transactions", q)
+	if ht.Assert.Equal(200, w.Code) {
+		var result horizon.TransactionBatch
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+
+		records := result.Embedded.Records
+		ht.Require.Len(records, 3)
+
+		ht.Assert.Equal(200, records[0].Status)
+		if ht.Assert.NotNil(records[0].Transaction) {
+			ht.Assert.Equal(existing, records[0].Transaction.Hash)
+		}
+
+		ht.Assert.Equal(missing, records[1].ID)
+		ht.Assert.Equal(404, records[1].Status)
+		ht.Assert.Nil(records[1].Transaction)
+
+		ht.Assert.Equal("not_real", records[2].ID)
+		ht.Assert.Equal(400, records[2].Status)
+	}
+
+	// without hashes, transactions are listed as before
+	w = ht.Get("/transactions")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+}
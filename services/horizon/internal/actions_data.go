@@ -1,13 +1,22 @@
 package horizon
 
 import (
+	"github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/services/horizon/internal/actions"
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
+	"github.com/stellar/go/services/horizon/internal/resourceadapter"
 	"github.com/stellar/go/support/render/hal"
 )
 
+// This file contains the actions:
+//
+// DataIndexAction: pages of the data entries of an account
+// DataShowAction: the value of a single data entry
+
 // Interface verifications
+var _ actions.JSONer = (*DataIndexAction)(nil)
 var _ actions.JSONer = (*DataShowAction)(nil)
 var _ actions.RawDataResponder = (*DataShowAction)(nil)
 var _ actions.EventStreamer = (*DataShowAction)(nil)
@@ -72,3 +81,57 @@ func (action *DataShowAction) loadParams() {
 func (action *DataShowAction) loadRecord() {
 	action.Err = action.CoreQ().AccountDataByKey(&action.Data, action.Address, action.Key)
 }
+
+// DataIndexAction renders a page of the data entries of an account, ordered
+// by key and optionally filtered to the keys starting with a prefix.
+type DataIndexAction struct {
+	Action
+	Address      string
+	Prefix       string
+	PagingParams db2.PageQuery
+	Records      []core.AccountData
+	Page         hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *DataIndexAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() { hal.Render(action.W, action.Page) },
+	)
+	return action.Err
+}
+
+func (action *DataIndexAction) loadParams() {
+	action.Address = action.GetAddress("account_id", actions.RequiredParam)
+	action.Prefix = action.GetString("prefix")
+	action.PagingParams = action.GetPageQuery(actions.DisableCursorValidation)
+}
+
+func (action *DataIndexAction) loadRecords() {
+	// Like /accounts/{account_id}, respond with a 404 for missing accounts
+	// rather than an empty page.
+	var account core.Account
+	action.Err = action.CoreQ().AccountByAddress(&account, action.Address)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.CoreQ().AccountDataPage(&action.Records, action.Address, action.Prefix, action.PagingParams)
+}
+
+func (action *DataIndexAction) loadPage() {
+	for _, record := range action.Records {
+		var res horizon.AccountDataEntry
+		resourceadapter.PopulateAccountDataEntry(action.R.Context(), &res, action.Address, record)
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.PopulateLinks()
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/effects"
 	"github.com/stellar/go/services/horizon/internal/test"
 )
 
@@ -43,3 +45,84 @@ func TestDataActions_Show(t *testing.T) {
 		ht.Assert.Equal("its got spaces!", w.Body.String())
 	}
 }
+
+func TestDataActions_Index(t *testing.T) {
+	ht := StartHTTPTest(t, "kahuna")
+	defer ht.Finish()
+
+	prefix := "/accounts/GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD"
+
+	w := ht.Get(prefix + "/data")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []horizon.AccountDataEntry
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 2) {
+			ht.Assert.Equal("name ", records[0].Key)
+			ht.Assert.Equal("aXRzIGdvdCBzcGFjZXMh", records[0].Value)
+			ht.Assert.Equal("name1", records[1].Key)
+			ht.Assert.Equal("MDAwMA==", records[1].Value)
+		}
+	}
+
+	// paging
+	w = ht.Get(prefix + "/data?limit=1&cursor=name%20")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []horizon.AccountDataEntry
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 1) {
+			ht.Assert.Equal("name1", records[0].Key)
+		}
+	}
+
+	w = ht.Get(prefix + "/data?order=desc")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []horizon.AccountDataEntry
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 2) {
+			ht.Assert.Equal("name1", records[0].Key)
+		}
+	}
+
+	// prefix
+	w = ht.Get(prefix + "/data?prefix=name1")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get(prefix + "/data?prefix=other")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	// missing account
+	w = ht.Get("/accounts/GDBAPLDCAEJV6LSEDFEAUDAVFYSNFRUYZ4X75YYJJMMX5KFVUOHX46SQ/data")
+	ht.Assert.Equal(404, w.Code)
+}
+
+func TestDataActions_History(t *testing.T) {
+	ht := StartHTTPTest(t, "kahuna")
+	defer ht.Finish()
+
+	prefix := "/accounts/GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD"
+
+	w := ht.Get(prefix + "/data/name1/history")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []effects.Base
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 3) {
+			ht.Assert.Equal("data_created", records[0].Type)
+			ht.Assert.Equal("data_updated", records[1].Type)
+			ht.Assert.Equal("data_updated", records[2].Type)
+		}
+	}
+
+	w = ht.Get(prefix + "/data/name2/history?order=desc")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []effects.Base
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 2) {
+			ht.Assert.Equal("data_removed", records[0].Type)
+			ht.Assert.Equal("data_created", records[1].Type)
+		}
+	}
+}
@@ -24,10 +24,12 @@ var _ actions.EventStreamer = (*EffectIndexAction)(nil)
 
 // EffectIndexAction renders a page of effect resources, identified by
 // a normal page query and optionally filtered by an account, ledger,
-// transaction, or operation.
+// transaction, or operation.  Effects of an account can be further filtered to
+// the changes of one of its data entries.
 type EffectIndexAction struct {
 	Action
 	AccountFilter     string
+	DataKeyFilter     string
 	LedgerFilter      int32
 	TransactionFilter string
 	OperationFilter   int64
@@ -109,6 +111,9 @@ func (action *EffectIndexAction) loadParams() {
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
 	action.OperationFilter = action.GetInt64("op_id")
+	if action.AccountFilter != "" {
+		action.DataKeyFilter = action.GetString("key")
+	}
 
 	filters, err := countNonEmpty(
 		action.AccountFilter,
@@ -135,6 +140,9 @@ func (action *EffectIndexAction) loadRecords() {
 	switch {
 	case action.AccountFilter != "":
 		effects.ForAccount(action.AccountFilter)
+		if action.DataKeyFilter != "" {
+			effects.ForDataEntry(action.DataKeyFilter)
+		}
 	case action.LedgerFilter > 0:
 		effects.ForLedger(action.LedgerFilter)
 	case action.OperationFilter > 0:
@@ -0,0 +1,77 @@
+package horizon
+
+import (
+	"net/http"
+
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/horizon/internal/actions"
+	"github.com/stellar/go/support/render/hal"
+)
+
+// Interface verification
+var _ actions.JSONer = (*HealthAction)(nil)
+var _ actions.JSONer = (*ReadyAction)(nil)
+
+// HealthAction renders the health of this horizon instance.  It responds with
+// 503 Service Unavailable only when the horizon database cannot be reached,
+// making it suitable for liveness probes.
+type HealthAction struct {
+	Action
+}
+
+// JSON is a method for actions.JSON
+func (action *HealthAction) JSON() error {
+	res := action.App.Health(action.R.Context())
+
+	healthy := true
+	for _, check := range res.Checks {
+		if check.Name == HealthCheckHorizonDB && !check.Healthy {
+			healthy = false
+		}
+	}
+
+	renderHealth(action.W, res, healthy)
+	return action.Err
+}
+
+// ReadyAction renders the health of this horizon instance.  It responds with
+// 503 Service Unavailable when any of the services horizon depends on is
+// unhealthy, making it suitable for load balancer readiness checks.
+type ReadyAction struct {
+	Action
+}
+
+// JSON is a method for actions.JSON
+func (action *ReadyAction) JSON() error {
+	res := action.App.Health(action.R.Context())
+
+	ready := true
+	for _, check := range res.Checks {
+		if !check.Healthy {
+			ready = false
+		}
+	}
+
+	renderHealth(action.W, res, ready)
+	return action.Err
+}
+
+func renderHealth(w http.ResponseWriter, res horizon.Health, ok bool) {
+	res.Status = healthStatusOK
+	if !ok {
+		res.Status = healthStatusUnavailable
+	}
+
+	js, err := hal.RenderToString(res, true)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", "inline")
+	w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+	if !ok {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(js)
+}
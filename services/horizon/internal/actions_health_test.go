@@ -0,0 +1,94 @@
+package horizon
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthAction(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/health")
+
+	if ht.Assert.Equal(200, w.Code) {
+		var actual horizon.Health
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal("ok", actual.Status)
+		ht.Assert.False(actual.Ingest)
+		ht.Assert.False(actual.IngestLeader)
+
+		checks := map[string]horizon.HealthCheck{}
+		for _, check := range actual.Checks {
+			checks[check.Name] = check
+		}
+		ht.Assert.True(checks[HealthCheckHorizonDB].Healthy)
+		ht.Assert.True(checks[HealthCheckCoreDB].Healthy)
+		ht.Assert.True(checks[HealthCheckIngestion].Healthy)
+		ht.Assert.NotContains(checks, HealthCheckRedis)
+	}
+}
+
+func TestReadyAction(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// stellar-core was not checked and txsub did not tick yet
+	w := ht.Get("/ready")
+
+	if ht.Assert.Equal(503, w.Code) {
+		var actual horizon.Health
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal("unavailable", actual.Status)
+
+		for _, check := range actual.Checks {
+			switch check.Name {
+			case HealthCheckCoreSync, HealthCheckTxsub:
+				ht.Assert.False(check.Healthy, check.Name)
+				ht.Assert.NotEmpty(check.Error, check.Name)
+			default:
+				ht.Assert.True(check.Healthy, check.Name)
+			}
+		}
+	}
+}
+
+func TestCheckRedisTimeout(t *testing.T) {
+	// a redis server accepting connections but never responding
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	app := &App{redis: &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", listener.Addr().String())
+		},
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = app.checkRedis(ctx)
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
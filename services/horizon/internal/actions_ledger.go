@@ -109,6 +109,7 @@ func (action *LedgerShowAction) JSON() error {
 		func() {
 			var res horizon.Ledger
 			resourceadapter.PopulateLedger(action.R.Context(), &res, action.Record)
+			setImmutableCacheHeaders(action.W, action.Record.ClosedAt)
 			hal.Render(action.W, res)
 		},
 	)
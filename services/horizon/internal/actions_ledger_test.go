@@ -2,6 +2,7 @@ package horizon
 
 import (
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/stellar/go/protocols/horizon"
@@ -25,6 +26,47 @@ func TestLedgerActions_Index(t *testing.T) {
 	}
 }
 
+func TestLedgerActions_ShowConditional(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/ledgers/2")
+	ht.Require.Equal(200, w.Code)
+	ht.Assert.Equal(immutableCacheControl, w.Header().Get("Cache-Control"))
+
+	etag := w.Header().Get("ETag")
+	lastModified := w.Header().Get("Last-Modified")
+	ht.Require.NotEmpty(etag)
+	ht.Require.NotEmpty(lastModified)
+
+	w = ht.Get("/ledgers/2", func(r *http.Request) {
+		r.Header.Set("If-None-Match", etag)
+	})
+	ht.Assert.Equal(304, w.Code)
+	ht.Assert.Equal(0, w.Body.Len())
+
+	w = ht.Get("/ledgers/2", func(r *http.Request) {
+		r.Header.Set("If-Modified-Since", lastModified)
+	})
+	ht.Assert.Equal(304, w.Code)
+
+	// other ledgers have other validators
+	w = ht.Get("/ledgers/3", func(r *http.Request) {
+		r.Header.Set("If-None-Match", etag)
+	})
+	ht.Assert.Equal(200, w.Code)
+
+	// mutable resources are validated by etag only
+	w = ht.Get("/ledgers")
+	ht.Assert.Equal(noCacheControl, w.Header().Get("Cache-Control"))
+	ht.Assert.Empty(w.Header().Get("Last-Modified"))
+	etag = w.Header().Get("ETag")
+	w = ht.Get("/ledgers", func(r *http.Request) {
+		r.Header.Set("If-None-Match", etag)
+	})
+	ht.Assert.Equal(304, w.Code)
+}
+
 func TestLedgerActions_Show(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
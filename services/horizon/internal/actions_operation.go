@@ -246,7 +246,10 @@ func (action *OperationShowAction) JSON() error {
 		action.loadRecord,
 		action.loadLedger,
 		action.loadResource,
-		func() { hal.Render(action.W, action.Resource) },
+		func() {
+			setImmutableCacheHeaders(action.W, action.Ledger.ClosedAt)
+			hal.Render(action.W, action.Resource)
+		},
 	)
 	return action.Err
 }
@@ -1,8 +1,11 @@
 package horizon
 
 import (
+	"fmt"
 	"net/http"
+	"reflect"
 
+	"github.com/stellar/go/amount"
 	"github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/services/horizon/internal/actions"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
@@ -25,8 +28,27 @@ type OrderBookShowAction struct {
 	Record   core.OrderBookSummary
 	Resource horizon.OrderBookSummary
 	Limit    uint64
+	// Precision is the number of decimal places price levels are bucketed
+	// by, or -1 when price levels are not aggregated.
+	Precision     int
+	Cumulative    bool
+	IncludeSpread bool
+	// Diff causes streams to emit the full order book once, followed by the
+	// price levels that changed at each ledger.
+	Diff bool
+
+	lastResource *horizon.OrderBookSummary
+	lastEvent    sse.Event
 }
 
+// maxOrderBookPrecision is the highest precision supported when aggregating
+// price levels, matching the precision of stellar amounts.
+const maxOrderBookPrecision = 7
+
+// orderBookAggregationDepth is the number of raw price levels loaded on each
+// side of an order book when aggregating it.
+const orderBookAggregationDepth = 1000
+
 // LoadQuery sets action.Query from the request params
 func (action *OrderBookShowAction) LoadQuery() {
 	action.Selling = action.GetAsset("selling_")
@@ -34,27 +56,69 @@ func (action *OrderBookShowAction) LoadQuery() {
 	action.Limit = action.GetLimit("limit", 20, 200)
 
 	if action.Err != nil {
-		action.Err = &problem.P{
-			Type:   "invalid_order_book",
-			Title:  "Invalid Order Book Parameters",
-			Status: http.StatusBadRequest,
-			Detail: "The parameters that specify what order book to view are invalid in some way. " +
-				"Please ensure that your type parameters (selling_asset_type and buying_asset_type) are one the " +
-				"following valid values: native, credit_alphanum4, credit_alphanum12.  Also ensure that you " +
-				"have specified selling_asset_code and selling_asset_issuer if selling_asset_type is not 'native', as well " +
-				"as buying_asset_code and buying_asset_issuer if buying_asset_type is not 'native'",
+		action.Err = invalidOrderBookProblem()
+		return
+	}
+
+	action.Precision = -1
+	if action.GetString("precision") != "" {
+		action.Precision = int(action.GetInt32("precision"))
+		if action.Err == nil && (action.Precision < 0 || action.Precision > maxOrderBookPrecision) {
+			action.SetInvalidField(
+				"precision",
+				fmt.Errorf("must be between 0 and %d", maxOrderBookPrecision),
+			)
 		}
 	}
+
+	action.Cumulative = action.GetBool("cumulative")
+	action.IncludeSpread = action.GetBool("spread")
+	action.Diff = action.GetBool("diff")
+}
+
+// invalidOrderBookProblem is the problem rendered when the assets identifying
+// an order book are invalid.
+func invalidOrderBookProblem() *problem.P {
+	return &problem.P{
+		Type:   "invalid_order_book",
+		Title:  "Invalid Order Book Parameters",
+		Status: http.StatusBadRequest,
+		Detail: "The parameters that specify what order book to view are invalid in some way. " +
+			"Please ensure that your type parameters (selling_asset_type and buying_asset_type) are one the " +
+			"following valid values: native, credit_alphanum4, credit_alphanum12.  Also ensure that you " +
+			"have specified selling_asset_code and selling_asset_issuer if selling_asset_type is not 'native', as well " +
+			"as buying_asset_code and buying_asset_issuer if buying_asset_type is not 'native'",
+	}
 }
 
 // LoadRecord populates action.Record
 func (action *OrderBookShowAction) LoadRecord() {
+	if action.Precision < 0 {
+		action.Err = action.CoreQ().GetOrderBookSummary(
+			&action.Record,
+			action.Selling,
+			action.Buying,
+			action.Limit,
+		)
+		return
+	}
+
+	var raw core.OrderBookSummary
 	action.Err = action.CoreQ().GetOrderBookSummary(
-		&action.Record,
+		&raw,
 		action.Selling,
 		action.Buying,
-		action.Limit,
+		orderBookAggregationDepth,
 	)
+	if action.Err != nil {
+		return
+	}
+
+	var err error
+	action.Record, err = raw.Aggregate(action.Precision, int(action.Limit))
+	if err != nil {
+		action.SetInvalidField("precision", err)
+	}
 }
 
 // LoadResource populates action.Record
@@ -66,6 +130,19 @@ func (action *OrderBookShowAction) LoadResource() {
 		action.Buying,
 		action.Record,
 	)
+	if action.Err != nil {
+		return
+	}
+
+	if action.Cumulative {
+		resourceadapter.PopulateOrderBookCumulative(&action.Resource, action.Record)
+	}
+
+	action.Resource.Spread = nil
+	if action.IncludeSpread {
+		action.Resource.Spread = &horizon.OrderBookSpread{}
+		resourceadapter.PopulateOrderBookSpread(action.Resource.Spread, action.Record)
+	}
 }
 
 // JSON is a method for actions.JSON
@@ -79,7 +156,138 @@ func (action *OrderBookShowAction) JSON() error {
 	return action.Err
 }
 
+// LoadEvent is a method for actions.SingleObjectStreamer.  When streaming
+// diffs, the first event is a "snapshot" of the order book and the following
+// ones are "diff" events containing only the price levels that changed.
 func (action *OrderBookShowAction) LoadEvent() (sse.Event, error) {
 	action.Do(action.LoadQuery, action.LoadRecord, action.LoadResource)
-	return sse.Event{Data: action.Resource}, action.Err
+	if action.Err != nil || !action.Diff {
+		return sse.Event{Data: action.Resource}, action.Err
+	}
+
+	if action.lastResource == nil {
+		action.lastEvent = sse.Event{Event: "snapshot", Data: action.Resource}
+	} else if diff, changed := diffOrderBooks(*action.lastResource, action.Resource); changed {
+		action.lastEvent = sse.Event{Event: "diff", Data: diff}
+	}
+
+	// an unchanged order book results in the same event as the previous one,
+	// which the stream does not send again.
+	current := action.Resource
+	action.lastResource = &current
+	return action.lastEvent, nil
+}
+
+// diffOrderBooks returns the price levels of `next` that are not in `prev`,
+// along with the levels of `prev` that were removed, with a zero amount.
+func diffOrderBooks(prev, next horizon.OrderBookSummary) (horizon.OrderBookDiff, bool) {
+	diff := horizon.OrderBookDiff{
+		Bids:    diffPriceLevels(prev.Bids, next.Bids),
+		Asks:    diffPriceLevels(prev.Asks, next.Asks),
+		Selling: next.Selling,
+		Buying:  next.Buying,
+	}
+
+	spreadChanged := !reflect.DeepEqual(prev.Spread, next.Spread)
+	if spreadChanged {
+		diff.Spread = next.Spread
+	}
+
+	changed := len(diff.Bids) > 0 || len(diff.Asks) > 0 || spreadChanged
+	return diff, changed
+}
+
+func diffPriceLevels(prev, next []horizon.PriceLevel) []horizon.PriceLevel {
+	result := []horizon.PriceLevel{}
+	existing := map[string]horizon.PriceLevel{}
+	for _, level := range prev {
+		existing[level.Price] = level
+	}
+
+	for _, level := range next {
+		old, ok := existing[level.Price]
+		delete(existing, level.Price)
+		if ok && old == level {
+			continue
+		}
+		result = append(result, level)
+	}
+
+	for _, level := range prev {
+		if _, removed := existing[level.Price]; !removed {
+			continue
+		}
+		level.Amount = amount.String(0)
+		level.Cumulative = ""
+		result = append(result, level)
+	}
+
+	return result
+}
+
+// Interface verifications
+var _ actions.JSONer = (*OrderBookTickerAction)(nil)
+
+// maxOrderBookTickerPairs is the maximum number of pairs a single ticker
+// request can include.
+const maxOrderBookTickerPairs = 20
+
+// OrderBookTickerAction renders the best bid and ask of many order books at
+// once.  Pairs are provided in the `pairs` parameter as a comma separated list
+// of `base/counter` assets, where an asset is either `native` or
+// `code:issuer`.
+type OrderBookTickerAction struct {
+	Action
+	Sellings []xdr.Asset
+	Buyings  []xdr.Asset
+	Records  []core.OrderBookSummary
+	Resource horizon.OrderBookTickers
+}
+
+// JSON is a method for actions.JSON
+func (action *OrderBookTickerAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadResource,
+		func() { hal.Render(action.W, action.Resource) },
+	)
+	return action.Err
+}
+
+func (action *OrderBookTickerAction) loadParams() {
+	action.Sellings, action.Buyings = action.GetAssetPairs("pairs", maxOrderBookTickerPairs)
+}
+
+func (action *OrderBookTickerAction) loadRecords() {
+	action.Records = make([]core.OrderBookSummary, len(action.Sellings))
+	for i := range action.Sellings {
+		action.Err = action.CoreQ().GetOrderBookSummary(
+			&action.Records[i],
+			action.Sellings[i],
+			action.Buyings[i],
+			1,
+		)
+		if action.Err != nil {
+			return
+		}
+	}
+}
+
+func (action *OrderBookTickerAction) loadResource() {
+	records := make([]horizon.OrderBookTicker, len(action.Records))
+	for i, record := range action.Records {
+		action.Err = resourceadapter.PopulateOrderBookTicker(
+			action.R.Context(),
+			&records[i],
+			action.Sellings[i],
+			action.Buyings[i],
+			record,
+		)
+		if action.Err != nil {
+			return
+		}
+	}
+
+	action.Resource.Embedded.Records = records
 }
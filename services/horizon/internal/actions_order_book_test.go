@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stellar/go/protocols/horizon"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestOrderBookActions_Show(t *testing.T) {
@@ -118,3 +119,112 @@ func TestOrderBookActions_Show(t *testing.T) {
 		ht.Assert.Equal("10.0000000", result.Bids[0].Amount)
 	}
 }
+
+func TestOrderBookActions_ShowOptions(t *testing.T) {
+	ht := StartHTTPTest(t, "order_books")
+	defer ht.Finish()
+
+	var result horizon.OrderBookSummary
+	base := "/order_book?selling_asset_type=native&buying_asset_type=credit_alphanum4&buying_asset_code=USD&buying_asset_issuer=GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"
+
+	// spread and cumulative volume
+	w := ht.Get(base + "&spread=true&cumulative=true")
+	if ht.Assert.Equal(200, w.Code) {
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+
+		ht.Require.NotNil(result.Spread)
+		ht.Assert.Equal("0.0666667", result.Spread.BestBid)
+		ht.Assert.Equal("0.1000000", result.Spread.BestAsk)
+		ht.Assert.Equal("0.0333333", result.Spread.Spread)
+		ht.Assert.Equal("0.0833333", result.Spread.MidPrice)
+
+		ht.Require.Len(result.Asks, 3)
+		ht.Require.Len(result.Bids, 3)
+		ht.Assert.Equal("100.0000000", result.Asks[0].Cumulative)
+		ht.Assert.Equal("1000.0000000", result.Asks[1].Cumulative)
+		ht.Assert.Equal("6000.0000000", result.Asks[2].Cumulative)
+		ht.Assert.Equal("10.0000000", result.Bids[0].Cumulative)
+		ht.Assert.Equal("110.0000000", result.Bids[1].Cumulative)
+		ht.Assert.Equal("1110.0000000", result.Bids[2].Cumulative)
+	}
+
+	// aggregated depth
+	result = horizon.OrderBookSummary{}
+	w = ht.Get(base + "&precision=1")
+	if ht.Assert.Equal(200, w.Code) {
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+
+		ht.Assert.Nil(result.Spread)
+		ht.Require.Len(result.Asks, 2)
+		ht.Require.Len(result.Bids, 1)
+		ht.Assert.Equal("0.1000000", result.Asks[0].Price)
+		ht.Assert.Equal("100.0000000", result.Asks[0].Amount)
+		ht.Assert.Equal("0.2000000", result.Asks[1].Price)
+		ht.Assert.Equal("5900.0000000", result.Asks[1].Amount)
+		ht.Assert.Equal("0.0000000", result.Bids[0].Price)
+		ht.Assert.Equal("1110.0000000", result.Bids[0].Amount)
+	}
+
+	w = ht.Get(base + "&precision=8")
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestOrderBookActions_Ticker(t *testing.T) {
+	ht := StartHTTPTest(t, "order_books")
+	defer ht.Finish()
+
+	var result horizon.OrderBookTickers
+
+	w := ht.Get("/order_book/ticker")
+	ht.Assert.Equal(400, w.Code)
+
+	w = ht.Get("/order_book/ticker?pairs=native")
+	ht.Assert.Equal(400, w.Code)
+
+	w = ht.Get("/order_book/ticker?pairs=native/USD:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4,USD:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4/native")
+	if ht.Assert.Equal(200, w.Code) {
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+
+		records := result.Embedded.Records
+		ht.Require.Len(records, 2)
+		ht.Assert.Equal("native", records[0].Selling.Type)
+		ht.Assert.Equal("0.0833333", records[0].MidPrice)
+		ht.Assert.Equal("100.0000000", records[0].BestAskAmount)
+		ht.Assert.Equal("USD", records[1].Selling.Code)
+		ht.Assert.Equal("10.0000000", records[1].BestBid)
+		ht.Assert.Equal("15.0000000", records[1].BestAsk)
+		ht.Assert.Equal("12.5000000", records[1].MidPrice)
+	}
+}
+
+func TestDiffOrderBooks(t *testing.T) {
+	level := func(price, amount string) horizon.PriceLevel {
+		return horizon.PriceLevel{Price: price, Amount: amount}
+	}
+
+	prev := horizon.OrderBookSummary{
+		Bids: []horizon.PriceLevel{level("1.0000000", "10.0000000"), level("0.9000000", "5.0000000")},
+		Asks: []horizon.PriceLevel{level("1.1000000", "3.0000000")},
+	}
+
+	diff, changed := diffOrderBooks(prev, prev)
+	assert.False(t, changed)
+	assert.Empty(t, diff.Bids)
+	assert.Empty(t, diff.Asks)
+
+	next := horizon.OrderBookSummary{
+		Bids: []horizon.PriceLevel{level("1.0000000", "12.0000000")},
+		Asks: []horizon.PriceLevel{level("1.1000000", "3.0000000"), level("1.2000000", "1.0000000")},
+	}
+
+	diff, changed = diffOrderBooks(prev, next)
+	assert.True(t, changed)
+	assert.Equal(t, []horizon.PriceLevel{
+		level("1.0000000", "12.0000000"),
+		level("0.9000000", "0.0000000"),
+	}, diff.Bids)
+	assert.Equal(t, []horizon.PriceLevel{level("1.2000000", "1.0000000")}, diff.Asks)
+}
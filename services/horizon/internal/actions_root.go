@@ -31,6 +31,7 @@ func (action *RootAction) JSON() error {
 		action.App.coreSupportedProtocolVersion,
 		action.App.config.FriendbotURL,
 	)
+	resourceadapter.PopulateCoreBackends(&res, action.App.coreBackends.Health())
 
 	hal.Render(action.W, res)
 	return action.Err
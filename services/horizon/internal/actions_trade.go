@@ -2,7 +2,6 @@ package horizon
 
 import (
 	"strconv"
-	gTime "time"
 
 	"github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/services/horizon/internal/actions"
@@ -173,67 +172,20 @@ func (action *TradeAggregateIndexAction) loadParams() {
 	action.StartTimeFilter = action.GetTimeMillis("start_time")
 	action.EndTimeFilter = action.GetTimeMillis("end_time")
 	action.ResolutionFilter = action.GetInt64("resolution")
-
-	//check if resolution is legal
-	resolutionDuration := gTime.Duration(action.ResolutionFilter) * gTime.Millisecond
-	if history.StrictResolutionFiltering {
-		if _, ok := history.AllowedResolutions[resolutionDuration]; !ok {
-			action.SetInvalidField("resolution", errors.New("illegal or missing resolution. "+
-				"allowed resolutions are: 1 minute (60000), 5 minutes (300000), 15 minutes (900000), 1 hour (3600000), "+
-				"1 day (86400000) and 1 week (604800000)"))
-		}
-	}
-	// check if offset is legal
-	offsetDuration := gTime.Duration(action.OffsetFilter) * gTime.Millisecond
-	if offsetDuration%gTime.Hour != 0 || offsetDuration >= gTime.Hour*24 || offsetDuration > resolutionDuration {
-		action.SetInvalidField("offset", errors.New("illegal or missing offset. offset must be a multiple of an"+
-			" hour, less than or equal to the resolution, and less than 24 hours"))
-	}
+	action.validateResolutionAndOffset(action.ResolutionFilter, action.OffsetFilter)
 }
 
 // loadRecords populates action.Records
 func (action *TradeAggregateIndexAction) loadRecords() {
-	historyQ := action.HistoryQ()
-
-	//get asset ids
-	baseAssetId, err := historyQ.GetCreateAssetID(action.BaseAssetFilter)
-	if err != nil {
-		action.Err = err
-		return
-	}
-	counterAssetId, err := historyQ.GetCreateAssetID(action.CounterAssetFilter)
-	if err != nil {
-		action.Err = err
-		return
-	}
-
-	//initialize the query builder with required params
-	tradeAggregationsQ, err := historyQ.GetTradeAggregationsQ(
-		baseAssetId, counterAssetId, action.ResolutionFilter, action.OffsetFilter, action.PagingParams)
-	if err != nil {
-		action.Err = err
-		return
-	}
-
-	//set time range if supplied
-	if !action.StartTimeFilter.IsNil() {
-		tradeAggregationsQ, err = tradeAggregationsQ.WithStartTime(action.StartTimeFilter)
-		if err != nil {
-			action.SetInvalidField("start_time", errors.New("illegal start time. adjusted start time must "+
-				"be less than the provided end time if the end time is greater than 0"))
-			return
-		}
-	}
-	if !action.EndTimeFilter.IsNil() {
-		tradeAggregationsQ, err = tradeAggregationsQ.WithEndTime(action.EndTimeFilter)
-		if err != nil {
-			action.SetInvalidField("end_time", errors.New("illegal end time. adjusted end time "+
-				"must be greater than the offset and greater than the provided start time"))
-			return
-		}
-	}
-
-	action.Err = historyQ.Select(&action.Records, tradeAggregationsQ.GetSql())
+	action.Records = action.loadTradeAggregations(
+		action.BaseAssetFilter,
+		action.CounterAssetFilter,
+		action.ResolutionFilter,
+		action.OffsetFilter,
+		action.StartTimeFilter,
+		action.EndTimeFilter,
+		action.PagingParams,
+	)
 }
 
 func (action *TradeAggregateIndexAction) loadPage() {
@@ -280,3 +232,167 @@ func (action *TradeAggregateIndexAction) loadPage() {
 		}
 	}
 }
+
+// Interface verification
+var _ actions.JSONer = (*TradeAggregateBatchAction)(nil)
+
+// maxTradeAggregationPairs is the maximum number of pairs a single batch of
+// trade aggregations can include.
+const maxTradeAggregationPairs = 20
+
+// TradeAggregateBatchAction renders the trade aggregations of many asset pairs
+// at once.  Pairs are provided in the `pairs` parameter as a comma separated
+// list of `base/counter` assets, where an asset is either `native` or
+// `code:issuer`.  The other parameters are the ones of
+// TradeAggregateIndexAction and apply to every pair.
+type TradeAggregateBatchAction struct {
+	Action
+	BaseAssets       []xdr.Asset
+	CounterAssets    []xdr.Asset
+	StartTimeFilter  time.Millis
+	EndTimeFilter    time.Millis
+	OffsetFilter     int64
+	ResolutionFilter int64
+	PagingParams     db2.PageQuery
+	Records          [][]history.TradeAggregation
+	Resource         horizon.TradeAggregationBatch
+}
+
+// JSON is a method for actions.JSON
+func (action *TradeAggregateBatchAction) JSON() error {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadResource,
+		func() { hal.Render(action.W, action.Resource) },
+	)
+	return action.Err
+}
+
+func (action *TradeAggregateBatchAction) loadParams() {
+	action.PagingParams = action.GetPageQuery()
+	action.BaseAssets, action.CounterAssets = action.GetAssetPairs("pairs", maxTradeAggregationPairs)
+	action.OffsetFilter = action.GetInt64("offset")
+	action.StartTimeFilter = action.GetTimeMillis("start_time")
+	action.EndTimeFilter = action.GetTimeMillis("end_time")
+	action.ResolutionFilter = action.GetInt64("resolution")
+	action.validateResolutionAndOffset(action.ResolutionFilter, action.OffsetFilter)
+}
+
+func (action *TradeAggregateBatchAction) loadRecords() {
+	action.Records = make([][]history.TradeAggregation, len(action.BaseAssets))
+	for i := range action.BaseAssets {
+		action.Records[i] = action.loadTradeAggregations(
+			action.BaseAssets[i],
+			action.CounterAssets[i],
+			action.ResolutionFilter,
+			action.OffsetFilter,
+			action.StartTimeFilter,
+			action.EndTimeFilter,
+			action.PagingParams,
+		)
+		if action.Err != nil {
+			return
+		}
+	}
+}
+
+func (action *TradeAggregateBatchAction) loadResource() {
+	series := make([]horizon.TradeAggregationSeries, len(action.Records))
+	for i, records := range action.Records {
+		action.Err = resourceadapter.PopulateTradeAggregationSeries(
+			action.R.Context(),
+			&series[i],
+			action.BaseAssets[i],
+			action.CounterAssets[i],
+			records,
+		)
+		if action.Err != nil {
+			return
+		}
+	}
+
+	action.Resource.Embedded.Records = series
+}
+
+// validateResolutionAndOffset checks the `resolution` and `offset` parameters
+// of trade aggregations.
+func (action *Action) validateResolutionAndOffset(resolution int64, offset int64) {
+	if action.Err != nil {
+		return
+	}
+
+	//check if resolution is legal
+	if !history.ValidResolution(resolution) {
+		action.SetInvalidField("resolution", errors.New("illegal or missing resolution. "+
+			"resolution must be a multiple of 1 minute (60000), e.g. 5 minutes (300000), 1 hour (3600000), "+
+			"1 day (86400000) or 1 week (604800000)"))
+		return
+	}
+	// check if offset is legal
+	if !history.ValidOffset(offset, resolution) {
+		action.SetInvalidField("offset", errors.New("illegal or missing offset. offset must be a multiple of a"+
+			" minute, less than or equal to the resolution, and less than 24 hours"))
+	}
+}
+
+// loadTradeAggregations loads the trade aggregations of a single asset pair.
+func (action *Action) loadTradeAggregations(
+	baseAsset xdr.Asset,
+	counterAsset xdr.Asset,
+	resolution int64,
+	offset int64,
+	startTime time.Millis,
+	endTime time.Millis,
+	pagingParams db2.PageQuery,
+) (records []history.TradeAggregation) {
+	historyQ := action.HistoryQ()
+
+	//get asset ids. assets that were never traded have no trade aggregations.
+	baseAssetId, err := historyQ.GetAssetID(baseAsset)
+	if historyQ.NoRows(err) {
+		return
+	}
+	if err != nil {
+		action.Err = err
+		return
+	}
+	counterAssetId, err := historyQ.GetAssetID(counterAsset)
+	if historyQ.NoRows(err) {
+		return
+	}
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	//initialize the query builder with required params
+	tradeAggregationsQ, err := historyQ.GetTradeAggregationsQ(
+		baseAssetId, counterAssetId, resolution, offset, pagingParams)
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	//set time range if supplied
+	if !startTime.IsNil() {
+		tradeAggregationsQ, err = tradeAggregationsQ.WithStartTime(startTime)
+		if err != nil {
+			action.SetInvalidField("start_time", errors.New("illegal start time. adjusted start time must "+
+				"be less than the provided end time if the end time is greater than 0"))
+			return
+		}
+	}
+	if !endTime.IsNil() {
+		tradeAggregationsQ, err = tradeAggregationsQ.WithEndTime(endTime)
+		if err != nil {
+			action.SetInvalidField("end_time", errors.New("illegal end time. adjusted end time "+
+				"must be greater than the offset and greater than the provided start time"))
+			return
+		}
+	}
+
+	action.Err = historyQ.Select(&records, tradeAggregationsQ.GetSql())
+	return
+}
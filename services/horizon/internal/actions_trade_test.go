@@ -1,6 +1,7 @@
 package horizon
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -212,7 +213,7 @@ func TestTradeActions_Aggregation(t *testing.T) {
 
 	//test illegal resolution
 	if history.StrictResolutionFiltering {
-		q.Add("resolution", strconv.FormatInt(hour/2, 10))
+		q.Add("resolution", strconv.FormatInt(minute/2, 10))
 		w = ht.GetWithParams(aggregationPath, q)
 		ht.Assert.Equal(400, w.Code)
 	}
@@ -433,7 +434,7 @@ func TestTradeActions_AggregationInvalidOffset(t *testing.T) {
 		startTime  int64
 		endTime    int64
 	}{
-		{offset: minute, resolution: hour},                                            // Test invalid offset value that's not hour aligned
+		{offset: minute / 2, resolution: hour},                                        // Test invalid offset value that's not minute aligned
 		{offset: 25 * hour, resolution: week},                                         // Test invalid offset value that's greater than 24 hours
 		{offset: 3 * hour, resolution: hour},                                          // Test invalid offset value that's greater than the resolution
 		{offset: 3 * hour, startTime: 28 * hour, endTime: 26 * hour, resolution: day}, // Test invalid end time that's less than the start time
@@ -511,3 +512,114 @@ func TestTradeActions_AggregationOffset(t *testing.T) {
 		})
 	}
 }
+
+func TestTradeActions_AggregationCustomResolution(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+	dbQ := &Q{ht.HorizonSession()}
+	// One trade every hour
+	ass1, ass2, err := PopulateTestTrades(dbQ, 0, 100, hour, 1)
+	ht.Require.NoError(err)
+
+	q := make(url.Values)
+	setAssetQuery(&q, "base_", ass1)
+	setAssetQuery(&q, "counter_", ass2)
+	q.Add("order", "asc")
+	q.Add("resolution", strconv.FormatInt(90*minute, 10))
+	q.Add("offset", strconv.FormatInt(30*minute, 10))
+	q.Add("start_time", "0")
+	q.Add("end_time", strconv.FormatInt(6*hour, 10))
+
+	w := ht.GetWithParams(aggregationPath, q)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+		var records []horizon.TradeAggregation
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal(30*minute, records[0].Timestamp)
+		ht.Assert.Equal(int64(1), records[0].TradeCount)
+		ht.Assert.Equal(120*minute, records[1].Timestamp)
+		ht.Assert.Equal(int64(2), records[1].TradeCount)
+		ht.Assert.Equal(210*minute, records[2].Timestamp)
+		ht.Assert.Equal(int64(1), records[2].TradeCount)
+	}
+}
+
+func TestTradeActions_AggregationBatch(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	const start = int64(1510693200000)
+	dbQ := &Q{ht.HorizonSession()}
+	ass1, ass2, err := PopulateTestTrades(dbQ, start, 10, minute, 0)
+	ht.Require.NoError(err)
+	ass3, ass4, err := PopulateTestTrades(dbQ, start, 5, minute, 10)
+	ht.Require.NoError(err)
+
+	pairAsset := func(asset xdr.Asset) string {
+		var typ, code, issuer string
+		ht.Require.NoError(asset.Extract(&typ, &code, &issuer))
+		return code + ":" + issuer
+	}
+
+	q := make(url.Values)
+	q.Add("pairs", pairAsset(ass1)+"/"+pairAsset(ass2)+","+pairAsset(ass4)+"/"+pairAsset(ass3))
+	q.Add("resolution", strconv.FormatInt(hour, 10))
+	q.Add("start_time", strconv.FormatInt(start, 10))
+	q.Add("end_time", strconv.FormatInt(start+hour, 10))
+
+	w := ht.GetWithParams("/trade_aggregations/batch", q)
+	if ht.Assert.Equal(200, w.Code) {
+		var batch horizon.TradeAggregationBatch
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &batch))
+		series := batch.Embedded.Records
+		if ht.Assert.Len(series, 2) {
+			ht.Assert.Equal(pairAsset(ass1), series[0].Base.Code+":"+series[0].Base.Issuer)
+			if ht.Assert.Len(series[0].Records, 1) {
+				ht.Assert.Equal(int64(10), series[0].Records[0].TradeCount)
+				ht.Assert.Equal("0.0005500", series[0].Records[0].BaseVolume)
+			}
+
+			// the second pair is reversed
+			ht.Assert.Equal(pairAsset(ass4), series[1].Base.Code+":"+series[1].Base.Issuer)
+			if ht.Assert.Len(series[1].Records, 1) {
+				ht.Assert.Equal(int64(5), series[1].Records[0].TradeCount)
+				ht.Assert.Equal("0.0001500", series[1].Records[0].CounterVolume)
+			}
+		}
+	}
+
+	q.Set("pairs", "native")
+	w = ht.GetWithParams("/trade_aggregations/batch", q)
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestTradeActions_AggregationUnknownAssets(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	const start = int64(1510693200000)
+	dbQ := &Q{ht.HorizonSession()}
+	ass1, _, err := PopulateTestTrades(dbQ, start, 10, minute, 0)
+	ht.Require.NoError(err)
+
+	// assets that were never traded are not in history_assets
+	unknown := GetTestAsset("UNKN")
+
+	for _, pair := range [][2]xdr.Asset{{unknown, ass1}, {ass1, unknown}} {
+		q := make(url.Values)
+		setAssetQuery(&q, "base_", pair[0])
+		setAssetQuery(&q, "counter_", pair[1])
+		q.Add("resolution", strconv.FormatInt(hour, 10))
+		q.Add("start_time", strconv.FormatInt(start, 10))
+		q.Add("end_time", strconv.FormatInt(start+hour, 10))
+
+		w := ht.GetWithParams(aggregationPath, q)
+		if ht.Assert.Equal(200, w.Code) {
+			ht.Assert.PageOf(0, w.Body)
+		}
+	}
+
+	// the request did not create the unknown asset
+	_, err = dbQ.GetAssetID(unknown)
+	ht.Assert.True(dbQ.NoRows(err))
+}
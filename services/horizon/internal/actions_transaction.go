@@ -190,7 +190,10 @@ func (action *TransactionShowAction) JSON() error {
 		action.loadParams,
 		action.loadRecord,
 		action.loadResource,
-		func() { hal.Render(action.W, action.Resource) },
+		func() {
+			setImmutableCacheHeaders(action.W, action.Record.LedgerCloseTime)
+			hal.Render(action.W, action.Resource)
+		},
 	)
 	return action.Err
 }
@@ -0,0 +1,349 @@
+package horizon
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/guregu/null"
+	"github.com/lib/pq"
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/services/horizon/internal/actions"
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	hProblem "github.com/stellar/go/services/horizon/internal/render/problem"
+	"github.com/stellar/go/services/horizon/internal/resourceadapter"
+	"github.com/stellar/go/services/horizon/internal/webhooks"
+	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/xdr"
+)
+
+// This file contains the actions:
+//
+// WebhookCreateAction: registers a new webhook
+// WebhookIndexAction: pages of webhooks
+// WebhookShowAction: single webhook by id
+// WebhookDestroyAction: removes a webhook
+// WebhookReplayAction: re-enqueues the events of a webhook after a cursor
+// WebhookDeadLetterIndexAction: pages of a webhook's dead letters
+
+// Interface verifications
+var _ actions.JSONer = (*WebhookCreateAction)(nil)
+var _ actions.JSONer = (*WebhookIndexAction)(nil)
+var _ actions.JSONer = (*WebhookShowAction)(nil)
+var _ actions.JSONer = (*WebhookDestroyAction)(nil)
+var _ actions.JSONer = (*WebhookReplayAction)(nil)
+var _ actions.JSONer = (*WebhookDeadLetterIndexAction)(nil)
+
+// WebhookCreateAction registers a webhook.  The webhook receives the events of
+// the ledgers ingested after its registration.
+type WebhookCreateAction struct {
+	Action
+	Record   history.Webhook
+	Resource horizon.Webhook
+}
+
+// JSON is a method for actions.JSON
+func (action *WebhookCreateAction) JSON() error {
+	action.Do(
+		action.ValidateBodyType,
+		action.loadParams,
+		action.createRecord,
+		func() {
+			resourceadapter.PopulateWebhook(action.R.Context(), &action.Resource, action.Record)
+			action.Resource.Secret = action.Record.Secret
+			hal.Render(action.W, action.Resource)
+		},
+	)
+	return action.Err
+}
+
+func (action *WebhookCreateAction) loadParams() {
+	action.Record.URL = action.GetString("url")
+	if action.Err != nil {
+		return
+	}
+
+	err := action.App.webhooks.ValidateURL(action.Record.URL)
+	if err != nil {
+		action.SetInvalidField("url", err)
+		return
+	}
+
+	if action.GetString("account") != "" {
+		action.Record.Account = null.StringFrom(action.GetAddress("account"))
+	}
+
+	if asset, ok := action.MaybeGetAsset(""); ok {
+		var typ, code, issuer string
+		action.Err = asset.Extract(&typ, &code, &issuer)
+		if action.Err != nil {
+			return
+		}
+
+		action.Record.AssetType = null.StringFrom(typ)
+		if asset.Type != xdr.AssetTypeAssetTypeNative {
+			action.Record.AssetCode = null.StringFrom(code)
+			action.Record.AssetIssuer = null.StringFrom(issuer)
+		}
+	}
+
+	action.Record.OperationTypes = action.getOperationTypes("operation_types")
+}
+
+// getOperationTypes parses a comma separated list of operation type names,
+// e.g. `payment,path_payment`.
+func (action *WebhookCreateAction) getOperationTypes(name string) pq.Int64Array {
+	result := pq.Int64Array{}
+	value := action.GetString(name)
+	if action.Err != nil || value == "" {
+		return result
+	}
+
+	names := map[string]xdr.OperationType{}
+	for typ, typeName := range operations.TypeNames {
+		names[typeName] = typ
+	}
+
+	for _, typeName := range strings.Split(value, ",") {
+		typ, ok := names[strings.TrimSpace(typeName)]
+		if !ok {
+			action.SetInvalidField(name, errors.New("unknown operation type: "+typeName))
+			return result
+		}
+		result = append(result, int64(typ))
+	}
+
+	return result
+}
+
+func (action *WebhookCreateAction) createRecord() {
+	secret := make([]byte, 32)
+	_, action.Err = rand.Read(secret)
+	if action.Err != nil {
+		return
+	}
+
+	action.Record.Secret = hex.EncodeToString(secret)
+	action.Record.LastLedger = ledger.CurrentState().HistoryLatest
+	action.Err = action.HistoryQ().InsertWebhook(&action.Record)
+}
+
+// WebhookIndexAction renders a page of webhook resources.
+type WebhookIndexAction struct {
+	Action
+	PagingParams db2.PageQuery
+	Records      []history.Webhook
+	Page         hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *WebhookIndexAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() { hal.Render(action.W, action.Page) },
+	)
+	return action.Err
+}
+
+func (action *WebhookIndexAction) loadParams() {
+	action.PagingParams = action.GetPageQuery()
+}
+
+func (action *WebhookIndexAction) loadRecords() {
+	action.Err = action.HistoryQ().WebhooksPage(&action.Records, action.PagingParams)
+}
+
+func (action *WebhookIndexAction) loadPage() {
+	for _, record := range action.Records {
+		var res horizon.Webhook
+		resourceadapter.PopulateWebhook(action.R.Context(), &res, record)
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.PopulateLinks()
+}
+
+// WebhookShowAction renders a webhook found by its id.
+type WebhookShowAction struct {
+	Action
+	ID       int64
+	Record   history.Webhook
+	Resource horizon.Webhook
+}
+
+// JSON is a method for actions.JSON
+func (action *WebhookShowAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadRecord,
+		func() {
+			resourceadapter.PopulateWebhook(action.R.Context(), &action.Resource, action.Record)
+			hal.Render(action.W, action.Resource)
+		},
+	)
+	return action.Err
+}
+
+func (action *WebhookShowAction) loadParams() {
+	action.ID = action.GetInt64("id")
+}
+
+func (action *WebhookShowAction) loadRecord() {
+	action.Err = action.HistoryQ().WebhookByID(&action.Record, action.ID)
+}
+
+// WebhookDestroyAction removes a webhook, along with its pending deliveries
+// and dead letters, and renders the removed webhook.
+type WebhookDestroyAction struct {
+	Action
+	ID       int64
+	Record   history.Webhook
+	Resource horizon.Webhook
+}
+
+// JSON is a method for actions.JSON
+func (action *WebhookDestroyAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadRecord,
+		func() {
+			action.Err = action.HistoryQ().DeleteWebhook(action.ID)
+		},
+		func() {
+			resourceadapter.PopulateWebhook(action.R.Context(), &action.Resource, action.Record)
+			hal.Render(action.W, action.Resource)
+		},
+	)
+	return action.Err
+}
+
+func (action *WebhookDestroyAction) loadParams() {
+	action.ID = action.GetInt64("id")
+}
+
+func (action *WebhookDestroyAction) loadRecord() {
+	action.Err = action.HistoryQ().WebhookByID(&action.Record, action.ID)
+}
+
+// WebhookReplayAction starts re-enqueueing the events of a webhook that come
+// after the provided cursor, which is the paging token of a previously
+// delivered event.
+type WebhookReplayAction struct {
+	Action
+	ID       int64
+	Cursor   string
+	Record   history.Webhook
+	Resource horizon.WebhookReplay
+}
+
+// JSON is a method for actions.JSON
+func (action *WebhookReplayAction) JSON() error {
+	action.Do(
+		action.ValidateBodyType,
+		action.loadParams,
+		action.loadRecord,
+		action.replay,
+		func() { hal.Render(action.W, action.Resource) },
+	)
+	return action.Err
+}
+
+func (action *WebhookReplayAction) loadParams() {
+	action.ID = action.GetInt64("id")
+	action.Cursor = action.GetString("cursor")
+	if action.Err != nil {
+		return
+	}
+
+	_, _, err := webhooks.ParseCursor(action.Cursor)
+	if err != nil {
+		action.SetInvalidField("cursor", err)
+	}
+}
+
+func (action *WebhookReplayAction) loadRecord() {
+	action.Err = action.HistoryQ().WebhookByID(&action.Record, action.ID)
+}
+
+func (action *WebhookReplayAction) replay() {
+	from, to, err := action.App.webhooks.StartReplay(action.Record, action.Cursor)
+	switch err {
+	case nil:
+	case webhooks.ErrReplayInProgress, webhooks.ErrReplayTooSoon:
+		p := hProblem.RateLimitExceeded
+		p.Detail = err.Error()
+		action.Err = &p
+		return
+	default:
+		action.Err = err
+		return
+	}
+
+	action.Resource = horizon.WebhookReplay{
+		WebhookID:  strconv.FormatInt(action.Record.ID, 10),
+		Cursor:     action.Cursor,
+		FromLedger: from,
+		ToLedger:   to,
+	}
+}
+
+// WebhookDeadLetterIndexAction renders a page of the deliveries of a webhook
+// that were abandoned after reaching the maximum number of attempts.
+type WebhookDeadLetterIndexAction struct {
+	Action
+	ID           int64
+	PagingParams db2.PageQuery
+	Records      []history.WebhookDeadLetter
+	Page         hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *WebhookDeadLetterIndexAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() { hal.Render(action.W, action.Page) },
+	)
+	return action.Err
+}
+
+func (action *WebhookDeadLetterIndexAction) loadParams() {
+	action.ID = action.GetInt64("id")
+	action.PagingParams = action.GetPageQuery()
+}
+
+func (action *WebhookDeadLetterIndexAction) loadRecords() {
+	var hook history.Webhook
+	action.Err = action.HistoryQ().WebhookByID(&hook, action.ID)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.HistoryQ().WebhookDeadLetters(&action.Records, action.ID, action.PagingParams)
+}
+
+func (action *WebhookDeadLetterIndexAction) loadPage() {
+	for _, record := range action.Records {
+		var res horizon.WebhookDeadLetter
+		resourceadapter.PopulateWebhookDeadLetter(action.R.Context(), &res, record)
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.PopulateLinks()
+}
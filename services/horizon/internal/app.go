@@ -24,6 +24,8 @@ import (
 	"github.com/stellar/go/services/horizon/internal/reap"
 	"github.com/stellar/go/services/horizon/internal/simplepath"
 	"github.com/stellar/go/services/horizon/internal/txsub"
+	"github.com/stellar/go/services/horizon/internal/verify"
+	"github.com/stellar/go/services/horizon/internal/webhooks"
 	"github.com/stellar/go/support/app"
 	"github.com/stellar/go/support/db"
 	"github.com/stellar/go/support/errors"
@@ -38,6 +40,7 @@ type App struct {
 	config                       Config
 	web                          *web
 	historyQ                     *history.Q
+	historyReplicas              *historyReplicas
 	coreQ                        *core.Q
 	ctx                          context.Context
 	cancel                       func()
@@ -47,9 +50,12 @@ type App struct {
 	currentProtocolVersion       int32
 	coreSupportedProtocolVersion int32
 	submitter                    *txsub.System
+	coreBackends                 *txsub.MultiSubmitter
 	paths                        paths.Finder
 	ingester                     *ingest.System
 	reaper                       *reap.System
+	webhooks                     *webhooks.System
+	verifier                     *verify.System
 	ticks                        *time.Ticker
 
 	// metrics
@@ -122,6 +128,10 @@ func (a *App) Serve() {
 func (a *App) Close() {
 	a.cancel()
 	a.ticks.Stop()
+
+	if a.ingester != nil {
+		a.ingester.ReleaseLeadership()
+	}
 }
 
 // CloseDB closes DB connections. When using during web server shut down make
@@ -129,6 +139,7 @@ func (a *App) Close() {
 // closed" errors.
 func (a *App) CloseDB() {
 	a.historyQ.Session.DB.Close()
+	a.historyReplicas.Close()
 	a.coreQ.Session.DB.Close()
 }
 
@@ -144,6 +155,14 @@ func (a *App) HorizonSession(ctx context.Context) *db.Session {
 	return &db.Session{DB: a.historyQ.Session.DB, Ctx: ctx}
 }
 
+// HorizonReadSession returns a new session that loads data from an up to date
+// replica of the horizon database or, when none is configured or up to date,
+// from the horizon database itself.  It must only be used for read-only
+// queries.  The returned session is bound to `ctx`.
+func (a *App) HorizonReadSession(ctx context.Context) *db.Session {
+	return a.historyReplicas.Session(ctx)
+}
+
 // CoreSession returns a new session that loads data from the stellar core
 // database. The returned session is bound to `ctx`.
 func (a *App) CoreSession(ctx context.Context) *db.Session {
@@ -338,17 +357,29 @@ func (a *App) DeleteUnretainedHistory() error {
 func (a *App) Tick() {
 	var wg sync.WaitGroup
 	log.Debug("ticking app")
-	// update ledger state, operation fee state, and stellar-core info in parallel
-	wg.Add(3)
+	// update ledger state, operation fee state, stellar-core info and the
+	// state of history replicas in parallel
+	wg.Add(4)
 	go func() { a.UpdateLedgerState(); wg.Done() }()
 	go func() { a.UpdateOperationFeeStatsState(); wg.Done() }()
 	go func() { a.UpdateStellarCoreInfo(); wg.Done() }()
+	go func() { a.historyReplicas.Update(a.ctx); wg.Done() }()
 	wg.Wait()
 
 	if a.ingester != nil {
 		go a.ingester.Tick()
 	}
 
+	if a.webhooks != nil && a.ingester != nil && a.ingester.IsLeader() {
+		go a.webhooks.Tick()
+	}
+
+	if a.verifier != nil {
+		go a.verifier.Tick()
+	}
+
+	go a.coreBackends.CheckHealth(a.ctx)
+
 	wg.Add(2)
 	go func() { a.reaper.Tick(); wg.Done() }()
 	go func() { a.submitter.Tick(a.ctx); wg.Done() }()
@@ -394,8 +425,15 @@ func (a *App) init() {
 	// reaper
 	a.reaper = reap.New(a.config.HistoryRetentionCount, a.HorizonSession(nil))
 
+	// webhooks
+	initWebhooks(a)
+
+	// verifier
+	initVerifier(a)
+
 	// web.init
 	a.web = mustInitWeb(a.ctx, a.historyQ, a.coreQ, a.config.SSEUpdateFrequency, a.config.StaleThreshold, a.config.IngestFailedTransactions)
+	a.web.historyReplicas = a.historyReplicas
 
 	// web.rate-limiter
 	a.web.rateLimiter = maybeInitWebRateLimiter(a.config.RateQuota)
@@ -406,7 +444,7 @@ func (a *App) init() {
 	a.web.mustInstallMiddlewares(a, a.config.ConnectionTimeout)
 
 	// web.actions
-	a.web.mustInstallActions(a.config.EnableAssetStats, a.config.FriendbotURL)
+	a.web.mustInstallActions(a.config.EnableAssetStats, a.config.EnableWebhooks, a.config.WebhooksAdminToken, a.config.FriendbotURL)
 
 	// metrics and log.metrics
 	a.metrics = metrics.NewRegistry()
@@ -426,6 +464,12 @@ func (a *App) init() {
 	// ingester.metrics
 	initIngesterMetrics(a)
 
+	// webhooks.metrics
+	initWebhooksMetrics(a)
+
+	// verifier.metrics
+	initVerifierMetrics(a)
+
 	// redis
 	initRedis(a)
 }
@@ -38,6 +38,9 @@ type Config struct {
 	Ingest bool
 	// IngestFailedTransactions toggles whether to ingest failed transactions
 	IngestFailedTransactions bool
+	// IngestLeaderElection causes ingesting instances to elect a single
+	// ingestion leader using a postgres advisory lock.
+	IngestLeaderElection bool
 	// HistoryRetentionCount represents the minimum number of ledgers worth of
 	// history data to retain in the horizon database. For the purposes of
 	// determining a "retention duration", each ledger roughly corresponds to 10
@@ -55,4 +58,28 @@ type Config struct {
 	// Enabling it has a negative impact on CPU when ingesting ledgers full of
 	// many different assets related operations.
 	EnableAssetStats bool
+	// EnableWebhooks is a feature flag that determines whether to expose the
+	// `/webhooks` endpoints and, when ingesting, deliver matching operations and
+	// effects to the registered webhooks after each ledger.
+	EnableWebhooks bool
+	// WebhooksAdminToken is the bearer token required by the `/webhooks`
+	// endpoints.
+	WebhooksAdminToken string
+	// StellarCoreSubmitURLs are the http endpoints of additional stellar-core
+	// instances transactions are submitted to when the one at StellarCoreURL
+	// is unhealthy.
+	StellarCoreSubmitURLs []string
+	// SubmitBroadcast causes transactions to be submitted to every configured
+	// stellar-core instance at once instead of the healthiest one.
+	SubmitBroadcast bool
+	// DatabaseReplicaURLs are read-only replicas of the horizon database.
+	// History queries of read-only requests are routed to them as long as
+	// they are not behind the horizon database.
+	DatabaseReplicaURLs []string
+	// VerifyInterval is the delay between two verifications of a sample of
+	// stellar-core state against the ingested history.  Zero disables the
+	// verification.
+	VerifyInterval time.Duration
+	// VerifySampleSize is the number of accounts verified per verification.
+	VerifySampleSize uint
 }
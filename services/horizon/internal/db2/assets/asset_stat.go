@@ -1,20 +1,38 @@
 package assets
 
 import (
+	"fmt"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/stellar/go/services/horizon/internal/db2"
 )
 
+// Sort orders supported by AssetStatsQ
+const (
+	// SortByAsset orders the assets by code, issuer and type
+	SortByAsset = "asset"
+	// SortByHolders orders the assets by the number of accounts holding them
+	SortByHolders = "holders"
+	// SortByVolume orders the assets by their traded volume over the last 24h
+	SortByVolume = "volume"
+)
+
 // AssetStatsR is the result from the AssetStatsQ query
 type AssetStatsR struct {
-	SortKey     string `db:"sort_key"`
-	Type        string `db:"asset_type"`
-	Code        string `db:"asset_code"`
-	Issuer      string `db:"asset_issuer"`
-	Amount      string `db:"amount"`
-	NumAccounts int32  `db:"num_accounts"`
-	Flags       int8   `db:"flags"`
-	Toml        string `db:"toml"`
+	SortKey                 string `db:"sort_key"`
+	Type                    string `db:"asset_type"`
+	Code                    string `db:"asset_code"`
+	Issuer                  string `db:"asset_issuer"`
+	Amount                  string `db:"amount"`
+	NumAccounts             int32  `db:"num_accounts"`
+	NumUnauthorizedAccounts int32  `db:"num_unauthorized_accounts"`
+	NumOffers               int32  `db:"num_offers"`
+	Volume24h               string `db:"volume_24h"`
+	TradeCount24h           int32  `db:"trade_count_24h"`
+	LastPrice               string `db:"last_price"`
+	Flags                   int8   `db:"flags"`
+	HomeDomain              string `db:"home_domain"`
+	Toml                    string `db:"toml"`
 }
 
 // PagingToken implementation for hal.Pageable
@@ -26,12 +44,33 @@ type AssetStatsR struct {
 type AssetStatsQ struct {
 	AssetCode   *string
 	AssetIssuer *string
-	PageQuery   *db2.PageQuery
+	// Sort is one of SortByAsset (the default), SortByHolders or SortByVolume
+	Sort      string
+	PageQuery *db2.PageQuery
+}
+
+const assetSortKey = "concat(hist.asset_code, '_', hist.asset_issuer, '_', hist.asset_type)"
+
+// sortKeys maps the supported sort orders to the expression used as cursor.
+// Numbers are zero padded so that they can be compared as strings.
+var sortKeys = map[string]string{
+	SortByAsset:   assetSortKey,
+	SortByHolders: "concat(lpad(stats.num_accounts::text, 10, '0'), '_', " + assetSortKey + ")",
+	SortByVolume:  "concat(lpad(stats.volume_24h::text, 40, '0'), '_', " + assetSortKey + ")",
 }
 
 // GetSQL allows this query to be executed by the caller
 func (q AssetStatsQ) GetSQL() (sq.SelectBuilder, error) {
-	sql := selectQuery
+	sort := q.Sort
+	if sort == "" {
+		sort = SortByAsset
+	}
+	sortKey, ok := sortKeys[sort]
+	if !ok {
+		return sq.SelectBuilder{}, fmt.Errorf("invalid sort: %s", q.Sort)
+	}
+
+	sql := selectQuery.Column(sortKey + " as sort_key")
 	if q.AssetCode != nil && *q.AssetCode != "" {
 		sql = sql.Where("hist.asset_code = ?", *q.AssetCode)
 	}
@@ -47,7 +86,7 @@ func (q AssetStatsQ) GetSQL() (sq.SelectBuilder, error) {
 			cursor = "zzzzzzzzzzzzz" // 12 + 1 "z"s so it will always be greater than the _ delimiter since code is max 12 chars
 		}
 
-		sql, err = q.PageQuery.ApplyToUsingCursor(sql, "concat(hist.asset_code, '_', hist.asset_issuer, '_', hist.asset_type)", cursor)
+		sql, err = q.PageQuery.ApplyToUsingCursor(sql, sortKey, cursor)
 		if err != nil {
 			return sql, err
 		}
@@ -59,13 +98,18 @@ func (q AssetStatsQ) GetSQL() (sq.SelectBuilder, error) {
 
 var selectQuery = sq.
 	Select(
-		"concat(hist.asset_code, '_', hist.asset_issuer, '_', hist.asset_type) as sort_key",
 		"hist.asset_type",
 		"hist.asset_code",
 		"hist.asset_issuer",
 		"stats.amount",
 		"stats.num_accounts",
+		"stats.num_unauthorized_accounts",
+		"stats.num_offers",
+		"stats.volume_24h",
+		"stats.trade_count_24h",
+		"stats.last_price",
 		"stats.flags",
+		"stats.home_domain",
 		"stats.toml",
 	).
 	From("history_assets hist").
@@ -1,12 +1,14 @@
 package assets
 
 import (
+	"fmt"
 	"strconv"
 	"testing"
 
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAssetsStatsQExec(t *testing.T) {
@@ -17,6 +19,7 @@ func TestAssetsStatsQExec(t *testing.T) {
 		Issuer:      "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4",
 		Amount:      "1009876000",
 		NumAccounts: 1,
+		Volume24h:   "0",
 		Flags:       1,
 		Toml:        "https://test.com/.well-known/stellar.toml",
 	}
@@ -28,6 +31,7 @@ func TestAssetsStatsQExec(t *testing.T) {
 		Issuer:      "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU",
 		Amount:      "10000000000",
 		NumAccounts: 1,
+		Volume24h:   "0",
 		Flags:       2,
 		Toml:        "",
 	}
@@ -39,10 +43,16 @@ func TestAssetsStatsQExec(t *testing.T) {
 		Issuer:      "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4",
 		Amount:      "3000010434000",
 		NumAccounts: 2,
+		Volume24h:   "0",
 		Flags:       1,
 		Toml:        "https://test.com/.well-known/stellar.toml",
 	}
 
+	byHolders := func(item AssetStatsR) AssetStatsR {
+		item.SortKey = fmt.Sprintf("%010d_%s", item.NumAccounts, item.SortKey)
+		return item
+	}
+
 	testCases := []struct {
 		query AssetStatsQ
 		want  []AssetStatsR
@@ -66,6 +76,15 @@ func TestAssetsStatsQExec(t *testing.T) {
 				},
 			},
 			[]AssetStatsR{item2, item1, item0},
+		}, {
+			AssetStatsQ{
+				Sort: SortByHolders,
+				PageQuery: &db2.PageQuery{
+					Order: "desc",
+					Limit: 10,
+				},
+			},
+			[]AssetStatsR{byHolders(item2), byHolders(item1), byHolders(item0)},
 		},
 	}
 
@@ -91,3 +110,8 @@ func TestAssetsStatsQExec(t *testing.T) {
 		})
 	}
 }
+
+func TestAssetsStatsQInvalidSort(t *testing.T) {
+	_, err := AssetStatsQ{Sort: "name"}.GetSQL()
+	assert.EqualError(t, err, "invalid sort: name")
+}
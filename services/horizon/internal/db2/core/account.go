@@ -51,6 +51,34 @@ func (q *Q) AccountByAddress(dest *Account, addy string) error {
 	return nil
 }
 
+// AccountsByAddresses loads the rows from `accounts` of every address of
+// `addys`.  Addresses without an account are omitted.
+func (q *Q) AccountsByAddresses(dest *[]Account, addys []string) error {
+	sql := selectAccount.Where(sq.Eq{"a.accountid": addys})
+	err := q.Select(dest, sql)
+	if err != nil {
+		return err
+	}
+
+	schemaVersion, err := q.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	if schemaVersion >= 9 {
+		// Since schema version 9, home_domain is base64 encoded.
+		for i, account := range *dest {
+			decoded, err := base64.StdEncoding.DecodeString(account.HomeDomain.String)
+			if err != nil {
+				return errors.Wrap(err, "Unable to base64 decode HomeDomain")
+			}
+			(*dest)[i].HomeDomain.String = string(decoded)
+		}
+	}
+
+	return nil
+}
+
 // SequencesForAddresses loads the current sequence number for every accountid
 // specified in `addys`
 func (q *Q) SequencesForAddresses(dest interface{}, addys []string) error {
@@ -101,3 +129,13 @@ var selectAccount = sq.Select(
 	"coalesce(a.buyingliabilities, 0) as buyingliabilities",
 	"coalesce(a.sellingliabilities, 0) as sellingliabilities",
 ).From("accounts a")
+
+// RandomAccounts loads up to `n` random rows from `accounts`, that were last
+// modified at or before ledger `maxLastModified`.
+func (q *Q) RandomAccounts(dest *[]Account, n uint64, maxLastModified int32) error {
+	sql := selectAccount.
+		Where("a.lastmodified <= ?", maxLastModified).
+		OrderBy("random()").
+		Limit(n)
+	return q.Select(dest, sql)
+}
@@ -3,8 +3,11 @@ package core
 import (
 	"encoding/base64"
 	"fmt"
+	"sort"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/support/errors"
 )
 
@@ -74,6 +77,89 @@ func (q *Q) AllDataByAddress(dest interface{}, addy string) error {
 	return nil
 }
 
+// AllDataByAddresses loads all data for every address of `addys`, ordered
+// by address.
+func (q *Q) AllDataByAddresses(dest *[]AccountData, addys []string) error {
+	schemaVersion, err := q.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	sql := selectAccountData.Where(sq.Eq{"accountid": addys}).OrderBy("accountid")
+	err = q.Select(dest, sql)
+	if err != nil {
+		return err
+	}
+
+	if schemaVersion >= 9 {
+		// Since schema version 9, keys are base64 encoded.
+		for i, val := range *dest {
+			decoded, err := base64.StdEncoding.DecodeString(val.Key)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("Error decoding data entry: %s", val.Key))
+			}
+			(*dest)[i].Key = string(decoded)
+		}
+	}
+
+	return nil
+}
+
+// AccountDataPage loads a page of the data entries of `addy` whose key starts
+// with `prefix`, ordered by key.  The cursor of `page` is the key of the entry
+// after (or, in descending order, before) which the page starts.
+//
+// Since schema version 9 keys are stored base64 encoded, so entries are
+// filtered and ordered after being loaded.  Accounts have at most a few
+// hundred subentries.
+func (q *Q) AccountDataPage(dest *[]AccountData, addy string, prefix string, page db2.PageQuery) error {
+	var all []AccountData
+	err := q.AllDataByAddress(&all, addy)
+	if err != nil {
+		return err
+	}
+
+	*dest = pageAccountData(all, prefix, page)
+	return nil
+}
+
+// pageAccountData returns the page of `entries` specified by `prefix` and
+// `page`.
+func pageAccountData(entries []AccountData, prefix string, page db2.PageQuery) []AccountData {
+	desc := page.Order == db2.OrderDescending
+
+	var result []AccountData
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Key, prefix) {
+			continue
+		}
+
+		if page.Cursor != "" {
+			if desc && entry.Key >= page.Cursor {
+				continue
+			}
+			if !desc && entry.Key <= page.Cursor {
+				continue
+			}
+		}
+
+		result = append(result, entry)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if desc {
+			return result[i].Key > result[j].Key
+		}
+		return result[i].Key < result[j].Key
+	})
+
+	if uint64(len(result)) > page.Limit {
+		result = result[:page.Limit]
+	}
+
+	return result
+}
+
 var selectAccountData = sq.Select(
 	"ad.accountid",
 	"ad.dataname",
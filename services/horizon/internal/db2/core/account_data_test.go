@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageAccountData(t *testing.T) {
+	entries := []AccountData{
+		{Key: "config.b"},
+		{Key: "other"},
+		{Key: "config.a"},
+		{Key: "config.c"},
+	}
+
+	keys := func(page []AccountData) []string {
+		var result []string
+		for _, entry := range page {
+			result = append(result, entry.Key)
+		}
+		return result
+	}
+
+	page := pageAccountData(entries, "", db2.MustPageQuery("", false, "asc", 10))
+	assert.Equal(t, []string{"config.a", "config.b", "config.c", "other"}, keys(page))
+
+	page = pageAccountData(entries, "config.", db2.MustPageQuery("", false, "asc", 2))
+	assert.Equal(t, []string{"config.a", "config.b"}, keys(page))
+
+	page = pageAccountData(entries, "config.", db2.MustPageQuery("config.b", false, "asc", 2))
+	assert.Equal(t, []string{"config.c"}, keys(page))
+
+	page = pageAccountData(entries, "config.", db2.MustPageQuery("", false, "desc", 2))
+	assert.Equal(t, []string{"config.c", "config.b"}, keys(page))
+
+	page = pageAccountData(entries, "config.", db2.MustPageQuery("config.b", false, "desc", 2))
+	assert.Equal(t, []string{"config.a"}, keys(page))
+
+	page = pageAccountData(entries, "missing", db2.MustPageQuery("", false, "asc", 2))
+	assert.Empty(t, page)
+}
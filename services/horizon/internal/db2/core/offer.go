@@ -174,34 +174,103 @@ func (q *Q) OffersByAddress(dest interface{}, addy string, pq db2.PageQuery) err
 
 	// Convert schema 8 results to xdr.Assets
 	for i, offer := range offers {
-		var sellingAsset, buyingAsset xdr.Asset
-
-		if offer.SellingAssetType == xdr.AssetTypeAssetTypeNative {
-			sellingAsset.SetNative()
-		} else {
-			var account xdr.AccountId
-			err := account.SetAddress(offer.SellingIssuer.String)
-			if err != nil {
-				return errors.Wrap(err, "Error setting offer.SellingIssuer")
-			}
-			sellingAsset.SetCredit(offer.SellingAssetCode.String, account)
+		newOffers[i], err = offer.schema8()
+		if err != nil {
+			return err
+		}
+	}
+
+	*dest.(*[]Offer) = newOffers
+	return nil
+}
+
+// schema8 returns the Offer with its assets built from the schema 8 asset
+// columns.
+func (o internalOffer) schema8() (Offer, error) {
+	var sellingAsset, buyingAsset xdr.Asset
+
+	if o.SellingAssetType == xdr.AssetTypeAssetTypeNative {
+		sellingAsset.SetNative()
+	} else {
+		var account xdr.AccountId
+		err := account.SetAddress(o.SellingIssuer.String)
+		if err != nil {
+			return Offer{}, errors.Wrap(err, "Error setting offer.SellingIssuer")
 		}
+		sellingAsset.SetCredit(o.SellingAssetCode.String, account)
+	}
 
-		if offer.BuyingAssetType == xdr.AssetTypeAssetTypeNative {
-			buyingAsset.SetNative()
-		} else {
-			var account xdr.AccountId
-			err := account.SetAddress(offer.BuyingIssuer.String)
-			if err != nil {
-				return errors.Wrap(err, "Error setting offer.BuyingIssuer")
-			}
-			buyingAsset.SetCredit(offer.BuyingAssetCode.String, account)
+	if o.BuyingAssetType == xdr.AssetTypeAssetTypeNative {
+		buyingAsset.SetNative()
+	} else {
+		var account xdr.AccountId
+		err := account.SetAddress(o.BuyingIssuer.String)
+		if err != nil {
+			return Offer{}, errors.Wrap(err, "Error setting offer.BuyingIssuer")
 		}
+		buyingAsset.SetCredit(o.BuyingAssetCode.String, account)
+	}
 
-		newOffers[i].SellingAsset = sellingAsset
-		newOffers[i].BuyingAsset = buyingAsset
+	offer := o.get()
+	offer.SellingAsset = sellingAsset
+	offer.BuyingAsset = buyingAsset
+	return offer, nil
+}
+
+// NumOffersForAsset returns the number of open offers that are either selling
+// or buying `asset`.
+func (q *Q) NumOffersForAsset(asset xdr.Asset) (int32, error) {
+	schemaVersion, err := q.SchemaVersion()
+	if err != nil {
+		return 0, err
 	}
 
-	*dest.(*[]Offer) = newOffers
-	return nil
+	filter, err := offersForAsset(schemaVersion, asset)
+	if err != nil {
+		return 0, err
+	}
+
+	sql := sq.Select("COUNT(*)").From("offers").Where(filter)
+
+	var count int32
+	err = q.Get(&count, sql)
+	return count, err
+}
+
+// offersForAsset returns the condition matching offers that are either
+// selling or buying `asset`.
+func offersForAsset(schemaVersion int, asset xdr.Asset) (sq.Sqlizer, error) {
+	if schemaVersion >= 9 {
+		assetXDRString, err := xdr.MarshalBase64(asset)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error marshaling asset")
+		}
+		return sq.Or{
+			sq.Eq{"sellingasset": assetXDRString},
+			sq.Eq{"buyingasset": assetXDRString},
+		}, nil
+	}
+
+	var (
+		t xdr.AssetType
+		c string
+		i string
+	)
+
+	err := asset.Extract(&t, &c, &i)
+	if err != nil {
+		return nil, err
+	}
+
+	if t == xdr.AssetTypeAssetTypeNative {
+		return sq.Or{
+			sq.Eq{"sellingassettype": t},
+			sq.Eq{"buyingassettype": t},
+		}, nil
+	}
+
+	return sq.Or{
+		sq.Eq{"sellingassettype": t, "sellingassetcode": c, "sellingissuer": i},
+		sq.Eq{"buyingassettype": t, "buyingassetcode": c, "buyingissuer": i},
+	}, nil
 }
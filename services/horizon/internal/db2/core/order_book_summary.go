@@ -3,6 +3,8 @@ package core
 import (
 	"bytes"
 	"fmt"
+	"math"
+	"math/big"
 	"text/template"
 
 	"github.com/go-errors/errors"
@@ -51,6 +53,87 @@ func (o *OrderBookSummary) filter(typ string, prepend bool) []OrderBookSummaryPr
 	return result
 }
 
+// Aggregate groups the price levels of the summary into buckets of
+// `precision` decimal places, summing the amounts of the levels that fall in
+// the same bucket.  Asks are rounded up and bids are rounded down, so that a
+// bucket's price is never better than the price of its member offers.  At most
+// `limit` buckets are kept on each side.
+func (o OrderBookSummary) Aggregate(precision int, limit int) (OrderBookSummary, error) {
+	if precision < 0 || precision > 7 {
+		return nil, errors.Errorf("invalid precision: %d", precision)
+	}
+
+	asks, err := aggregatePriceLevels(o.Asks(), "ask", precision, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	bids, err := aggregatePriceLevels(o.Bids(), "bid", precision, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the ordering of the summary query: asks, then bids, each sorted by
+	// ascending price.
+	result := make(OrderBookSummary, 0, len(asks)+len(bids))
+	result = append(result, asks...)
+	for i := len(bids) - 1; i >= 0; i-- {
+		result = append(result, bids[i])
+	}
+
+	return result, nil
+}
+
+// aggregatePriceLevels buckets `levels`, which must be sorted from the best to
+// the worst price.
+func aggregatePriceLevels(
+	levels []OrderBookSummaryPriceLevel,
+	typ string,
+	precision int,
+	limit int,
+) ([]OrderBookSummaryPriceLevel, error) {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+	result := []OrderBookSummaryPriceLevel{}
+
+	var current *big.Int
+	for _, level := range levels {
+		scaled := new(big.Int).Mul(big.NewInt(int64(level.Pricen)), scale)
+		bucket, rem := new(big.Int).QuoRem(scaled, big.NewInt(int64(level.Priced)), new(big.Int))
+		if typ == "ask" && rem.Sign() != 0 {
+			bucket.Add(bucket, big.NewInt(1))
+		}
+
+		if current != nil && current.Cmp(bucket) == 0 {
+			result[len(result)-1].Amount += level.Amount
+			continue
+		}
+
+		if len(result) == limit {
+			break
+		}
+
+		price := new(big.Rat).SetFrac(bucket, scale)
+		if !price.Num().IsInt64() || price.Num().Int64() > math.MaxInt32 ||
+			!price.Denom().IsInt64() || price.Denom().Int64() > math.MaxInt32 {
+			return nil, errors.Errorf("price %s cannot be represented with precision %d", level.PriceAsString(), precision)
+		}
+
+		pricef, _ := price.Float64()
+		result = append(result, OrderBookSummaryPriceLevel{
+			Type: typ,
+			PriceLevel: PriceLevel{
+				Pricen: int32(price.Num().Int64()),
+				Priced: int32(price.Denom().Int64()),
+				Pricef: pricef,
+				Amount: level.Amount,
+			},
+		})
+		current = bucket
+	}
+
+	return result, nil
+}
+
 // GetOrderBookSummary loads a summary of an order book identified by a
 // selling/buying pair. It is designed to drive an order book summary client
 // interface (bid/ask spread, prices and volume, etc).
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stellar/go/services/horizon/internal/test"
 	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetOrderBookSummary(t *testing.T) {
@@ -98,3 +100,71 @@ func TestGetOrderBookSummary_Regress310(t *testing.T) {
 	tt.Assert.Equal(1.0/10.1, asks[1].Pricef)
 	tt.Assert.Equal(1.0/10.0, asks[2].Pricef)
 }
+
+func TestOrderBookSummaryAggregate(t *testing.T) {
+	level := func(typ string, n, d int32, amount int64) OrderBookSummaryPriceLevel {
+		return OrderBookSummaryPriceLevel{
+			Type: typ,
+			PriceLevel: PriceLevel{
+				Pricen: n,
+				Priced: d,
+				Pricef: float64(n) / float64(d),
+				Amount: amount,
+			},
+		}
+	}
+
+	// asks, then bids, each sorted by ascending price like the summary query
+	summary := OrderBookSummary{
+		level("ask", 101, 100, 10),
+		level("ask", 109, 100, 20),
+		level("ask", 111, 100, 30),
+		level("ask", 13, 10, 40),
+		level("bid", 80, 100, 50),
+		level("bid", 91, 100, 60),
+		level("bid", 99, 100, 70),
+	}
+
+	aggregated, err := summary.Aggregate(1, 20)
+	require.NoError(t, err)
+
+	asks := aggregated.Asks()
+	if assert.Len(t, asks, 3) {
+		assert.Equal(t, "1.1000000", asks[0].PriceAsString())
+		assert.Equal(t, int64(30), asks[0].Amount)
+		assert.Equal(t, "1.2000000", asks[1].PriceAsString())
+		assert.Equal(t, int64(30), asks[1].Amount)
+		assert.Equal(t, "1.3000000", asks[2].PriceAsString())
+		assert.Equal(t, int64(40), asks[2].Amount)
+	}
+
+	bids := aggregated.Bids()
+	if assert.Len(t, bids, 2) {
+		assert.Equal(t, "0.9000000", bids[0].PriceAsString())
+		assert.Equal(t, int64(130), bids[0].Amount)
+		assert.Equal(t, "0.8000000", bids[1].PriceAsString())
+		assert.Equal(t, int64(50), bids[1].Amount)
+	}
+
+	// limit applies to each side
+	aggregated, err = summary.Aggregate(1, 1)
+	require.NoError(t, err)
+	assert.Len(t, aggregated.Asks(), 1)
+	assert.Len(t, aggregated.Bids(), 1)
+	assert.Equal(t, "0.9000000", aggregated.Bids()[0].PriceAsString())
+
+	// zero precision buckets by whole units
+	aggregated, err = summary.Aggregate(0, 20)
+	require.NoError(t, err)
+	assert.Len(t, aggregated.Asks(), 1)
+	assert.Equal(t, "2.0000000", aggregated.Asks()[0].PriceAsString())
+	assert.Equal(t, int64(100), aggregated.Asks()[0].Amount)
+	assert.Len(t, aggregated.Bids(), 1)
+	assert.Equal(t, "0.0000000", aggregated.Bids()[0].PriceAsString())
+
+	_, err = summary.Aggregate(8, 20)
+	assert.Error(t, err)
+
+	_, err = OrderBookSummary{level("ask", 10000, 3, 1)}.Aggregate(7, 20)
+	assert.Error(t, err)
+}
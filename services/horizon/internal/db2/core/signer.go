@@ -49,6 +49,55 @@ func (q *Q) SignersByAddress(dest interface{}, addy string) error {
 	return nil
 }
 
+// SignersByAddresses loads all signer rows for every address of `addys`
+func (q *Q) SignersByAddresses(dest *[]Signer, addys []string) error {
+	schemaVersion, err := q.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	if schemaVersion < 9 {
+		sql := selectSigner.Where(sq.Eq{"accountid": addys})
+		return q.Select(dest, sql)
+	}
+
+	var rows []struct {
+		Accountid string  `db:"accountid"`
+		Signers   *string `db:"signers"`
+	}
+	sql := sq.Select("a.accountid", "a.signers").
+		From("accounts a").
+		Where(sq.Eq{"a.accountid": addys})
+	err = q.Select(&rows, sql)
+	if err != nil {
+		return err
+	}
+
+	signers := []Signer{}
+	for _, row := range rows {
+		if row.Signers == nil {
+			continue
+		}
+
+		var signersXDR []xdr.Signer
+		err = xdr.SafeUnmarshalBase64(*row.Signers, &signersXDR)
+		if err != nil {
+			return errors.Wrap(err, "Error decoding []xdr.Signer")
+		}
+
+		for _, signer := range signersXDR {
+			signers = append(signers, Signer{
+				Accountid: row.Accountid,
+				Publickey: signer.Key.Address(),
+				Weight:    int32(signer.Weight),
+			})
+		}
+	}
+
+	*dest = signers
+	return nil
+}
+
 var selectSigner = sq.Select(
 	"si.accountid",
 	"si.publickey",
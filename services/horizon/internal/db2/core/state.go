@@ -0,0 +1,181 @@
+package core
+
+import (
+	"encoding/base64"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// StateFilter restricts the ledger entries streamed by the Stream* methods.
+// Empty fields do not filter anything.
+type StateFilter struct {
+	// AccountPrefix only matches entries owned by accounts whose address starts
+	// with the given prefix.
+	AccountPrefix string
+	// Asset only matches trustlines and offers involving the given asset.
+	Asset *xdr.Asset
+}
+
+// StreamAccounts calls `fn` for every account matching `filter`, ordered by
+// address.
+func (q *Q) StreamAccounts(filter StateFilter, fn func(Account) error) error {
+	schemaVersion, err := q.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	sql := selectAccount.OrderBy("a.accountid")
+	if filter.AccountPrefix != "" {
+		sql = sql.Where("a.accountid LIKE ?", filter.AccountPrefix+"%")
+	}
+
+	return q.stream(sql, func(rows *sqlx.Rows) error {
+		var account Account
+		err := rows.StructScan(&account)
+		if err != nil {
+			return err
+		}
+
+		if schemaVersion >= 9 {
+			// Since schema version 9, home_domain is base64 encoded.
+			decoded, err := base64.StdEncoding.DecodeString(account.HomeDomain.String)
+			if err != nil {
+				return errors.Wrap(err, "Unable to base64 decode HomeDomain")
+			}
+			account.HomeDomain.String = string(decoded)
+		}
+
+		return fn(account)
+	})
+}
+
+// StreamTrustlines calls `fn` for every trustline matching `filter`, ordered
+// by account and asset.
+func (q *Q) StreamTrustlines(filter StateFilter, fn func(Trustline) error) error {
+	sql := selectTrustline.OrderBy("tl.accountid", "tl.assettype", "tl.assetcode", "tl.issuer")
+	if filter.AccountPrefix != "" {
+		sql = sql.Where("tl.accountid LIKE ?", filter.AccountPrefix+"%")
+	}
+
+	if filter.Asset != nil {
+		var (
+			t xdr.AssetType
+			c string
+			i string
+		)
+
+		err := filter.Asset.Extract(&t, &c, &i)
+		if err != nil {
+			return err
+		}
+
+		sql = sql.Where(sq.Eq{"tl.assettype": t})
+		if t != xdr.AssetTypeAssetTypeNative {
+			sql = sql.Where(sq.Eq{"tl.assetcode": c, "tl.issuer": i})
+		}
+	}
+
+	return q.stream(sql, func(rows *sqlx.Rows) error {
+		var tl Trustline
+		err := rows.StructScan(&tl)
+		if err != nil {
+			return err
+		}
+		return fn(tl)
+	})
+}
+
+// StreamOffers calls `fn` for every offer matching `filter`, ordered by offer
+// id.
+func (q *Q) StreamOffers(filter StateFilter, fn func(Offer) error) error {
+	schemaVersion, err := q.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	sql := sq.Select("co.*").From("offers co").OrderBy("co.offerid")
+	if filter.AccountPrefix != "" {
+		sql = sql.Where("co.sellerid LIKE ?", filter.AccountPrefix+"%")
+	}
+
+	if filter.Asset != nil {
+		condition, err := offersForAsset(schemaVersion, *filter.Asset)
+		if err != nil {
+			return err
+		}
+		sql = sql.Where(condition)
+	}
+
+	return q.stream(sql, func(rows *sqlx.Rows) error {
+		var offer internalOffer
+		err := rows.StructScan(&offer)
+		if err != nil {
+			return err
+		}
+
+		if schemaVersion >= 9 {
+			return fn(offer.get())
+		}
+
+		converted, err := offer.schema8()
+		if err != nil {
+			return err
+		}
+		return fn(converted)
+	})
+}
+
+// StreamAccountData calls `fn` for every data entry matching `filter`,
+// ordered by account and key.
+func (q *Q) StreamAccountData(filter StateFilter, fn func(AccountData) error) error {
+	schemaVersion, err := q.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	sql := selectAccountData.OrderBy("ad.accountid", "ad.dataname")
+	if filter.AccountPrefix != "" {
+		sql = sql.Where("ad.accountid LIKE ?", filter.AccountPrefix+"%")
+	}
+
+	return q.stream(sql, func(rows *sqlx.Rows) error {
+		var data AccountData
+		err := rows.StructScan(&data)
+		if err != nil {
+			return err
+		}
+
+		if schemaVersion >= 9 {
+			// Since schema version 9, keys are base64 encoded.
+			decoded, err := base64.StdEncoding.DecodeString(data.Key)
+			if err != nil {
+				return errors.Wrap(err, "Error decoding data entry: "+data.Key)
+			}
+			data.Key = string(decoded)
+		}
+
+		return fn(data)
+	})
+}
+
+// stream runs `sql` and calls `fn` for every resulting row, without loading
+// the whole result set in memory.
+func (q *Q) stream(sql sq.SelectBuilder, fn func(*sqlx.Rows) error) error {
+	rows, err := q.Query(sql)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = fn(rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
+)
+
+func TestStreamState(t *testing.T) {
+	tt := test.Start(t).Scenario("trades")
+	defer tt.Finish()
+	q := &Q{tt.CoreSession()}
+
+	usd := xdr.MustNewCreditAsset("USD", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	native := xdr.MustNewNativeAsset()
+
+	var accounts []Account
+	err := q.StreamAccounts(StateFilter{}, func(a Account) error {
+		accounts = append(accounts, a)
+		return nil
+	})
+	if tt.Assert.NoError(err) && tt.Assert.Len(accounts, 5) {
+		tt.Assert.Equal("GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", accounts[0].Accountid)
+		tt.Assert.Equal("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", accounts[4].Accountid)
+	}
+
+	accounts = nil
+	err = q.StreamAccounts(StateFilter{AccountPrefix: "GC2"}, func(a Account) error {
+		accounts = append(accounts, a)
+		return nil
+	})
+	if tt.Assert.NoError(err) && tt.Assert.Len(accounts, 1) {
+		tt.Assert.Equal("GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4", accounts[0].Accountid)
+	}
+
+	var trustlines []Trustline
+	err = q.StreamTrustlines(StateFilter{Asset: &usd}, func(tl Trustline) error {
+		trustlines = append(trustlines, tl)
+		return nil
+	})
+	if tt.Assert.NoError(err) && tt.Assert.Len(trustlines, 2) {
+		for _, tl := range trustlines {
+			tt.Assert.Equal("USD", tl.Assetcode)
+		}
+	}
+
+	var offers []Offer
+	err = q.StreamOffers(StateFilter{Asset: &usd}, func(o Offer) error {
+		offers = append(offers, o)
+		return nil
+	})
+	if tt.Assert.NoError(err) && tt.Assert.Len(offers, 4) {
+		tt.Assert.Equal(int64(1), offers[0].OfferID)
+		tt.Assert.Equal(int64(4), offers[3].OfferID)
+	}
+
+	offers = nil
+	err = q.StreamOffers(StateFilter{Asset: &native, AccountPrefix: "GCX"}, func(o Offer) error {
+		offers = append(offers, o)
+		return nil
+	})
+	if tt.Assert.NoError(err) && tt.Assert.Len(offers, 1) {
+		tt.Assert.Equal(int64(4), offers[0].OfferID)
+		tt.Assert.True(offers[0].BuyingAsset.Equals(native))
+	}
+}
@@ -79,6 +79,12 @@ func (q *Q) TrustlinesByAddress(dest interface{}, addy string) error {
 	return q.Select(dest, sql)
 }
 
+// TrustlinesByAddresses loads all trustlines for every address of `addys`
+func (q *Q) TrustlinesByAddresses(dest interface{}, addys []string) error {
+	sql := selectTrustline.Where(sq.Eq{"accountid": addys})
+	return q.Select(dest, sql)
+}
+
 // BalancesForAsset returns all the balances by asset type, code, issuer
 func (q *Q) BalancesForAsset(
 	assetType int32,
@@ -99,6 +105,24 @@ func (q *Q) BalancesForAsset(
 	return result.Count, result.Sum, err
 }
 
+// UnauthorizedTrustlinesForAsset returns the number of trustlines to the asset
+// that are not authorized by its issuer.
+func (q *Q) UnauthorizedTrustlinesForAsset(
+	assetType int32,
+	assetCode string,
+	assetIssuer string,
+) (int32, error) {
+	sql := sq.Select("COUNT(*)").From("trustlines").Where(sq.Eq{
+		"assettype": assetType,
+		"assetcode": assetCode,
+		"issuer":    assetIssuer,
+		"flags":     0,
+	})
+	var count int32
+	err := q.Get(&count, sql)
+	return count, err
+}
+
 var selectTrustline = sq.Select(
 	"tl.accountid",
 	"tl.assettype",
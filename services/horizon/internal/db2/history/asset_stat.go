@@ -0,0 +1,35 @@
+package history
+
+import (
+	"time"
+)
+
+// UpdateAssetStatsVolume recomputes the traded volume and the number of trades
+// of every asset in the asset_stats table, using the trades that closed at or
+// after `since`.  Only the rows whose values changed are updated.
+func (q *Q) UpdateAssetStatsVolume(since time.Time) error {
+	_, err := q.ExecRaw(`
+		WITH volumes AS (
+			SELECT asset_id, SUM(amount) AS volume, COUNT(*) AS trade_count
+			FROM (
+				SELECT base_asset_id AS asset_id, base_amount AS amount
+				FROM history_trades WHERE ledger_closed_at >= $1
+				UNION ALL
+				SELECT counter_asset_id AS asset_id, counter_amount AS amount
+				FROM history_trades WHERE ledger_closed_at >= $1
+			) AS sides
+			GROUP BY asset_id
+		)
+		UPDATE asset_stats SET
+			volume_24h = COALESCE(volumes.volume, 0),
+			trade_count_24h = COALESCE(volumes.trade_count, 0)
+		FROM asset_stats AS current
+		LEFT JOIN volumes ON volumes.asset_id = current.id
+		WHERE asset_stats.id = current.id
+		AND (
+			asset_stats.volume_24h <> COALESCE(volumes.volume, 0) OR
+			asset_stats.trade_count_24h <> COALESCE(volumes.trade_count, 0)
+		)
+	`, since.UTC())
+	return err
+}
@@ -60,6 +60,16 @@ func (q *EffectsQ) ForAccount(aid string) *EffectsQ {
 	return q
 }
 
+// ForDataEntry filters the query to only the effects that created, updated or
+// removed the data entry named `name`.  Combine it with ForAccount to get the
+// history of the data entry of a single account.
+func (q *EffectsQ) ForDataEntry(name string) *EffectsQ {
+	q.sql = q.sql.
+		Where(sq.Eq{"heff.type": []EffectType{EffectDataCreated, EffectDataUpdated, EffectDataRemoved}}).
+		Where("heff.details->>'name' = ?", name)
+	return q
+}
+
 // ForLedger filters the query to only effects in a specific ledger,
 // specified by its sequence.
 func (q *EffectsQ) ForLedger(seq int32) *EffectsQ {
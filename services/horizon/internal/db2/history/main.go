@@ -8,6 +8,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
+	"github.com/lib/pq"
 	"github.com/stellar/go/support/db"
 	"github.com/stellar/go/xdr"
 )
@@ -130,11 +131,17 @@ type Asset struct {
 
 // AssetStat is a row in the asset_stats table representing the stats per Asset
 type AssetStat struct {
-	ID          int64  `db:"id"`
-	Amount      string `db:"amount"`
-	NumAccounts int32  `db:"num_accounts"`
-	Flags       int8   `db:"flags"`
-	Toml        string `db:"toml"`
+	ID                      int64  `db:"id"`
+	Amount                  string `db:"amount"`
+	NumAccounts             int32  `db:"num_accounts"`
+	NumUnauthorizedAccounts int32  `db:"num_unauthorized_accounts"`
+	NumOffers               int32  `db:"num_offers"`
+	Volume24h               string `db:"volume_24h"`
+	TradeCount24h           int32  `db:"trade_count_24h"`
+	LastPrice               string `db:"last_price"`
+	Flags                   int8   `db:"flags"`
+	HomeDomain              string `db:"home_domain"`
+	Toml                    string `db:"toml"`
 }
 
 // Effect is a row of data from the `history_effects` table
@@ -243,6 +250,14 @@ type Operation struct {
 	TransactionSuccessful *bool `db:"transaction_successful"`
 }
 
+// OperationParticipant is a row of data from the
+// `history_operation_participants` table, joined with the participating
+// account's address.
+type OperationParticipant struct {
+	OperationID int64  `db:"history_operation_id"`
+	Address     string `db:"address"`
+}
+
 // OperationsQ is a helper struct to aid in configuring queries that loads
 // slices of Operation structs.
 type OperationsQ struct {
@@ -336,6 +351,44 @@ type TransactionsQ struct {
 	includeFailed bool
 }
 
+// Webhook is a row of data from the `webhooks` table
+type Webhook struct {
+	ID             int64         `db:"id"`
+	URL            string        `db:"url"`
+	Secret         string        `db:"secret"`
+	Account        null.String   `db:"account"`
+	AssetType      null.String   `db:"asset_type"`
+	AssetCode      null.String   `db:"asset_code"`
+	AssetIssuer    null.String   `db:"asset_issuer"`
+	OperationTypes pq.Int64Array `db:"operation_types"`
+	LastLedger     int32         `db:"last_ledger"`
+	CreatedAt      time.Time     `db:"created_at"`
+}
+
+// WebhookDeadLetter is a row of data from the `webhook_dead_letters` table
+type WebhookDeadLetter struct {
+	ID          int64       `db:"id"`
+	WebhookID   int64       `db:"webhook_id"`
+	PagingToken string      `db:"paging_token"`
+	Payload     string      `db:"payload"`
+	Attempts    int32       `db:"attempts"`
+	LastError   null.String `db:"last_error"`
+	CreatedAt   time.Time   `db:"created_at"`
+	FailedAt    time.Time   `db:"failed_at"`
+}
+
+// WebhookDelivery is a row of data from the `webhook_deliveries` table
+type WebhookDelivery struct {
+	ID            int64       `db:"id"`
+	WebhookID     int64       `db:"webhook_id"`
+	PagingToken   string      `db:"paging_token"`
+	Payload       string      `db:"payload"`
+	Attempts      int32       `db:"attempts"`
+	NextAttemptAt time.Time   `db:"next_attempt_at"`
+	LastError     null.String `db:"last_error"`
+	CreatedAt     time.Time   `db:"created_at"`
+}
+
 // ElderLedger loads the oldest ledger known to the history database
 func (q *Q) ElderLedger(dest interface{}) error {
 	return q.GetRaw(dest, `SELECT COALESCE(MIN(sequence), 0) FROM history_ledgers`)
@@ -0,0 +1,108 @@
+package history
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/stellar/go/services/horizon/internal/toid"
+)
+
+// StreamTransactions calls `fn` for every transaction, failed ones included,
+// of the ledgers from `from` to `to` inclusive, in the order they were
+// applied.
+func (q *Q) StreamTransactions(from, to int32, fn func(Transaction) error) error {
+	start, end := ledgerRangeIDs(from, to)
+	sql := selectTransaction.
+		Where("ht.id >= ? AND ht.id < ?", start, end).
+		OrderBy("ht.id asc")
+
+	return q.stream(sql, func(rows *sqlx.Rows) error {
+		var tx Transaction
+		err := rows.StructScan(&tx)
+		if err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// StreamOperations calls `fn` for every operation, including the operations
+// of failed transactions, of the ledgers from `from` to `to` inclusive, in the
+// order they were applied.
+func (q *Q) StreamOperations(from, to int32, fn func(Operation) error) error {
+	start, end := ledgerRangeIDs(from, to)
+	sql := selectOperation.
+		Where("hop.id >= ? AND hop.id < ?", start, end).
+		OrderBy("hop.id asc")
+
+	return q.stream(sql, func(rows *sqlx.Rows) error {
+		var op Operation
+		err := rows.StructScan(&op)
+		if err != nil {
+			return err
+		}
+		return fn(op)
+	})
+}
+
+// StreamEffects calls `fn` for every effect of the ledgers from `from` to `to`
+// inclusive, in the order they occurred.
+func (q *Q) StreamEffects(from, to int32, fn func(Effect) error) error {
+	start, end := ledgerRangeIDs(from, to)
+	sql := selectEffect.
+		Where("heff.history_operation_id >= ? AND heff.history_operation_id < ?", start, end).
+		OrderBy("heff.history_operation_id asc, heff.order asc")
+
+	return q.stream(sql, func(rows *sqlx.Rows) error {
+		var effect Effect
+		err := rows.StructScan(&effect)
+		if err != nil {
+			return err
+		}
+		return fn(effect)
+	})
+}
+
+// StreamTrades calls `fn` for every trade of the ledgers from `from` to `to`
+// inclusive, in the order they occurred.
+func (q *Q) StreamTrades(from, to int32, fn func(Trade) error) error {
+	start, end := ledgerRangeIDs(from, to)
+	sql := q.Trades().sql.
+		Where("htrd.history_operation_id >= ? AND htrd.history_operation_id < ?", start, end).
+		OrderBy("htrd.history_operation_id asc", `htrd."order" asc`)
+
+	return q.stream(sql, func(rows *sqlx.Rows) error {
+		var trade Trade
+		err := rows.StructScan(&trade)
+		if err != nil {
+			return err
+		}
+		return fn(trade)
+	})
+}
+
+// ledgerRangeIDs returns the range of total order ids, `start` inclusive and
+// `end` exclusive, of the ledgers from `from` to `to` inclusive.
+func ledgerRangeIDs(from, to int32) (start int64, end int64) {
+	first := toid.ID{LedgerSequence: from}
+	last := toid.ID{LedgerSequence: to + 1}
+	return first.ToInt64(), last.ToInt64()
+}
+
+// stream calls `fn` for every row loaded by `sql`, without loading all of them
+// in memory.
+func (q *Q) stream(sql sq.SelectBuilder, fn func(*sqlx.Rows) error) error {
+	rows, err := q.Query(sql)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = fn(rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
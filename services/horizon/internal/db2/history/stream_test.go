@@ -0,0 +1,67 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestStreamQueries(t *testing.T) {
+	tt := test.Start(t).Scenario("failed_transactions")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var latest int32
+	tt.Require.NoError(q.LatestLedger(&latest))
+
+	count := func(table string) (result int) {
+		tt.Require.NoError(q.GetRaw(&result, "SELECT COUNT(*) FROM "+table))
+		return
+	}
+
+	var txs []Transaction
+	err := q.StreamTransactions(1, latest, func(tx Transaction) error {
+		txs = append(txs, tx)
+		return nil
+	})
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(txs, count("history_transactions"))
+		for i := 1; i < len(txs); i++ {
+			tt.Assert.True(txs[i-1].ID < txs[i].ID)
+		}
+	}
+
+	var ops []Operation
+	err = q.StreamOperations(1, latest, func(op Operation) error {
+		ops = append(ops, op)
+		return nil
+	})
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, count("history_operations"))
+	}
+
+	var effects []Effect
+	err = q.StreamEffects(1, latest, func(effect Effect) error {
+		effects = append(effects, effect)
+		return nil
+	})
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(effects, count("history_effects"))
+	}
+
+	// ledgers outside of the range are not streamed
+	txs = nil
+	err = q.StreamTransactions(latest+1, latest+10, func(tx Transaction) error {
+		txs = append(txs, tx)
+		return nil
+	})
+	if tt.Assert.NoError(err) {
+		tt.Assert.Empty(txs)
+	}
+
+	err = q.StreamTransactions(latest, latest, func(tx Transaction) error {
+		tt.Assert.Equal(latest, tx.LedgerSequence)
+		return nil
+	})
+	tt.Assert.NoError(err)
+}
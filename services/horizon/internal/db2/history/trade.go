@@ -3,6 +3,7 @@ package history
 import (
 	"fmt"
 	"math"
+	"math/big"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/stellar/go/services/horizon/internal/db2"
@@ -300,6 +301,35 @@ func (q *Q) InsertTrade(
 	return nil
 }
 
+// LastTradePrice returns the price, in units of `quoteAssetID`, of the most
+// recent trade between the two assets.  An empty string is returned when the
+// assets never traded.
+func (q *Q) LastTradePrice(assetID int64, quoteAssetID int64) (string, error) {
+	orderPreserved, baseAssetID, counterAssetID := getCanonicalAssetOrder(assetID, quoteAssetID)
+	sql := sq.Select("base_amount", "counter_amount").
+		From("history_trades").
+		Where(sq.Eq{"base_asset_id": baseAssetID, "counter_asset_id": counterAssetID}).
+		OrderBy("history_operation_id DESC", `"order" DESC`).
+		Limit(1)
+
+	var trade struct {
+		BaseAmount    int64 `db:"base_amount"`
+		CounterAmount int64 `db:"counter_amount"`
+	}
+	err := q.Get(&trade, sql)
+	if q.NoRows(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "could not load last trade")
+	}
+
+	if orderPreserved {
+		return big.NewRat(trade.CounterAmount, trade.BaseAmount).FloatString(7), nil
+	}
+	return big.NewRat(trade.BaseAmount, trade.CounterAmount).FloatString(7), nil
+}
+
 func getCanonicalAssetOrder(assetId1 int64, assetId2 int64) (orderPreserved bool, baseAssetId int64, counterAssetId int64) {
 	if assetId1 < assetId2 {
 		return true, assetId1, assetId2
@@ -5,27 +5,48 @@ import (
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/support/errors"
 	strtime "github.com/stellar/go/support/time"
 	"github.com/stellar/go/xdr"
 )
 
-// AllowedResolutions is the set of trade aggregation time windows allowed to be used as the
-// `resolution` parameter.
-var AllowedResolutions = map[time.Duration]struct{}{
-	time.Minute:        {}, //1 minute
-	time.Minute * 5:    {}, //5 minutes
-	time.Minute * 15:   {}, //15 minutes
-	time.Hour:          {}, //1 hour
-	time.Hour * 24:     {}, //day
-	time.Hour * 24 * 7: {}, //week
-}
+// ResolutionUnit is the smallest trade aggregation time window.  Offsets
+// must be multiples of it and, when StrictResolutionFiltering is enabled,
+// resolutions too.
+const ResolutionUnit = time.Minute
 
 // StrictResolutionFiltering represents a simple feature flag to determine whether only
-// predetermined resolutions of trade aggregations are allowed.
+// resolutions that are multiples of ResolutionUnit are allowed.
 var StrictResolutionFiltering = true
 
+// tradesPerMinuteTable is the table of trades aggregated per minute and asset
+// pair, maintained by RebuildTradeAggregations.
+const tradesPerMinuteTable = "history_trades_60000"
+
+// ValidResolution returns true if `resolution` (in milliseconds) is allowed
+// to be used as the `resolution` parameter of trade aggregations.
+func ValidResolution(resolution int64) bool {
+	resolutionDuration := time.Duration(resolution) * time.Millisecond
+	if resolutionDuration <= 0 {
+		return false
+	}
+	return !StrictResolutionFiltering || resolutionDuration%ResolutionUnit == 0
+}
+
+// ValidOffset returns true if `offset` (in milliseconds) is allowed to be used
+// as the `offset` parameter of trade aggregations of the given resolution.
+// Offset must be 1) a multiple of a minute 2) less than or equal to the
+// resolution and 3) less than 24 hours.
+func ValidOffset(offset int64, resolution int64) bool {
+	offsetDuration := time.Duration(offset) * time.Millisecond
+	return offsetDuration >= 0 &&
+		offsetDuration%ResolutionUnit == 0 &&
+		offsetDuration < time.Hour*24 &&
+		offset <= resolution
+}
+
 // TradeAggregation represents an aggregation of trades from the trades table
 type TradeAggregation struct {
 	Timestamp     int64     `db:"timestamp"`
@@ -55,19 +76,12 @@ type TradeAggregationsQ struct {
 func (q Q) GetTradeAggregationsQ(baseAssetID int64, counterAssetID int64, resolution int64,
 	offset int64, pagingParams db2.PageQuery) (*TradeAggregationsQ, error) {
 
-	//convert resolution to a duration struct
-	resolutionDuration := time.Duration(resolution) * time.Millisecond
-	offsetDuration := time.Duration(offset) * time.Millisecond
-
 	//check if resolution allowed
-	if StrictResolutionFiltering {
-		if _, ok := AllowedResolutions[resolutionDuration]; !ok {
-			return &TradeAggregationsQ{}, errors.New("resolution is not allowed")
-		}
-	}
-	// check if offset is allowed. Offset must be 1) a multiple of an hour 2) less than the resolution and 3)
-	// less than 24 hours
-	if offsetDuration%time.Hour != 0 || offsetDuration >= time.Hour*24 || offsetDuration > resolutionDuration {
+	if !ValidResolution(resolution) {
+		return &TradeAggregationsQ{}, errors.New("resolution is not allowed")
+	}
+	// check if offset is allowed
+	if !ValidOffset(offset, resolution) {
 		return &TradeAggregationsQ{}, errors.New("offset is not allowed.")
 	}
 
@@ -117,11 +131,18 @@ func (q *TradeAggregationsQ) WithEndTime(endTime strtime.Millis) (*TradeAggregat
 	}
 }
 
-// GetSql generates a sql statement to aggregate Trades based on given parameters
+// GetSql generates a sql statement to aggregate Trades based on given parameters.
+// When the resolution and the offset are multiples of a minute, the per minute
+// aggregations maintained during ingestion are used instead of the trades.
 func (q *TradeAggregationsQ) GetSql() sq.SelectBuilder {
 	var orderPreserved bool
 	orderPreserved, q.baseAssetID, q.counterAssetID = getCanonicalAssetOrder(q.baseAssetID, q.counterAssetID)
 
+	minute := int64(time.Minute / time.Millisecond)
+	if q.resolution%minute == 0 && q.offset%minute == 0 {
+		return q.getSqlFromMinutes(orderPreserved)
+	}
+
 	var bucketSQL sq.SelectBuilder
 	if orderPreserved {
 		bucketSQL = bucketTrades(q.resolution, q.offset)
@@ -158,6 +179,116 @@ func (q *TradeAggregationsQ) GetSql() sq.SelectBuilder {
 		OrderBy("timestamp " + q.pagingParams.Order)
 }
 
+// getSqlFromMinutes generates a sql statement to aggregate the per minute
+// aggregations of trades.
+func (q *TradeAggregationsQ) getSqlFromMinutes(orderPreserved bool) sq.SelectBuilder {
+	var bucketSQL sq.SelectBuilder
+	if orderPreserved {
+		bucketSQL = bucketMinutes(q.resolution, q.offset)
+	} else {
+		bucketSQL = reverseBucketMinutes(q.resolution, q.offset)
+	}
+
+	bucketSQL = bucketSQL.From(tradesPerMinuteTable + " htrd60").
+		Where(sq.Eq{"htrd60.base_asset_id": q.baseAssetID, "htrd60.counter_asset_id": q.counterAssetID})
+
+	//apply time filters
+	bucketSQL = bucketSQL.Where(sq.GtOrEq{"htrd60.timestamp": q.startTime.ToInt64()})
+	if !q.endTime.IsNil() {
+		bucketSQL = bucketSQL.Where(sq.Lt{"htrd60.timestamp": q.endTime.ToInt64()})
+	}
+
+	//ensure open/close order
+	bucketSQL = bucketSQL.OrderBy("htrd60.timestamp")
+
+	return sq.Select(
+		"timestamp",
+		"sum(count) as count",
+		"sum(base_volume) as base_volume",
+		"sum(counter_volume) as counter_volume",
+		"sum(counter_volume)/sum(base_volume) as avg",
+		"max_price(high) as high",
+		"min_price(low) as low",
+		"first(open)  as open",
+		"last(close) as close",
+	).
+		FromSelect(bucketSQL, "htrd").
+		GroupBy("timestamp").
+		Limit(q.pagingParams.Limit).
+		OrderBy("timestamp " + q.pagingParams.Order)
+}
+
+// RebuildTradeAggregations recomputes the per minute aggregations of the
+// trades that closed between `from` and `to` (inclusive), replacing the
+// existing aggregations of these minutes.
+func (q *Q) RebuildTradeAggregations(from, to time.Time) error {
+	start := from.UTC().Truncate(time.Minute)
+	end := to.UTC().Truncate(time.Minute).Add(time.Minute)
+	startMillis := start.UnixNano() / int64(time.Millisecond)
+	endMillis := end.UnixNano() / int64(time.Millisecond)
+
+	_, err := q.ExecRaw(
+		`DELETE FROM `+tradesPerMinuteTable+` WHERE "timestamp" >= ? AND "timestamp" < ?`,
+		startMillis, endMillis,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete trade aggregations")
+	}
+
+	_, err = q.ExecRaw(`
+		INSERT INTO `+tradesPerMinuteTable+`
+		SELECT
+			"timestamp",
+			base_asset_id,
+			counter_asset_id,
+			count(*),
+			sum(base_amount),
+			sum(counter_amount),
+			max_price(price),
+			min_price(price),
+			first(price),
+			last(price)
+		FROM (
+			SELECT
+				`+formatBucketTimestampSelect(int64(time.Minute/time.Millisecond), 0)+`,
+				base_asset_id,
+				counter_asset_id,
+				base_amount,
+				counter_amount,
+				ARRAY[price_n, price_d] as price
+			FROM history_trades
+			WHERE ledger_closed_at >= ? AND ledger_closed_at < ?
+			ORDER BY history_operation_id, "order"
+		) AS htrd
+		GROUP BY base_asset_id, counter_asset_id, "timestamp"
+	`, start, end)
+	return errors.Wrap(err, "failed to insert trade aggregations")
+}
+
+// TradesClosedAtRange returns the close times of the first and last trades of
+// the operations with ids in [start, end).  ok is false when there are none.
+func (q *Q) TradesClosedAtRange(start, end int64) (from, to time.Time, ok bool, err error) {
+	var closedAt struct {
+		From pq.NullTime `db:"from"`
+		To   pq.NullTime `db:"to"`
+	}
+
+	err = q.GetRaw(&closedAt, `
+		SELECT min(ledger_closed_at) as "from", max(ledger_closed_at) as "to"
+		FROM history_trades
+		WHERE history_operation_id >= ? AND history_operation_id < ?
+	`, start, end)
+	if err != nil {
+		return from, to, false, errors.Wrap(err, "failed to load trades close times")
+	}
+
+	if !closedAt.From.Valid || !closedAt.To.Valid {
+		return from, to, false, nil
+	}
+
+	return closedAt.From.Time, closedAt.To.Time, true, nil
+}
+
 // formatBucketTimestampSelect formats a sql select clause for a bucketed timestamp, based on given resolution
 // and the offset. Given a time t, it gives it a timestamp defined by
 // f(t) = ((t - offset)/resolution)*resolution + offset.
@@ -195,3 +326,42 @@ func reverseBucketTrades(resolution int64, offset int64) sq.SelectBuilder {
 		"ARRAY[price_d, price_n] as price",
 	)
 }
+
+// formatMinuteBucketTimestampSelect is the equivalent of
+// formatBucketTimestampSelect for per minute aggregations.
+func formatMinuteBucketTimestampSelect(resolution int64, offset int64) string {
+	return fmt.Sprintf("div(htrd60.timestamp - %d, %d)*%d + %d as timestamp",
+		offset, resolution, resolution, offset)
+}
+
+// bucketMinutes generates a select statement to filter rows from the per
+// minute aggregations table, with a timestamp rounded to resolution.
+func bucketMinutes(resolution int64, offset int64) sq.SelectBuilder {
+	return sq.Select(
+		formatMinuteBucketTimestampSelect(resolution, offset),
+		"htrd60.count",
+		"htrd60.base_volume",
+		"htrd60.counter_volume",
+		"htrd60.high",
+		"htrd60.low",
+		"htrd60.open",
+		"htrd60.close",
+	)
+}
+
+// reverseBucketMinutes generates a select statement to filter rows from the
+// per minute aggregations table, with a timestamp rounded to resolution and
+// reversed base/counter.
+func reverseBucketMinutes(resolution int64, offset int64) sq.SelectBuilder {
+	return sq.Select(
+		formatMinuteBucketTimestampSelect(resolution, offset),
+		"htrd60.count",
+		"htrd60.counter_volume as base_volume",
+		"htrd60.base_volume as counter_volume",
+		// the highest price of the reversed pair is the inverse of the lowest one
+		"ARRAY[htrd60.low[2], htrd60.low[1]] as high",
+		"ARRAY[htrd60.high[2], htrd60.high[1]] as low",
+		"ARRAY[htrd60.open[2], htrd60.open[1]] as open",
+		"ARRAY[htrd60.close[2], htrd60.close[1]] as close",
+	)
+}
@@ -0,0 +1,35 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidResolution(t *testing.T) {
+	minute := int64(time.Minute / time.Millisecond)
+
+	assert.True(t, ValidResolution(minute))
+	assert.True(t, ValidResolution(90*minute))
+	assert.True(t, ValidResolution(7*24*60*minute))
+	assert.False(t, ValidResolution(0))
+	assert.False(t, ValidResolution(-minute))
+	assert.False(t, ValidResolution(minute/2))
+	assert.False(t, ValidResolution(minute+1))
+}
+
+func TestValidOffset(t *testing.T) {
+	minute := int64(time.Minute / time.Millisecond)
+	hour := 60 * minute
+
+	assert.True(t, ValidOffset(0, minute))
+	assert.True(t, ValidOffset(30*minute, 90*minute))
+	assert.True(t, ValidOffset(hour, hour))
+	assert.True(t, ValidOffset(23*hour, 7*24*hour))
+	assert.False(t, ValidOffset(-minute, hour))
+	assert.False(t, ValidOffset(minute/2, hour))
+	assert.False(t, ValidOffset(2*hour, hour))
+	assert.False(t, ValidOffset(24*hour, 7*24*hour))
+}
@@ -26,6 +26,13 @@ func (q *Q) TransactionByHash(dest interface{}, hash string) error {
 	return q.Get(dest, sql)
 }
 
+// TransactionsByHashes loads the rows from `history_transactions` of every
+// hash of `hashes`.  Hashes without a transaction are omitted.
+func (q *Q) TransactionsByHashes(dest interface{}, hashes []string) error {
+	sql := selectTransaction.Where(sq.Eq{"ht.transaction_hash": hashes})
+	return q.Select(dest, sql)
+}
+
 // Transactions provides a helper to filter rows from the `history_transactions`
 // table with pre-defined filters.  See `TransactionsQ` methods for the
 // available filters.
@@ -0,0 +1,127 @@
+package history
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/xdr"
+)
+
+// DerivedBalance is the balance of an account in an asset, as derived from the
+// effects of the account.  Amount is a decimal string, in units of the asset.
+type DerivedBalance struct {
+	AssetType   string `db:"asset_type"`
+	AssetCode   string `db:"asset_code"`
+	AssetIssuer string `db:"asset_issuer"`
+	Amount      string `db:"amount"`
+}
+
+// LedgerChainBreak is a ledger whose previous ledger hash is not the hash of
+// the previous ledger in the `history_ledgers` table.
+type LedgerChainBreak struct {
+	Sequence           int32  `db:"sequence"`
+	PreviousLedgerHash string `db:"previous_ledger_hash"`
+	ActualPreviousHash string `db:"actual_previous_hash"`
+}
+
+// SampledAssetStat is a row of the `asset_stats` table, along with the asset
+// it describes.
+type SampledAssetStat struct {
+	AssetType               string `db:"asset_type"`
+	AssetCode               string `db:"asset_code"`
+	AssetIssuer             string `db:"asset_issuer"`
+	Amount                  string `db:"amount"`
+	NumAccounts             int32  `db:"num_accounts"`
+	NumUnauthorizedAccounts int32  `db:"num_unauthorized_accounts"`
+}
+
+// DerivedBalances loads the balances of `address`, whose history id is
+// `accountID`, derived from its creation, credit, debit and trade effects.
+// The trade effects of the path payments sent by the account are ignored, as
+// the debit effect of the payment already accounts for them.  Transaction
+// fees are not effects, so native balances do not account for the fees paid
+// by the account: see FeesPaid.
+func (q *Q) DerivedBalances(dest *[]DerivedBalance, accountID int64, address string) error {
+	return q.SelectRaw(dest, `
+		WITH trade_effects AS (
+			SELECT heff.details
+			FROM history_effects heff
+			JOIN history_operations hop ON hop.id = heff.history_operation_id
+			WHERE heff.history_account_id = $1 AND heff.type = $6
+			AND NOT (hop.type = $7 AND hop.source_account = $2)
+		)
+		SELECT asset_type, asset_code, asset_issuer, SUM(amount)::text AS amount
+		FROM (
+			SELECT 'native' AS asset_type, '' AS asset_code, '' AS asset_issuer,
+				(details->>'starting_balance')::numeric AS amount
+			FROM history_effects WHERE history_account_id = $1 AND type = $3
+			UNION ALL
+			SELECT details->>'asset_type', COALESCE(details->>'asset_code', ''), COALESCE(details->>'asset_issuer', ''),
+				(details->>'amount')::numeric
+			FROM history_effects WHERE history_account_id = $1 AND type = $4
+			UNION ALL
+			SELECT details->>'asset_type', COALESCE(details->>'asset_code', ''), COALESCE(details->>'asset_issuer', ''),
+				-(details->>'amount')::numeric
+			FROM history_effects WHERE history_account_id = $1 AND type = $5
+			UNION ALL
+			SELECT heff.details->>'bought_asset_type', COALESCE(heff.details->>'bought_asset_code', ''), COALESCE(heff.details->>'bought_asset_issuer', ''),
+				(heff.details->>'bought_amount')::numeric
+			FROM trade_effects heff
+			UNION ALL
+			SELECT heff.details->>'sold_asset_type', COALESCE(heff.details->>'sold_asset_code', ''), COALESCE(heff.details->>'sold_asset_issuer', ''),
+				-(heff.details->>'sold_amount')::numeric
+			FROM trade_effects heff
+		) AS changes
+		GROUP BY asset_type, asset_code, asset_issuer
+	`, accountID, address, EffectAccountCreated, EffectAccountCredited, EffectAccountDebited, EffectTrade, xdr.OperationTypePathPayment)
+}
+
+// CountAccountEffects loads the number of effects of type `typ` of the account
+// with the history id `accountID`.
+func (q *Q) CountAccountEffects(dest *int, accountID int64, typ EffectType) error {
+	sql := sq.Select("COUNT(*)").From("history_effects").Where(sq.Eq{
+		"history_account_id": accountID,
+		"type":               typ,
+	})
+	return q.Get(dest, sql)
+}
+
+// FeesPaid loads the total fees, in stroops, paid by `address` for the
+// transactions it submitted.
+func (q *Q) FeesPaid(dest *int64, address string) error {
+	sql := sq.Select("COALESCE(SUM(fee_paid), 0)").
+		From("history_transactions").
+		Where("account = ?", address)
+	return q.Get(dest, sql)
+}
+
+// LedgerChainBreaks loads the ledgers, from ledger `from`, whose previous
+// ledger hash is not the hash of the previous ledger.
+func (q *Q) LedgerChainBreaks(dest *[]LedgerChainBreak, from int32) error {
+	sql := sq.Select(
+		"l.sequence",
+		"COALESCE(l.previous_ledger_hash, '') AS previous_ledger_hash",
+		"p.ledger_hash AS actual_previous_hash",
+	).
+		From("history_ledgers l").
+		Join("history_ledgers p ON p.sequence = l.sequence - 1").
+		Where("l.sequence >= ?", from).
+		Where("l.previous_ledger_hash IS DISTINCT FROM p.ledger_hash").
+		OrderBy("l.sequence")
+	return q.Select(dest, sql)
+}
+
+// RandomAssetStats loads up to `n` random rows of the `asset_stats` table.
+func (q *Q) RandomAssetStats(dest *[]SampledAssetStat, n uint64) error {
+	sql := sq.Select(
+		"ha.asset_type",
+		"ha.asset_code",
+		"ha.asset_issuer",
+		"ast.amount",
+		"ast.num_accounts",
+		"ast.num_unauthorized_accounts",
+	).
+		From("asset_stats ast").
+		Join("history_assets ha ON ha.id = ast.id").
+		OrderBy("random()").
+		Limit(n)
+	return q.Select(dest, sql)
+}
@@ -0,0 +1,171 @@
+package history
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/toid"
+)
+
+// Webhooks loads all registered webhooks into `dest`, ordered by id.
+func (q *Q) Webhooks(dest interface{}) error {
+	sql := selectWebhook.OrderBy("wh.id asc")
+	return q.Select(dest, sql)
+}
+
+// WebhooksPage loads a page of registered webhooks into `dest`.
+func (q *Q) WebhooksPage(dest interface{}, page db2.PageQuery) error {
+	sql, err := page.ApplyTo(selectWebhook, "wh.id")
+	if err != nil {
+		return err
+	}
+
+	return q.Select(dest, sql)
+}
+
+// WebhookByID loads a single webhook with `id` into `dest`.
+func (q *Q) WebhookByID(dest interface{}, id int64) error {
+	sql := selectWebhook.Limit(1).Where("wh.id = ?", id)
+	return q.Get(dest, sql)
+}
+
+// InsertWebhook inserts a new webhook, populating `dest` with the stored row.
+func (q *Q) InsertWebhook(dest *Webhook) error {
+	sql := sq.Insert("webhooks").
+		Columns(
+			"url",
+			"secret",
+			"account",
+			"asset_type",
+			"asset_code",
+			"asset_issuer",
+			"operation_types",
+			"last_ledger",
+			"created_at",
+		).
+		Values(
+			dest.URL,
+			dest.Secret,
+			dest.Account,
+			dest.AssetType,
+			dest.AssetCode,
+			dest.AssetIssuer,
+			dest.OperationTypes,
+			dest.LastLedger,
+			time.Now().UTC(),
+		).
+		Suffix("RETURNING *")
+
+	return q.Get(dest, sql)
+}
+
+// DeleteWebhook removes the webhook with `id`, along with any pending
+// deliveries and dead letters.
+func (q *Q) DeleteWebhook(id int64) error {
+	_, err := q.Exec(sq.Delete("webhooks").Where("id = ?", id))
+	return err
+}
+
+// UpdateWebhooksLastLedger records that the webhooks identified by `ids` have
+// had all events up to and including ledger `seq` enqueued.
+func (q *Q) UpdateWebhooksLastLedger(ids []int64, seq int32) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	sql := sq.Update("webhooks").
+		Set("last_ledger", seq).
+		Where(sq.Eq{"id": ids})
+
+	_, err := q.Exec(sql)
+	return err
+}
+
+// InsertWebhookDelivery enqueues a payload for delivery to a webhook.
+func (q *Q) InsertWebhookDelivery(webhookID int64, pagingToken, payload string) error {
+	now := time.Now().UTC()
+	sql := sq.Insert("webhook_deliveries").
+		Columns("webhook_id", "paging_token", "payload", "next_attempt_at", "created_at").
+		Values(webhookID, pagingToken, payload, now, now)
+
+	_, err := q.Exec(sql)
+	return err
+}
+
+// DueWebhookDeliveries loads at most `limit` deliveries whose next attempt is
+// due at or before `now`.
+func (q *Q) DueWebhookDeliveries(dest interface{}, now time.Time, limit uint64) error {
+	sql := sq.Select("whd.*").
+		From("webhook_deliveries whd").
+		Where("whd.next_attempt_at <= ?", now.UTC()).
+		OrderBy("whd.next_attempt_at asc, whd.id asc").
+		Limit(limit)
+
+	return q.Select(dest, sql)
+}
+
+// RescheduleWebhookDelivery records a failed attempt for delivery `id` and
+// schedules the next attempt at `next`.
+func (q *Q) RescheduleWebhookDelivery(id int64, next time.Time, lastError string) error {
+	_, err := q.ExecRaw(`
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3
+		WHERE id = $1
+	`, id, next.UTC(), lastError)
+	return err
+}
+
+// DeleteWebhookDelivery removes a delivery, typically after it succeeded.
+func (q *Q) DeleteWebhookDelivery(id int64) error {
+	_, err := q.Exec(sq.Delete("webhook_deliveries").Where("id = ?", id))
+	return err
+}
+
+// MoveWebhookDeliveryToDeadLetters removes delivery `id` from the delivery
+// queue and records it in the `webhook_dead_letters` table.
+func (q *Q) MoveWebhookDeliveryToDeadLetters(id int64, lastError string) error {
+	_, err := q.ExecRaw(`
+		WITH moved AS (
+			DELETE FROM webhook_deliveries WHERE id = $1 RETURNING *
+		)
+		INSERT INTO webhook_dead_letters
+			(webhook_id, paging_token, payload, attempts, last_error, created_at, failed_at)
+		SELECT webhook_id, paging_token, payload, attempts + 1, $2, created_at, $3
+		FROM moved
+	`, id, lastError, time.Now().UTC())
+	return err
+}
+
+// WebhookDeadLetters loads a page of dead letters for a webhook into `dest`.
+func (q *Q) WebhookDeadLetters(dest interface{}, webhookID int64, page db2.PageQuery) error {
+	sql := sq.Select("whdl.*").
+		From("webhook_dead_letters whdl").
+		Where("whdl.webhook_id = ?", webhookID)
+
+	sql, err := page.ApplyTo(sql, "whdl.id")
+	if err != nil {
+		return err
+	}
+
+	return q.Select(dest, sql)
+}
+
+// OperationParticipantsByLedger loads the participants of every operation in
+// ledger `seq` into `dest`.
+func (q *Q) OperationParticipantsByLedger(dest interface{}, seq int32) error {
+	start := toid.ID{LedgerSequence: seq}
+	end := toid.ID{LedgerSequence: seq + 1}
+	sql := sq.Select("hopp.history_operation_id", "ha.address").
+		From("history_operation_participants hopp").
+		Join("history_accounts ha ON ha.id = hopp.history_account_id").
+		Where(
+			"hopp.history_operation_id >= ? AND hopp.history_operation_id < ?",
+			start.ToInt64(),
+			end.ToInt64(),
+		)
+
+	return q.Select(dest, sql)
+}
+
+var selectWebhook = sq.Select("wh.*").From("webhooks wh")
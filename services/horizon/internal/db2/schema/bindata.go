@@ -8,6 +8,9 @@
 // migrations/14_fix_asset_toml_field.sql
 // migrations/15_ledger_failed_txs.sql
 // migrations/16_ingest_failed_transactions.sql
+// migrations/17_webhooks.sql
+// migrations/18_asset_stats_details.sql
+// migrations/19_trade_aggregations.sql
 // migrations/1_initial_schema.sql
 // migrations/2_index_participants_by_toid.sql
 // migrations/3_use_sequence_in_history_accounts.sql
@@ -85,7 +88,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5c\x6d\x6f\xdb\x38\x12\xfe\x9e\x5f\x41\x2c\x0a\xc4\xc6\x39\x3d\xcb\x89\xd3\xbc\x6c\x0b\x78\x1d\x35\x35\xd6\x55\xba\xb6\x7c\xdd\xa2\x28\x08\x5a\xa2\x6d\x5e\x65\x51\x15\xe9\x6e\xb2\x87\xfb\xef\x07\xbd\x5a\x2f\xa4\x28\xd9\x4a\x7b\xdf\x62\x6b\xfc\xcc\x33\xc3\x19\x0e\x39\xa4\x72\x76\x76\x72\x76\x06\x3e\x50\xc6\xd7\x3e\x9e\xff\x31\x05\x36\xe2\x68\x89\x18\x06\xf6\x6e\xeb\x9d\x9c\x9d\x9d\x04\xcf\xef\x76\x5b\x0f\xdb\x60\xe5\xd3\xed\x5e\xe0\x3b\xf6\x19\xa1\x2e\xb8\x7e\x79\xf9\x52\xcb\x48\x2d\x9f\x80\xb7\x86\xc1\xcf\x0b\x22\x27\x73\xdd\x04\x8c\x23\x8e\xb7\xd8\xe5\x90\x93\x2d\xa6\x3b\x0e\x5e\x83\xfe\x6d\xf8\xc8\xa1\xd6\xd7\xf2\xb7\x96\x43\x02\x69\xec\x5a\xd4\x26\xee\x1a\xbc\x06\xa7\x0b\xf3\xed\xd5\xe9\x6d\x02\xe7\xda\xc8\xb7\xa1\x45\xdd\x15\xf5\xb7\xc4\x5d\x43\xc6\x7d\xe2\xae\x19\x78\x0d\xa8\x1b\x63\x6c\xb0\xf5\x15\xae\x76\xae\xc5\x09\x75\xe1\x92\xda\x04\x07\xcf\x57\xc8\x61\x38\xa7\x66\x4b\x5c\xb8\xc5\x8c\xa1\x75\x28\xf0\x17\xf2\x5d\xe2\xae\x6f\x63\xee\x18\xf9\xd6\x06\x7a\x88\x6f\xc0\x6b\xe0\xed\x96\x0e\xb1\x7a\x81\xb1\x16\xe2\xc8\xa1\x81\x58\xe4\x4f\x03\x6d\xf1\x0d\x58\x11\x9f\x71\x88\xd6\xeb\x0e\x72\x9f\xb0\x13\x5a\xdd\x03\xfb\xbf\xbb\xb7\xc0\x7c\xf2\xf0\x0d\x78\xbb\x30\xc6\xe6\xe4\xc1\xb8\x05\x73\x6b\x83\xb7\xe8\x26\xc6\xbe\x05\x0f\x7f\xb9\xd8\xbf\x01\x67\xe1\x40\x8c\x67\xfa\xc8\xd4\x53\x69\x35\x3e\x98\xe9\xe6\x62\x66\xcc\x33\xdf\x9d\x00\x00\xc0\x74\x64\xdc\x2f\x46\xf7\x3a\x60\xdf\x1c\x30\x79\xff\x7e\x61\x8e\x7e\x9b\xea\x60\x6e\xce\x26\x63\x33\x94\x18\xcd\xc1\x0b\xf8\x02\xcc\xf5\xa9\x3e\x36\xc1\x0b\x2d\xf8\x74\x7b\x92\x37\xcf\x41\xcf\x6a\x9d\x0a\xbe\x35\xe3\x06\x22\xe3\xb6\xe8\x11\x7a\x3e\xb1\x70\x48\xc1\xdd\x6d\xb1\x4f\xac\xcf\x5f\x7a\x20\xfd\xf3\x58\xfb\x6a\x68\x48\x4d\x4c\xbf\x3a\xc8\xc2\xce\x09\x00\xe3\xd1\x5c\x07\x1f\xdf\xe9\x06\x78\xa1\x7d\xd6\xbe\xfc\xf3\x85\xf6\x79\xf0\xe5\xcd\x8b\x41\xf8\xf7\xe0\xf3\xe0\x0b\x30\xa3\x87\x40\x9f\xce\xf5\xc0\x29\xba\x71\xd7\x15\x7a\x86\xb8\xcf\xed\x19\xb5\x86\xe7\xf6\xcc\xaf\x87\x78\x26\xcc\xc7\x8e\x20\x03\x46\xf7\xf7\x33\xfd\x7e\x64\xea\xf5\x1c\x91\x8a\x97\x11\x43\xc6\x00\xcc\x03\x5f\x05\xf3\x57\x32\x03\xf4\xa2\xaf\xcd\x4f\x1f\x74\xf0\x3a\x9b\x11\x5d\x51\xd6\xb6\xca\xb1\x08\x58\xa0\x98\xa4\x71\x7d\x86\x69\x62\x74\xca\x11\x75\x30\x4b\x11\x68\x81\x69\x2e\x21\xf3\x74\xf7\x51\x56\x66\x9b\x04\x6b\xab\x6c\x05\xa0\x45\xb6\xd9\x24\xa9\x64\x1b\x54\x2e\x1b\xaf\xd0\xce\xe1\x90\xa3\xa5\x83\x99\x87\x2c\x1c\xd4\xd1\xd3\xdb\xfc\xd3\xbf\x08\xdf\x40\x4a\xec\x4c\x69\xcc\xd9\x8a\x18\xc3\x1c\x06\x15\x9c\x25\x26\x86\x09\x56\xcf\xbc\x28\x17\x33\x18\xb1\x45\xc4\x06\x4b\xb2\x26\x2e\x07\xc6\x83\x09\x8c\xc5\x74\x1a\x99\x83\xb6\x74\xe7\x72\x60\x6d\x90\x8f\x2c\x8e\x7d\xf0\x1d\xf9\x4f\xc1\x0a\x20\x2f\xe6\xee\xb6\x10\x59\x56\x20\xcb\x00\x71\x39\x5e\x63\xbf\x20\xb2\x72\xd0\x9a\x01\xb6\x45\x8e\x53\x56\xc3\xe9\xd6\x29\x2b\xe9\x0c\x86\xc3\x6e\x2a\x59\x1e\xf6\x35\xf5\x3d\xb8\x25\x6b\x1f\x05\x2b\x8a\xc3\xdd\x51\xc0\xd9\xbb\x84\xe3\xc7\x92\x43\x3c\xcf\x21\xd8\x86\x88\x83\x60\x95\xc4\x38\xda\x7a\x20\x18\xb3\xf0\x23\xf8\x9b\xba\xb8\x4c\x74\x43\x18\xa7\xfe\x53\xea\x22\x48\x6c\xc8\xf0\xb7\x84\xf0\x5c\xff\x63\xa1\x1b\xe3\x9a\x9c\x13\x69\x19\x6a\x1c\x86\xa3\x99\x09\x3e\x4e\xcc\x77\x40\x0b\xbf\x98\x18\xe3\x99\xfe\x5e\x37\x4c\xf0\xdb\xa7\xf8\x2b\xe3\x01\xbc\x9f\x18\xff\x1a\x4d\x17\x7a\xfa\x79\xf4\xe7\xfe\xf3\x78\x34\x7e\xa7\x03\x4d\x65\xcc\xc1\x6e\x2f\x02\x95\x42\xf1\x4e\x7f\x3b\x5a\x4c\x4d\xe0\xe2\x47\xfe\x1d\x39\x9d\x53\x89\xc5\xa7\x37\x37\x3e\x5e\x5b\x0e\x62\xac\x5b\x1c\x2e\xdb\xf6\x31\x63\x82\xd8\xba\xbc\xe8\x56\x0c\x54\x90\x20\x2d\x58\x16\xc2\xec\xed\x12\x67\x46\x94\x8d\xfc\xc9\xc3\x62\x9a\x42\x71\x8b\xda\x22\x71\x6d\x20\x16\x27\x8c\xed\xb0\x2f\xf8\xc1\xf0\xb2\x2a\xc3\xf2\x86\xb4\x1c\xb6\x59\xcc\x1f\x16\xb4\x55\x86\x80\x87\x8f\x86\x7e\x07\x7e\xfb\xa4\xb0\x68\x34\x35\xf5\x99\xc2\xa0\x14\xab\xf0\xf8\x25\xb1\x65\xdc\xf0\x6a\x85\xad\x16\xa2\x2e\xc6\x89\xc3\xae\x90\x33\x50\x36\xd3\x27\x72\xd4\xc3\xd1\x3c\x28\x95\xfc\x85\xfa\x36\xf6\x7f\x91\x44\x73\x18\xc7\xe2\x47\x36\xe6\x88\x38\x0c\xfc\x9b\x51\x77\x29\x0f\x36\x07\xdb\x6b\xec\x1f\xef\x87\x18\x27\xf6\x03\xc3\xdf\x76\xd8\xb5\x64\xdc\x22\x61\xb8\x41\x6c\x53\x2b\x0b\x3d\x1f\x7f\x27\x74\xc7\xa0\xf2\x87\xb1\x5b\x7c\xe4\x32\x14\x6d\x7d\xc3\x81\x48\x79\x24\xb3\x5c\xbf\xa0\x61\x3f\x10\xf5\xe4\x2d\x87\x32\x51\x61\x0a\x36\xf2\x69\x6d\x2a\xfe\xc6\xc7\x88\x2b\x7f\x14\xc9\xee\x3c\xbb\xb6\x6c\x1a\x3a\xf1\xc7\xad\x47\x7d\x8e\x7d\x98\xf4\x22\x8a\xb6\x68\xa5\xf5\x00\x47\x0e\xb4\x28\x71\x99\x38\x06\x57\x18\x43\x8f\x52\x47\xfc\x74\x89\x18\x86\x2b\x2c\x1b\xeb\xf0\xb1\x8f\x19\xf6\xbf\xcb\x44\x82\x75\x28\x7f\x84\xe1\x32\x89\xfc\x2d\x93\xf2\x7c\xca\xa9\x45\x1d\xa9\x5d\xc5\x31\x4a\x82\x05\x23\x1b\xfb\xe1\xf2\x22\xfa\x9e\xed\x2c\x0b\x33\xb6\xda\x39\x50\x1a\x28\xb1\xe1\x88\x38\xd8\x96\x4b\xc9\xd3\x6a\x1f\x4f\x1e\xf2\x39\xb1\x88\x87\xda\xa8\xde\x62\x58\x55\xcd\xab\x3f\xdb\xa8\xe7\xaf\xa6\x26\xb7\x5b\xc6\x2a\x75\xfc\xa8\xb2\xd6\xc8\xd0\x23\xcb\x5c\xa5\xae\x72\xd9\x13\x8b\x57\x94\xc1\xf4\x07\x2d\xc6\xa6\x6a\x9b\x93\x4d\x27\xe9\x56\x28\x58\xf9\x5b\x91\x29\x61\x05\x3c\xb2\x00\xc6\x99\x4f\x77\x7e\xb0\x7f\x8c\xa2\x5b\x52\x7a\x92\xe9\xe4\xf4\xf4\xe6\x46\xbe\x15\x93\xe7\x01\xf7\x91\x8d\x8f\x77\x67\x04\x53\x58\x57\x1c\xbb\x5e\x88\xa7\xc4\x43\xaa\x17\x5d\xad\xb0\x2f\x55\x1b\xce\xf2\xaa\x55\x4f\x24\x14\x2d\x91\x2b\x45\xa2\x7d\xb0\x50\x20\xd4\x80\x7d\xa5\xae\x54\xae\x52\x5d\x2a\x55\xa1\x31\xa4\x44\x18\x64\xd8\x71\xb0\x0f\x96\x94\x3a\x18\xb9\x49\x4d\x22\x16\x86\x6e\xae\xfe\x46\xdf\xe5\x6b\x72\x88\x51\xf0\x60\x9e\x81\xf0\xe1\xf8\xc1\x98\x9b\xb3\xd1\xc4\x30\x0b\x61\x01\x33\x7e\x82\x61\xaf\x1f\x8c\xdf\xe9\xe3\xdf\x41\xa7\x93\xf5\xe0\x1b\xd0\xef\x76\x55\x50\xa2\x9f\x27\x4e\xfb\xb5\xe4\xc7\x1a\x78\x39\x9f\x16\xe0\x0b\x0e\x0f\x09\x56\xa6\x52\x3a\x53\xb4\x5a\x47\x65\xc0\x75\x2b\x69\x9d\x29\xec\x98\x5a\x2a\xe3\xd7\x6e\x35\x55\x68\xf9\x51\xf5\xb4\xa1\xb1\x47\x56\x54\x85\xb6\x72\x4d\x95\xfd\xa0\xa2\xaa\x66\x7e\xd2\x6a\xac\x26\xf1\x99\xa5\x54\x7b\x13\x15\xcf\xfd\x8a\xad\x59\xdd\xc2\x5b\x5d\x43\x85\xb2\x7b\xd5\xf2\x5d\x06\x92\xa6\x9e\x6c\x87\xf6\x53\xf6\x58\xfc\x11\x62\xf7\x3b\x76\xa8\x87\x45\x7d\x4b\xfe\x18\xec\x78\x76\x0e\x97\x3c\xdc\x62\x8e\x24\x8f\x02\x2f\xc8\x1e\x33\xb2\x76\x11\xdf\xf9\x58\xd4\x62\xbb\xbe\xec\x7e\xfe\xb2\x5f\xbc\xfc\xe7\xbf\xa2\xe5\xcb\xe7\x2f\xc5\xad\x17\xde\x52\x49\x37\x6c\x8f\xe5\x52\x17\x57\x2e\x86\xf6\x58\x65\x98\xd8\x32\xb2\xc5\x70\x49\x77\xae\x1d\xb6\xac\xaf\x7c\xe4\xae\x71\x71\x3b\x96\xd4\x56\x55\x6b\x0c\x10\x3b\xc9\xaa\x98\x63\xad\xa9\x20\x4a\xab\x07\x63\x5a\x6c\x13\x81\xe8\xf9\xf8\x61\xba\x78\x6f\x04\x43\x3d\xd7\xcd\x8a\x7e\x68\xb6\xf3\x94\xed\x86\x36\xdb\x2f\xb4\x67\x84\x04\xbf\x91\x51\x95\xfb\x8c\x3a\x46\x4a\x2b\x6a\x6b\x66\x4a\x35\x34\x32\x54\x31\xfd\x8b\x4d\xbd\x43\x1c\x81\x15\xf5\x15\xa7\x42\xe0\x6e\x64\x8e\x14\xe6\x49\x20\xab\x4e\x57\xea\xc0\x4e\x8c\xb9\x3e\x33\xc1\xc4\x30\x1f\x4a\x27\x2c\x61\x21\x9e\x83\xce\xa9\x06\x89\x4b\x38\x41\x0e\x64\x21\xd6\x4b\xf6\xcd\x39\xed\x81\xd3\x41\x5f\xbb\x3e\xeb\x0f\xce\x06\x1a\xd0\xce\x6f\x86\x17\x37\xe7\x17\x2f\xfb\xe7\x83\xfe\xe0\xea\x1f\x7d\xed\xb4\x7b\x5b\x0f\x7d\x00\x89\x6b\xe3\xc7\xbc\x57\x97\x4f\x90\x53\x62\x57\x6a\xba\xb8\xbc\xd6\x2e\x9b\x68\x3a\x87\x3b\x86\xd3\x6a\x02\x89\x0b\x8b\x67\x15\x95\xfa\x86\xd7\x97\xaf\x06\x4d\xf4\x5d\x40\x64\xdb\xb0\xd8\x7f\xaa\xd4\xf1\xaa\x3f\xbc\xd2\x9a\xe8\x18\xc2\xa8\x74\x25\xab\xe8\xf0\xdc\xb2\x52\xc5\x95\x76\x31\x6c\xa2\xe1\x32\xd1\x10\x4f\x60\x35\x34\x5c\xf7\xaf\x1a\xa9\x78\x05\xb7\xd4\x26\xab\xa7\xda\x46\x68\xfd\x61\xbf\x51\x90\x5d\xe5\x8c\x88\x72\xb0\x86\x1a\x6d\x38\x7c\x75\xde\x4c\x4f\x30\xe4\x68\xbd\xf6\xf1\x1a\x71\xea\x57\x46\x94\x36\xb8\xb8\x3e\xbf\x68\x02\x7f\x1d\xc2\x47\x9d\x49\xf8\x68\xfb\xd5\xe8\x57\xfd\xeb\x26\xe0\x5a\x3f\x44\x8f\xc7\x20\xdc\x8e\x56\xe2\x9f\x6b\x83\xeb\x66\x0a\xb4\xac\x82\x74\x7f\x13\x64\x7f\xb5\xa2\x8b\xeb\x66\xa3\xa0\x0d\x72\xe3\x1c\xef\x28\xa3\xdb\x6e\x95\x9a\x2e\x86\xfd\x7e\xa3\x01\xd1\xce\x23\x73\xd2\x7d\x78\xf5\x80\x0f\xfb\xda\x55\x33\x97\x5d\xc0\x15\x79\x8c\xad\xe1\x74\xeb\xc0\x15\xc1\x4e\xe5\xbc\xa8\x0d\xb5\x57\xfd\x57\x8d\x94\x0c\x93\x03\x92\xa4\x71\xfd\xa8\x30\xe3\x42\x1b\x34\x33\xe3\x12\x12\x77\x8d\x19\x87\xe5\xd6\xb8\x42\xd5\xf0\xf2\x32\x19\x7b\x49\x0d\xac\x3c\xeb\x6e\x52\x5b\x1b\xdd\x03\x08\x96\x0b\x0a\xdc\xf8\xee\xd4\xfe\xda\xe3\x4b\x86\xab\xcf\xc8\x7b\x40\xeb\x45\x17\x4a\x6a\x98\x5b\x3e\xfe\x3e\xc2\xd8\xca\x23\xd7\x56\x4c\xcd\x2d\x7f\x9b\x18\x2a\x3a\x72\x3d\x62\xc9\x54\x75\x82\xd9\x02\x6c\x8d\x13\x9c\xc3\x87\xa9\xd9\x11\x42\x1b\xc3\x56\xbd\xc0\x6f\x32\x8c\x92\x23\x83\x16\x5c\x2e\xe8\x9c\xb7\x83\xaa\x6e\x22\x1e\x3e\x94\x4d\xbb\x57\x6d\x0c\xa6\x6a\x13\xd3\x64\x38\xa5\xbd\xaa\xe6\x2e\xc9\xde\x74\xcb\xd6\x6d\xef\x2b\x7e\x4a\xa0\xf7\x7d\xe3\xa6\xfb\xc0\x0c\x62\x74\xb1\xf5\xee\x2e\xdb\x85\x2e\x2a\x04\x1f\x66\x93\xf7\xa3\xd9\x27\xf0\xbb\xfe\x09\x74\x88\xad\xba\xd0\x56\xfc\xdc\x12\xeb\x02\xaa\x88\xb9\x48\xb1\x92\x7d\xa1\x83\x51\x98\x9d\xf7\xd7\x96\xe0\xfe\xc2\x13\xcc\xde\x4e\x82\xad\x58\x97\x57\x2b\x32\xee\x20\x62\x60\x61\x4c\xfe\x58\xe8\xa0\xb3\x17\xef\x65\x6e\x6e\xf5\x72\xf7\xac\x1a\xba\xa6\x9d\x61\x6d\x6c\x78\xa3\x41\x95\x74\x74\x14\x73\x79\xbb\x96\x89\x95\x54\x59\x5a\x41\xab\xb6\xe5\xd2\x26\x8f\x72\xea\x6b\xd7\x7a\x99\x9a\x2a\xfb\x2b\xa9\x29\x3d\x10\x85\xf4\xf2\x29\x8c\xf6\xc4\x90\x89\x71\xa7\xff\x59\xef\xd0\x20\x14\xcd\xa3\x80\x07\xa3\x98\x0c\x8b\xf9\xc4\xb8\x07\x4b\xee\x63\x9c\xcd\x2e\x39\x9b\x28\xc7\x8e\xe7\x13\xdf\x89\xac\xc5\x48\x92\xd7\xcb\x74\x9d\x7d\x30\x9d\x3d\x44\x96\x49\xee\x84\x25\xcf\x27\x12\xee\x95\x8e\x30\x44\xe4\x36\x88\x6d\x8e\x61\x16\x9e\xe4\xd4\xa2\x55\x3c\xff\x11\xb1\x89\x96\xc5\xc7\xf0\x89\x10\xea\x31\x2a\x1c\x2e\xf5\xca\xe7\x48\xc2\x94\x87\x38\x88\x8d\xf0\xf9\x01\x4c\xe3\x2a\x11\x11\x2e\xc0\x65\x69\x27\x77\x34\x73\x8c\x45\x57\x2a\x7a\xc9\xf5\x09\x19\xd9\x7d\x33\xfb\x48\x9a\xc4\xae\x4d\x70\x7f\x7e\xdc\x13\xde\x03\x51\x90\xa6\x1e\xf4\xda\xe2\x1d\x63\x65\xa9\x4b\x4a\xd5\x41\x96\x88\x0d\xe0\x8f\xed\x19\x10\x63\x49\x62\xfa\x40\x13\xf2\x97\x01\xca\x46\x50\x2f\x88\xca\x0d\x3d\xc8\x86\x98\xfc\x1e\xe3\x50\xe7\x57\x3b\x3a\xbd\x5a\x1b\x4c\xd5\xc7\xfb\x3a\x0f\x97\xa5\x9c\xdc\x13\xce\x71\x14\x33\xca\xfa\xb5\x2d\x5a\x25\xcc\x7a\xd3\x9b\x88\x20\x8f\x86\x84\x1f\x33\xac\x7b\x8c\xc3\x43\x52\x15\x7e\xdc\xb7\x03\x25\xd9\x1b\x5a\x47\x10\x2e\x83\x15\x98\xdb\xb8\xc0\xb3\x70\x35\xac\x9a\x60\xd8\x7e\x6d\x87\x5e\x08\x55\x8b\x5c\xd2\xf3\x95\x52\x2b\x5c\x3a\x3b\x9a\x5f\x01\x4f\x45\xb2\x7c\xe7\x4d\xc9\xb4\x1d\x3f\xe6\xd0\xea\xb2\x54\x7a\xb3\x1d\x6e\xb5\x38\x55\x73\x49\x18\x3b\x94\x7e\xdd\x79\xc7\x31\xca\x63\xd5\x1e\xd1\xe4\x56\x9d\x90\x9f\x87\x88\x1f\xfe\x7b\x80\x56\x18\x16\xd1\xea\xe5\x6d\x4c\xb0\x57\xba\x08\xd8\x2b\x5d\x26\x95\x18\xd1\xc2\xbc\x1d\xe3\xa8\x18\x37\x5c\x1d\x05\xa8\xad\x79\xb7\x81\x63\x95\x7e\x8b\xce\xd1\x4b\x67\x0b\xd4\x85\xf1\x1b\x76\xc7\x3a\x54\xa9\x20\xb7\x4f\x4b\xde\x18\xcc\xef\x8c\x22\xc1\x06\xdc\x8f\x8f\x83\x2a\x6c\x35\x63\x41\x96\xe5\x01\xe3\x55\x78\x80\xc7\x9f\xbc\xc3\xf7\xe0\x95\xa8\xca\x65\x7f\x20\xa4\x20\x1a\xaf\xa1\x02\xc8\x34\x88\x5a\x62\x2b\x82\x56\x2e\xdf\xea\x46\x72\x06\xbc\xed\x60\xc8\x41\x1f\xb2\xde\x94\xc3\x15\x5e\xa7\x6a\xdf\xd1\xa5\x17\xb6\x94\xf4\x0b\x3f\xa8\x6f\x4c\xe6\xfd\xb9\x67\xf3\x7f\xf6\x1d\x3d\x95\x25\x19\xd9\xfa\x46\x88\xde\x06\x7c\x36\x6b\x84\xaf\x1e\xaa\xcc\x12\xfd\xa8\xbe\x7d\x49\x13\xe5\xd9\x6c\x4a\xef\xe1\xaa\xec\x90\x76\xbb\xf2\xd0\xfb\x13\xc1\xe7\x48\xed\x22\xba\x70\x03\xdc\x34\xc1\xf3\xa0\xf9\x2d\x54\x4b\x19\x5e\xa5\xa2\x8e\x0d\x8a\x7d\x5d\xa5\xb2\xf6\xca\x57\x19\xb8\x16\x77\x75\x11\xcb\x6e\xb6\x9f\x23\x6c\xca\xf8\x07\x6f\xf5\xa3\x9b\x41\x49\x21\x4f\x3a\x8c\x70\x49\xe9\xd7\x83\xbd\x5c\x81\xa9\x5c\x22\x74\x3a\xc9\xbb\x6d\x67\x6f\xde\x80\x53\x46\x1d\x3b\x73\x9a\x76\x7a\x73\xc3\xf1\x23\xef\x76\x7b\x40\x2e\x68\x51\xbb\x9e\x60\xd4\x8b\x97\x8b\x2e\xe9\x6e\xbd\xe1\xb5\xd4\xe7\x44\xab\x09\xe4\x44\x0b\x14\xba\xe0\xe3\x3b\x7d\xa6\x47\x41\x06\x5e\x83\xf3\x73\xc9\xe9\x45\xf9\x20\x9a\xd8\x70\x95\x39\x26\x7a\xfb\xfb\x8f\x39\x8e\x8e\xd5\x82\xb7\x0f\x33\x7d\x72\x6f\xa4\x47\x40\x60\xa6\xbf\xd5\x67\xba\x31\xd6\xe7\x85\x53\x91\xf0\xe9\x83\x01\x16\x1f\xee\x82\x90\x99\xe9\xd1\x3f\x74\x0a\xbe\xba\xd3\xa7\xba\xa9\x83\xf1\x68\x3e\x1e\xdd\xe9\xd5\x2f\x21\x8a\xdf\x1a\x4b\xbb\x08\xed\x39\x23\xaf\x47\x71\x48\x26\x63\x92\xf7\x4f\xb1\x6d\x24\x74\x56\xbc\xd0\x57\x9c\x28\x4a\x3d\x11\x6f\x65\x7f\xba\x1f\xb2\x3c\x44\x5e\x48\xba\x04\xd5\x01\xd3\xcc\x03\xe5\xa6\xd2\x4f\x74\x83\x84\x4c\xde\x17\x82\x36\x58\xbb\x41\x51\x6c\x71\xfc\x3f\x38\x44\x1e\x1a\xa5\x1e\x52\xdd\xe8\x90\xfd\xef\x4b\x60\xd1\xad\xe7\x60\x8e\x43\x1b\xfe\x17\x00\x00\xff\xff\x6c\x88\xa8\x5e\x28\x53\x00\x00")
+var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcd\x5d\xeb\x6f\xdb\x46\x12\xff\x9e\xbf\x62\x11\x04\xb0\x85\x93\x73\x92\x2c\xf9\x95\x36\x80\x4e\x66\x5c\xa3\x8e\x9c\x4a\xf2\xb5\x41\x51\x10\x94\xb8\x92\x78\xa1\x48\x96\xa4\x1c\xbb\x87\xfb\xdf\x6f\xf8\xe6\x92\xfb\x22\xb5\x4e\xda\x0f\xad\xc5\x1d\xfe\xe6\xb1\xb3\x33\xb3\x2f\xf6\xe4\xe4\xd5\xc9\x09\xfa\xe4\x06\xe1\xc6\xc7\xf3\x5f\xee\x90\x69\x84\xc6\xd2\x08\x30\x32\xf7\x3b\x0f\xda\x5e\x45\xed\xd7\xf0\x37\x36\xd1\xda\x77\x77\x05\xc1\x23\xf6\x03\xcb\x75\xd0\xe5\xdb\xb3\xb7\xfd\x12\xd5\xf2\x19\x79\x1b\x3d\x7a\xbd\x42\xf2\x6a\xae\x2d\x50\x10\x1a\x21\xde\x61\x27\xd4\x43\x6b\x87\xdd\x7d\x88\x7e\x44\xbd\x77\x71\x93\xed\xae\xbe\xd4\x9f\xae\x6c\x2b\xa2\xc6\xce\xca\x35\x2d\x67\x03\x0d\x47\x0f\x8b\x0f\x17\x47\xef\x32\x38\xc7\x34\x7c\x53\x5f\xb9\xce\xda\xf5\x77\x40\xa1\x07\xa1\x0f\xff\x09\x80\xd2\x75\x52\x8c\x2d\x06\xe8\xf5\xde\x59\x85\x20\x8e\xbe\x04\x24\x1c\xb5\xaf\x0d\x3b\xc0\x04\x1b\x00\xd0\x77\x38\x08\x8c\x4d\x4c\xf0\xd5\xf0\x1d\xc0\x7a\x97\xca\x8e\x0d\x7f\xb5\xd5\x3d\x23\xdc\x42\x9b\xb7\x5f\xda\xd6\xaa\x1b\x29\xbb\x02\x9b\xd8\x6e\x44\x76\x12\xdb\x73\x6a\xec\xf0\x15\x5a\x5b\x7e\x10\xea\xc6\x66\x73\x6c\x38\xcf\xd8\x8e\xb5\xee\xa2\xe2\xef\xce\x3b\xb4\x78\xf6\x80\xf0\xc3\xc3\x74\xb2\xb8\xbd\x9f\xbe\x43\x73\x90\x74\x67\x5c\xa5\xd8\xef\xd0\xfd\x57\x07\xfb\x57\xe8\x24\xee\x88\xc9\x4c\x1b\x2f\xb4\x9c\x5a\x8c\x8f\x66\xda\xe2\x61\x36\x9d\x97\x9e\xbd\x42\xf0\xcf\xdd\x78\x7a\xf3\x30\xbe\xd1\x50\xf0\xa7\x8d\x6e\x3f\x7e\x7c\x58\x8c\xff\x75\xa7\xa1\xf9\x62\x76\x3b\x59\xc4\x14\xe3\x39\x7a\xa3\xbf\x41\x73\xed\x4e\x9b\x2c\xd0\x9b\x7e\xf4\x0b\xb4\x23\xd4\xb3\x8d\x17\xd5\x4e\x04\xaf\x4c\xb9\x01\x4d\xb9\x9d\xf1\xa4\x7b\xbe\xb5\xc2\xb1\x08\xce\x7e\x87\xe1\xc7\xef\x7f\x74\x51\xfe\xe7\xa1\xfa\x49\x70\xc8\x55\xcc\x1f\xb5\xd2\xf0\x18\x9e\x4d\xc6\x73\x0d\xfd\xfa\x93\x36\x85\xce\xfc\xbd\xff\xc7\x3f\xe1\xdf\x83\x3f\xde\xbf\x19\xc4\x7f\x0f\xe0\x6f\xb4\x48\x1a\x91\x76\x07\x94\x60\x14\x6d\x7a\xdd\xa1\x5a\x06\x46\xc8\x0b\x5b\x46\xcc\xe1\xa5\x2d\xf3\x43\x1b\xcb\xc4\xe3\xf1\x98\x32\x02\xc6\x37\x37\x33\xed\x06\x74\x94\x33\x44\x4e\x5e\x47\x8c\x25\x46\x68\x1e\xd9\x2a\x8a\x5f\x59\x04\xe8\x26\x8f\x17\x9f\x3f\x69\xf0\xb8\x34\x22\x3a\xb4\x51\xab\x54\xc6\x2a\x60\x45\xc4\x6c\x18\xcb\x4b\x98\x0f\x8c\xe3\xba\x47\xb5\x96\x92\x06\x5a\x91\x94\x18\x90\xa4\xb8\x85\x97\x75\x98\xc3\x41\xa9\xb4\x14\xd0\xaa\xb4\xe5\x41\xc2\x95\x36\xca\x5c\x26\x5e\x1b\x7b\x1b\x72\xae\xb1\xb4\x71\xe0\x19\x2b\x1c\xe5\xd1\xa3\x77\x64\xeb\x57\x2b\xdc\xea\xae\x65\x96\x52\x23\xa1\xab\x11\x04\x38\xd4\xa3\x0c\x1e\x64\x2a\xc6\x03\x4c\x4e\xbd\x64\x2c\x96\x30\x52\x8d\x2c\x28\x19\xac\x8d\xe5\x84\x68\x7a\xbf\x40\xd3\x87\xbb\xbb\x44\x1d\x63\xe7\xee\xe1\xe1\x6a\x6b\xf8\xc6\x2a\xc4\x3e\x7a\x34\xfc\xe7\xa8\x02\x20\xc9\x40\x5b\xdd\x58\xad\x22\xda\x00\x01\x0a\xde\x00\x29\x49\xb2\xb6\x0d\x28\x07\x82\x9d\x61\xdb\x75\x36\xa1\xbb\xb3\xeb\x4c\x8e\x07\xa3\x51\x87\xc2\x69\xef\x18\xfb\x70\xeb\xfa\xd6\x5f\xd8\xac\xb3\xbd\xd6\x3e\x8c\x1f\xee\x16\xa8\x47\x79\xd3\x5d\xaf\xa1\x1a\x12\x92\x3e\xba\x36\x74\x9f\x3e\x18\x6e\xb3\x7e\x64\x92\x86\xbe\x61\x62\x3d\x16\x21\xa6\x17\x41\xc7\x43\x31\xf6\x19\x8a\x51\xb3\x97\x8e\x8e\xae\xae\x44\x26\xdf\xba\x20\xa1\xe9\xee\x0c\xcb\xa1\x58\xee\x74\xd0\x91\x44\xab\x8f\xa6\x8d\xeb\x7b\x50\x83\x6d\x7c\x23\x2a\xd4\xda\x7b\x59\x05\xa7\xf0\xb4\x10\x3f\xd5\xfc\xcc\xf3\xa0\xf6\x83\xde\x0c\x51\x54\x7c\x82\x6b\x42\xe5\x1a\x0d\x85\xf8\x27\xfa\xcb\x75\x70\x5d\xd0\xad\x15\x84\xae\xff\x9c\xbb\x80\x6e\x99\x7a\x80\xff\xcc\x04\x9e\x6b\xbf\x3c\x68\xd3\x89\xa4\xcc\x19\x35\x0b\x35\x1d\xdd\xe3\xd9\x02\xfd\x7a\xbb\xf8\x09\xf5\xe3\x07\xb7\x53\x78\xfd\xa3\x36\x5d\xa0\x7f\x7d\x4e\x1f\x4d\xef\xd1\xc7\xdb\xe9\xbf\xc7\x77\x0f\x5a\xfe\x7b\xfc\x5b\xf1\x7b\x32\x9e\xfc\xa4\xa1\xbe\x48\x99\xd6\x66\xaf\x02\xd5\x46\x78\xe6\x17\x0e\x74\xc3\xa3\x61\x1f\x1f\x31\x34\x06\xb7\xf1\xf1\x66\x05\x1e\x1b\x54\x47\xa1\x61\x9a\x3e\x14\xe8\x14\xc7\x3b\x1b\x76\x38\x1d\x15\xc5\x1d\x05\x9a\xc5\x30\x85\x5e\xf4\x80\x93\x04\xb9\x10\x58\xd1\xc5\xa4\x92\xc3\xfc\x86\x46\xde\x1f\xd0\xc9\xad\x20\xd8\x03\x59\xfd\x85\xd1\x59\x87\x33\xc2\x48\x45\x14\xbb\x6d\x19\xf3\x9b\x39\x2d\x4f\x11\x74\xff\xeb\x54\xbb\x06\x5e\x02\x8d\xc6\x77\x0b\x6d\x26\x50\x28\xc7\xaa\x34\xbf\xb5\x4c\x96\x6c\x18\x62\xfe\x4a\x81\xd7\xa5\x38\xa9\xdb\x55\xc6\x8c\xce\x4a\xa0\x19\x9d\xeb\xe1\x24\x0e\x32\x29\x5f\xbb\xbe\x89\xfd\xd7\x0c\x6f\x8e\xfd\x98\xde\x64\xe2\xd0\xb0\xec\x00\xfd\x27\x70\x9d\x25\xdb\xd9\x6c\x6c\xc2\xbb\x87\xdb\x21\xc5\x49\xed\x00\x7d\xb2\xc7\xce\x8a\x25\x5b\x42\xac\x6f\x8d\x60\x2b\x35\x0a\x3d\x1f\x3f\x5a\xee\x3e\xd0\x85\x2f\xe6\xe9\xd7\x09\x8c\x64\x45\x21\xee\x08\x61\x02\x2e\x3a\x42\x8e\x7e\x65\xbb\x01\x2d\x31\x45\xeb\x23\x79\x6e\xaa\xbe\xe3\x63\x23\x14\xbe\x94\xd0\xee\x3d\x53\x9a\x36\x77\x9d\xf4\xe7\xce\x73\x7d\x30\x8b\x9e\x2d\xf1\x54\x75\xe9\xd7\xca\xac\xd0\xb0\x41\x6f\x0b\xb2\x31\xd5\x07\xd7\x18\xeb\x9e\xeb\xda\xf4\xd6\x68\xc5\x49\x07\x12\x46\x5f\xc7\xcd\x90\x16\xb0\xff\xc8\x22\x89\xca\xfb\xf0\x49\x8f\xab\x4f\xa8\xde\x18\x54\x9e\xef\x86\xee\xca\xb5\x99\x7a\xf5\x18\x5e\x86\xa1\x16\xf3\xe3\xf2\x22\x79\x1e\xec\x57\x2b\x48\x53\xeb\xbd\xad\x33\x1d\x25\x55\x1c\x46\x10\x74\x02\x93\x8a\x3d\xac\x0a\x7f\xf2\x0c\x3f\xb4\x56\x96\x67\xa8\xc8\xde\x74\x58\x51\xce\x93\x8f\x36\xe2\xf8\xd5\x54\x65\xb5\x69\x8c\xcb\xe3\x5b\xa5\xb5\x46\x8a\x1e\x98\xe6\xb8\xbc\xea\x69\x8f\x4e\xce\x49\x83\xf9\x0b\x0a\x7d\x53\x34\x7b\x2c\x0f\x27\xe6\x0c\x33\xaa\xfc\x57\x89\x2a\x71\x06\x3c\x30\x01\xa6\x23\xdf\xdd\xfb\xd1\xb4\x3c\xf1\x6e\x46\xea\x69\x3b\x41\xca\xec\x10\xcf\xfd\x0e\x37\x67\x02\x53\xa9\x2b\x0e\xad\x17\xd2\x90\xd8\x26\x7b\xc5\x93\x64\x26\xdb\x38\xca\x8b\xaa\x9e\x84\x28\x29\x91\xb9\x24\xc9\xf2\x02\x95\x20\xe6\x00\x82\x88\x78\xe5\x74\x5c\x76\x39\x15\x87\x63\x2c\x92\x15\xc0\x80\xb3\x6d\x30\xe8\x12\x12\x21\x36\x9c\x2c\x27\x45\xcb\x3c\x0e\x91\x7f\x93\x67\x64\x4e\x8e\x31\x2a\x16\x24\x25\xa0\x36\x4e\xee\xa7\xf3\xc5\x6c\x7c\x0b\xc1\x8b\x74\x0b\xbd\x64\x27\x3d\xde\x42\x41\x10\xb2\x26\x3f\xa3\xe3\xe3\xb2\x05\xdf\xa3\x5e\xa7\x23\x82\xa2\xbd\x9e\x19\xed\x87\x9a\x1d\x25\xf0\x08\x9b\x56\xe0\x2b\x06\x8f\x05\x14\x0d\x25\xfd\xac\x07\xff\x28\x1a\x50\x09\x58\x3a\xac\x5e\xe7\xee\xff\xba\xb5\xc7\x36\x70\x34\x5e\x85\x94\x2c\x2e\xe5\x0b\x4b\x74\x1e\x5c\xa2\xad\xb5\xd9\x16\x0b\x8c\xd5\x81\xef\x7e\x65\xb6\x41\x50\x71\x98\x8d\x71\xa8\xa0\xb4\x72\x3b\x2d\x0f\xef\x4a\x8b\x1f\x16\xb0\x6c\xf9\x23\x93\x77\x0e\x29\x80\x58\xf2\xa9\x2d\x81\x04\x5c\xbe\x55\x11\xd4\x50\xd9\x03\xcb\x20\x01\xb7\x7a\x21\xc4\x7a\x81\x53\x0a\x95\x5e\x51\xea\xab\x99\x7f\x96\x45\x92\x9e\xf9\xa6\x09\x5b\x30\x9f\x96\xad\x96\xf8\x85\x0f\x95\xb6\x60\xcd\x9e\x1a\x1a\xcc\xa1\xc7\x9a\x56\x7f\x97\x89\x31\x4c\x31\xb1\xf3\x88\x6d\x10\x8a\xb6\xd8\x0c\xcd\x30\x4d\xdd\xdb\x21\xa3\x71\x07\xf5\x24\xa3\x29\xb2\x02\xab\x39\xb0\x36\x8e\x11\xee\x01\x9a\x62\xf6\xcb\xb3\x0e\xc4\xd4\xbc\xe2\xfc\xef\xff\x68\x35\x67\x2d\x26\xef\xf0\xce\x65\x2c\x61\x16\x58\x0e\x98\x41\x62\xc3\x20\xc2\xaa\xc3\xa4\x9a\x81\x39\xf5\x25\x74\x9c\x19\x6f\x8e\x5c\x80\x03\x6f\x70\x75\x0e\x9d\x15\x44\xa2\xf5\x4c\xe8\x8d\x6c\x54\xa5\x32\x4a\x85\x82\x64\x58\xdd\x4f\xef\xaa\x6b\x7b\x28\x69\x9f\xdc\xdf\x3d\x7c\x9c\x46\x5d\x1d\x6d\x97\xb1\x17\xb1\xcb\xcb\x85\xe5\x25\xec\x66\x93\x3c\x75\x4a\x30\xf0\x1b\x29\xc5\x9d\x1c\xca\x28\xc9\xcc\xa8\xca\xd4\x64\x72\x68\xa4\xa8\x20\xfc\xd3\x55\xbd\x36\x60\x40\xae\x5d\x5f\xb0\x43\x8a\xae\xc7\x8b\xb1\x40\x3d\x06\x24\x6f\x4b\x4c\x06\xf6\x76\x3a\xd7\x20\x4f\x43\x0d\x7d\x5f\xdb\x16\x8b\x13\xf1\x1c\x1d\x1f\xf5\x75\xcb\xb1\x42\xcb\xb0\xf5\x20\xc6\x7a\x1b\xfc\x69\x1f\x75\xd1\xd1\xa0\xd7\xbf\x3c\xe9\x0d\x4e\x06\x7d\xd4\x3f\xbd\x1a\x0d\xaf\x4e\x87\x6f\x7b\xa7\x83\xde\xe0\xe2\x1f\xbd\xfe\x11\xd8\x41\x0a\x7d\x00\xe8\x26\x7e\x22\xad\xba\x04\x8b\xbb\x96\xc9\xe5\x34\x3c\xbb\xec\x9f\x35\xe1\x74\xaa\xef\xa1\xcc\xcd\xb2\x09\xb0\xd5\xab\x1b\x4c\x5c\x7e\xa3\xcb\xb3\xf3\x41\x13\x7e\x43\xdd\x30\x4d\xbd\xba\x68\xc8\xe5\x71\xde\x1b\x5d\xf4\x9b\xf0\x18\xe9\x49\xea\xca\xe6\x16\xf1\x1e\x3e\x97\xc5\x45\x7f\x38\x6a\xc2\xe1\x2c\xe3\x90\x06\x30\x09\x0e\x97\xbd\x8b\x46\x2c\xce\xf5\x9d\x6b\x5a\xeb\x67\x69\x25\xfa\xbd\x51\xaf\x91\x93\x5d\x10\x4a\x24\x63\x50\x82\x4d\x7f\x34\x3a\x3f\x6d\xc6\x27\xea\x72\x63\xb3\x81\x68\x60\x80\x6b\x71\x3d\xaa\x3f\x18\x5e\x9e\x0e\x9b\xc0\x5f\xc6\xf0\xc9\x72\xb2\xfe\x64\xfa\x7c\xf4\x8b\xde\x65\x13\xf0\x7e\x2f\x46\x4f\xfb\x20\x5e\x43\xe0\xe2\x9f\xf6\x07\x97\xcd\x18\xf4\xcb\x0c\xf2\xf9\x4d\x34\xfa\xf9\x8c\x86\x97\xcd\x7a\xa1\x3f\x20\xfa\x39\x5d\x06\x48\x4e\x7e\x72\x39\x0d\x47\xbd\x5e\xa3\x0e\xe9\x9f\x26\xea\xe4\x8b\x27\xfc\x0e\x1f\xf5\xfa\x17\xcd\x4c\x36\xd4\xd7\xd6\x53\xaa\x4d\x74\x18\x05\x7e\x62\xdb\xe4\x33\xe9\x9f\xf7\xce\x1b\x31\x19\x65\xbb\x5a\xd9\x6e\xc3\x93\x40\x8d\x21\x74\x7d\x23\x0e\x67\xd0\xcd\x1b\x28\x95\xf5\xfa\x7e\x86\x80\xd5\xe8\xec\xac\x59\xdf\x9f\xeb\x5f\xf1\x72\xeb\xba\x5f\x54\x03\x5f\x10\x4e\x95\xae\xed\x2a\xe6\x71\x99\xba\x53\x16\x41\x1a\xd9\x87\x51\x23\x70\x0f\x70\x34\xa9\x3d\x1a\x1d\x6e\x89\xca\x29\x01\x6e\x7a\xce\xb2\x38\x22\xfd\x16\xcc\xcb\x3d\xf8\xd1\x45\xfd\x6e\x72\xf8\x4c\x42\xdd\xfa\x99\x8e\x03\x94\xe5\x9e\x23\x50\xa2\x2a\x31\x3d\x68\xa2\x28\xed\x1c\xc1\x01\x25\x25\x6f\x5b\x5e\x01\xac\xc4\xb6\x64\xfb\x6e\x6a\xb6\x2f\xa6\xa2\xdb\xf8\x13\xa0\x26\xdd\xc8\xd8\x07\x53\x60\x72\xca\x76\x90\x1a\x54\xf1\x22\x6b\xfb\xae\x6c\xba\xba\xa7\xa2\x33\x45\x93\xbc\x26\xdd\xc9\x5c\xcb\x6b\x6e\x92\xf2\xa9\xd8\x72\x0a\xf2\xbe\xe0\xe7\x0c\xba\xd8\x0c\x69\x3a\x4f\x2e\x21\x26\x87\xe0\xaf\xaf\xcb\x5b\x2b\x55\x86\xe8\xd3\xec\xf6\xe3\x78\xf6\x19\xfd\xac\x7d\x46\xc7\x96\x29\x3a\xa5\x59\xfd\xad\x48\xea\x0a\x2a\x4d\x72\x1a\x63\xa1\xf4\x95\x15\x9e\x4a\x74\x2e\xce\xe2\xe9\xc5\x29\x3e\xbd\x7c\xe4\x4e\x57\xa2\x1d\xc9\x96\xa6\x5c\x2b\xc1\xd0\xc3\xf4\x16\x86\x0b\x3a\x2e\xc8\xbb\xa5\xe3\x88\x5d\xe2\xf0\x60\x43\xd3\x78\xdf\x47\xf1\x46\x9d\xca\x58\xf1\x12\xc4\x72\xb5\x9a\xd1\x99\xf0\x34\xe5\x88\x25\xad\x39\xb1\xf3\x48\x7b\xa8\x58\xcb\x32\x34\x4f\xb7\x9a\x08\xa4\x46\xc4\xe6\x67\xb7\xb6\xd1\xd9\x2d\x6f\x9f\x36\x5f\x62\x14\x86\x7d\xe5\x36\xa1\xb2\x11\xd8\x87\x2d\x9a\xb0\xf7\x13\x3b\x2d\x9f\xe3\x91\x9e\x29\x72\x3b\xbd\xd6\x7e\x93\xdb\x50\x8a\x49\x49\x14\x50\xa9\x1a\x08\x1e\xe6\xb7\xd3\x1b\xb4\x0c\x7d\x8c\xcb\x91\x85\x2d\x4d\x12\x5f\x0e\x97\x27\x3d\xe4\x2c\x25\x11\x23\xa6\x2d\xf3\x39\x46\x6b\x71\x0a\x88\xb2\x24\xc4\xee\x1b\x29\x4f\x42\xdc\xad\x6d\x6f\xd1\x84\x8b\x76\xe9\x0e\x91\x2c\xde\xe5\x93\x12\xab\xba\x37\x48\x93\x26\x99\x12\x1c\x22\x4f\x82\x20\x27\x51\x65\xe3\xb1\x5b\xdf\x63\xa4\x0e\x79\x1d\x47\xbe\x11\xb7\xb7\x90\x34\xcd\x90\x89\xc0\x15\xb8\xb2\xd8\xd9\xa1\x6b\x42\x62\xda\x19\xa9\x6e\x76\x1e\x8a\x25\x6c\xb1\xd1\x71\xa0\x98\x96\x29\x2d\x60\x71\xb6\xa0\x8b\x5a\x08\xed\x7a\xba\xa7\x4a\xee\x14\xab\x2c\x3a\x23\x4d\xb7\xd2\x84\xae\x40\xf8\xa4\x4e\x81\x14\x8b\xe1\xd3\x2d\x55\x20\x0f\x8a\xd4\x95\x00\xab\x45\xa3\xdb\x6d\xa5\x43\x2a\x7c\x81\xd1\xd6\xf8\x7c\x43\xe7\x67\xe5\xa3\x50\x7d\xb8\xad\x49\xb8\xb2\xc8\xd9\xc1\x7f\x42\x46\xba\x44\x65\xbb\xaa\x12\xab\x86\x29\x17\xde\x68\x02\x86\x49\x97\x84\x87\x74\x6b\x81\xd1\xde\x25\x45\xee\x17\xfa\x66\xc4\xa4\x7c\xe4\xf2\x00\x81\xeb\x60\x15\xc9\xa3\x53\xa8\x84\x9c\x95\xb3\x9e\x7c\x01\xe3\xa5\x79\x35\xe2\xc5\x50\x52\xc2\x65\xfb\x01\x4c\xd1\x2a\xa7\x48\x0f\x96\xaf\x82\x27\x12\xb2\x7e\x88\x55\x28\xa9\x1a\x3b\x12\x68\xb2\x52\x0a\xad\xa9\x46\x36\x29\x99\xf8\xb2\x64\x12\xdb\xae\xfb\x65\xef\x1d\x26\x11\x89\x25\xdd\xa3\xd9\x31\x59\xaa\x7c\x9e\x61\xf9\xf1\x67\x54\x94\x48\x58\x45\x93\x1b\xb7\x9c\xf9\x5c\xf5\x74\x38\x43\x09\x05\x71\x3b\xc5\x11\x49\xdc\xb0\x3a\x8a\x50\x95\x59\xb7\x81\x61\xa5\xec\x76\xd6\x8b\xcf\x55\x64\x33\xe6\x83\x84\xab\x60\xd5\xe5\x4b\xd7\x1a\x08\x29\x79\xb3\xf5\xe4\x10\x48\x6d\xe3\x07\x0c\x9e\xde\xe9\x3d\xb4\xc7\x85\x0c\x88\x89\x64\x76\x47\x99\x9c\xba\x25\x84\x0d\x64\x3f\xdc\x51\x79\xd8\x62\x89\x29\x61\x80\x04\x4c\xa7\x09\x11\x5e\xb4\x04\xd8\xda\x27\xb8\xa8\xc2\x79\x49\x44\x24\x10\x34\x2d\xf2\x22\xc8\xdc\xcb\x15\x49\x4b\x83\x16\xd6\x97\xec\xa1\xc6\x04\x57\xed\x0c\x04\x74\x9b\x82\x98\x0d\x57\xb9\xc0\xa9\xde\xd0\xb5\x2b\xa2\x42\xf1\x2b\x2f\xc8\x2b\x53\xba\xb1\xfb\x62\xf6\x2f\xdf\x0a\x16\x69\x52\xa2\x95\x57\x82\x76\xff\xf8\xc5\xb4\xa1\x5e\x76\x16\xa9\x45\x7b\x49\x5e\xbf\x6c\x95\xe7\xc5\x74\xca\x0f\x91\x8b\xf4\x60\x2e\xc7\x91\xd0\xc5\x76\xed\x4b\x0c\xed\x2a\x3a\x75\x86\xde\x74\x80\x93\xa0\xe4\x1c\x4f\xd1\x08\xe7\xb1\x90\xd1\x41\x30\xf1\xe4\x32\x53\x97\xbe\xea\xc0\x52\xb2\x8b\x93\x58\x79\x35\xe0\x25\xdc\xa6\x8e\xdf\x7a\x2d\x22\x39\x87\x94\x25\xf2\x6c\x09\x54\x5f\x42\x39\xda\xda\xca\x1c\x4c\x61\x89\x70\x7c\x9c\xdd\xa6\x3d\x79\xff\x1e\x1d\x05\xae\x6d\x96\xb6\x3a\x8f\xae\xae\xa2\x8b\x0f\x9d\x4e\x17\xb1\x09\xa3\x5d\x09\x29\xc2\x64\xb3\x80\x4d\xba\x74\xf7\x9b\x6d\x28\xc5\x9e\x20\xe5\x0b\x40\x90\x56\x44\xe8\x44\x1f\xa1\x9b\x69\x89\x93\xa1\x1f\xd1\xe9\x29\x63\x7b\xa5\x7e\x4a\xc0\x32\xf5\x75\x69\x1f\xeb\xc3\xcf\xdf\xe6\xac\x40\xca\x16\x7d\xb8\x9f\x69\xb7\x37\xd3\x7c\x8f\x0a\xcd\xb4\x0f\xa0\xc9\x74\xa2\xcd\x2b\xdb\x36\x71\x2b\xb8\xc1\xc3\xa7\xeb\xc8\x65\x66\x5a\xf2\x65\xbe\xe8\xd1\xb5\x76\xa7\xc1\xa3\xc9\x78\x3e\x19\x5f\x6b\xfc\x4d\x4e\xfa\x3d\xd5\x7c\x99\x43\x9d\x31\x48\x3e\x12\xbb\x9c\x34\x49\x48\xfb\x54\xd7\xb5\xa8\xc6\x4a\x0b\x7d\xf1\x76\x2f\x9d\x7f\x3a\xd7\xfe\xee\x76\x28\xcb\x41\xb3\x42\xb6\x8c\xc1\x77\x98\x66\x16\xa8\xaf\x7a\x7d\x47\x33\x30\x84\x21\x6d\x41\x59\xa7\x53\xeb\x14\xd5\x35\x98\xbf\x83\x41\xd8\xae\x51\x5b\xe4\x6a\xe6\x1d\xd9\xc9\xe0\xd6\x97\x2b\x33\x00\xc1\xf7\x25\xf6\x3e\xf5\xab\x81\xbd\xe1\x45\xf5\x8e\x63\x80\x57\x3e\x6e\x74\x1d\x92\xf7\xd5\x21\xd1\xa7\xc5\xe4\xbe\x28\x26\xfb\x21\xb1\xea\x05\xcb\x88\x6f\xfe\x0d\xc3\xda\xa5\xc2\xe2\x39\xe5\xc3\x83\xe9\xbe\xf0\x21\x97\x33\x39\xd7\xa3\xb3\x5e\x53\x73\x0d\xba\x82\xf6\xd2\xd7\x9d\x05\xc2\xb7\xbc\xd6\x5c\x41\x2d\xae\x2f\xe7\x87\xe7\xeb\xd7\x94\x73\xe7\x3f\xf8\x6e\x5e\x8e\x24\x75\x07\xaf\x22\x2b\xef\x5a\x61\x0e\x9c\xbf\xa3\xe6\x0c\x4f\x06\x47\x8b\x62\x04\x2b\xe1\x99\x9c\x94\x5a\x37\xb1\x6d\x3d\x62\xdf\xc2\x07\x07\xa3\x12\x94\x20\x2c\x65\x2f\xb0\xda\x3d\x63\x13\x7d\x22\x3d\x74\xbf\x60\x47\xee\xab\x68\xc6\xb3\xed\x1a\xf4\x0f\x67\x86\x21\xde\x79\x32\x9f\x3f\x85\x77\xf5\x94\xba\xd9\xf7\x60\xe2\xc8\x81\x7d\xdf\x2d\x7f\x5a\x4b\x55\xb0\x28\x59\x55\x69\xd8\xa8\xe3\x7e\xa3\x00\x22\x56\xe8\xb0\x50\x52\xc7\xaf\x05\x95\x12\x09\x3b\xbc\x94\xdd\x59\x55\xa0\x29\x63\x36\x09\x39\x75\x9d\x24\x82\x4f\x99\x19\x05\x47\x69\x40\x2a\x01\x73\x42\x53\x95\x7d\x8b\x20\x15\xcd\xd6\xcb\x23\xb5\xf5\x1a\x80\x18\x3a\x9a\xf0\x51\x4c\x49\xac\x06\x54\x82\x46\xcb\xae\x28\xe2\xa1\xba\xaa\xb7\x75\xc7\x54\x84\x21\xeb\xde\xa2\x91\xa8\x78\xb3\xd4\x93\x4d\x9d\xf9\xf3\xe4\x82\xa9\x61\x42\xc5\x05\xd6\xf3\x55\x24\x9f\x02\xec\xef\x9c\x7e\xd4\xa7\x0e\xe2\x03\x8d\x0a\xd2\x4d\x61\x47\xd5\x09\xa7\x86\xfc\xed\x52\x8e\x50\xa9\x83\x93\x4e\x8d\x03\x2d\xed\x14\x44\xdc\xc4\x53\x72\x65\x85\xa9\xa7\x84\xda\x30\xf9\xd4\x74\x93\x4b\x3f\x25\x86\x54\x2c\xd5\x29\xa8\x80\xe6\xc7\xba\x8a\x08\x0d\xd2\x50\xe9\x4d\xc8\x16\xe9\x73\x05\x39\x88\x8a\x4b\x26\xa0\x92\x31\x89\x14\x54\x44\xb3\x2e\x92\x94\x9d\xce\xf8\x65\xb3\x50\x8b\xbe\x79\xc9\x4c\xc4\xfa\x9f\x49\xa1\x95\xbb\xf3\x40\x00\x1c\xeb\xf3\x7f\x02\xb4\x7d\x31\x79\x6a\x00\x00")
 
 func latestSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -100,7 +103,7 @@ func latestSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "latest.sql", size: 21288, mode: os.FileMode(420), modTime: time.Unix(1551104083, 0)}
+	info := bindataFileInfo{name: "latest.sql", size: 27257, mode: os.FileMode(420), modTime: time.Unix(1792361820, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -245,6 +248,66 @@ func migrations16_ingest_failed_transactionsSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations17_webhooksSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x95\x5d\x6f\x9b\x30\x18\x85\xef\xf9\x15\xef\x5d\x13\xad\x91\xba\xaa\xab\x26\xf5\xca\x05\x37\x45\x23\x10\x81\xe9\xc7\xa6\x09\x39\xe0\x65\xa8\x14\x23\xdb\x59\x17\x4d\xfb\xef\x73\x02\x04\x48\x18\xea\xb4\xec\x6e\xbe\x34\xe7\x1c\xbf\x1f\x8f\xc4\x64\x02\x6f\x9e\xd3\xa5\xa0\x8a\x41\x58\x18\x86\xe9\x63\x44\x30\x10\x74\xed\x60\x78\x61\x8b\xaf\x9c\x3f\x49\x18\x19\xa0\x4f\x9a\x40\xe7\x5c\xdb\xd3\x00\xfb\x36\x72\xe0\xf0\xcc\x7d\x7b\x86\xfc\x47\xf8\x80\x1f\x4f\xb7\xe6\x95\xc8\x3a\x82\x3b\xe4\x9b\xb7\xc8\x1f\x9d\x9f\x5d\xbc\x1f\xef\x99\x5d\x8f\x80\x1b\x3a\x4e\xe9\x94\x2c\x16\x4c\x1d\x3a\x2f\x2f\xc6\x07\xcf\x76\x9d\x34\x8e\xf9\x2a\x57\x7d\xce\x4a\x20\x25\x53\x91\x5a\x17\x6c\x50\x10\xf3\x64\x4f\xf0\xf6\xbc\x23\x48\xa5\x5c\x31\xd1\x16\xbc\xbb\xac\x04\xbc\x60\x7a\xb8\x29\xcf\xb7\xcf\x48\xb0\x5d\x82\xa7\xd8\xff\xf4\xb9\x67\x68\x75\xf5\x60\xe1\x1b\x14\x3a\x04\x4e\x7e\xfc\x3c\x29\x63\x32\x2a\x55\x94\xb1\x64\x59\x3e\x53\xc7\x00\x0c\xc4\x94\x4e\x3d\x3c\xbd\xdc\x24\xa2\xd5\x1c\x88\x3d\xc3\x01\x41\xb3\x39\xdc\xdb\xe4\xd6\x0b\xc9\xf6\x06\x3e\x7a\x2e\xde\x39\x8d\xf1\x55\x3f\x0a\x51\xc2\xb2\xf4\x1b\x13\x29\x3b\x0a\x14\x75\x6a\x1d\xa2\xcd\xba\x31\x18\xec\x0a\x7c\x7c\x83\x7d\xec\x9a\x38\x68\x00\xf5\x5c\x3d\x33\x07\xeb\x72\x4d\x14\x98\xc8\xc2\x65\x7e\x41\x97\x69\xbe\x8c\x14\x7f\x62\xf9\x9f\xa1\x53\xd0\x75\xc6\x69\xd3\x1b\xc1\x0f\xbf\xa9\xeb\x00\x3a\xa5\xd8\x73\xa1\x64\xfd\xf5\x35\x9b\xda\x2d\xfc\xac\xcc\xc8\xd9\x77\x15\x55\x41\x9b\xc5\xbd\x66\x67\x2d\x4e\x98\x10\x5c\x34\x75\x1f\x87\x03\xdb\xb5\xf0\x43\x0f\x07\xd1\x62\x1d\xb5\xeb\xdd\xec\xa2\x87\x96\x30\xb0\xdd\x29\x2c\x94\x60\x0c\x46\x7b\xfd\x0d\xd0\x46\x13\x4d\xbd\x16\x8a\xff\xbc\x1d\x91\xb7\x7f\xc1\x4a\xe9\xfc\x42\xd3\xac\x65\xfc\x6b\xca\x9a\xfd\x6f\x38\xab\xee\xbb\x88\xb5\x10\xe9\x40\xd6\x2c\xfb\x54\x53\xb3\x79\x63\xd2\xfa\xd9\x59\xfc\x25\x37\x0c\xcb\xf7\xe6\x43\xcc\xc5\x54\xc6\x34\x61\x57\xfd\xc2\x1d\xdc\x03\xb2\xd6\xc7\x5f\x51\x33\x76\xe7\x6c\x07\x00\x00")
+
+func migrations17_webhooksSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations17_webhooksSql,
+		"migrations/17_webhooks.sql",
+	)
+}
+
+func migrations17_webhooksSql() (*asset, error) {
+	bytes, err := migrations17_webhooksSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/17_webhooks.sql", size: 1900, mode: os.FileMode(420), modTime: time.Unix(1792360072, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations18_asset_stats_detailsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x91\xcd\x4e\xc3\x30\x10\x84\xef\x7e\x8a\xbd\x15\x44\x23\xa1\xc2\xad\x27\x13\x9b\x12\xc9\x75\x90\xe5\x20\x71\xb2\xac\xc4\x25\x91\x1a\xbb\xb2\x1d\x90\x78\x7a\x42\x39\xb4\x6e\x8a\xda\xeb\xec\x7e\xfb\x33\x93\x65\x70\xd7\x77\x1f\x5e\x47\x03\xd5\x0e\x61\x26\xa9\x00\x89\x9f\x18\x05\x1d\x82\x89\x2a\x44\x1d\x03\xc2\x84\x40\x5e\xb2\x6a\xcd\xc1\x0e\xbd\x1a\xac\x1e\x62\xeb\x7c\xf7\x6d\x1a\xa5\xeb\xda\x0d\x36\x06\x28\xb8\xa4\xab\x11\xe7\xa5\x04\x5e\x31\x06\x84\x3e\xe3\x8a\x49\xb8\x9f\x9f\x0e\x70\x9b\x8d\xf1\x57\x13\x9f\x6e\x3b\xf4\x46\x2d\x1e\xdb\xb1\x6b\x4d\x45\x91\x5f\x22\xa2\xd7\x8d\x51\xfb\xbb\xf6\xd8\x95\x8b\xb6\x3a\x44\xb5\xf3\x5d\x6d\x20\x7f\xc1\x02\xe7\xbf\x6e\xbc\x61\xf1\x5e\xf0\xd5\x94\x9d\xcd\x12\xb8\x75\xe3\x8d\x8d\xeb\x75\x67\xa7\xf4\xcd\xc3\xe2\xf6\xdc\x84\x25\x42\xd9\x51\x04\xc4\x7d\xd9\x7f\x43\x20\xa2\x7c\xbd\x98\xc2\x7c\xd2\xf6\xe7\x75\xaa\x1f\x1c\x4d\xf5\x13\xdf\xd2\xe2\xc1\x9d\x54\x3f\x7a\x7c\x89\x7e\x00\xb4\x48\x3e\x78\x51\x02\x00\x00")
+
+func migrations18_asset_stats_detailsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations18_asset_stats_detailsSql,
+		"migrations/18_asset_stats_details.sql",
+	)
+}
+
+func migrations18_asset_stats_detailsSql() (*asset, error) {
+	bytes, err := migrations18_asset_stats_detailsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/18_asset_stats_details.sql", size: 593, mode: os.FileMode(420), modTime: time.Unix(1792361151, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations19_trade_aggregationsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x54\x5b\x8f\xa2\x30\x14\x7e\xef\xaf\x38\xf1\x09\x5c\x9d\xcc\xbe\xcc\x8b\x4f\xa8\xac\x21\xab\x60\x50\x93\x31\x93\x09\xa9\xb4\x42\x13\xa0\xa4\x2d\x73\xf9\xf7\x5b\xca\xc8\xa0\x83\xc6\x6d\x02\x69\x4f\xbf\x73\xfb\xce\xe9\x19\x8f\xe1\x57\xce\x12\x81\x15\x85\x5d\x89\xd0\x78\x0c\x29\x93\x8a\x8b\xcf\x48\x09\x4c\xa8\x8c\x9e\x1e\xf5\x82\x94\x67\x44\x82\x4a\x29\x34\x62\xc0\x49\x22\x68\xa2\xd5\x08\x94\x54\x40\xce\x8a\x4a\x9b\xc0\x05\x01\x2c\x25\x55\x50\x62\x26\x1e\x6a\x73\x9e\x02\x26\x21\xc7\xac\x50\xfa\xd3\x70\x52\x09\x56\x24\xa0\x3f\x2a\x15\xe3\x85\x51\xaa\xa4\xbe\x51\x1c\x0e\x15\xcb\x48\xe3\xa3\x75\xa1\x31\x12\xf8\xb1\x36\x86\x8b\x4f\x10\x54\xf2\xac\x32\x9a\x2a\xc5\xc6\x3a\x86\xbc\xca\x14\x2b\x33\xaa\x71\xf5\xc9\x44\xf3\x80\x66\xa1\xeb\x6c\x5d\xd8\x3a\xd3\xa5\xdb\x9f\x97\x85\x40\xaf\x81\x62\xb9\x0e\x06\xe7\xe5\x00\xcc\x9a\x7a\x0b\xcf\xdf\xd6\x3b\x3f\xd8\x82\xbf\x5b\x2e\x47\x06\x78\xc0\x92\x46\x26\xc1\x88\x91\x5b\xc0\x98\x57\x85\xa2\xe2\x1b\x7b\x13\x08\x9d\xa5\x51\xee\xc2\x0d\xfb\x5d\xbf\xe9\xcc\x73\xda\x00\xfd\xdd\xca\x0d\xbd\xd9\x55\xd7\x2d\xf6\x1a\x30\x65\x49\xda\xf5\x7c\x02\xbe\xbc\x5e\x00\x33\xfe\x0e\x77\x01\x79\x49\x8b\xbb\x80\x71\xc6\x25\xbd\x07\xb8\x0e\xbd\x95\x13\xee\xe1\xaf\xbb\x07\xeb\x8c\xfd\xd1\x0f\x8e\x47\xdd\x3a\xda\xc8\x9e\xa0\x53\x03\x78\xfe\xdc\x7d\x86\x54\x09\xf2\xf4\x18\x1d\x74\x0b\x9c\x60\x10\xf8\xfd\x7d\xb1\xdb\x78\xfe\x02\x0e\x4a\x50\x0a\x56\xd7\xac\x36\xea\xf9\x1b\x37\xdc\xd6\x85\x0a\x7a\x95\xd1\xc6\x5d\xba\xb3\xed\x65\x67\xf5\x74\x50\x7f\xaf\x74\xa4\xd6\xd0\x6e\x4e\xb2\xca\xbf\xd2\xcf\x6b\x79\x47\xda\x6a\x77\x2f\x72\xfc\x11\x95\x82\xc5\xd4\x32\xff\x93\x94\x15\x3d\xd2\x23\x13\x52\x9d\x49\x32\xdc\x0a\xd0\x9f\x30\x58\x7d\xbd\x92\x4e\x5a\xf5\x22\xec\xcd\x8a\x6b\xa4\x45\x3f\x74\xfa\xb1\xb2\x68\xc9\xe3\x14\x8e\x82\xe7\x90\x51\x92\xe8\xa0\x4c\x9d\x49\x84\x95\x0d\x43\xf8\xad\xb9\xb1\xf5\x78\x80\x03\x4b\x58\x1d\x2a\x18\xba\xec\x61\x43\xb9\xbe\xf8\xc1\xd6\x15\xc6\xae\xb3\xf6\xad\x60\xe8\xe8\x81\x5f\xc8\x9d\x30\x74\xf6\x2f\x26\xd9\xa8\x18\x41\xb3\x21\xaf\x75\x34\x66\x6f\x80\x86\x84\xf3\x52\x1b\x79\x10\xce\xf5\x4b\x9d\xee\xdb\x3b\xfd\x00\x84\x19\x58\x4d\x3b\x72\x41\xa8\x18\x20\x1b\x9c\x8d\xe9\x3e\xb4\x08\x83\xdd\xba\x56\xf8\x9f\x56\x9e\x98\xb1\xdc\x8e\xe9\x39\x7f\x2f\xd0\x3c\x0c\xd6\xb7\xe6\x9a\x2e\x4c\xac\x8f\x13\xf4\x0f\x4e\xf3\x46\x85\xe1\x05\x00\x00")
+
+func migrations19_trade_aggregationsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations19_trade_aggregationsSql,
+		"migrations/19_trade_aggregations.sql",
+	)
+}
+
+func migrations19_trade_aggregationsSql() (*asset, error) {
+	bytes, err := migrations19_trade_aggregationsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/19_trade_aggregations.sql", size: 1505, mode: os.FileMode(420), modTime: time.Unix(1792361820, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _migrations1_initial_schemaSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x5a\x5f\x6f\xdb\xc8\x11\x7f\xf7\xa7\x18\xdc\x8b\x6c\xd4\x6a\x2f\xb8\xe2\x70\x95\xe1\x03\x14\x99\x69\x84\xca\x54\x22\x51\x4d\x82\xc3\x61\xb1\x22\x47\xd4\xd6\xe4\x2e\xb3\xbb\x74\xa4\x2b\xfa\xdd\x0b\x52\x24\xc5\xff\xa4\x1c\xc9\xf7\x28\xee\xec\xcc\xfc\x66\x66\x7f\x33\x5c\x6a\x38\x84\xbf\xf8\xcc\x95\x54\x23\xac\x82\xab\xe1\xf0\x6a\x38\x84\x0f\x42\x69\x57\xe2\xf2\xe3\x0c\x1c\xaa\xe9\x9a\x2a\x04\x27\xf4\xe3\xe5\xab\xa5\x61\x81\xd2\x54\xa3\x8f\x5c\x13\xcd\x7c\x14\xa1\x86\x7b\xf8\xf1\x2e\x5e\xf2\x84\xfd\x54\x7d\x6a\x7b\x2c\x92\x46\x6e\x0b\x87\x71\x17\xee\x61\xb0\xb2\xde\xfd\x32\xb8\x4b\xd5\x71\x87\x4a\x87\xd8\x82\x6f\x84\xf4\x19\x77\x89\xd2\x92\x71\x57\xc1\x3d\x08\x9e\xe8\xd8\xa2\xfd\x44\x36\x21\xb7\x35\x13\x9c\xac\x85\xc3\x30\x5a\xdf\x50\x4f\x61\xc1\x8c\xcf\x38\xf1\x51\x29\xea\xc6\x02\xdf\xa8\xe4\x8c\xbb\x77\x57\x09\x3c\x93\xfa\x38\x82\xc0\x0b\x5c\xf5\xd5\xbb\x03\x6b\x1f\xe0\x08\x8c\xcf\x96\x61\x2e\xa7\x73\xf3\x0e\x96\xf6\x16\x7d\x3a\x82\xe1\x1d\xcc\xbf\x71\x94\x23\x18\xc6\xc8\x27\x0b\x63\x6c\x19\x47\x49\x98\xbe\x03\x73\x6e\x81\xf1\x79\xba\xb4\x96\xa9\x42\xf8\x34\xb5\xde\xc3\x72\xf2\xde\x78\x1c\x43\xe0\x12\x9b\x6a\xea\x89\xc8\x7a\xc1\xfc\x51\x4b\xc9\x91\xc9\xfc\xf1\xd1\x30\xad\x16\x37\x0e\x02\x30\x37\xab\x4a\x60\xba\x84\xc1\x87\xd9\xdf\x02\x37\x4a\x5e\x20\x85\x8d\x4e\x28\xa9\x07\x1e\xe5\x6e\x48\x5d\x1c\x94\xfd\xd8\x2a\x2d\x24\x9e\x2f\x0a\x07\x7d\xc5\x20\x84\x6b\x8f\xd9\xcd\x01\x28\xba\xf0\x32\xfc\x89\xd9\x08\x7e\x54\xb2\xa0\xf7\x01\xc2\x46\x48\x88\x9e\x47\x15\xa7\x50\x2b\x10\x1b\xb8\x7e\xc2\xfd\x2d\x3c\x53\x2f\xc4\x1b\x08\x28\x93\x2a\x0e\x49\x5c\x86\x48\xa5\xbd\x25\x01\xd5\x5b\xb8\x4f\xbc\xbe\x2d\xa6\x30\x12\x73\x70\x43\x43\x4f\x13\x4d\xd7\x1e\xaa\x80\xda\x18\x95\xf3\xa0\xb4\xfa\x8d\xe9\x2d\x11\xcc\xc9\x55\x68\x31\xee\x2c\xf2\x6c\x4f\xa8\x6d\x8b\x90\x6b\x95\xc2\xb7\xc6\x6f\x67\xc6\x11\x7c\x12\xbb\x2c\x02\x77\x60\x65\x66\x47\xf9\x7c\xc4\xfb\x2a\x5a\xe1\xfa\x0a\x00\x80\x39\xb0\x66\x2e\xe3\x3a\xce\x94\xb9\x9a\xcd\x6e\xe3\xe7\xd4\x71\x24\x2a\x05\xf6\x96\x4a\x6a\x6b\x94\xf0\x4c\xe5\x9e\x71\xf7\xfa\xe7\xbf\xdf\x5c\xdd\x54\x6a\x25\xd1\x8e\x9b\x0d\xda\xe7\x76\x39\x51\x9a\x78\x5c\x02\x42\x9a\x10\xa4\x72\x22\x40\x49\x63\x5e\x68\x92\xfc\x41\x48\x07\xe5\x0f\xc0\xb8\x46\x17\x65\x69\x35\xae\x97\xfa\x25\x07\x35\x65\x9e\x82\xff\x28\xc1\xd7\xcd\x41\xf1\xd0\x71\x51\x9e\x39\x28\x89\xd2\x24\x28\x0a\xbf\x86\xc8\xed\x26\x47\x0f\xc2\x64\x4b\xd5\xb6\x3e\xa3\x25\xf9\x40\xe2\x33\x13\xa1\x22\x9d\x1b\x93\x18\x49\xca\x15\x3d\xb0\x6f\x9c\x95\xcc\x8f\x07\xe3\xdd\x78\x35\xb3\xe0\xc7\x92\x85\x63\x56\xfa\xc9\xdb\x9e\x50\xe8\x10\xaa\x21\xea\x20\x4a\x53\x3f\x80\xe8\x20\x45\xbd\x24\x7a\x02\x7f\x08\x8e\xe5\x3d\x12\xa9\xee\xdc\x74\x90\x0d\x03\xa7\xb7\x6c\x56\x47\xc9\x4f\x3f\x10\x52\xa3\x24\xcf\x28\x15\x13\xbc\x82\xe5\x4d\xb9\xa2\x84\xa6\x1e\xb1\x05\xe3\xaa\xbe\x20\x37\x88\x24\x10\xc2\xab\x5f\x8d\x9a\x2e\xd9\x60\x53\xae\xe3\x65\x89\x0a\xe5\x73\x93\x88\x4f\x77\x44\xef\x88\x42\x4d\x14\xfb\xa3\x2a\xd5\x5c\xca\xc7\xb4\x05\x54\x6a\x66\xb3\x80\x9e\x9d\xa1\xea\x6d\x1c\xf9\xaa\x1e\x53\xff\xe3\xde\x4d\x20\xa7\xe2\x27\xcc\x21\x0a\xbf\xa6\x61\x58\x1a\x1f\x57\x86\x39\x69\x89\x44\x1e\x7c\x2a\xdd\xcf\x46\x8c\x60\x69\x8d\x17\xd6\xa1\x91\xbe\x89\x1f\x4c\xcd\xc9\xc2\x88\x5b\xdf\xdb\x2f\xc9\x23\x73\x0e\x8f\x53\xf3\xdf\xe3\xd9\xca\xc8\x7e\x8f\x3f\x1f\x7f\x4f\xc6\x93\xf7\x06\xbc\x39\x0b\x50\x98\x7f\x32\x8d\x07\x78\xfb\xa5\x03\xf1\x78\x66\x19\x8b\x13\x01\x67\xba\x3b\xc4\xff\xca\x9c\x4e\x2c\x97\x2a\xd4\xae\x66\x9a\xa7\xc7\xc6\x86\x1b\x04\x1e\xb3\x0f\xb8\xe2\x7e\xf4\x9d\xed\xe8\xf0\x48\x89\x50\xda\x98\x96\x7a\x03\xf7\xa7\x3c\x35\x18\x8c\x46\x15\x89\x1e\x87\x22\x0f\xef\x72\xb4\xd0\x64\x25\x8e\x7d\x03\x2d\xd4\xed\xad\x4f\xc0\xf7\x90\x42\x93\x67\xe7\xa5\x85\x0e\x2b\xaf\x45\x0c\x27\x82\xfd\x4e\x6a\xe8\xb0\x56\x25\x87\xa6\x0d\x2d\xf4\x90\xdb\x72\xb9\x92\x4d\x29\x22\xef\x5f\xef\x71\x2c\x99\xc2\x3a\x86\xbc\xbe\x0c\xd2\x4e\x06\xb5\xb2\x47\xd3\xcd\xf3\x0a\x6d\x6c\xcd\x4d\xb3\xde\x9f\x32\xad\xe9\x1d\x41\xfe\x8c\x9e\x08\x10\x34\xee\x2a\x54\xbd\x8b\x66\xa7\xd0\xd3\x0d\x8b\x3e\x46\xaf\x90\xb5\x4b\x51\x14\x9a\x96\x15\x73\x39\xd5\xa1\xc4\xba\x37\xaa\x7f\xfc\x7c\xf3\xdb\xef\x47\x16\xfe\xef\xff\xea\x78\xf8\xb7\xdf\xcb\x43\x1c\xfa\x82\xc4\xdd\xa0\xca\xd9\x99\x2e\x2e\x38\xb6\xb2\xfa\x51\x57\x55\x4d\x82\x8c\xf9\x48\xd6\x22\xe4\x8e\x8a\x32\xf7\x8b\xa4\xdc\xc5\x98\x0c\xf3\x87\x89\x39\xe9\xd1\x49\x6c\xf7\x3a\xef\x87\xe3\x32\x37\x67\x5d\xdd\x1d\x0e\xf2\x93\xf9\x6c\xf5\x68\x46\x29\x8d\x5e\xa8\x53\x94\x1c\x77\xfa\x99\x7a\xd7\x83\x5e\x03\xc5\x60\x34\x92\xe8\xda\x1e\x55\xaa\xc2\xe8\x67\x43\xd1\xd8\xac\x4e\xc2\xd1\xc1\x7e\x6d\x48\x3a\x42\x11\x3c\xe1\xfe\x78\xad\x62\x2e\xad\xc5\x78\x6a\xb6\xa0\xad\x12\xde\x89\x09\x8c\x4b\x69\xfc\xf0\x90\xb3\xd6\xc7\x47\xf8\xb0\x98\x3e\x8e\x17\x5f\xe0\x5f\xc6\x17\xb8\x66\xce\xe9\x3d\xf8\x82\x48\x9b\x6c\xb6\x61\x6d\xf5\xb3\x13\xed\x3a\x1b\x50\x52\x48\x53\xf3\xc1\xf8\xfc\x82\x46\x15\xef\xcb\xe9\x83\xb9\x59\xdf\xb6\x56\xcb\xa9\xf9\x4f\x58\x6b\x89\x08\xd7\x89\xf0\x6d\xa5\x2f\xd4\x79\x1a\xb5\xb7\xb3\xb9\x19\xf7\xca\x5e\x3e\x96\x3b\x6c\x9d\x6b\x87\x86\x7a\x36\xe7\x0e\xea\xfa\xb9\x57\xea\xe5\xb7\xd5\xb6\x5d\x5b\xe3\x04\xc9\x7a\x7f\x58\xff\x5e\xb7\x57\xe6\xf4\xe3\x2a\xf5\xbe\xa4\x3b\x8f\x21\xbd\x76\x2b\xb8\x5f\xf7\x9a\x7d\x9b\xde\xa0\x35\x79\x7e\xa4\xd5\x73\xfa\xcc\x9c\xde\xde\x1e\xa7\xfa\xdb\xda\x8b\x82\x0e\x04\x22\x20\xc1\x45\x40\x24\x8a\xf3\x38\x1a\xfa\xdf\x8b\x60\x55\xd1\x64\x37\x7a\xeb\xfd\xd9\x01\x15\x75\xe7\x31\xa5\x77\x95\x05\x10\xf5\xee\xe5\x4f\xef\x45\x7c\xac\x18\xe8\x77\x6c\x6b\xbc\x65\xdc\xc1\x1d\x29\xdf\xab\x13\xc1\x49\x72\x79\x7e\x56\xd7\x3b\xad\xe5\x71\x64\x97\xfc\x45\xf6\x3e\x08\x9e\x00\xe4\xcc\xe1\x6f\x33\xd4\xed\x7e\x67\x0a\x12\x0a\x88\xf4\x45\x73\xf1\x79\xe8\xbd\xd5\x44\x27\x01\x45\x42\x1d\x5e\x27\x87\x23\x52\x99\x5d\x72\x5f\xc2\xf5\x3a\x3b\x9d\x87\x34\x93\xec\x0f\xe2\xa2\x35\x53\xb0\xf3\x12\x8a\x69\x56\x57\xba\xc5\xbf\x70\x0a\x2a\x1f\x0d\x3a\xb1\x94\x36\xf4\x47\x96\xfb\x86\xf3\x3a\x99\xc9\x7f\x34\xea\x82\x95\x93\xed\x8f\xa8\xee\xf3\xd4\xeb\x40\xab\xfd\x30\xd6\x85\xb1\x6e\x53\x7f\xb0\xe9\xa4\xf8\x3a\x00\xb3\x8b\x9e\x2e\x50\x8d\x93\x7f\x51\xf5\xf1\x8e\xfc\xe2\xdc\x50\x36\x55\x3b\x55\x9d\xca\x10\x45\xa5\xc5\x7b\xe4\x4b\x50\x44\x9b\xbd\x3e\x80\x8a\x3b\x4e\x03\x77\xa1\x9e\x59\xb5\xd2\x0b\x48\x5d\xe7\x8c\x87\x66\xbd\xbb\xd0\x34\x9e\x28\x6e\x18\x08\x5f\x38\x8f\x57\x13\xd2\x9c\x8f\xfc\xf8\x79\xf1\xe3\x52\x35\xf6\xe2\x49\x58\x4b\xea\x60\x36\x1b\xa5\xef\x92\x64\x2d\xc4\xd3\x79\x0a\xaa\xc5\x40\xe7\x08\x76\x7d\x9d\x7e\x17\x1b\xfe\xfa\x2b\x0c\x94\xf0\x1c\x42\x95\x42\x1d\x97\xe2\x60\x34\xd2\xb8\xd3\x37\x37\xb7\xd0\x2c\x68\x0b\xa7\x9f\x20\x53\x2a\x44\xd9\x2c\xba\x16\xa1\xbb\xd5\xbd\xcc\x17\x44\xdb\x1d\x28\x88\x96\x5c\xb8\x81\x4f\xef\x8d\x85\x71\x38\x4f\x70\x0f\x3f\xfd\x94\xcb\x5e\xd3\xbf\xf9\xc0\x16\x7e\xe0\xa1\xc6\x38\x13\xf9\x3f\x02\x3e\x88\x6f\xfc\xca\x91\x22\x80\xf8\x3f\x4e\xf5\xe5\x62\x53\x65\x53\x07\xef\x3a\x04\x8b\x07\xaa\x6d\x53\x8e\x23\x7a\x89\xf5\xd7\x9c\xb6\xb6\x36\x99\xb4\xaa\xda\x64\xb2\x37\x96\x4c\xe8\xff\x01\x00\x00\xff\xff\x5d\xb2\x1f\x7d\x3f\x29\x00\x00")
 
 func migrations1_initial_schemaSqlBytes() ([]byte, error) {
@@ -505,6 +568,9 @@ var _bindata = map[string]func() (*asset, error){
 	"migrations/14_fix_asset_toml_field.sql":            migrations14_fix_asset_toml_fieldSql,
 	"migrations/15_ledger_failed_txs.sql":               migrations15_ledger_failed_txsSql,
 	"migrations/16_ingest_failed_transactions.sql":      migrations16_ingest_failed_transactionsSql,
+	"migrations/17_webhooks.sql":                        migrations17_webhooksSql,
+	"migrations/18_asset_stats_details.sql":             migrations18_asset_stats_detailsSql,
+	"migrations/19_trade_aggregations.sql":              migrations19_trade_aggregationsSql,
 	"migrations/1_initial_schema.sql":                   migrations1_initial_schemaSql,
 	"migrations/2_index_participants_by_toid.sql":       migrations2_index_participants_by_toidSql,
 	"migrations/3_use_sequence_in_history_accounts.sql": migrations3_use_sequence_in_history_accountsSql,
@@ -567,6 +633,9 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"14_fix_asset_toml_field.sql":            &bintree{migrations14_fix_asset_toml_fieldSql, map[string]*bintree{}},
 		"15_ledger_failed_txs.sql":               &bintree{migrations15_ledger_failed_txsSql, map[string]*bintree{}},
 		"16_ingest_failed_transactions.sql":      &bintree{migrations16_ingest_failed_transactionsSql, map[string]*bintree{}},
+		"17_webhooks.sql":                        &bintree{migrations17_webhooksSql, map[string]*bintree{}},
+		"18_asset_stats_details.sql":             &bintree{migrations18_asset_stats_detailsSql, map[string]*bintree{}},
+		"19_trade_aggregations.sql":              &bintree{migrations19_trade_aggregationsSql, map[string]*bintree{}},
 		"1_initial_schema.sql":                   &bintree{migrations1_initial_schemaSql, map[string]*bintree{}},
 		"2_index_participants_by_toid.sql":       &bintree{migrations2_index_participants_by_toidSql, map[string]*bintree{}},
 		"3_use_sequence_in_history_accounts.sql": &bintree{migrations3_use_sequence_in_history_accountsSql, map[string]*bintree{}},
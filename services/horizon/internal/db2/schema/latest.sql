@@ -105,7 +105,13 @@ CREATE TABLE asset_stats (
     amount character varying NOT NULL,
     num_accounts integer NOT NULL,
     flags smallint NOT NULL,
-    toml character varying(255) NOT NULL
+    toml character varying(255) NOT NULL,
+    num_unauthorized_accounts integer DEFAULT 0 NOT NULL,
+    num_offers integer DEFAULT 0 NOT NULL,
+    volume_24h numeric DEFAULT 0 NOT NULL,
+    trade_count_24h integer DEFAULT 0 NOT NULL,
+    last_price character varying DEFAULT ''::character varying NOT NULL,
+    home_domain character varying(32) DEFAULT ''::character varying NOT NULL
 );
 
 
@@ -282,6 +288,24 @@ CREATE TABLE history_trades (
 );
 
 
+--
+-- Name: history_trades_60000; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_trades_60000 (
+    "timestamp" bigint NOT NULL,
+    base_asset_id bigint NOT NULL,
+    counter_asset_id bigint NOT NULL,
+    count integer NOT NULL,
+    base_volume numeric NOT NULL,
+    counter_volume numeric NOT NULL,
+    high numeric[] NOT NULL,
+    low numeric[] NOT NULL,
+    open numeric[] NOT NULL,
+    close numeric[] NOT NULL
+);
+
+
 --
 -- Name: history_transaction_participants; Type: TABLE; Schema: public; Owner: -
 --
@@ -387,6 +411,9 @@ INSERT INTO gorp_migrations VALUES ('13_trade_offer_ids.sql', '2019-02-21 13:54:
 INSERT INTO gorp_migrations VALUES ('14_fix_asset_toml_field.sql', '2019-02-21 13:54:34.151707+01');
 INSERT INTO gorp_migrations VALUES ('15_ledger_failed_txs.sql', '2019-02-21 13:54:34.154129+01');
 INSERT INTO gorp_migrations VALUES ('16_ingest_failed_transactions.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('17_webhooks.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('18_asset_stats_details.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('19_trade_aggregations.sql', '2019-02-21 13:54:34.155663+01');
 
 
 --
@@ -511,6 +538,14 @@ ALTER TABLE ONLY history_operation_participants
     ADD CONSTRAINT history_operation_participants_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: history_trades_60000 history_trades_60000_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trades_60000
+    ADD CONSTRAINT history_trades_60000_pkey PRIMARY KEY (base_asset_id, counter_asset_id, "timestamp");
+
+
 --
 -- Name: history_transaction_participants history_transaction_participants_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -673,6 +708,13 @@ CREATE UNIQUE INDEX htrd_pid ON history_trades USING btree (history_operation_id
 CREATE INDEX htrd_time_lookup ON history_trades USING btree (ledger_closed_at);
 
 
+--
+-- Name: htrd60_by_timestamp; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX htrd60_by_timestamp ON history_trades_60000 USING btree ("timestamp");
+
+
 --
 -- Name: index_history_accounts_on_address; Type: INDEX; Schema: public; Owner: -
 --
@@ -811,6 +853,188 @@ ALTER TABLE ONLY history_trades
     ADD CONSTRAINT history_trades_counter_asset_id_fkey FOREIGN KEY (counter_asset_id) REFERENCES history_assets(id);
 
 
+--
+-- Name: webhooks; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE webhooks (
+    id bigint NOT NULL,
+    url character varying(2048) NOT NULL,
+    secret character varying(64) NOT NULL,
+    account character varying(64),
+    asset_type character varying(64),
+    asset_code character varying(12),
+    asset_issuer character varying(56),
+    operation_types integer[] DEFAULT '{}'::integer[] NOT NULL,
+    last_ledger integer NOT NULL,
+    created_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: webhooks_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE webhooks_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: webhooks_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE webhooks_id_seq OWNED BY webhooks.id;
+
+
+--
+-- Name: webhooks id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY webhooks ALTER COLUMN id SET DEFAULT nextval('webhooks_id_seq'::regclass);
+
+
+--
+-- Name: webhooks webhooks_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY webhooks
+    ADD CONSTRAINT webhooks_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhook_deliveries; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE webhook_deliveries (
+    id bigint NOT NULL,
+    webhook_id bigint NOT NULL,
+    paging_token character varying(64) NOT NULL,
+    payload text NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp without time zone NOT NULL,
+    last_error text,
+    created_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: webhook_deliveries_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE webhook_deliveries_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: webhook_deliveries_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE webhook_deliveries_id_seq OWNED BY webhook_deliveries.id;
+
+
+--
+-- Name: webhook_deliveries id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY webhook_deliveries ALTER COLUMN id SET DEFAULT nextval('webhook_deliveries_id_seq'::regclass);
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhook_deliveries_by_next_attempt; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX webhook_deliveries_by_next_attempt ON webhook_deliveries USING btree (next_attempt_at);
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_webhook_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_webhook_id_fkey FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE;
+
+
+--
+-- Name: webhook_dead_letters; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE webhook_dead_letters (
+    id bigint NOT NULL,
+    webhook_id bigint NOT NULL,
+    paging_token character varying(64) NOT NULL,
+    payload text NOT NULL,
+    attempts integer NOT NULL,
+    last_error text,
+    created_at timestamp without time zone NOT NULL,
+    failed_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: webhook_dead_letters_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE webhook_dead_letters_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: webhook_dead_letters_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE webhook_dead_letters_id_seq OWNED BY webhook_dead_letters.id;
+
+
+--
+-- Name: webhook_dead_letters id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY webhook_dead_letters ALTER COLUMN id SET DEFAULT nextval('webhook_dead_letters_id_seq'::regclass);
+
+
+--
+-- Name: webhook_dead_letters webhook_dead_letters_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY webhook_dead_letters
+    ADD CONSTRAINT webhook_dead_letters_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhook_dead_letters_by_webhook; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX webhook_dead_letters_by_webhook ON webhook_dead_letters USING btree (webhook_id, id);
+
+
+--
+-- Name: webhook_dead_letters webhook_dead_letters_webhook_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY webhook_dead_letters
+    ADD CONSTRAINT webhook_dead_letters_webhook_id_fkey FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE;
+
+
 --
 -- PostgreSQL database dump complete
 --
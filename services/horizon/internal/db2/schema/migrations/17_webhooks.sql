@@ -0,0 +1,46 @@
+-- +migrate Up
+
+CREATE TABLE webhooks (
+    id              BIGSERIAL                   PRIMARY KEY,
+    url             VARCHAR(2048)               NOT NULL,
+    secret          VARCHAR(64)                 NOT NULL,
+    account         VARCHAR(64),
+    asset_type      VARCHAR(64),
+    asset_code      VARCHAR(12),
+    asset_issuer    VARCHAR(56),
+    operation_types INTEGER[]                   NOT NULL DEFAULT '{}',
+    last_ledger     INTEGER                     NOT NULL,
+    created_at      TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    id              BIGSERIAL                   PRIMARY KEY,
+    webhook_id      BIGINT                      NOT NULL REFERENCES webhooks ON DELETE CASCADE,
+    paging_token    VARCHAR(64)                 NOT NULL,
+    payload         TEXT                        NOT NULL,
+    attempts        INTEGER                     NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    last_error      TEXT,
+    created_at      TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX webhook_deliveries_by_next_attempt ON webhook_deliveries USING btree (next_attempt_at);
+
+CREATE TABLE webhook_dead_letters (
+    id              BIGSERIAL                   PRIMARY KEY,
+    webhook_id      BIGINT                      NOT NULL REFERENCES webhooks ON DELETE CASCADE,
+    paging_token    VARCHAR(64)                 NOT NULL,
+    payload         TEXT                        NOT NULL,
+    attempts        INTEGER                     NOT NULL,
+    last_error      TEXT,
+    created_at      TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    failed_at       TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX webhook_dead_letters_by_webhook ON webhook_dead_letters USING btree (webhook_id, id);
+
+-- +migrate Down
+
+DROP TABLE webhook_dead_letters cascade;
+DROP TABLE webhook_deliveries cascade;
+DROP TABLE webhooks cascade;
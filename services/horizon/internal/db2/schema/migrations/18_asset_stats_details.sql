@@ -0,0 +1,17 @@
+-- +migrate Up
+ALTER TABLE asset_stats
+ADD COLUMN num_unauthorized_accounts INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN num_offers INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN volume_24h NUMERIC NOT NULL DEFAULT 0,
+ADD COLUMN trade_count_24h INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN last_price CHARACTER VARYING NOT NULL DEFAULT '',
+ADD COLUMN home_domain CHARACTER VARYING(32) NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE asset_stats
+DROP COLUMN num_unauthorized_accounts,
+DROP COLUMN num_offers,
+DROP COLUMN volume_24h,
+DROP COLUMN trade_count_24h,
+DROP COLUMN last_price,
+DROP COLUMN home_domain;
@@ -0,0 +1,48 @@
+-- +migrate Up
+
+-- history_trades_60000 holds the trades aggregated per minute and asset pair.
+-- It is maintained during ingestion and used to build trade aggregations of
+-- any resolution that is a multiple of a minute.
+CREATE TABLE history_trades_60000 (
+    "timestamp"      BIGINT    NOT NULL,
+    base_asset_id    BIGINT    NOT NULL,
+    counter_asset_id BIGINT    NOT NULL,
+    count            INTEGER   NOT NULL,
+    base_volume      NUMERIC   NOT NULL,
+    counter_volume   NUMERIC   NOT NULL,
+    high             NUMERIC[] NOT NULL,
+    low              NUMERIC[] NOT NULL,
+    open             NUMERIC[] NOT NULL,
+    close            NUMERIC[] NOT NULL,
+    PRIMARY KEY (base_asset_id, counter_asset_id, "timestamp")
+);
+
+CREATE INDEX htrd60_by_timestamp ON history_trades_60000 USING btree ("timestamp");
+
+INSERT INTO history_trades_60000
+SELECT
+    "timestamp",
+    base_asset_id,
+    counter_asset_id,
+    count(*),
+    sum(base_amount),
+    sum(counter_amount),
+    max_price(price),
+    min_price(price),
+    first(price),
+    last(price)
+FROM (
+    SELECT
+        div(cast((extract(epoch from ledger_closed_at) * 1000) as bigint), 60000)*60000 as "timestamp",
+        base_asset_id,
+        counter_asset_id,
+        base_amount,
+        counter_amount,
+        ARRAY[price_n, price_d] as price
+    FROM history_trades
+    ORDER BY history_operation_id, "order"
+) AS htrd
+GROUP BY base_asset_id, counter_asset_id, "timestamp";
+
+-- +migrate Down
+DROP TABLE history_trades_60000 cascade;
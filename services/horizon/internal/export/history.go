@@ -0,0 +1,484 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/resourceadapter"
+	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+)
+
+// Table is a kind of history record that can be exported.
+type Table string
+
+const (
+	TableTransactions Table = "transactions"
+	TableOperations   Table = "operations"
+	TableEffects      Table = "effects"
+	TableTrades       Table = "trades"
+)
+
+// AllTables are all the tables that can be exported, in the order they are
+// exported.
+var AllTables = []Table{TableTransactions, TableOperations, TableEffects, TableTrades}
+
+// DefaultChunkSize is the default number of ledgers exported to every file.
+const DefaultChunkSize = 10000
+
+// progressFile is the name of the file, in the export directory, recording the
+// last exported ledger.
+const progressFile = "export-history.json"
+
+// ParseTables parses a comma separated list of tables.
+func ParseTables(s string) ([]Table, error) {
+	var tables []Table
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		table := Table(name)
+		switch table {
+		case TableTransactions, TableOperations, TableEffects, TableTrades:
+		default:
+			return nil, fmt.Errorf("invalid table: %s (expected transactions, operations, effects or trades)", name)
+		}
+
+		for _, t := range tables {
+			if t == table {
+				return nil, fmt.Errorf("duplicate table: %s", name)
+			}
+		}
+		tables = append(tables, table)
+	}
+
+	if len(tables) == 0 {
+		return nil, errors.New("no table to export")
+	}
+	return tables, nil
+}
+
+// History exports the transactions, operations, effects and trades stored in
+// the horizon database, in ledger order.  Ledgers are exported in chunks of
+// ChunkSize ledgers, every chunk being written to one file per table, named
+// after the table and the chunk ledger range, for example
+// `operations-0000010000-0000019999.jsonl`.  Every chunk is read in a single
+// repeatable read transaction so that the files of a chunk are consistent
+// with each other.
+//
+// After every chunk the last exported ledger is recorded in the export
+// directory, so that an interrupted export resumes after the last complete
+// chunk when run again with the same options.
+type History struct {
+	HorizonDB *db.Session
+	// From is the first ledger to export.  Zero exports from the oldest ledger
+	// in the horizon database.
+	From int32
+	// To is the last ledger to export.  Zero exports up to the latest ledger in
+	// the horizon database.
+	To        int32
+	Tables    []Table
+	Format    Format
+	ChunkSize int32
+	Dir       string
+}
+
+// HistoryResult describes a completed history export.
+type HistoryResult struct {
+	// From and To are the ledger range exported by this run.  From is greater
+	// than To if everything was exported by a previous run.
+	From int32
+	To   int32
+	// Files maps the path of every written file to the number of rows it
+	// contains.
+	Files map[string]int
+}
+
+// historyProgress is the content of the progress file.
+type historyProgress struct {
+	Tables      []Table `json:"tables"`
+	Format      Format  `json:"format"`
+	ChunkSize   int32   `json:"chunk_size"`
+	FirstLedger int32   `json:"first_ledger"`
+	LastLedger  int32   `json:"last_ledger"`
+}
+
+// resume returns the first ledger to export when the export should start at
+// `from`: the ledger after the last exported one when `from` is in the range
+// of ledgers that were already exported, `from` otherwise.  It resets the
+// progress when the export does not continue the previous one.
+func (p *historyProgress) resume(from int32) int32 {
+	if p.LastLedger != 0 && p.FirstLedger <= from && from <= p.LastLedger {
+		return p.LastLedger + 1
+	}
+
+	p.FirstLedger = from
+	p.LastLedger = 0
+	return from
+}
+
+// Run exports the history.
+func (h *History) Run() (*HistoryResult, error) {
+	if h.ChunkSize <= 0 {
+		h.ChunkSize = DefaultChunkSize
+	}
+
+	q := &history.Q{Session: h.HorizonDB}
+
+	var elder, latest int32
+	err := q.ElderLedger(&elder)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading oldest ledger failed")
+	}
+	err = q.LatestLedger(&latest)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading latest ledger failed")
+	}
+
+	from, to := h.From, h.To
+	if from == 0 {
+		from = elder
+	}
+	if to == 0 {
+		to = latest
+	}
+
+	switch {
+	case from > to:
+		return nil, fmt.Errorf("invalid ledger range: %d is after %d", from, to)
+	case from < elder:
+		return nil, fmt.Errorf("cannot export from ledger %d: the oldest ledger in the horizon database is %d", from, elder)
+	case to > latest:
+		return nil, fmt.Errorf("cannot export up to ledger %d: the latest ledger in the horizon database is %d", to, latest)
+	}
+
+	progress, err := h.loadProgress()
+	if err != nil {
+		return nil, err
+	}
+	from = progress.resume(from)
+
+	result := &HistoryResult{From: from, To: to, Files: map[string]int{}}
+
+	for start := from; start <= to; start += h.ChunkSize {
+		end := start + h.ChunkSize - 1
+		if end > to {
+			end = to
+		}
+
+		err = h.exportChunk(result, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		progress.LastLedger = end
+		err = h.saveProgress(progress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// exportChunk writes the files of the ledgers from `start` to `end`.
+func (h *History) exportChunk(result *HistoryResult, start, end int32) error {
+	q := &history.Q{Session: h.HorizonDB.Clone()}
+
+	err := q.Begin()
+	if err != nil {
+		return errors.Wrap(err, "begin failed")
+	}
+	defer q.Rollback()
+
+	_, err = q.ExecRaw("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY")
+	if err != nil {
+		return errors.Wrap(err, "set transaction failed")
+	}
+
+	for _, table := range h.Tables {
+		var (
+			proto interface{}
+			fn    func(rowWriter) error
+		)
+
+		switch table {
+		case TableTransactions:
+			proto = transactionRow{}
+			fn = func(w rowWriter) error {
+				return q.StreamTransactions(start, end, func(tx history.Transaction) error {
+					return w.Write(newTransactionRow(tx))
+				})
+			}
+		case TableOperations:
+			proto = operationRow{}
+			fn = func(w rowWriter) error {
+				return q.StreamOperations(start, end, func(op history.Operation) error {
+					return w.Write(newOperationRow(op))
+				})
+			}
+		case TableEffects:
+			proto = effectRow{}
+			fn = func(w rowWriter) error {
+				return q.StreamEffects(start, end, func(effect history.Effect) error {
+					return w.Write(newEffectRow(effect))
+				})
+			}
+		case TableTrades:
+			proto = tradeRow{}
+			fn = func(w rowWriter) error {
+				return q.StreamTrades(start, end, func(trade history.Trade) error {
+					return w.Write(newTradeRow(trade))
+				})
+			}
+		default:
+			return fmt.Errorf("invalid table: %s", table)
+		}
+
+		name := fmt.Sprintf("%s-%010d-%010d.%s", table, start, end, h.Format)
+		path := filepath.Join(h.Dir, name)
+
+		count, err := writeFile(path, h.Format, proto, fn)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("exporting %s of ledgers %d-%d failed", table, start, end))
+		}
+		result.Files[path] = count
+	}
+
+	return nil
+}
+
+// loadProgress loads the progress of a previous export to the same directory.
+// It fails if that export was made with different options.
+func (h *History) loadProgress() (historyProgress, error) {
+	progress := historyProgress{
+		Tables:    h.Tables,
+		Format:    h.Format,
+		ChunkSize: h.ChunkSize,
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(h.Dir, progressFile))
+	if os.IsNotExist(err) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, errors.Wrap(err, "reading progress failed")
+	}
+
+	var previous historyProgress
+	err = json.Unmarshal(data, &previous)
+	if err != nil {
+		return progress, errors.Wrap(err, "parsing progress failed")
+	}
+
+	if !sameTables(previous.Tables, h.Tables) || previous.Format != h.Format || previous.ChunkSize != h.ChunkSize {
+		return progress, fmt.Errorf(
+			"%s was written by an export with different tables, format or chunk size: use another directory or remove it",
+			filepath.Join(h.Dir, progressFile),
+		)
+	}
+
+	return previous, nil
+}
+
+// saveProgress records `progress` in the export directory.
+func (h *History) saveProgress(progress historyProgress) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return errors.Wrap(err, "encoding progress failed")
+	}
+
+	path := filepath.Join(h.Dir, progressFile)
+	err = ioutil.WriteFile(path+".tmp", data, 0644)
+	if err != nil {
+		return errors.Wrap(err, "writing progress failed")
+	}
+
+	return errors.Wrap(os.Rename(path+".tmp", path), "writing progress failed")
+}
+
+func sameTables(a, b []Table) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// transactionRow is a transaction.  The XDR fields are base64 encoded.
+type transactionRow struct {
+	ID               int64  `json:"id"`
+	Hash             string `json:"hash"`
+	Ledger           int32  `json:"ledger"`
+	LedgerCloseTime  string `json:"ledger_close_time"`
+	ApplicationOrder int32  `json:"application_order"`
+	Account          string `json:"account"`
+	AccountSequence  string `json:"account_sequence"`
+	FeePaid          int32  `json:"fee_paid"`
+	OperationCount   int32  `json:"operation_count"`
+	Successful       bool   `json:"successful"`
+	MemoType         string `json:"memo_type"`
+	Memo             string `json:"memo"`
+	Signatures       string `json:"signatures"`
+	ValidAfter       int64  `json:"valid_after"`
+	ValidBefore      int64  `json:"valid_before"`
+	EnvelopeXDR      string `json:"envelope_xdr"`
+	ResultXDR        string `json:"result_xdr"`
+	ResultMetaXDR    string `json:"result_meta_xdr"`
+	FeeMetaXDR       string `json:"fee_meta_xdr"`
+}
+
+func newTransactionRow(tx history.Transaction) transactionRow {
+	return transactionRow{
+		ID:               tx.ID,
+		Hash:             tx.TransactionHash,
+		Ledger:           tx.LedgerSequence,
+		LedgerCloseTime:  tx.LedgerCloseTime.UTC().Format(time.RFC3339),
+		ApplicationOrder: tx.ApplicationOrder,
+		Account:          tx.Account,
+		AccountSequence:  tx.AccountSequence,
+		FeePaid:          tx.FeePaid,
+		OperationCount:   tx.OperationCount,
+		// NULL indicates a successful transaction, see history.Transaction.
+		Successful:    tx.Successful == nil || *tx.Successful,
+		MemoType:      tx.MemoType,
+		Memo:          tx.Memo.String,
+		Signatures:    tx.SignatureString,
+		ValidAfter:    tx.ValidAfter.Int64,
+		ValidBefore:   tx.ValidBefore.Int64,
+		EnvelopeXDR:   tx.TxEnvelope,
+		ResultXDR:     tx.TxResult,
+		ResultMetaXDR: tx.TxMeta,
+		FeeMetaXDR:    tx.TxFeeMeta,
+	}
+}
+
+// operationRow is an operation.  Details are the type specific fields of the
+// operation, as served by the operations endpoints.
+type operationRow struct {
+	ID                    int64     `json:"id"`
+	TransactionID         int64     `json:"transaction_id"`
+	TransactionHash       string    `json:"transaction_hash"`
+	TransactionSuccessful bool      `json:"transaction_successful"`
+	Ledger                int32     `json:"ledger"`
+	ApplicationOrder      int32     `json:"application_order"`
+	Type                  string    `json:"type"`
+	TypeI                 int32     `json:"type_i"`
+	SourceAccount         string    `json:"source_account"`
+	Details               jsonValue `json:"details"`
+}
+
+func newOperationRow(op history.Operation) operationRow {
+	return operationRow{
+		ID:                    op.ID,
+		TransactionID:         op.TransactionID,
+		TransactionHash:       op.TransactionHash,
+		TransactionSuccessful: op.TransactionSuccessful == nil || *op.TransactionSuccessful,
+		Ledger:                toid.Parse(op.ID).LedgerSequence,
+		ApplicationOrder:      op.ApplicationOrder,
+		Type:                  operations.TypeNames[op.Type],
+		TypeI:                 int32(op.Type),
+		SourceAccount:         op.SourceAccount,
+		Details:               jsonValue(op.DetailsString.String),
+	}
+}
+
+// effectRow is an effect.  Details are the type specific fields of the effect,
+// as served by the effects endpoints.
+type effectRow struct {
+	ID          string    `json:"id"`
+	OperationID int64     `json:"operation_id"`
+	Order       int32     `json:"order"`
+	Ledger      int32     `json:"ledger"`
+	Account     string    `json:"account"`
+	Type        string    `json:"type"`
+	TypeI       int32     `json:"type_i"`
+	Details     jsonValue `json:"details"`
+}
+
+func newEffectRow(effect history.Effect) effectRow {
+	return effectRow{
+		ID:          effect.ID(),
+		OperationID: effect.HistoryOperationID,
+		Order:       effect.Order,
+		Ledger:      effect.LedgerSequence(),
+		Account:     effect.Account,
+		Type:        resourceadapter.EffectTypeNames[effect.Type],
+		TypeI:       int32(effect.Type),
+		Details:     jsonValue(effect.DetailsString.String),
+	}
+}
+
+// tradeRow is a trade.  Offer ids are empty when not known.
+type tradeRow struct {
+	ID                 string `json:"id"`
+	OperationID        int64  `json:"operation_id"`
+	Order              int32  `json:"order"`
+	Ledger             int32  `json:"ledger"`
+	LedgerCloseTime    string `json:"ledger_close_time"`
+	OfferID            int64  `json:"offer_id"`
+	BaseOfferID        string `json:"base_offer_id"`
+	BaseAccount        string `json:"base_account"`
+	BaseAssetType      string `json:"base_asset_type"`
+	BaseAssetCode      string `json:"base_asset_code"`
+	BaseAssetIssuer    string `json:"base_asset_issuer"`
+	BaseAmount         string `json:"base_amount"`
+	CounterOfferID     string `json:"counter_offer_id"`
+	CounterAccount     string `json:"counter_account"`
+	CounterAssetType   string `json:"counter_asset_type"`
+	CounterAssetCode   string `json:"counter_asset_code"`
+	CounterAssetIssuer string `json:"counter_asset_issuer"`
+	CounterAmount      string `json:"counter_amount"`
+	BaseIsSeller       bool   `json:"base_is_seller"`
+	PriceN             int64  `json:"price_n"`
+	PriceD             int64  `json:"price_d"`
+}
+
+func newTradeRow(trade history.Trade) tradeRow {
+	return tradeRow{
+		ID:                 trade.PagingToken(),
+		OperationID:        trade.HistoryOperationID,
+		Order:              trade.Order,
+		Ledger:             toid.Parse(trade.HistoryOperationID).LedgerSequence,
+		LedgerCloseTime:    trade.LedgerCloseTime.UTC().Format(time.RFC3339),
+		OfferID:            trade.OfferID,
+		BaseOfferID:        formatOfferID(trade.BaseOfferID),
+		BaseAccount:        trade.BaseAccount,
+		BaseAssetType:      trade.BaseAssetType,
+		BaseAssetCode:      trade.BaseAssetCode,
+		BaseAssetIssuer:    trade.BaseAssetIssuer,
+		BaseAmount:         amount.String(trade.BaseAmount),
+		CounterOfferID:     formatOfferID(trade.CounterOfferID),
+		CounterAccount:     trade.CounterAccount,
+		CounterAssetType:   trade.CounterAssetType,
+		CounterAssetCode:   trade.CounterAssetCode,
+		CounterAssetIssuer: trade.CounterAssetIssuer,
+		CounterAmount:      amount.String(trade.CounterAmount),
+		BaseIsSeller:       trade.BaseIsSeller,
+		PriceN:             trade.PriceN.Int64,
+		PriceD:             trade.PriceD.Int64,
+	}
+}
+
+func formatOfferID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
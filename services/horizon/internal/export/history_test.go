@@ -0,0 +1,168 @@
+package export
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTables(t *testing.T) {
+	tables, err := ParseTables("transactions, trades")
+	require.NoError(t, err)
+	assert.Equal(t, []Table{TableTransactions, TableTrades}, tables)
+
+	for _, invalid := range []string{"", ",", "ledgers", "effects,effects"} {
+		_, err = ParseTables(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestEffectRowDetails(t *testing.T) {
+	row := newEffectRow(history.Effect{
+		Account:            "GA",
+		HistoryOperationID: 8589938689,
+		Order:              1,
+		Type:               history.EffectDataCreated,
+		DetailsString:      null.StringFrom(`{"name":"a","value":"MQ=="}`),
+	})
+
+	var buf bytes.Buffer
+	w := newRowWriter(FormatJSONL, &buf, effectRow{})
+	require.NoError(t, w.Write(row))
+	assert.Equal(t,
+		`{"id":"0000000008589938689-0000000001","operation_id":8589938689,"order":1,"ledger":2,`+
+			`"account":"GA","type":"data_created","type_i":40,"details":{"name":"a","value":"MQ=="}}`+"\n",
+		buf.String(),
+	)
+
+	buf.Reset()
+	w = newRowWriter(FormatCSV, &buf, effectRow{})
+	require.NoError(t, w.Write(row))
+	require.NoError(t, w.Flush())
+	assert.Equal(t,
+		"id,operation_id,order,ledger,account,type,type_i,details\n"+
+			`0000000008589938689-0000000001,8589938689,1,2,GA,data_created,40,"{""name"":""a"",""value"":""MQ==""}"`+"\n",
+		buf.String(),
+	)
+
+	// effects without details
+	row.Details = ""
+	buf.Reset()
+	w = newRowWriter(FormatJSONL, &buf, effectRow{})
+	require.NoError(t, w.Write(row))
+	assert.Contains(t, buf.String(), `"details":null`)
+}
+
+func TestHistoryProgress(t *testing.T) {
+	dir, err := ioutil.TempDir("", "export-history")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	h := &History{Tables: []Table{TableOperations}, Format: FormatCSV, ChunkSize: 100, Dir: dir}
+
+	progress, err := h.loadProgress()
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), progress.LastLedger)
+
+	progress.LastLedger = 199
+	require.NoError(t, h.saveProgress(progress))
+
+	progress, err = h.loadProgress()
+	require.NoError(t, err)
+	assert.Equal(t, int32(199), progress.LastLedger)
+
+	// exports starting in the exported range resume after it, other exports
+	// start over
+	progress = historyProgress{FirstLedger: 1000, LastLedger: 2000}
+	assert.Equal(t, int32(2001), progress.resume(1000))
+	assert.Equal(t, int32(2001), progress.resume(1500))
+	assert.Equal(t, int32(2001), progress.resume(2000))
+
+	assert.Equal(t, int32(500), progress.resume(500))
+	assert.Equal(t, int32(500), progress.FirstLedger)
+	assert.Equal(t, int32(0), progress.LastLedger)
+
+	progress = historyProgress{FirstLedger: 1000, LastLedger: 2000}
+	assert.Equal(t, int32(2001), progress.resume(2001))
+	assert.Equal(t, int32(3000), progress.resume(3000))
+	assert.Equal(t, int32(3000), progress.FirstLedger)
+
+	// an export with other options cannot resume
+	other := *h
+	other.Format = FormatJSONL
+	_, err = other.loadProgress()
+	assert.Error(t, err)
+
+	other = *h
+	other.Tables = []Table{TableOperations, TableEffects}
+	_, err = other.loadProgress()
+	assert.Error(t, err)
+}
+
+func TestHistoryRun(t *testing.T) {
+	tt := test.Start(t).Scenario("failed_transactions")
+	defer tt.Finish()
+
+	dir, err := ioutil.TempDir("", "export-history")
+	tt.Require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	h := &History{
+		HorizonDB: tt.HorizonSession(),
+		From:      1,
+		Tables:    AllTables,
+		Format:    FormatJSONL,
+		ChunkSize: 2,
+		Dir:       dir,
+	}
+
+	result, err := h.Run()
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int32(1), result.From)
+
+	var transactions int
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&transactions, "SELECT COUNT(*) FROM history_transactions"))
+
+	exported := 0
+	for path, count := range result.Files {
+		if filepath.Base(path)[:len("transactions")] == "transactions" {
+			exported += count
+		}
+	}
+	tt.Assert.Equal(transactions, exported)
+	tt.Assert.Len(result.Files, len(AllTables)*int((result.To+1)/2))
+
+	_, err = os.Stat(filepath.Join(dir, "transactions-0000000001-0000000002.jsonl"))
+	tt.Assert.NoError(err)
+
+	// running again resumes after the last exported ledger
+	result, err = h.Run()
+	tt.Require.NoError(err)
+	tt.Assert.Empty(result.Files)
+
+	// an earlier range is exported even though later ledgers were
+	latest := result.To
+	tt.Require.True(latest > 2)
+	h.Dir, err = ioutil.TempDir("", "export-history")
+	tt.Require.NoError(err)
+	defer os.RemoveAll(h.Dir)
+
+	h.From, h.To = latest, latest
+	_, err = h.Run()
+	tt.Require.NoError(err)
+
+	h.From, h.To = 1, 2
+	result, err = h.Run()
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int32(1), result.From)
+	tt.Assert.Equal(int32(2), result.To)
+	tt.Assert.Len(result.Files, len(AllTables))
+}
@@ -0,0 +1,207 @@
+// Package export contains the data export subsystem of horizon.  It writes
+// the ledger state found in the stellar-core database and the history found
+// in the horizon database to files that can be used for audits and analytics.
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Format is the file format rows are exported in.
+type Format string
+
+const (
+	// FormatJSONL writes one JSON object per line.
+	FormatJSONL Format = "jsonl"
+	// FormatCSV writes a CSV file with a header row.
+	FormatCSV Format = "csv"
+)
+
+// ParseFormat returns the Format named `name`.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(name); f {
+	case FormatJSONL, FormatCSV:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid format: %s (expected jsonl or csv)", name)
+	}
+}
+
+// ParseAsset parses an asset given either as `native` or as `code:issuer`.
+func ParseAsset(s string) (xdr.Asset, error) {
+	var asset xdr.Asset
+
+	if s == "native" {
+		err := asset.SetNative()
+		return asset, err
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return asset, fmt.Errorf("invalid asset: %s (expected native or code:issuer)", s)
+	}
+
+	var issuer xdr.AccountId
+	err := issuer.SetAddress(parts[1])
+	if err != nil {
+		return asset, errors.Wrap(err, "invalid asset issuer")
+	}
+
+	err = asset.SetCredit(parts[0], issuer)
+	if err != nil {
+		return asset, errors.Wrap(err, "invalid asset code")
+	}
+	return asset, nil
+}
+
+// writeFile writes the rows produced by `fn` to the file at `path`, returning
+// the number of rows written.  Rows are written to a temporary file that is
+// only moved in place once all rows have been written, so that an interrupted
+// export never leaves a partial file behind.
+func writeFile(path string, format Format, proto interface{}, fn func(rowWriter) error) (int, error) {
+	tmp := path + ".tmp"
+
+	file, err := os.Create(tmp)
+	if err != nil {
+		return 0, errors.Wrap(err, "creating file failed")
+	}
+	defer os.Remove(tmp)
+	defer file.Close()
+
+	buf := bufio.NewWriter(file)
+	counter := &countingWriter{rowWriter: newRowWriter(format, buf, proto)}
+
+	err = fn(counter)
+	if err != nil {
+		return 0, err
+	}
+
+	err = counter.Flush()
+	if err != nil {
+		return 0, errors.Wrap(err, "writing file failed")
+	}
+
+	err = buf.Flush()
+	if err != nil {
+		return 0, errors.Wrap(err, "writing file failed")
+	}
+
+	err = file.Close()
+	if err != nil {
+		return 0, errors.Wrap(err, "closing file failed")
+	}
+
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return 0, errors.Wrap(err, "renaming file failed")
+	}
+
+	return counter.count, nil
+}
+
+// countingWriter counts the rows written to the wrapped rowWriter.
+type countingWriter struct {
+	rowWriter
+	count int
+}
+
+func (w *countingWriter) Write(row interface{}) error {
+	w.count++
+	return w.rowWriter.Write(row)
+}
+
+// jsonValue is a JSON document.  It is embedded as is in JSONL files and
+// written as text in CSV files.
+type jsonValue string
+
+// MarshalJSON implements json.Marshaler.
+func (v jsonValue) MarshalJSON() ([]byte, error) {
+	if v == "" {
+		return []byte("null"), nil
+	}
+	return []byte(v), nil
+}
+
+// rowWriter writes rows, structs whose fields are tagged with `json`, to an
+// underlying writer.
+type rowWriter interface {
+	Write(row interface{}) error
+	Flush() error
+}
+
+// newRowWriter returns a rowWriter for rows of the same type as `proto`.
+func newRowWriter(format Format, w io.Writer, proto interface{}) rowWriter {
+	if format == FormatCSV {
+		t := reflect.Indirect(reflect.ValueOf(proto)).Type()
+		header := make([]string, t.NumField())
+		for i := range header {
+			header[i] = strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		}
+		return &csvWriter{w: csv.NewWriter(w), header: header}
+	}
+	return &jsonlWriter{enc: json.NewEncoder(w)}
+}
+
+// jsonlWriter writes every row as a JSON object on its own line.
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonlWriter) Write(row interface{}) error {
+	return w.enc.Encode(row)
+}
+
+func (w *jsonlWriter) Flush() error {
+	return nil
+}
+
+// csvWriter writes every row as a CSV record, preceded by a header built from
+// the `json` tags of the row type.
+type csvWriter struct {
+	w           *csv.Writer
+	header      []string
+	wroteHeader bool
+}
+
+func (w *csvWriter) Write(row interface{}) error {
+	err := w.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(row))
+	record := make([]string, v.NumField())
+	for i := range record {
+		record[i] = fmt.Sprint(v.Field(i).Interface())
+	}
+	return w.w.Write(record)
+}
+
+func (w *csvWriter) Flush() error {
+	err := w.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+
+	w.wroteHeader = true
+	return w.w.Write(w.header)
+}
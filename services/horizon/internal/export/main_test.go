@@ -0,0 +1,85 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("csv")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	format, err = ParseFormat("jsonl")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatJSONL, format)
+
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestParseAsset(t *testing.T) {
+	asset, err := ParseAsset("native")
+	require.NoError(t, err)
+	assert.True(t, asset.Equals(xdr.MustNewNativeAsset()))
+
+	asset, err = ParseAsset("USD:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	require.NoError(t, err)
+	assert.True(t, asset.Equals(xdr.MustNewCreditAsset("USD", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")))
+
+	for _, invalid := range []string{"", "USD", "USD:", "USD:GABC", "TOOLONGASSETCODE:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"} {
+		_, err = ParseAsset(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCheckStateLedger(t *testing.T) {
+	assert.NoError(t, checkStateLedger(0, 10))
+	assert.NoError(t, checkStateLedger(10, 10))
+
+	err := checkStateLedger(9, 10)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "past ledger 9")
+	}
+
+	err = checkStateLedger(11, 10)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "database is at ledger 10")
+	}
+}
+
+func TestRowWriters(t *testing.T) {
+	rows := []dataRow{
+		{AccountID: "GA", Name: "name", Value: "dmFsdWU="},
+		{AccountID: "GB", Name: "with,comma", Value: ""},
+	}
+
+	var buf bytes.Buffer
+	w := newRowWriter(FormatCSV, &buf, dataRow{})
+	for _, row := range rows {
+		require.NoError(t, w.Write(row))
+	}
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "account_id,name,value\nGA,name,dmFsdWU=\nGB,\"with,comma\",\n", buf.String())
+
+	buf.Reset()
+	w = newRowWriter(FormatCSV, &buf, dataRow{})
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "account_id,name,value\n", buf.String())
+
+	buf.Reset()
+	w = newRowWriter(FormatJSONL, &buf, dataRow{})
+	for _, row := range rows {
+		require.NoError(t, w.Write(row))
+	}
+	require.NoError(t, w.Flush())
+	assert.Equal(t,
+		`{"account_id":"GA","name":"name","value":"dmFsdWU="}`+"\n"+
+			`{"account_id":"GB","name":"with,comma","value":""}`+"\n",
+		buf.String(),
+	)
+}
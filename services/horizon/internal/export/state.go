@@ -0,0 +1,264 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// State exports the accounts, trustlines, offers and data entries stored in
+// the stellar-core database.  All entries are read in a single repeatable read
+// transaction so that the exported files describe the state of one ledger,
+// even while stellar-core keeps closing ledgers.
+type State struct {
+	CoreDB *db.Session
+	// Ledger is the ledger the state must be exported at.  The stellar-core
+	// database only stores the current state, so it must be the latest ledger
+	// closed by stellar-core.  Zero exports the state at the latest ledger.
+	Ledger int32
+	Format Format
+	Filter core.StateFilter
+	// Dir is the directory the files are written to.  One file is written for
+	// every kind of entry, named after it and the exported ledger, for example
+	// `accounts-1234.jsonl`.
+	Dir string
+}
+
+// StateResult describes a completed state export.
+type StateResult struct {
+	Ledger int32
+	// Files maps the path of every written file to the number of rows it
+	// contains.
+	Files map[string]int
+}
+
+// checkStateLedger returns an error unless the state at ledger `requested`
+// can be exported from a stellar-core database at ledger `latest`.  Zero
+// requests the latest ledger.
+func checkStateLedger(requested, latest int32) error {
+	switch {
+	case requested == 0 || requested == latest:
+		return nil
+	case requested < latest:
+		return fmt.Errorf(
+			"cannot export state at past ledger %d: stellar-core database only stores the state at its latest ledger %d",
+			requested, latest,
+		)
+	default:
+		return fmt.Errorf(
+			"cannot export state at ledger %d: stellar-core database is at ledger %d, wait until it is closed",
+			requested, latest,
+		)
+	}
+}
+
+// Run exports the state, returning the ledger it was exported at.
+func (s *State) Run() (*StateResult, error) {
+	q := &core.Q{Session: s.CoreDB.Clone()}
+
+	err := q.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin failed")
+	}
+	defer q.Rollback()
+
+	_, err = q.ExecRaw("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY")
+	if err != nil {
+		return nil, errors.Wrap(err, "set transaction failed")
+	}
+
+	var latest int32
+	err = q.LatestLedger(&latest)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading latest ledger failed")
+	}
+
+	err = checkStateLedger(s.Ledger, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StateResult{Ledger: latest, Files: map[string]int{}}
+
+	err = s.export(result, "accounts", accountRow{}, func(w rowWriter) error {
+		return q.StreamAccounts(s.Filter, func(a core.Account) error {
+			return w.Write(newAccountRow(a))
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.export(result, "trustlines", trustlineRow{}, func(w rowWriter) error {
+		return q.StreamTrustlines(s.Filter, func(tl core.Trustline) error {
+			return w.Write(newTrustlineRow(tl))
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.export(result, "offers", offerRow{}, func(w rowWriter) error {
+		return q.StreamOffers(s.Filter, func(o core.Offer) error {
+			row, err := newOfferRow(o)
+			if err != nil {
+				return err
+			}
+			return w.Write(row)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.export(result, "data", dataRow{}, func(w rowWriter) error {
+		return q.StreamAccountData(s.Filter, func(d core.AccountData) error {
+			return w.Write(dataRow{
+				AccountID: d.Accountid,
+				Name:      d.Key,
+				Value:     d.Value,
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// export writes the rows produced by `fn` to the file of the `name` entries.
+func (s *State) export(
+	result *StateResult,
+	name string,
+	proto interface{},
+	fn func(rowWriter) error,
+) error {
+	path := filepath.Join(s.Dir, fmt.Sprintf("%s-%d.%s", name, result.Ledger, s.Format))
+
+	count, err := writeFile(path, s.Format, proto, fn)
+	if err != nil {
+		return errors.Wrap(err, "exporting "+name+" failed")
+	}
+
+	result.Files[path] = count
+	return nil
+}
+
+type accountRow struct {
+	AccountID            string `json:"account_id"`
+	Balance              string `json:"balance"`
+	Sequence             string `json:"sequence"`
+	NumSubentries        int32  `json:"num_subentries"`
+	InflationDestination string `json:"inflation_destination"`
+	HomeDomain           string `json:"home_domain"`
+	MasterWeight         byte   `json:"master_weight"`
+	LowThreshold         byte   `json:"low_threshold"`
+	MedThreshold         byte   `json:"med_threshold"`
+	HighThreshold        byte   `json:"high_threshold"`
+	Flags                int32  `json:"flags"`
+	BuyingLiabilities    string `json:"buying_liabilities"`
+	SellingLiabilities   string `json:"selling_liabilities"`
+	LastModifiedLedger   uint32 `json:"last_modified_ledger"`
+}
+
+func newAccountRow(a core.Account) accountRow {
+	return accountRow{
+		AccountID:            a.Accountid,
+		Balance:              amount.String(a.Balance),
+		Sequence:             a.Seqnum,
+		NumSubentries:        a.Numsubentries,
+		InflationDestination: a.Inflationdest.String,
+		HomeDomain:           a.HomeDomain.String,
+		MasterWeight:         a.Thresholds[0],
+		LowThreshold:         a.Thresholds[1],
+		MedThreshold:         a.Thresholds[2],
+		HighThreshold:        a.Thresholds[3],
+		Flags:                int32(a.Flags),
+		BuyingLiabilities:    amount.String(a.BuyingLiabilities),
+		SellingLiabilities:   amount.String(a.SellingLiabilities),
+		LastModifiedLedger:   a.LastModified,
+	}
+}
+
+type trustlineRow struct {
+	AccountID          string `json:"account_id"`
+	AssetType          string `json:"asset_type"`
+	AssetCode          string `json:"asset_code"`
+	AssetIssuer        string `json:"asset_issuer"`
+	Balance            string `json:"balance"`
+	Limit              string `json:"limit"`
+	Flags              int32  `json:"flags"`
+	BuyingLiabilities  string `json:"buying_liabilities"`
+	SellingLiabilities string `json:"selling_liabilities"`
+	LastModifiedLedger uint32 `json:"last_modified_ledger"`
+}
+
+func newTrustlineRow(tl core.Trustline) trustlineRow {
+	var assetType string
+	xdr.Asset{Type: tl.Assettype}.Extract(&assetType, nil, nil)
+
+	return trustlineRow{
+		AccountID:          tl.Accountid,
+		AssetType:          assetType,
+		AssetCode:          tl.Assetcode,
+		AssetIssuer:        tl.Issuer,
+		Balance:            amount.String(tl.Balance),
+		Limit:              amount.String(tl.Tlimit),
+		Flags:              tl.Flags,
+		BuyingLiabilities:  amount.String(tl.BuyingLiabilities),
+		SellingLiabilities: amount.String(tl.SellingLiabilities),
+		LastModifiedLedger: tl.LastModified,
+	}
+}
+
+type offerRow struct {
+	OfferID            int64  `json:"offer_id"`
+	SellerID           string `json:"seller_id"`
+	SellingAssetType   string `json:"selling_asset_type"`
+	SellingAssetCode   string `json:"selling_asset_code"`
+	SellingAssetIssuer string `json:"selling_asset_issuer"`
+	BuyingAssetType    string `json:"buying_asset_type"`
+	BuyingAssetCode    string `json:"buying_asset_code"`
+	BuyingAssetIssuer  string `json:"buying_asset_issuer"`
+	Amount             string `json:"amount"`
+	PriceN             int32  `json:"price_n"`
+	PriceD             int32  `json:"price_d"`
+	Price              string `json:"price"`
+	Flags              int32  `json:"flags"`
+	LastModifiedLedger int32  `json:"last_modified_ledger"`
+}
+
+func newOfferRow(o core.Offer) (row offerRow, err error) {
+	err = o.SellingAsset.Extract(&row.SellingAssetType, &row.SellingAssetCode, &row.SellingAssetIssuer)
+	if err != nil {
+		return
+	}
+
+	err = o.BuyingAsset.Extract(&row.BuyingAssetType, &row.BuyingAssetCode, &row.BuyingAssetIssuer)
+	if err != nil {
+		return
+	}
+
+	row.OfferID = o.OfferID
+	row.SellerID = o.SellerID
+	row.Amount = amount.String(o.Amount)
+	row.PriceN = o.Pricen
+	row.PriceD = o.Priced
+	row.Price = o.PriceAsString()
+	row.Flags = o.Flags
+	row.LastModifiedLedger = o.Lastmodified
+	return
+}
+
+// dataRow is a data entry.  Value is base64 encoded, as it is on the network.
+type dataRow struct {
+	AccountID string `json:"account_id"`
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+}
@@ -0,0 +1,182 @@
+package horizon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	// healthCheckTimeout bounds the duration of a single health check.
+	healthCheckTimeout = 2 * time.Second
+	// defaultMaxIngestionLag is the number of ledgers history may lag behind
+	// stellar-core before the instance is not ready, unless StaleThreshold is
+	// configured.
+	defaultMaxIngestionLag = 10
+	// maxTxsubTickAge is the time after which the transaction submission
+	// system is considered stuck if it did not complete a tick.
+	maxTxsubTickAge = 10 * time.Second
+
+	healthStatusOK          = "ok"
+	healthStatusUnavailable = "unavailable"
+)
+
+// Names of the health checks.
+const (
+	HealthCheckHorizonDB = "horizon_db"
+	HealthCheckCoreDB    = "core_db"
+	HealthCheckCoreSync  = "core_sync"
+	HealthCheckIngestion = "ingestion"
+	HealthCheckTxsub     = "txsub"
+	HealthCheckRedis     = "redis"
+)
+
+// healthChecker checks one of the services horizon depends on.
+type healthChecker func(ctx context.Context) error
+
+// Health runs every health check of the app, in parallel, and returns their
+// results.  The Status of the result is left empty.
+func (a *App) Health(ctx context.Context) horizon.Health {
+	ls := ledger.CurrentState()
+	res := horizon.Health{
+		CoreLatestLedger:    ls.CoreLatest,
+		HistoryLatestLedger: ls.HistoryLatest,
+		IngestionLag:        ls.CoreLatest - ls.HistoryLatest,
+	}
+
+	if a.ingester != nil {
+		res.Ingest = true
+		res.IngestLeader = a.ingester.IsLeader()
+	}
+
+	names := []string{
+		HealthCheckHorizonDB,
+		HealthCheckCoreDB,
+		HealthCheckCoreSync,
+		HealthCheckIngestion,
+		HealthCheckTxsub,
+	}
+	checkers := []healthChecker{
+		pingDB(a.historyQ.Session),
+		pingDB(a.coreQ.Session),
+		a.checkCoreSync,
+		a.checkIngestion,
+		a.checkTxsub,
+	}
+
+	if a.redis != nil {
+		names = append(names, HealthCheckRedis)
+		checkers = append(checkers, a.checkRedis)
+	}
+
+	res.Checks = make([]horizon.HealthCheck, len(checkers))
+
+	var wg sync.WaitGroup
+	wg.Add(len(checkers))
+
+	for i := range checkers {
+		go func(i int) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			res.Checks[i].Name = names[i]
+			err := checkers[i](ctx)
+			if err != nil {
+				res.Checks[i].Error = err.Error()
+			} else {
+				res.Checks[i].Healthy = true
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return res
+}
+
+func pingDB(session *db.Session) healthChecker {
+	return func(ctx context.Context) error {
+		return session.DB.PingContext(ctx)
+	}
+}
+
+// checkCoreSync fails unless at least one of the stellar-core instances
+// transactions are submitted to is synced.
+func (a *App) checkCoreSync(ctx context.Context) error {
+	backends := a.coreBackends.Health()
+	for _, b := range backends {
+		if b.Healthy {
+			return nil
+		}
+	}
+
+	if len(backends) > 0 && !backends[0].Checked {
+		return errors.New("stellar-core was not checked yet")
+	}
+	return errors.New("stellar-core is not synced")
+}
+
+// checkIngestion fails when history lags too far behind stellar-core.
+func (a *App) checkIngestion(ctx context.Context) error {
+	maxLag := int32(defaultMaxIngestionLag)
+	if a.config.StaleThreshold > 0 {
+		maxLag = int32(a.config.StaleThreshold)
+	}
+
+	ls := ledger.CurrentState()
+	if lag := ls.CoreLatest - ls.HistoryLatest; lag > maxLag {
+		return fmt.Errorf("history is %d ledgers behind stellar-core", lag)
+	}
+	return nil
+}
+
+// checkTxsub fails when the transaction submission system did not tick
+// recently.
+func (a *App) checkTxsub(ctx context.Context) error {
+	lastTick := a.submitter.LastTick()
+	if lastTick.IsZero() {
+		return errors.New("transaction submission did not tick yet")
+	}
+
+	if age := time.Since(lastTick); age > maxTxsubTickAge {
+		return fmt.Errorf("transaction submission last ticked %s ago", age.Truncate(time.Second))
+	}
+	return nil
+}
+
+// checkRedis pings redis.  Dialing a new connection or testing an idle one
+// does not take a context, so the check returns when `ctx` is done even if
+// redis does not respond.
+func (a *App) checkRedis(ctx context.Context) error {
+	result := make(chan error, 1)
+	go func() {
+		conn, err := a.redis.GetContext(ctx)
+		if err != nil {
+			result <- err
+			return
+		}
+		defer conn.Close()
+
+		timeout := healthCheckTimeout
+		if deadline, ok := ctx.Deadline(); ok {
+			timeout = time.Until(deadline)
+		}
+		_, err = redis.DoWithTimeout(conn, timeout, "PING")
+		result <- err
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
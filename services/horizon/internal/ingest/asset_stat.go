@@ -3,6 +3,7 @@ package ingest
 import (
 	"database/sql"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
@@ -12,6 +13,10 @@ import (
 	"github.com/stellar/go/xdr"
 )
 
+// volumeWindow is the period over which the traded volume of assets is
+// computed.
+const volumeWindow = 24 * time.Hour
+
 func (assetStats *AssetStats) init() {
 	assetStats.batchInsertBuilder = &BatchInsertBuilder{
 		TableName: AssetStatsTableName,
@@ -19,7 +24,11 @@ func (assetStats *AssetStats) init() {
 			"id",
 			"amount",
 			"num_accounts",
+			"num_unauthorized_accounts",
+			"num_offers",
+			"last_price",
 			"flags",
+			"home_domain",
 			"toml",
 		},
 	}
@@ -119,7 +128,11 @@ func (assetStats *AssetStats) UpdateAssetStats() error {
 				assetStat.ID,
 				assetStat.Amount,
 				assetStat.NumAccounts,
+				assetStat.NumUnauthorizedAccounts,
+				assetStat.NumOffers,
+				assetStat.LastPrice,
 				assetStat.Flags,
+				assetStat.HomeDomain,
 				assetStat.Toml,
 			)
 		}
@@ -135,10 +148,17 @@ func (assetStats *AssetStats) UpdateAssetStats() error {
 		// can perform a direct upsert if postgres > 9.4
 		// is.Ingestion.assetStats = is.Ingestion.assetStats.
 		// 	Suffix("ON CONFLICT (id) DO UPDATE SET (amount, num_accounts, flags, toml) = (excluded.amount, excluded.num_accounts, excluded.flags, excluded.toml)")
-		return errors.Wrap(assetStats.batchInsertBuilder.Exec(assetStats.HistorySession), "Error inserting asset_stats row")
+		err = errors.Wrap(assetStats.batchInsertBuilder.Exec(assetStats.HistorySession), "Error inserting asset_stats row")
+		if err != nil {
+			return err
+		}
 	}
 
-	return nil
+	// The 24h volume changes as time passes, even for assets that were not
+	// modified, so it is refreshed for all the assets after every cycle.
+	historyQ := &history.Q{Session: assetStats.HistorySession}
+	err := historyQ.UpdateAssetStatsVolume(time.Now().Add(-volumeWindow))
+	return errors.Wrap(err, "Error updating asset_stats volume")
 }
 
 // func (assetStats *AssetStats) addAssetsFromAccount(coreQ *core.Q, account *xdr.AccountId) {
@@ -216,17 +236,37 @@ func (assetStats *AssetStats) computeAssetStat(asset *xdr.Asset) (*history.Asset
 		return nil, errors.Wrap(err, "statTrustlinesInfo error")
 	}
 
-	flags, toml, err := statAccountInfo(assetStats.CoreSession, assetIssuer)
+	coreQ := &core.Q{Session: assetStats.CoreSession}
+	numUnauthorizedAccounts, err := coreQ.UnauthorizedTrustlinesForAsset(int32(assetType), assetCode, assetIssuer)
+	if err != nil {
+		return nil, errors.Wrap(err, "coreQ.UnauthorizedTrustlinesForAsset error")
+	}
+
+	numOffers, err := coreQ.NumOffersForAsset(*asset)
+	if err != nil {
+		return nil, errors.Wrap(err, "coreQ.NumOffersForAsset error")
+	}
+
+	lastPrice, err := statLastPrice(historyQ, assetID)
+	if err != nil {
+		return nil, errors.Wrap(err, "statLastPrice error")
+	}
+
+	flags, homeDomain, toml, err := statAccountInfo(assetStats.CoreSession, assetIssuer)
 	if err != nil {
 		return nil, errors.Wrap(err, "statAccountInfo error")
 	}
 
 	return &history.AssetStat{
-		ID:          assetID,
-		Amount:      amount,
-		NumAccounts: numAccounts,
-		Flags:       flags,
-		Toml:        toml,
+		ID:                      assetID,
+		Amount:                  amount,
+		NumAccounts:             numAccounts,
+		NumUnauthorizedAccounts: numUnauthorizedAccounts,
+		NumOffers:               numOffers,
+		LastPrice:               lastPrice,
+		Flags:                   flags,
+		HomeDomain:              homeDomain,
+		Toml:                    toml,
 	}, nil
 }
 
@@ -236,8 +276,23 @@ func statTrustlinesInfo(coreSession *db.Session, assetType xdr.AssetType, assetC
 	return coreQ.BalancesForAsset(int32(assetType), assetCode, assetIssuer)
 }
 
+// statLastPrice returns the price, in lumens, of the last trade of the asset
+// against lumens.
+func statLastPrice(historyQ *history.Q, assetID int64) (string, error) {
+	nativeID, err := historyQ.GetAssetID(xdr.MustNewNativeAsset())
+	if historyQ.NoRows(err) {
+		// lumens never traded
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "historyQ.GetAssetID error")
+	}
+
+	return historyQ.LastTradePrice(assetID, nativeID)
+}
+
 // statAccountInfo fetches all the stats from the accounts table
-func statAccountInfo(coreSession *db.Session, accountID string) (int8, string, error) {
+func statAccountInfo(coreSession *db.Session, accountID string) (int8, string, string, error) {
 	var account core.Account
 	// We don't need liabilities data here so let's use the old V9 query
 	coreQ := &core.Q{Session: coreSession}
@@ -248,22 +303,18 @@ func statAccountInfo(coreSession *db.Session, accountID string) (int8, string, e
 		// but a new field (`deleted`?) should be introduced in 0.16.0.
 		// See: https://github.com/stellar/stellar-core/issues/1835
 		if err == sql.ErrNoRows {
-			return 0, "", nil
+			return 0, "", "", nil
 		}
-		return -1, "", errors.Wrap(err, "coreQ.AccountByAddress error")
+		return -1, "", "", errors.Wrap(err, "coreQ.AccountByAddress error")
 	}
 
-	var toml string
-	if !account.HomeDomain.Valid {
-		toml = ""
-	} else {
-		trimmed := strings.TrimSpace(account.HomeDomain.String)
-		if trimmed != "" {
+	var homeDomain, toml string
+	if account.HomeDomain.Valid {
+		homeDomain = strings.TrimSpace(account.HomeDomain.String)
+		if homeDomain != "" {
 			toml = "https://" + account.HomeDomain.String + "/.well-known/stellar.toml"
-		} else {
-			toml = ""
 		}
 	}
 
-	return int8(account.Flags), toml, nil
+	return int8(account.Flags), homeDomain, toml, nil
 }
@@ -132,26 +132,31 @@ func TestStatTrustlinesInfo(t *testing.T) {
 
 func TestStatAccountInfo(t *testing.T) {
 	testCases := []struct {
-		account   string
-		wantFlags int8
-		wantToml  string
+		account        string
+		wantFlags      int8
+		wantHomeDomain string
+		wantToml       string
 	}{
 		{
 			"GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU",
 			0,
+			"example.com",
 			"https://example.com/.well-known/stellar.toml",
 		}, {
 			"GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2",
 			1,
+			"abc.com",
 			"https://abc.com/.well-known/stellar.toml",
 		}, {
 			"GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON",
 			2,
 			"",
+			"",
 		}, {
 			"GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4",
 			3,
 			"",
+			"",
 		},
 	}
 
@@ -162,9 +167,10 @@ func TestStatAccountInfo(t *testing.T) {
 
 			session := &db.Session{DB: tt.CoreDB}
 
-			flags, toml, err := statAccountInfo(session, kase.account)
+			flags, homeDomain, toml, err := statAccountInfo(session, kase.account)
 			tt.Require.NoError(err)
 			tt.Assert.Equal(kase.wantFlags, flags)
+			tt.Assert.Equal(kase.wantHomeDomain, homeDomain)
 			tt.Assert.Equal(kase.wantToml, toml)
 		})
 	}
@@ -21,11 +21,18 @@ func (ingest *Ingestion) ClearAll() error {
 }
 
 // Clear removes a range of data from the history database, exclusive of the end
-// id provided.
+// id provided.  The per minute trade aggregations of the removed trades are
+// rebuilt from the remaining trades.
 func (ingest *Ingestion) Clear(start int64, end int64) error {
 	clear := ingest.DB.DeleteRange
+	q := history.Q{Session: ingest.DB}
 
-	err := clear(start, end, "history_effects", "history_operation_id")
+	tradesFrom, tradesTo, hasTrades, err := q.TradesClosedAtRange(start, end)
+	if err != nil {
+		return errors.Wrap(err, "Error loading history_trades close times")
+	}
+
+	err = clear(start, end, "history_effects", "history_operation_id")
 	if err != nil {
 		return errors.Wrap(err, "Error clearing history_effects")
 	}
@@ -53,6 +60,12 @@ func (ingest *Ingestion) Clear(start int64, end int64) error {
 	if err != nil {
 		return errors.Wrap(err, "Error clearing history_trades")
 	}
+	if hasTrades {
+		err = q.RebuildTradeAggregations(tradesFrom, tradesTo)
+		if err != nil {
+			return errors.Wrap(err, "Error clearing history_trades_60000")
+		}
+	}
 
 	return nil
 }
@@ -304,6 +317,14 @@ func (ingest *Ingestion) Trade(
 	return nil
 }
 
+// TradeAggregations rebuilds the per minute aggregations of the trades of a
+// ledger closed at `closedAt`.  It must be called after the trades of the
+// ledger have been flushed.
+func (ingest *Ingestion) TradeAggregations(closedAt time.Time) error {
+	q := history.Q{Session: ingest.DB}
+	return q.RebuildTradeAggregations(closedAt, closedAt)
+}
+
 // Transaction ingests the provided transaction data into a new row in the
 // `history_transactions` table
 func (ingest *Ingestion) Transaction(
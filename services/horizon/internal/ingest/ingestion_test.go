@@ -11,6 +11,7 @@ import (
 	"github.com/stellar/go/services/horizon/internal/db2/sqx"
 	"github.com/stellar/go/services/horizon/internal/test"
 	testDB "github.com/stellar/go/services/horizon/internal/test/db"
+	"github.com/stellar/go/services/horizon/internal/toid"
 	"github.com/stellar/go/support/db"
 	"github.com/stellar/go/xdr"
 	"github.com/stretchr/testify/assert"
@@ -270,3 +271,50 @@ func TestTradeIngestTimestamp(t *testing.T) {
 
 	tt.Require.Equal(trades[len(trades)-1].LedgerCloseTime, ledgers[len(ledgers)-1].ClosedAt)
 }
+
+func TestClearTradeAggregations(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("trades")
+	defer tt.Finish()
+	s := ingest(tt, Config{EnableAssetStats: false})
+	q := history.Q{Session: s.Ingestion.DB}
+
+	var trades []history.Trade
+	err := q.Trades().Select(&trades)
+	tt.Require.NoError(err)
+	tt.Require.NotEmpty(trades)
+
+	aggregations := func() []history.TradeAggregation {
+		var rows []history.TradeAggregation
+		err := q.SelectRaw(&rows, `
+			SELECT "timestamp", count, base_volume, counter_volume
+			FROM history_trades_60000 ORDER BY "timestamp", base_asset_id, counter_asset_id
+		`)
+		tt.Require.NoError(err)
+		return rows
+	}
+	rebuilt := func() []history.TradeAggregation {
+		err := q.RebuildTradeAggregations(time.Unix(0, 0), time.Now())
+		tt.Require.NoError(err)
+		return aggregations()
+	}
+	tt.Require.NotEmpty(aggregations())
+
+	// clearing the ledger of the last trade only removes its trades from the
+	// aggregations
+	last := toid.Parse(trades[len(trades)-1].HistoryOperationID).LedgerSequence
+	err = s.Ingestion.Clear(toid.New(last, 0, 0).ToInt64(), toid.New(last+1, 0, 0).ToInt64())
+	tt.Require.NoError(err)
+	cleared := aggregations()
+	tt.Assert.Equal(rebuilt(), cleared)
+
+	var count int
+	err = q.GetRaw(&count, `SELECT coalesce(sum(count), 0) FROM history_trades_60000`)
+	tt.Require.NoError(err)
+	err = q.Trades().Select(&trades)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(len(trades), count)
+
+	err = s.Ingestion.ClearAll()
+	tt.Require.NoError(err)
+	tt.Assert.Empty(aggregations())
+}
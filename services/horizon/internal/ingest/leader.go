@@ -0,0 +1,180 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// leaderLockID is the key of the postgres advisory lock held by the horizon
+// instance that ingests into the horizon database.
+const leaderLockID int64 = 0x686f72697a6f6e // "horizon"
+
+// leaderCheckTimeout bounds the duration of a single leader election query.
+const leaderCheckTimeout = 5 * time.Second
+
+// IsLeader returns true if this instance currently holds the ingestion lock.
+// It is always true when leader election is disabled.
+func (i *System) IsLeader() bool {
+	if !i.LeaderElection {
+		return true
+	}
+
+	i.leaderLock.Lock()
+	defer i.leaderLock.Unlock()
+	return i.leaderConn != nil
+}
+
+// ReleaseLeadership waits for the ingestion session in progress, if any, then
+// releases the ingestion lock, if held, so that another instance can take
+// over ingestion.  The instance does not take part in the election anymore.
+func (i *System) ReleaseLeadership() {
+	i.leaderLock.Lock()
+	i.leaderReleased = true
+	i.leaderLock.Unlock()
+
+	i.lock.Lock()
+	done := i.currentDone
+	i.lock.Unlock()
+	if done != nil {
+		<-done
+	}
+
+	i.leaderLock.Lock()
+	conn := i.leaderConn
+	i.leaderLock.Unlock()
+	if conn == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), leaderCheckTimeout)
+	defer cancel()
+
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", leaderLockID)
+	if err != nil {
+		log.WithField("err", err).Warn("ingest: failed to release leadership")
+	}
+
+	i.stepDown(conn)
+}
+
+// elect returns true if this instance may ingest: either leader election is
+// disabled or this instance holds the ingestion lock, acquiring it if
+// possible.  Postgres releases advisory locks when the connection holding them
+// is closed, so when the leader dies another instance takes over at its next
+// tick.
+func (i *System) elect() bool {
+	if !i.LeaderElection {
+		return true
+	}
+
+	// elections are serialized, leaderLock only guards the leader state so
+	// that it is not held during database calls
+	i.electLock.Lock()
+	defer i.electLock.Unlock()
+
+	i.leaderLock.Lock()
+	conn, released := i.leaderConn, i.leaderReleased
+	i.leaderLock.Unlock()
+
+	if released {
+		return false
+	}
+
+	if conn != nil {
+		return i.checkLeaderConn(conn) == nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), leaderCheckTimeout)
+	defer cancel()
+
+	conn, err := i.HorizonDB.DB.Conn(ctx)
+	if err != nil {
+		log.WithField("err", err).Error("ingest: leader election failed")
+		return false
+	}
+
+	acquired, err := tryAdvisoryLock(ctx, conn)
+	if err != nil || !acquired {
+		if err != nil {
+			log.WithField("err", err).Error("ingest: leader election failed")
+		}
+		conn.Close()
+		return false
+	}
+
+	i.leaderLock.Lock()
+	if i.leaderReleased {
+		// leadership was released during the election
+		i.leaderLock.Unlock()
+		conn.Close()
+		return false
+	}
+	i.leaderConn = conn
+	i.leaderLock.Unlock()
+
+	i.Metrics.LeaderGauge.Update(1)
+	i.Metrics.LeadershipChangesCounter.Inc(1)
+	log.Info("ingest: this instance is now the ingestion leader")
+	return true
+}
+
+// checkLeadership returns an error unless this instance still holds the
+// ingestion lock.  Ingestion sessions started by Tick call it before
+// committing every ledger so that an instance that lost the lock during a
+// session stops ingesting.
+func (i *System) checkLeadership() error {
+	i.leaderLock.Lock()
+	conn := i.leaderConn
+	i.leaderLock.Unlock()
+
+	if conn == nil {
+		return errors.New("not the ingestion leader")
+	}
+
+	return i.checkLeaderConn(conn)
+}
+
+// checkLeaderConn checks that `conn`, which holds the ingestion lock, is still
+// alive: the lock is held as long as the connection that acquired it is.  The
+// instance steps down when it is not.
+func (i *System) checkLeaderConn(conn *sql.Conn) error {
+	ctx, cancel := context.WithTimeout(context.Background(), leaderCheckTimeout)
+	defer cancel()
+
+	err := conn.PingContext(ctx)
+	if err == nil {
+		return nil
+	}
+
+	log.WithField("err", err).Error("ingest: lost leadership, connection holding the lock failed")
+	i.stepDown(conn)
+	return errors.Wrap(err, "lost ingestion leadership")
+}
+
+// stepDown drops `conn`, the connection holding the ingestion lock, unless
+// another election replaced it already.
+func (i *System) stepDown(conn *sql.Conn) {
+	i.leaderLock.Lock()
+	if i.leaderConn != conn {
+		i.leaderLock.Unlock()
+		return
+	}
+	i.leaderConn = nil
+	i.leaderLock.Unlock()
+
+	conn.Close()
+	i.Metrics.LeaderGauge.Update(0)
+	i.Metrics.LeadershipChangesCounter.Inc(1)
+}
+
+func tryAdvisoryLock(ctx context.Context, conn *sql.Conn) (bool, error) {
+	var acquired bool
+	err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", leaderLockID).Scan(&acquired)
+	if err != nil {
+		return false, errors.Wrap(err, "pg_try_advisory_lock failed")
+	}
+	return acquired, nil
+}
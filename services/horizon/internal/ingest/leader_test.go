@@ -0,0 +1,69 @@
+package ingest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestLeaderElection(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	first := sys(tt, Config{})
+	first.LeaderElection = true
+	second := sys(tt, Config{})
+	second.LeaderElection = true
+
+	// the first instance to try becomes the leader
+	tt.Assert.True(first.elect())
+	tt.Assert.True(first.IsLeader())
+	tt.Assert.Equal(int64(1), first.Metrics.LeaderGauge.Value())
+
+	tt.Assert.False(second.elect())
+	tt.Assert.False(second.IsLeader())
+	tt.Assert.Nil(second.Tick())
+
+	// the leader keeps its leadership
+	tt.Assert.True(first.elect())
+	tt.Assert.NoError(first.checkLeadership())
+	tt.Assert.Error(second.checkLeadership())
+
+	// another instance takes over once the leader is gone
+	first.ReleaseLeadership()
+	tt.Assert.False(first.IsLeader())
+	tt.Assert.Equal(int64(0), first.Metrics.LeaderGauge.Value())
+	tt.Assert.Equal(int64(2), first.Metrics.LeadershipChangesCounter.Count())
+
+	tt.Assert.True(second.elect())
+	tt.Assert.False(first.elect())
+	tt.Assert.Error(first.checkLeadership())
+	second.ReleaseLeadership()
+
+	// an instance that released its leadership does not take part in the
+	// election anymore
+	tt.Assert.False(first.elect())
+	tt.Assert.False(first.IsLeader())
+
+	// without leader election, every instance ingests
+	third := sys(tt, Config{})
+	tt.Assert.True(third.elect())
+	tt.Assert.True(third.IsLeader())
+}
+
+func TestSessionCheckLeadership(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	is := NewSession(sys(tt, Config{}))
+	is.CheckLeadership = func() error { return errors.New("not the ingestion leader") }
+	is.checkLeadership()
+	tt.Assert.EqualError(is.Err, "checkLeadership error: not the ingestion leader")
+
+	// sessions without a leadership check, such as reingestions, are not
+	// affected
+	is = NewSession(sys(tt, Config{}))
+	is.checkLeadership()
+	tt.Assert.NoError(is.Err)
+}
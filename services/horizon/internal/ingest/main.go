@@ -4,6 +4,7 @@
 package ingest
 
 import (
+	"database/sql"
 	"sync"
 
 	sq "github.com/Masterminds/squirrel"
@@ -124,9 +125,20 @@ type System struct {
 	HistoryRetentionCount uint
 	// IngestFailedTransactions toggles whether to ingest failed transactions
 	IngestFailedTransactions bool
+	// LeaderElection causes the system to only ingest while holding a postgres
+	// advisory lock on the horizon database, so that a single horizon instance
+	// ingests at a time.
+	LeaderElection bool
 
 	lock    sync.Mutex
 	current *Session
+	// currentDone is closed once the current session is finished
+	currentDone chan struct{}
+
+	electLock      sync.Mutex
+	leaderLock     sync.Mutex
+	leaderConn     *sql.Conn
+	leaderReleased bool
 }
 
 // IngesterMetrics tracks all the metrics for the ingestion subsystem
@@ -134,6 +146,13 @@ type IngesterMetrics struct {
 	ClearLedgerTimer  metrics.Timer
 	IngestLedgerTimer metrics.Timer
 	LoadLedgerTimer   metrics.Timer
+
+	// LeaderGauge is 1 while this instance is the ingestion leader, 0
+	// otherwise.
+	LeaderGauge metrics.Gauge
+	// LeadershipChangesCounter counts the times this instance became or
+	// stopped being the ingestion leader.
+	LeadershipChangesCounter metrics.Counter
 }
 
 // BatchInsertBuilder works like sq.InsertBuilder but has a better support for batching
@@ -188,6 +207,9 @@ type Session struct {
 	Metrics *IngesterMetrics
 	// AssetStats calculates asset stats
 	AssetStats *AssetStats
+	// CheckLeadership, when set, is called before committing every ledger.
+	// The session fails when it returns an error.
+	CheckLeadership func() error
 
 	//
 	// Results fields
@@ -214,6 +236,8 @@ func New(network string, coreURL string, core, horizon *db.Session, config Confi
 	i.Metrics.ClearLedgerTimer = metrics.NewTimer()
 	i.Metrics.IngestLedgerTimer = metrics.NewTimer()
 	i.Metrics.LoadLedgerTimer = metrics.NewTimer()
+	i.Metrics.LeaderGauge = metrics.NewGauge()
+	i.Metrics.LeadershipChangesCounter = metrics.NewCounter()
 	return i
 }
 
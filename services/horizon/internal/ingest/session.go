@@ -48,7 +48,9 @@ func (is *Session) Run() {
 		is.validateLedger()
 		is.clearLedger()
 		is.ingestLedger()
+		is.checkLeadership()
 		is.flush()
+		is.ingestTradeAggregations()
 
 		i++
 		if i%100 == 0 {
@@ -80,6 +82,11 @@ func (is *Session) Run() {
 		return
 	}
 
+	is.checkLeadership()
+	if is.Err != nil {
+		return
+	}
+
 	is.Err = is.Ingestion.Close()
 	if is.Err != nil {
 		is.Err = errors.Wrap(is.Err, "Ingestion.Close error")
@@ -128,6 +135,16 @@ func (is *Session) effectFlagDetails(flagDetails map[string]bool, flagPtr *xdr.U
 	}
 }
 
+// checkLeadership fails the session when it must only be committed by the
+// ingestion leader and this instance lost leadership.
+func (is *Session) checkLeadership() {
+	if is.Err != nil || is.CheckLeadership == nil {
+		return
+	}
+
+	is.Err = errors.Wrap(is.CheckLeadership(), "checkLeadership error")
+}
+
 func (is *Session) flush() {
 	if is.Err != nil {
 		return
@@ -521,6 +538,20 @@ func (is *Session) ingestSignerEffects(effects *EffectIngestion, op xdr.SetOptio
 
 }
 
+// ingestTradeAggregations updates the per minute aggregations of trades with
+// the trades of the current ledger.
+func (is *Session) ingestTradeAggregations() {
+	if is.Err != nil {
+		return
+	}
+
+	closedAt := time.Unix(is.Cursor.Ledger().CloseTime, 0).UTC()
+	is.Err = is.Ingestion.TradeAggregations(closedAt)
+	if is.Err != nil {
+		is.Err = errors.Wrap(is.Err, "Ingestion.TradeAggregations error")
+	}
+}
+
 func (is *Session) ingestTrades() {
 	if is.Err != nil {
 		return
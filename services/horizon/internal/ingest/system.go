@@ -204,8 +204,14 @@ func (i *System) ReingestSingle(sequence int32) error {
 }
 
 // Tick triggers the ingestion system to ingest any new ledger data, provided
-// that there currently is not an import session in progress.
+// that there currently is not an import session in progress and, when leader
+// election is enabled, that this instance is the ingestion leader.
 func (i *System) Tick() *Session {
+	if !i.elect() {
+		log.Debug("ingest: not the ingestion leader")
+		return nil
+	}
+
 	i.lock.Lock()
 	if i.current != nil {
 		log.Info("ingest: already in progress")
@@ -214,7 +220,11 @@ func (i *System) Tick() *Session {
 	}
 
 	is := NewSession(i)
+	if i.LeaderElection {
+		is.CheckLeadership = i.checkLeadership
+	}
 	i.current = is
+	i.currentDone = make(chan struct{})
 	i.lock.Unlock()
 
 	i.runOnce()
@@ -244,6 +254,10 @@ func (i *System) runOnce() {
 	defer func() {
 		i.lock.Lock()
 		i.current = nil
+		if i.currentDone != nil {
+			close(i.currentDone)
+			i.currentDone = nil
+		}
 		i.lock.Unlock()
 	}()
 
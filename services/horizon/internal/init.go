@@ -1,6 +1,7 @@
 package horizon
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 	"time"
@@ -14,6 +15,8 @@ import (
 	"github.com/stellar/go/services/horizon/internal/txsub"
 	results "github.com/stellar/go/services/horizon/internal/txsub/results/db"
 	"github.com/stellar/go/services/horizon/internal/txsub/sequence"
+	"github.com/stellar/go/services/horizon/internal/verify"
+	"github.com/stellar/go/services/horizon/internal/webhooks"
 	"github.com/stellar/go/support/db"
 	"github.com/stellar/go/support/log"
 )
@@ -29,6 +32,31 @@ func mustInitHorizonDB(app *App) {
 	session.DB.SetMaxIdleConns(app.config.MaxDBConnections)
 	session.DB.SetMaxOpenConns(app.config.MaxDBConnections)
 	app.historyQ = &history.Q{session}
+
+	app.historyReplicas = &historyReplicas{primary: session.DB}
+	for i, replicaURL := range app.config.DatabaseReplicaURLs {
+		replica, err := db.Open("postgres", replicaURL)
+		if err != nil {
+			log.Fatalf("cannot open Horizon DB replica %d: %v", i, err)
+		}
+
+		replica.DB.SetMaxIdleConns(app.config.MaxDBConnections)
+		replica.DB.SetMaxOpenConns(app.config.MaxDBConnections)
+		app.historyReplicas.replicas = append(app.historyReplicas.replicas, &historyReplica{
+			name: replicaName(i, replicaURL),
+			db:   replica.DB,
+		})
+	}
+}
+
+// replicaName returns the host of the database at `dsn` or, if it cannot be
+// parsed, its position in the configuration.
+func replicaName(i int, dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil || u.Host == "" {
+		return fmt.Sprintf("#%d", i)
+	}
+	return u.Host
 }
 
 func mustInitCoreDB(app *App) {
@@ -66,6 +94,27 @@ func initIngester(app *App) {
 
 	app.ingester.SkipCursorUpdate = app.config.SkipCursorUpdate
 	app.ingester.HistoryRetentionCount = app.config.HistoryRetentionCount
+	app.ingester.LeaderElection = app.config.IngestLeaderElection
+}
+
+// initWebhooks initializes the webhooks system when enabled.  Deliveries are
+// only made by the ingestion leader.
+func initWebhooks(app *App) {
+	if !app.config.EnableWebhooks {
+		return
+	}
+
+	app.webhooks = webhooks.New(app.HorizonSession(nil))
+}
+
+func initVerifier(app *App) {
+	if app.config.VerifyInterval == 0 {
+		return
+	}
+
+	app.verifier = verify.New(app.config.VerifyInterval, app.HorizonSession(nil), app.CoreSession(nil))
+	app.verifier.AccountSampleSize = uint64(app.config.VerifySampleSize)
+	app.verifier.CheckNativeBalances = app.config.IngestFailedTransactions
 }
 
 // initSentry initialized the default sentry client with the configured DSN
@@ -124,6 +173,31 @@ func initIngesterMetrics(app *App) {
 		app.ingester.Metrics.IngestLedgerTimer)
 	app.metrics.Register("ingester.clear_ledger",
 		app.ingester.Metrics.ClearLedgerTimer)
+	app.metrics.Register("ingester.leader",
+		app.ingester.Metrics.LeaderGauge)
+	app.metrics.Register("ingester.leadership_changes",
+		app.ingester.Metrics.LeadershipChangesCounter)
+}
+
+func initWebhooksMetrics(app *App) {
+	if app.webhooks == nil {
+		return
+	}
+
+	app.metrics.Register("webhooks.enqueued", app.webhooks.Metrics.EnqueuedMeter)
+	app.metrics.Register("webhooks.delivered", app.webhooks.Metrics.DeliveredMeter)
+	app.metrics.Register("webhooks.failed", app.webhooks.Metrics.FailedMeter)
+	app.metrics.Register("webhooks.dead_letters", app.webhooks.Metrics.DeadLetterMeter)
+}
+
+func initVerifierMetrics(app *App) {
+	if app.verifier == nil {
+		return
+	}
+
+	app.metrics.Register("verify.runs", app.verifier.Metrics.RunTimer)
+	app.metrics.Register("verify.mismatches", app.verifier.Metrics.MismatchCounter)
+	app.metrics.Register("verify.last_mismatches", app.verifier.Metrics.LastMismatchesGauge)
 }
 
 func initTxSubMetrics(app *App) {
@@ -173,9 +247,12 @@ func initRedis(app *App) {
 	}
 }
 
+// redisDialTimeout bounds the duration of connecting to redis.
+const redisDialTimeout = 5 * time.Second
+
 func dialRedis(redisURL *url.URL) func() (redis.Conn, error) {
 	return func() (redis.Conn, error) {
-		c, err := redis.Dial("tcp", redisURL.Host)
+		c, err := redis.Dial("tcp", redisURL.Host, redis.DialConnectTimeout(redisDialTimeout))
 		if err != nil {
 			return nil, err
 		}
@@ -198,9 +275,12 @@ func dialRedis(redisURL *url.URL) func() (redis.Conn, error) {
 func initSubmissionSystem(app *App) {
 	cq := &core.Q{Session: app.CoreSession(nil)}
 
+	urls := append([]string{app.config.StellarCoreURL}, app.config.StellarCoreSubmitURLs...)
+	app.coreBackends = txsub.NewMultiSubmitter(http.DefaultClient, urls, app.config.SubmitBroadcast)
+
 	app.submitter = &txsub.System{
 		Pending:         txsub.NewDefaultSubmissionList(),
-		Submitter:       txsub.NewDefaultSubmitter(http.DefaultClient, app.config.StellarCoreURL),
+		Submitter:       app.coreBackends,
 		SubmissionQueue: sequence.NewManager(),
 		Results: &results.DB{
 			Core:    cq,
@@ -1,7 +1,10 @@
 package horizon
 
 import (
+	"bufio"
 	"context"
+	"crypto/subtle"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -13,6 +16,7 @@ import (
 	"github.com/stellar/go/services/horizon/internal/hchi"
 	"github.com/stellar/go/services/horizon/internal/httpx"
 	"github.com/stellar/go/services/horizon/internal/render"
+	hProblem "github.com/stellar/go/services/horizon/internal/render/problem"
 	"github.com/stellar/go/support/log"
 	"github.com/stellar/go/support/render/problem"
 )
@@ -28,17 +32,144 @@ func appContextMiddleware(app *App) func(http.Handler) http.Handler {
 	}
 }
 
-// requestCacheHeadersMiddleware adds caching headers to each response.
+const (
+	// noCacheControl is the Cache-Control of responses that may change.
+	noCacheControl = "no-cache, no-store, max-age=0"
+	// immutableCacheControl is the Cache-Control of responses that never
+	// change, such as the ledgers, transactions and operations in history.
+	immutableCacheControl = "public, max-age=31536000, immutable"
+)
+
+// requestCacheHeadersMiddleware adds caching headers to each response and
+// answers conditional requests: when a successful GET or HEAD response
+// carries an ETag matching the request's If-None-Match header, or a
+// Last-Modified date not after the request's If-Modified-Since header, a 304
+// Not Modified response is sent without a body instead.
 func requestCacheHeadersMiddleware(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Before changing this read Stack Overflow answer about staled request
 		// in older versions of Chrome:
 		// https://stackoverflow.com/questions/27513994/chrome-stalls-when-making-multiple-requests-to-same-resource
-		w.Header().Set("Cache-Control", "no-cache, no-store, max-age=0")
-		h.ServeHTTP(w, r)
+		w.Header().Set("Cache-Control", noCacheControl)
+
+		// Upgraded connections and streams are never answered with a 304.
+		conditional := r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != ""
+		streaming := r.Header.Get("Upgrade") != "" || strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+		if !conditional || streaming || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		h.ServeHTTP(&conditionalResponseWriter{ResponseWriter: w, r: r}, r)
 	})
 }
 
+// setImmutableCacheHeaders marks the response as never changing, so that it
+// can be cached for a long time, and sets its Last-Modified date.
+func setImmutableCacheHeaders(w http.ResponseWriter, lastModified time.Time) {
+	w.Header().Set("Cache-Control", immutableCacheControl)
+	w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+}
+
+// conditionalResponseWriter replaces a successful response with a 304 Not
+// Modified response when the validators of the response match the
+// conditional headers of the request.
+type conditionalResponseWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	wroteHeader bool
+	notModified bool
+}
+
+func (w *conditionalResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if code == http.StatusOK && notModified(w.r, w.Header()) {
+		w.notModified = true
+		for _, name := range []string{"Content-Type", "Content-Length", "Content-Disposition", "Content-Encoding"} {
+			w.Header().Del(name)
+		}
+		code = http.StatusNotModified
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *conditionalResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.notModified {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher so that streaming responses keep working.
+func (w *conditionalResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok && !w.notModified {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker so that connections can be upgraded.
+func (w *conditionalResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	return h.Hijack()
+}
+
+// CloseNotify implements http.CloseNotifier so that handlers are notified
+// when the client disconnects.
+func (w *conditionalResponseWriter) CloseNotify() <-chan bool {
+	if cn, ok := w.ResponseWriter.(http.CloseNotifier); ok {
+		return cn.CloseNotify()
+	}
+	// never notifies
+	return make(chan bool)
+}
+
+// notModified evaluates the conditional headers of `r` against the response
+// `header`, as described by RFC 7232.  If-Modified-Since is ignored when
+// If-None-Match is present.
+func notModified(r *http.Request, header http.Header) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		etag := header.Get("ETag")
+		if etag == "" {
+			return false
+		}
+
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || weakETag(candidate) == weakETag(etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	ims, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	lastModified, err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(ims)
+}
+
+// weakETag strips the weakness indicator of `etag`, since If-None-Match uses
+// the weak comparison function.
+func weakETag(etag string) string {
+	return strings.TrimPrefix(etag, "W/")
+}
+
 func contextMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -151,6 +282,24 @@ func (w *web) RateLimitMiddleware(next http.Handler) http.Handler {
 	return w.rateLimiter.RateLimit(next)
 }
 
+// requireBearerToken only lets through the requests whose Authorization
+// header carries `token` as a bearer token.
+func requireBearerToken(token string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			provided := strings.TrimPrefix(header, "Bearer ")
+			if token == "" || provided == header ||
+				subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				problem.Render(r.Context(), w, hProblem.Unauthorized)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
 // recoverMiddleware helps the server recover from panics. It ensures that
 // no request can fully bring down the horizon server, and it also logs the
 // panics to the logging subsystem.
@@ -1,8 +1,13 @@
 package horizon
 
 import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stellar/go/services/horizon/internal/test"
 	"github.com/stretchr/testify/assert"
@@ -142,3 +147,153 @@ func TestRateLimit_Redis(t *testing.T) {
 	w = rh.Get("/", test.RequestHelperRemoteAddr("127.0.0.2"))
 	assert.Equal(t, 200, w.Code)
 }
+
+func TestRequestCacheHeadersMiddleware(t *testing.T) {
+	closedAt := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	handler := requestCacheHeadersMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/immutable" {
+			setImmutableCacheHeaders(w, closedAt)
+		}
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+		w.Write([]byte(`{"a":1}`))
+	}))
+
+	get := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", path, nil)
+		for name, value := range headers {
+			r.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("/", nil)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, noCacheControl, w.Header().Get("Cache-Control"))
+	assert.Equal(t, `{"a":1}`, w.Body.String())
+
+	w = get("/immutable", nil)
+	assert.Equal(t, immutableCacheControl, w.Header().Get("Cache-Control"))
+	assert.Equal(t, "Fri, 01 Mar 2019 12:00:00 GMT", w.Header().Get("Last-Modified"))
+
+	// If-None-Match
+	for _, inm := range []string{`"abc"`, `W/"abc"`, `"xyz", "abc"`, "*"} {
+		w = get("/", map[string]string{"If-None-Match": inm})
+		assert.Equal(t, 304, w.Code, inm)
+		assert.Empty(t, w.Body.String(), inm)
+		assert.Empty(t, w.Header().Get("Content-Type"), inm)
+		assert.Equal(t, `"abc"`, w.Header().Get("ETag"), inm)
+	}
+	w = get("/", map[string]string{"If-None-Match": `"xyz"`})
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"a":1}`, w.Body.String())
+
+	// If-Modified-Since
+	w = get("/immutable", map[string]string{"If-Modified-Since": "Fri, 01 Mar 2019 12:00:00 GMT"})
+	assert.Equal(t, 304, w.Code)
+	w = get("/immutable", map[string]string{"If-Modified-Since": "Fri, 01 Mar 2019 11:59:59 GMT"})
+	assert.Equal(t, 200, w.Code)
+	w = get("/", map[string]string{"If-Modified-Since": "Fri, 01 Mar 2019 12:00:00 GMT"})
+	assert.Equal(t, 200, w.Code)
+
+	// If-None-Match takes precedence over If-Modified-Since
+	w = get("/immutable", map[string]string{
+		"If-None-Match":     `"xyz"`,
+		"If-Modified-Since": "Fri, 01 Mar 2019 12:00:00 GMT",
+	})
+	assert.Equal(t, 200, w.Code)
+
+	// only successful GET and HEAD responses are replaced
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Set("If-None-Match", `"abc"`)
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, r)
+	assert.Equal(t, 200, rw.Code)
+
+	notFound := requestCacheHeadersMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"abc"`)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	r = httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("If-None-Match", `"abc"`)
+	rw = httptest.NewRecorder()
+	notFound.ServeHTTP(rw, r)
+	assert.Equal(t, 404, rw.Code)
+}
+
+// hijackRecorder is a ResponseRecorder which can be hijacked.
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	r.hijacked = true
+	return nil, nil, nil
+}
+
+func (r *hijackRecorder) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
+
+func TestRequestCacheHeadersMiddlewareUpgrades(t *testing.T) {
+	var wrapped bool
+	handler := requestCacheHeadersMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, wrapped = w.(*conditionalResponseWriter)
+		if _, ok := w.(http.CloseNotifier); !ok {
+			t.Error("response writer is not a http.CloseNotifier")
+		}
+		if h, ok := w.(http.Hijacker); ok {
+			h.Hijack()
+		} else {
+			t.Error("response writer is not a http.Hijacker")
+		}
+	}))
+
+	// conditional requests can be hijacked through the wrapper
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("If-None-Match", `"abc"`)
+	w := &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handler.ServeHTTP(w, r)
+	assert.True(t, wrapped)
+	assert.True(t, w.hijacked)
+
+	// upgrades and streams are not wrapped
+	for name, value := range map[string]string{"Upgrade": "websocket", "Accept": "text/event-stream"} {
+		r = httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("If-None-Match", `"abc"`)
+		r.Header.Set(name, value)
+		w = &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+		handler.ServeHTTP(w, r)
+		assert.False(t, wrapped, name)
+		assert.True(t, w.hijacked, name)
+	}
+}
+
+func TestRequireBearerToken(t *testing.T) {
+	handler := requireBearerToken("s3cret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+
+	get := func(authorization string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/webhooks", nil)
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("Bearer s3cret")
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "ok", w.Body.String())
+
+	for _, authorization := range []string{"", "s3cret", "Bearer wrong", "Bearer s3cret2", "Basic s3cret"} {
+		w = get(authorization)
+		assert.Equal(t, 401, w.Code, authorization)
+		assert.Contains(t, w.Body.String(), "unauthorized", authorization)
+	}
+}
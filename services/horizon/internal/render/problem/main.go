@@ -29,6 +29,17 @@ var (
 			"headers.",
 	}
 
+	// Unauthorized is a well-known problem type.  Use it as a shortcut
+	// in your actions.
+	Unauthorized = problem.P{
+		Type:   "unauthorized",
+		Title:  "Unauthorized",
+		Status: http.StatusUnauthorized,
+		Detail: "The request is missing the credentials required by this " +
+			"endpoint.  Provide the configured token in the 'Authorization: " +
+			"Bearer' header.",
+	}
+
 	// NotImplemented is a well-known problem type.  Use it as a shortcut
 	// in your actions.
 	NotImplemented = problem.P{
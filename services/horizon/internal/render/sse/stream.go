@@ -30,6 +30,7 @@ type Stream struct {
 	initSync sync.Once  // Variable to ensure that Init only writes the preamble once.
 	mu       sync.Mutex // Mutex protects the following fields
 	w        http.ResponseWriter
+	callback func(Event)
 	done     bool
 	sent     int
 	limit    int
@@ -43,11 +44,24 @@ func NewStream(ctx context.Context, w http.ResponseWriter) *Stream {
 	}
 }
 
+// NewCallbackStream creates a new stream which, instead of writing events to
+// a http response, passes every event sent to `callback`.  It lets the events
+// of streaming actions be delivered through other transports.
+func NewCallbackStream(ctx context.Context, callback func(Event)) *Stream {
+	return &Stream{
+		ctx:      ctx,
+		callback: callback,
+	}
+}
+
 // Init function is only executed once. It writes the preamble event which includes the HTTP response code and a
 // hello message. This should be called before any method that writes to the client to ensure that the preamble
 // has been sent first.
 func (s *Stream) Init() {
 	s.initSync.Do(func() {
+		if s.callback != nil {
+			return
+		}
 		ok := WritePreamble(s.ctx, s.w)
 		if !ok {
 			s.done = true
@@ -58,8 +72,7 @@ func (s *Stream) Init() {
 func (s *Stream) Send(e Event) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.Init()
-	WriteEvent(s.ctx, s.w, e)
+	s.write(e)
 	s.sent++
 }
 
@@ -78,8 +91,7 @@ func (s *Stream) SetLimit(limit int) {
 func (s *Stream) Done() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.Init()
-	WriteEvent(s.ctx, s.w, goodbyeEvent)
+	s.write(goodbyeEvent)
 	s.done = true
 }
 
@@ -106,7 +118,7 @@ func (s *Stream) Err(err error) {
 
 	// If we haven't sent an event, we should simply return the normal HTTP
 	// error because it means that we haven't sent the preamble.
-	if s.sent == 0 {
+	if s.sent == 0 && s.callback == nil {
 		problem.Render(s.ctx, s.w, err)
 		return
 	}
@@ -123,7 +135,18 @@ func (s *Stream) Err(err error) {
 		err = errBadStream
 	}
 
-	s.Init()
-	WriteEvent(s.ctx, s.w, Event{Error: err})
+	s.write(Event{Error: err})
 	s.done = true
 }
+
+// write delivers `e` to the client.  Not safe to call concurrently and meant
+// for internal use.
+func (s *Stream) write(e Event) {
+	if s.callback != nil {
+		s.callback(e)
+		return
+	}
+
+	s.Init()
+	WriteEvent(s.ctx, s.w, e)
+}
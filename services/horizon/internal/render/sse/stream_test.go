@@ -94,6 +94,25 @@ func (suite *StreamTestSuite) TestStream_SentCount() {
 	assert.Equal(suite.T(), 5, suite.stream.SentCount())
 }
 
+// Tests that callback streams pass events on instead of writing them.
+func (suite *StreamTestSuite) TestStream_Callback() {
+	var events []Event
+	stream := NewCallbackStream(suite.ctx, func(e Event) {
+		events = append(events, e)
+	})
+
+	stream.Init()
+	stream.Send(Event{ID: "1", Data: "test message"})
+	stream.Err(errors.New("example error"))
+
+	if assert.Len(suite.T(), events, 2) {
+		assert.Equal(suite.T(), Event{ID: "1", Data: "test message"}, events[0])
+		assert.Error(suite.T(), events[1].Error)
+	}
+	assert.Equal(suite.T(), 1, stream.SentCount())
+	assert.True(suite.T(), stream.IsDone())
+}
+
 // Runs the test suite.
 func TestStreamTestSuite(t *testing.T) {
 	suite.Run(t, new(StreamTestSuite))
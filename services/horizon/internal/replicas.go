@@ -0,0 +1,120 @@
+package horizon
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/log"
+)
+
+// replicaCheckTimeout bounds the duration of loading the latest ledger of a
+// single replica.
+const replicaCheckTimeout = 2 * time.Second
+
+// historyReplica is a read-only replica of the horizon database.
+type historyReplica struct {
+	// name identifies the replica in logs, without any credentials.
+	name string
+	db   *sqlx.DB
+
+	lock         sync.RWMutex
+	checked      bool
+	latestLedger int32
+	err          error
+}
+
+// upToDate returns true if the replica ingested at least up to `ledger`.
+func (r *historyReplica) upToDate(ledger int32) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.checked && r.err == nil && r.latestLedger >= ledger
+}
+
+// historyReplicas routes the history queries of read-only requests to
+// replicas of the horizon database.  A replica is only used when it is not
+// behind the horizon database, as last seen by `ledger.CurrentState()`, so
+// that responses never go back in time.  When no replica is up to date the
+// primary database is used instead.
+type historyReplicas struct {
+	primary  *sqlx.DB
+	replicas []*historyReplica
+
+	// next is incremented on every session to spread load across replicas.
+	next uint32
+}
+
+// Session returns a new session bound to `ctx` loading data from an up to date
+// replica or, if none is, from the primary database.
+func (hr *historyReplicas) Session(ctx context.Context) *db.Session {
+	return &db.Session{DB: hr.choose(ledger.CurrentState().HistoryLatest), Ctx: ctx}
+}
+
+// choose returns the database of a replica that ingested at least up to
+// `historyLatest`, in round robin, or the primary database if none did.
+func (hr *historyReplicas) choose(historyLatest int32) *sqlx.DB {
+	n := uint32(len(hr.replicas))
+	if n == 0 {
+		return hr.primary
+	}
+
+	start := atomic.AddUint32(&hr.next, 1)
+	for i := uint32(0); i < n; i++ {
+		r := hr.replicas[(start+i)%n]
+		if r.upToDate(historyLatest) {
+			return r.db
+		}
+	}
+
+	return hr.primary
+}
+
+// Update loads the latest ledger of every replica, in parallel.
+func (hr *historyReplicas) Update(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(len(hr.replicas))
+
+	for _, r := range hr.replicas {
+		go func(r *historyReplica) {
+			defer wg.Done()
+			hr.update(ctx, r)
+		}(r)
+	}
+
+	wg.Wait()
+}
+
+func (hr *historyReplicas) update(ctx context.Context, r *historyReplica) {
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	var latest int32
+	q := &history.Q{Session: &db.Session{DB: r.db, Ctx: ctx}}
+	err := q.LatestLedger(&latest)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err != nil && (r.err == nil || !r.checked) {
+		log.WithFields(log.F{"replica": r.name, "err": err}).
+			Warn("failed to load the latest ledger of history replica")
+	}
+
+	r.checked = true
+	r.err = err
+	if err == nil {
+		r.latestLedger = latest
+	}
+}
+
+// Close closes the connections to every replica.
+func (hr *historyReplicas) Close() {
+	for _, r := range hr.replicas {
+		r.db.Close()
+	}
+}
@@ -0,0 +1,29 @@
+package resourceadapter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	. "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/support/render/hal"
+)
+
+// PopulateAccountDataEntry fills out the resource's fields
+func PopulateAccountDataEntry(
+	ctx context.Context,
+	dest *AccountDataEntry,
+	address string,
+	row core.AccountData,
+) {
+	dest.Key = row.Key
+	dest.Value = row.Value
+	dest.PT = row.Key
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	self := fmt.Sprintf("/accounts/%s/data/%s", address, url.PathEscape(row.Key))
+	dest.Links.Self = lb.Link(self)
+	dest.Links.History = lb.PagedLink(self, "history")
+}
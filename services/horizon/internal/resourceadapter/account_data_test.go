@@ -0,0 +1,30 @@
+package resourceadapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPopulateAccountDataEntry(t *testing.T) {
+	var res horizon.AccountDataEntry
+	PopulateAccountDataEntry(context.Background(), &res, "GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD", core.AccountData{
+		Key:   "config/fee rate",
+		Value: "MTA=",
+	})
+
+	assert.Equal(t, "config/fee rate", res.Key)
+	assert.Equal(t, "MTA=", res.Value)
+	assert.Equal(t, "config/fee rate", res.PagingToken())
+	assert.Equal(t,
+		"/accounts/GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD/data/config%2Ffee%20rate",
+		res.Links.Self.Href,
+	)
+	assert.Equal(t,
+		"/accounts/GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD/data/config%2Ffee%20rate/history{?cursor,limit,order}",
+		res.Links.History.Href,
+	)
+}
@@ -26,6 +26,16 @@ func PopulateAssetStat(
 		return errors.Wrap(err, "Invalid amount in PopulateAssetStat")
 	}
 	res.NumAccounts = row.NumAccounts
+	res.NumAuthorizedTrustlines = row.NumAccounts
+	res.NumUnauthorizedTrustlines = row.NumUnauthorizedAccounts
+	res.NumOffers = row.NumOffers
+	res.Volume24h, err = amount.IntStringToAmount(row.Volume24h)
+	if err != nil {
+		return errors.Wrap(err, "Invalid volume in PopulateAssetStat")
+	}
+	res.TradeCount24h = row.TradeCount24h
+	res.LastPrice = row.LastPrice
+	res.HomeDomain = row.HomeDomain
 	res.Flags = AccountFlags{
 		(row.Flags & int8(xdr.AccountFlagsAuthRequiredFlag)) != 0,
 		(row.Flags & int8(xdr.AccountFlagsAuthRevocableFlag)) != 0,
@@ -11,14 +11,20 @@ import (
 
 func TestLargeAmount(t *testing.T) {
 	row := assets.AssetStatsR{
-		SortKey:     "",
-		Type:        "credit_alphanum4",
-		Code:        "XIM",
-		Issuer:      "GBZ35ZJRIKJGYH5PBKLKOZ5L6EXCNTO7BKIL7DAVVDFQ2ODJEEHHJXIM",
-		Amount:      "100000000000000000000", // 10T
-		NumAccounts: 429,
-		Flags:       0,
-		Toml:        "https://xim.com/.well-known/stellar.toml",
+		SortKey:                 "",
+		Type:                    "credit_alphanum4",
+		Code:                    "XIM",
+		Issuer:                  "GBZ35ZJRIKJGYH5PBKLKOZ5L6EXCNTO7BKIL7DAVVDFQ2ODJEEHHJXIM",
+		Amount:                  "100000000000000000000", // 10T
+		NumAccounts:             429,
+		NumUnauthorizedAccounts: 3,
+		NumOffers:               12,
+		Volume24h:               "20000000000000000000", // 2T
+		TradeCount24h:           57,
+		LastPrice:               "0.2500000",
+		Flags:                   0,
+		HomeDomain:              "xim.com",
+		Toml:                    "https://xim.com/.well-known/stellar.toml",
 	}
 	var res protocol.AssetStat
 	err := PopulateAssetStat(context.Background(), &res, row)
@@ -29,5 +35,12 @@ func TestLargeAmount(t *testing.T) {
 	assert.Equal(t, "GBZ35ZJRIKJGYH5PBKLKOZ5L6EXCNTO7BKIL7DAVVDFQ2ODJEEHHJXIM", res.Issuer)
 	assert.Equal(t, "10000000000000.0000000", res.Amount)
 	assert.Equal(t, int32(429), res.NumAccounts)
+	assert.Equal(t, int32(429), res.NumAuthorizedTrustlines)
+	assert.Equal(t, int32(3), res.NumUnauthorizedTrustlines)
+	assert.Equal(t, int32(12), res.NumOffers)
+	assert.Equal(t, "2000000000000.0000000", res.Volume24h)
+	assert.Equal(t, int32(57), res.TradeCount24h)
+	assert.Equal(t, "0.2500000", res.LastPrice)
+	assert.Equal(t, "xim.com", res.HomeDomain)
 	assert.Equal(t, "https://xim.com/.well-known/stellar.toml", res.Links.Toml.Href)
 }
@@ -2,7 +2,9 @@ package resourceadapter
 
 import (
 	"context"
+	"math/big"
 
+	"github.com/stellar/go/amount"
 	. "github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/xdr"
@@ -46,3 +48,72 @@ func populatePriceLevels(destp *[]PriceLevel, rows []core.OrderBookSummaryPriceL
 		}
 	}
 }
+
+// PopulateOrderBookCumulative fills out the cumulative amount of every price
+// level of the summary, starting at the best price of each side.
+func PopulateOrderBookCumulative(dest *OrderBookSummary, row core.OrderBookSummary) {
+	populateCumulative(dest.Bids, row.Bids())
+	populateCumulative(dest.Asks, row.Asks())
+}
+
+func populateCumulative(dest []PriceLevel, rows []core.OrderBookSummaryPriceLevel) {
+	var total int64
+	for i, row := range rows {
+		total += row.Amount
+		dest[i].Cumulative = amount.String(xdr.Int64(total))
+	}
+}
+
+// PopulateOrderBookSpread fills out the best bid and ask of the summary,
+// along with the spread and midprice derived from them.
+func PopulateOrderBookSpread(dest *OrderBookSpread, row core.OrderBookSummary) {
+	var bid, ask *big.Rat
+
+	if bids := row.Bids(); len(bids) > 0 {
+		bid = big.NewRat(int64(bids[0].Pricen), int64(bids[0].Priced))
+		dest.BestBid = bid.FloatString(7)
+	}
+
+	if asks := row.Asks(); len(asks) > 0 {
+		ask = big.NewRat(int64(asks[0].Pricen), int64(asks[0].Priced))
+		dest.BestAsk = ask.FloatString(7)
+	}
+
+	if bid == nil || ask == nil {
+		return
+	}
+
+	dest.Spread = new(big.Rat).Sub(ask, bid).FloatString(7)
+	mid := new(big.Rat).Add(ask, bid)
+	dest.MidPrice = mid.Quo(mid, big.NewRat(2, 1)).FloatString(7)
+}
+
+// PopulateOrderBookTicker fills out a ticker from a summary that contains at
+// least the best price level of each side.
+func PopulateOrderBookTicker(
+	ctx context.Context,
+	dest *OrderBookTicker,
+	selling xdr.Asset,
+	buying xdr.Asset,
+	row core.OrderBookSummary,
+) error {
+	err := PopulateAsset(ctx, &dest.Selling, selling)
+	if err != nil {
+		return err
+	}
+	err = PopulateAsset(ctx, &dest.Buying, buying)
+	if err != nil {
+		return err
+	}
+
+	PopulateOrderBookSpread(&dest.OrderBookSpread, row)
+
+	if bids := row.Bids(); len(bids) > 0 {
+		dest.BestBidAmount = bids[0].AmountAsString()
+	}
+	if asks := row.Asks(); len(asks) > 0 {
+		dest.BestAskAmount = asks[0].AmountAsString()
+	}
+
+	return nil
+}
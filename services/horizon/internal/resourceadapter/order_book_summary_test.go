@@ -0,0 +1,71 @@
+package resourceadapter
+
+import (
+	"context"
+	"testing"
+
+	protocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPriceLevel(typ string, n, d int32, amount int64) core.OrderBookSummaryPriceLevel {
+	return core.OrderBookSummaryPriceLevel{
+		Type: typ,
+		PriceLevel: core.PriceLevel{
+			Pricen: n,
+			Priced: d,
+			Pricef: float64(n) / float64(d),
+			Amount: amount,
+		},
+	}
+}
+
+func TestPopulateOrderBookSpreadAndCumulative(t *testing.T) {
+	row := core.OrderBookSummary{
+		testPriceLevel("ask", 15, 1, 100000000),
+		testPriceLevel("ask", 20, 1, 1000000000),
+		testPriceLevel("bid", 9, 1, 9000000000),
+		testPriceLevel("bid", 10, 1, 1000000000),
+	}
+
+	selling := xdr.MustNewCreditAsset("USD", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	buying := xdr.MustNewNativeAsset()
+
+	var res protocol.OrderBookSummary
+	err := PopulateOrderBookSummary(context.Background(), &res, selling, buying, row)
+	require.NoError(t, err)
+	PopulateOrderBookCumulative(&res, row)
+
+	assert.Equal(t, "10.0000000", res.Bids[0].Price)
+	assert.Equal(t, "100.0000000", res.Bids[0].Cumulative)
+	assert.Equal(t, "1000.0000000", res.Bids[1].Cumulative)
+	assert.Equal(t, "10.0000000", res.Asks[0].Cumulative)
+	assert.Equal(t, "110.0000000", res.Asks[1].Cumulative)
+
+	var spread protocol.OrderBookSpread
+	PopulateOrderBookSpread(&spread, row)
+	assert.Equal(t, "10.0000000", spread.BestBid)
+	assert.Equal(t, "15.0000000", spread.BestAsk)
+	assert.Equal(t, "5.0000000", spread.Spread)
+	assert.Equal(t, "12.5000000", spread.MidPrice)
+
+	// one-sided books have no spread
+	spread = protocol.OrderBookSpread{}
+	PopulateOrderBookSpread(&spread, row[:2])
+	assert.Equal(t, "", spread.BestBid)
+	assert.Equal(t, "15.0000000", spread.BestAsk)
+	assert.Equal(t, "", spread.Spread)
+	assert.Equal(t, "", spread.MidPrice)
+
+	var ticker protocol.OrderBookTicker
+	err = PopulateOrderBookTicker(context.Background(), &ticker, selling, buying, row)
+	require.NoError(t, err)
+	assert.Equal(t, "USD", ticker.Selling.Code)
+	assert.Equal(t, "native", ticker.Buying.Type)
+	assert.Equal(t, "12.5000000", ticker.MidPrice)
+	assert.Equal(t, "100.0000000", ticker.BestBidAmount)
+	assert.Equal(t, "10.0000000", ticker.BestAskAmount)
+}
@@ -3,10 +3,12 @@ package resourceadapter
 import (
 	"context"
 	"net/url"
+	"time"
 
 	"github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/services/horizon/internal/httpx"
 	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/services/horizon/internal/txsub"
 	"github.com/stellar/go/support/render/hal"
 )
 
@@ -46,3 +48,24 @@ func PopulateRoot(
 	dest.Links.Transaction = lb.Link("/transactions/{hash}")
 	dest.Links.Transactions = lb.PagedLink("/transactions")
 }
+
+// PopulateCoreBackends fills in the health of the stellar-core instances
+// transactions are submitted to.
+func PopulateCoreBackends(dest *horizon.Root, backends []txsub.BackendHealth) {
+	dest.CoreSubmissionBackends = make([]horizon.CoreBackend, len(backends))
+	for i, b := range backends {
+		cb := &dest.CoreSubmissionBackends[i]
+		cb.URL = b.URL
+		cb.Healthy = b.Healthy
+		cb.State = b.State
+		cb.Ledger = b.Ledger
+		cb.LedgerAge = int64(b.LedgerAge / time.Second)
+		if b.Err != nil {
+			cb.Error = b.Err.Error()
+		}
+		if b.Checked {
+			checkedAt := b.CheckedAt
+			cb.CheckedAt = &checkedAt
+		}
+	}
+}
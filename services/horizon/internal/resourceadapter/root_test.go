@@ -4,9 +4,12 @@ import (
 	"context"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stellar/go/clients/horizon"
 	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/services/horizon/internal/txsub"
+	"github.com/stellar/go/support/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,6 +54,42 @@ func TestPopulateRoot(t *testing.T) {
 	assert.Empty(t, res.Links.Friendbot)
 }
 
+func TestPopulateCoreBackends(t *testing.T) {
+	checkedAt := time.Unix(1500000000, 0)
+	res := &horizon.Root{}
+	PopulateCoreBackends(res, []txsub.BackendHealth{
+		{
+			URL:       "http://core1:11626",
+			Checked:   true,
+			Healthy:   true,
+			State:     "Synced!",
+			Ledger:    10,
+			LedgerAge: 3 * time.Second,
+			CheckedAt: checkedAt,
+		},
+		{
+			URL:     "http://core2:11626",
+			Checked: true,
+			Err:     errors.New("http request errored"),
+		},
+		{URL: "http://core3:11626"},
+	})
+
+	if assert.Len(t, res.CoreSubmissionBackends, 3) {
+		assert.Equal(t, "http://core1:11626", res.CoreSubmissionBackends[0].URL)
+		assert.True(t, res.CoreSubmissionBackends[0].Healthy)
+		assert.Equal(t, "Synced!", res.CoreSubmissionBackends[0].State)
+		assert.Equal(t, int32(10), res.CoreSubmissionBackends[0].Ledger)
+		assert.Equal(t, int64(3), res.CoreSubmissionBackends[0].LedgerAge)
+		assert.Equal(t, checkedAt, *res.CoreSubmissionBackends[0].CheckedAt)
+
+		assert.False(t, res.CoreSubmissionBackends[1].Healthy)
+		assert.Equal(t, "http request errored", res.CoreSubmissionBackends[1].Error)
+
+		assert.Nil(t, res.CoreSubmissionBackends[2].CheckedAt)
+	}
+}
+
 func urlMustParse(t *testing.T, s string) *url.URL {
 	if u, err := url.Parse(s); err != nil {
 		t.Fatalf("Unable to parse URL: %s/%v", s, err)
@@ -7,6 +7,7 @@ import (
 	"github.com/stellar/go/price"
 	. "github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/xdr"
 )
 
 // Populate fills out the details of a trade using a row from the history_trades
@@ -31,3 +32,31 @@ func PopulateTradeAggregation(
 	dest.CloseR = row.Close
 	return
 }
+
+// PopulateTradeAggregationSeries fills out the trade aggregations of an asset
+// pair.
+func PopulateTradeAggregationSeries(
+	ctx context.Context,
+	dest *TradeAggregationSeries,
+	base xdr.Asset,
+	counter xdr.Asset,
+	rows []history.TradeAggregation,
+) error {
+	err := PopulateAsset(ctx, &dest.Base, base)
+	if err != nil {
+		return err
+	}
+	err = PopulateAsset(ctx, &dest.Counter, counter)
+	if err != nil {
+		return err
+	}
+
+	dest.Records = make([]TradeAggregation, len(rows))
+	for i, row := range rows {
+		err = PopulateTradeAggregation(ctx, &dest.Records[i], row)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
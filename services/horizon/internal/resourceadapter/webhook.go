@@ -0,0 +1,55 @@
+package resourceadapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/xdr"
+)
+
+// PopulateWebhook fills out the resource's fields
+func PopulateWebhook(ctx context.Context, dest *horizon.Webhook, row history.Webhook) {
+	dest.ID = fmt.Sprintf("%d", row.ID)
+	dest.PT = dest.ID
+	dest.URL = row.URL
+	dest.Account = row.Account.String
+	dest.AssetType = row.AssetType.String
+	dest.AssetCode = row.AssetCode.String
+	dest.AssetIssuer = row.AssetIssuer.String
+	dest.LastLedger = row.LastLedger
+	dest.CreatedAt = row.CreatedAt
+
+	dest.OperationTypes = make([]string, 0, len(row.OperationTypes))
+	for _, typ := range row.OperationTypes {
+		dest.OperationTypes = append(dest.OperationTypes, operations.TypeNames[xdr.OperationType(typ)])
+	}
+
+	self := fmt.Sprintf("/webhooks/%d", row.ID)
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	dest.Links.Self = lb.Link(self)
+	dest.Links.Replay = lb.Link(self, "replay{?cursor}")
+	dest.Links.DeadLetters = lb.PagedLink(self, "dead_letters")
+}
+
+// PopulateWebhookDeadLetter fills out the resource's fields
+func PopulateWebhookDeadLetter(
+	ctx context.Context,
+	dest *horizon.WebhookDeadLetter,
+	row history.WebhookDeadLetter,
+) {
+	dest.ID = fmt.Sprintf("%d", row.ID)
+	dest.PT = dest.ID
+	dest.WebhookID = fmt.Sprintf("%d", row.WebhookID)
+	dest.EventToken = row.PagingToken
+	dest.Payload = json.RawMessage(row.Payload)
+	dest.Attempts = row.Attempts
+	dest.LastError = row.LastError.String
+	dest.CreatedAt = row.CreatedAt
+	dest.FailedAt = row.FailedAt
+}
@@ -60,6 +60,7 @@ ALTER TABLE IF EXISTS ONLY public.history_operation_participants DROP CONSTRAINT
 ALTER TABLE IF EXISTS ONLY public.history_assets DROP CONSTRAINT IF EXISTS history_assets_pkey;
 ALTER TABLE IF EXISTS ONLY public.history_assets DROP CONSTRAINT IF EXISTS history_assets_asset_code_asset_type_asset_issuer_key;
 ALTER TABLE IF EXISTS ONLY public.gorp_migrations DROP CONSTRAINT IF EXISTS gorp_migrations_pkey;
+ALTER TABLE IF EXISTS ONLY public.history_trades_60000 DROP CONSTRAINT IF EXISTS history_trades_60000_pkey;
 ALTER TABLE IF EXISTS ONLY public.asset_stats DROP CONSTRAINT IF EXISTS asset_stats_pkey;
 ALTER TABLE IF EXISTS public.history_transaction_participants ALTER COLUMN id DROP DEFAULT;
 ALTER TABLE IF EXISTS public.history_operation_participants ALTER COLUMN id DROP DEFAULT;
@@ -67,6 +68,7 @@ ALTER TABLE IF EXISTS public.history_assets ALTER COLUMN id DROP DEFAULT;
 DROP TABLE IF EXISTS public.history_transactions;
 DROP SEQUENCE IF EXISTS public.history_transaction_participants_id_seq;
 DROP TABLE IF EXISTS public.history_transaction_participants;
+DROP TABLE IF EXISTS public.history_trades_60000;
 DROP TABLE IF EXISTS public.history_trades;
 DROP TABLE IF EXISTS public.history_operations;
 DROP SEQUENCE IF EXISTS public.history_operation_participants_id_seq;
@@ -210,7 +212,13 @@ CREATE TABLE asset_stats (
     amount character varying NOT NULL,
     num_accounts integer NOT NULL,
     flags smallint NOT NULL,
-    toml character varying(255) NOT NULL
+    toml character varying(255) NOT NULL,
+    num_unauthorized_accounts integer DEFAULT 0 NOT NULL,
+    num_offers integer DEFAULT 0 NOT NULL,
+    volume_24h numeric DEFAULT 0 NOT NULL,
+    trade_count_24h integer DEFAULT 0 NOT NULL,
+    last_price character varying DEFAULT ''::character varying NOT NULL,
+    home_domain character varying(32) DEFAULT ''::character varying NOT NULL
 );
 
 
@@ -387,6 +395,24 @@ CREATE TABLE history_trades (
 );
 
 
+--
+-- Name: history_trades_60000; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_trades_60000 (
+    "timestamp" bigint NOT NULL,
+    base_asset_id bigint NOT NULL,
+    counter_asset_id bigint NOT NULL,
+    count integer NOT NULL,
+    base_volume numeric NOT NULL,
+    counter_volume numeric NOT NULL,
+    high numeric[] NOT NULL,
+    low numeric[] NOT NULL,
+    open numeric[] NOT NULL,
+    close numeric[] NOT NULL
+);
+
+
 --
 -- Name: history_transaction_participants; Type: TABLE; Schema: public; Owner: -
 --
@@ -649,6 +675,14 @@ ALTER TABLE ONLY history_operation_participants
     ADD CONSTRAINT history_operation_participants_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: history_trades_60000 history_trades_60000_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trades_60000
+    ADD CONSTRAINT history_trades_60000_pkey PRIMARY KEY (base_asset_id, counter_asset_id, "timestamp");
+
+
 --
 -- Name: history_transaction_participants history_transaction_participants_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -811,6 +845,13 @@ CREATE UNIQUE INDEX htrd_pid ON history_trades USING btree (history_operation_id
 CREATE INDEX htrd_time_lookup ON history_trades USING btree (ledger_closed_at);
 
 
+--
+-- Name: htrd60_by_timestamp; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX htrd60_by_timestamp ON history_trades_60000 USING btree ("timestamp");
+
+
 --
 -- Name: index_history_accounts_on_address; Type: INDEX; Schema: public; Owner: -
 --
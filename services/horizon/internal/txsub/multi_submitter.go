@@ -0,0 +1,299 @@
+package txsub
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+)
+
+// DefaultMaxLedgerAge is the age of the last closed ledger above which a
+// stellar-core backend is considered unhealthy.
+const DefaultMaxLedgerAge = 60 * time.Second
+
+// healthCheckTimeout bounds the duration of a single backend health check.
+const healthCheckTimeout = 5 * time.Second
+
+// BackendHealth describes the last known health of a stellar-core backend.
+type BackendHealth struct {
+	// URL is the backend url, without any credentials.
+	URL string
+	// Checked is false until the first health check of the backend completed.
+	Checked   bool
+	Healthy   bool
+	State     string
+	Ledger    int32
+	LedgerAge time.Duration
+	// Err is the error of the last health check or submission, if any.
+	Err       error
+	CheckedAt time.Time
+}
+
+// backend is a stellar-core instance transactions can be submitted to.
+type backend struct {
+	submitter *submitter
+
+	lock   sync.RWMutex
+	health BackendHealth
+}
+
+func (b *backend) Health() BackendHealth {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.health
+}
+
+// rank orders backends by preference: healthy backends first, then backends
+// that have not been checked yet, then unhealthy ones.
+func (b *backend) rank() int {
+	h := b.Health()
+	switch {
+	case h.Checked && h.Healthy:
+		return 0
+	case !h.Checked:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// MultiSubmitter is a Submitter submitting to several stellar-core instances.
+// Transactions are submitted to the healthiest instance and, when it cannot be
+// reached or fails to handle the submission, to the next one.  When Broadcast
+// is enabled transactions are instead submitted to every instance at once.
+//
+// A transaction rejected by stellar-core is never submitted to another
+// instance: it would be rejected there too.
+type MultiSubmitter struct {
+	Broadcast    bool
+	MaxLedgerAge time.Duration
+
+	backends []*backend
+	log      *log.Entry
+
+	lock     sync.Mutex
+	checking bool
+}
+
+// NewMultiSubmitter returns a new MultiSubmitter submitting to the
+// stellar-core instances at `urls`, in order of preference, using the http
+// client `h`.
+func NewMultiSubmitter(h *http.Client, urls []string, broadcast bool) *MultiSubmitter {
+	ms := &MultiSubmitter{
+		Broadcast:    broadcast,
+		MaxLedgerAge: DefaultMaxLedgerAge,
+		log:          log.DefaultLogger.WithField("service", "txsub.multi_submitter"),
+	}
+
+	for _, u := range urls {
+		sub := NewDefaultSubmitter(h, u).(*submitter)
+		sub.Log = sub.Log.WithField("backend", redactURL(u))
+		ms.backends = append(ms.backends, &backend{
+			submitter: sub,
+			health:    BackendHealth{URL: redactURL(u)},
+		})
+	}
+
+	return ms
+}
+
+// Health returns the last known health of every backend, in the configured
+// order.
+func (ms *MultiSubmitter) Health() []BackendHealth {
+	result := make([]BackendHealth, len(ms.backends))
+	for i, b := range ms.backends {
+		result[i] = b.Health()
+	}
+	return result
+}
+
+// CheckHealth loads the info of every backend, in parallel, to update their
+// health.  If a previous check is still in progress, CheckHealth returns
+// immediately.
+func (ms *MultiSubmitter) CheckHealth(ctx context.Context) {
+	ms.lock.Lock()
+	if ms.checking {
+		ms.lock.Unlock()
+		return
+	}
+	ms.checking = true
+	ms.lock.Unlock()
+
+	defer func() {
+		ms.lock.Lock()
+		ms.checking = false
+		ms.lock.Unlock()
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(len(ms.backends))
+
+	for _, b := range ms.backends {
+		go func(b *backend) {
+			defer wg.Done()
+			ms.checkHealth(ctx, b)
+		}(b)
+	}
+
+	wg.Wait()
+}
+
+func (ms *MultiSubmitter) checkHealth(ctx context.Context, b *backend) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	resp, err := b.submitter.StellarCore.Info(ctx)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	wasHealthy := b.health.Healthy
+	b.health.Checked = true
+	b.health.CheckedAt = time.Now()
+	b.health.Err = err
+
+	if err != nil {
+		b.health.Healthy = false
+	} else {
+		b.health.State = resp.Info.State
+		b.health.Ledger = int32(resp.Info.Ledger.Num)
+		b.health.LedgerAge = time.Duration(resp.Info.Ledger.Age) * time.Second
+		b.health.Healthy = resp.IsSynced() && b.health.LedgerAge <= ms.MaxLedgerAge
+	}
+
+	if wasHealthy != b.health.Healthy {
+		logger := ms.log.Ctx(ctx).WithFields(log.F{
+			"backend": b.health.URL,
+			"state":   b.health.State,
+			"err":     err,
+		})
+		if b.health.Healthy {
+			logger.Info("stellar-core backend is healthy")
+		} else {
+			logger.Warn("stellar-core backend is unhealthy")
+		}
+	}
+}
+
+// markUnhealthy flags `b` as unhealthy until its next health check.
+func (ms *MultiSubmitter) markUnhealthy(b *backend, err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.health.Checked = true
+	b.health.Healthy = false
+	b.health.Err = err
+}
+
+// ordered returns the backends in order of preference: healthy backends with
+// the most recent ledger first, then the other ones in the configured order.
+func (ms *MultiSubmitter) ordered() []*backend {
+	result := make([]*backend, len(ms.backends))
+	copy(result, ms.backends)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		ri, rj := result[i].rank(), result[j].rank()
+		if ri != rj {
+			return ri < rj
+		}
+		if ri == 0 {
+			return result[i].Health().LedgerAge < result[j].Health().LedgerAge
+		}
+		return false
+	})
+
+	return result
+}
+
+// Submit implements Submitter.
+func (ms *MultiSubmitter) Submit(ctx context.Context, env string) SubmissionResult {
+	if len(ms.backends) == 0 {
+		return SubmissionResult{Err: errors.New("no stellar-core backend configured")}
+	}
+
+	if ms.Broadcast {
+		return ms.broadcast(ctx, env)
+	}
+
+	var result SubmissionResult
+	for _, b := range ms.ordered() {
+		result = b.submitter.Submit(ctx, env)
+		if !ms.failover(ctx, b, result) {
+			return result
+		}
+	}
+
+	return result
+}
+
+// broadcast submits `env` to every backend at once.  The result of the most
+// preferred backend that accepted the transaction is returned or, if none
+// did, the result of the most preferred backend.
+func (ms *MultiSubmitter) broadcast(ctx context.Context, env string) SubmissionResult {
+	backends := ms.ordered()
+	results := make([]SubmissionResult, len(backends))
+
+	var wg sync.WaitGroup
+	wg.Add(len(backends))
+
+	for i, b := range backends {
+		go func(i int, b *backend) {
+			defer wg.Done()
+			results[i] = b.submitter.Submit(ctx, env)
+			ms.failover(ctx, b, results[i])
+		}(i, b)
+	}
+
+	wg.Wait()
+
+	for _, result := range results {
+		if result.Err == nil {
+			return result
+		}
+	}
+
+	for _, result := range results {
+		if _, ok := result.Err.(*FailedTransactionError); ok {
+			return result
+		}
+	}
+
+	return results[0]
+}
+
+// failover returns true if the submission to `b` failed for a reason that is
+// not the transaction itself, in which case `b` is flagged as unhealthy.
+// Submissions cancelled by `ctx`, for example when the client disconnects,
+// are not failed over and do not flag the backend.
+func (ms *MultiSubmitter) failover(ctx context.Context, b *backend, result SubmissionResult) bool {
+	if result.Err == nil {
+		return false
+	}
+
+	if _, ok := result.Err.(*FailedTransactionError); ok {
+		return false
+	}
+
+	cause := errors.Cause(result.Err)
+	if ctx.Err() != nil || cause == context.Canceled || cause == context.DeadlineExceeded {
+		return false
+	}
+
+	ms.markUnhealthy(b, result.Err)
+	return true
+}
+
+// redactURL removes the credentials, if any, from `raw`.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+
+	u.User = nil
+	return u.String()
+}
@@ -0,0 +1,166 @@
+package txsub
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCoreMock returns a server acting as a stellar-core instance in the given
+// state, responding to submissions with `status`.
+func newCoreMock(state string, age int, status string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/info":
+			fmt.Fprintf(w, `{"info": {"state": %q, "ledger": {"num": 10, "age": %d}}}`, state, age)
+		case "/tx":
+			fmt.Fprintf(w, `{"status": %q, "error": "AAAAAAAAAAD////7AAAAAA=="}`, status)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestMultiSubmitterFailover(t *testing.T) {
+	ctx := test.Context()
+
+	down := newCoreMock("Synced!", 1, "PENDING")
+	down.Close()
+	up := newCoreMock("Synced!", 1, "PENDING")
+	defer up.Close()
+
+	ms := NewMultiSubmitter(http.DefaultClient, []string{down.URL, up.URL}, false)
+
+	// the first backend is unreachable, the second one gets the submission
+	sr := ms.Submit(ctx, "hello")
+	assert.Nil(t, sr.Err)
+
+	health := ms.Health()
+	assert.True(t, health[0].Checked)
+	assert.False(t, health[0].Healthy)
+	assert.NotNil(t, health[0].Err)
+	assert.False(t, health[1].Checked)
+
+	// errors when every backend is unreachable
+	ms = NewMultiSubmitter(http.DefaultClient, []string{down.URL}, false)
+	sr = ms.Submit(ctx, "hello")
+	assert.NotNil(t, sr.Err)
+
+	// errors without backends
+	ms = NewMultiSubmitter(http.DefaultClient, nil, false)
+	sr = ms.Submit(ctx, "hello")
+	assert.NotNil(t, sr.Err)
+}
+
+func TestMultiSubmitterCancelledSubmission(t *testing.T) {
+	core := newCoreMock("Synced!", 1, "PENDING")
+	defer core.Close()
+
+	ctx, cancel := context.WithCancel(test.Context())
+	cancel()
+
+	// a cancelled submission does not flag the backends as unhealthy
+	ms := NewMultiSubmitter(http.DefaultClient, []string{core.URL, core.URL}, false)
+	sr := ms.Submit(ctx, "hello")
+	assert.NotNil(t, sr.Err)
+	for _, health := range ms.Health() {
+		assert.False(t, health.Checked)
+	}
+
+	ms = NewMultiSubmitter(http.DefaultClient, []string{core.URL}, true)
+	sr = ms.Submit(ctx, "hello")
+	assert.NotNil(t, sr.Err)
+	assert.False(t, ms.Health()[0].Checked)
+}
+
+func TestMultiSubmitterRejectedTransaction(t *testing.T) {
+	ctx := test.Context()
+
+	rejecting := newCoreMock("Synced!", 1, "ERROR")
+	defer rejecting.Close()
+	accepting := newCoreMock("Synced!", 1, "PENDING")
+	defer accepting.Close()
+
+	// a transaction rejected by stellar-core is not submitted to other backends
+	ms := NewMultiSubmitter(http.DefaultClient, []string{rejecting.URL, accepting.URL}, false)
+	sr := ms.Submit(ctx, "hello")
+	assert.IsType(t, &FailedTransactionError{}, sr.Err)
+	assert.False(t, ms.Health()[0].Checked)
+
+	// when broadcasting, the transaction is accepted by the second backend
+	ms = NewMultiSubmitter(http.DefaultClient, []string{rejecting.URL, accepting.URL}, true)
+	sr = ms.Submit(ctx, "hello")
+	assert.Nil(t, sr.Err)
+}
+
+func TestMultiSubmitterHealth(t *testing.T) {
+	ctx := test.Context()
+
+	syncing := newCoreMock("Catching up", 1, "ERROR")
+	defer syncing.Close()
+	stale := newCoreMock("Synced!", 120, "ERROR")
+	defer stale.Close()
+	healthy := newCoreMock("Synced!", 3, "PENDING")
+	defer healthy.Close()
+
+	ms := NewMultiSubmitter(
+		http.DefaultClient,
+		[]string{syncing.URL, stale.URL, "http://user:secret@" + healthy.Listener.Addr().String()},
+		false,
+	)
+	ms.CheckHealth(ctx)
+
+	health := ms.Health()
+	assert.False(t, health[0].Healthy)
+	assert.Equal(t, "Catching up", health[0].State)
+	assert.False(t, health[1].Healthy)
+	assert.Equal(t, 120*time.Second, health[1].LedgerAge)
+	assert.True(t, health[2].Healthy)
+	assert.Equal(t, int32(10), health[2].Ledger)
+	assert.Equal(t, "http://"+healthy.Listener.Addr().String(), health[2].URL)
+
+	// the healthy backend is preferred
+	sr := ms.Submit(ctx, "hello")
+	assert.Nil(t, sr.Err)
+}
+
+func TestMultiSubmitterCheckHealthSkipsWhileRunning(t *testing.T) {
+	ctx := test.Context()
+
+	var checks int32
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&checks, 1)
+		<-release
+		fmt.Fprint(w, `{"info": {"state": "Synced!", "ledger": {"num": 10, "age": 1}}}`)
+	}))
+	defer slow.Close()
+
+	ms := NewMultiSubmitter(http.DefaultClient, []string{slow.URL}, false)
+
+	done := make(chan struct{})
+	go func() {
+		ms.CheckHealth(ctx)
+		close(done)
+	}()
+
+	// wait for the first check to reach the backend
+	for atomic.LoadInt32(&checks) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// the second check returns without loading the info of the backend
+	ms.CheckHealth(ctx)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&checks))
+
+	close(release)
+	<-done
+	assert.True(t, ms.Health()[0].Healthy)
+}
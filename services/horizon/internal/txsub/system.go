@@ -19,6 +19,7 @@ type System struct {
 
 	tickMutex      sync.Mutex
 	tickInProgress bool
+	lastTick       time.Time
 
 	Pending           OpenSubmissionList
 	Results           ResultProvider
@@ -259,6 +260,18 @@ func (sys *System) Tick(ctx context.Context) {
 
 	sys.Metrics.OpenSubmissionsGauge.Update(int64(stillOpen))
 	sys.Metrics.BufferedSubmissionsGauge.Update(int64(sys.SubmissionQueue.Size()))
+
+	sys.tickMutex.Lock()
+	sys.lastTick = time.Now()
+	sys.tickMutex.Unlock()
+}
+
+// LastTick returns the time the last successful tick completed at, or the
+// zero time if no tick completed yet.
+func (sys *System) LastTick() time.Time {
+	sys.tickMutex.Lock()
+	defer sys.tickMutex.Unlock()
+	return sys.lastTick
 }
 
 // Init initializes `sys`
@@ -0,0 +1,114 @@
+// Package verify contains the ingestion verification subsystem for horizon.
+// Periodically, the system samples accounts and assets from the stellar-core
+// database and compares their state with the state derived from the history
+// database: account balances are recomputed from effects, asset statistics
+// are compared with trustlines and the hash chain of the most recent history
+// ledgers is checked.  Mismatches point at ingestion bugs or at a corrupted
+// history database; they are reported as metrics and logged.
+package verify
+
+import (
+	"sync"
+	"time"
+
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/stellar/go/support/db"
+	ilog "github.com/stellar/go/support/log"
+)
+
+var log = ilog.DefaultLogger.WithField("service", "verify")
+
+// Check names the kind of verification a mismatch was found by.
+type Check string
+
+const (
+	// CheckLedgerChain mismatches are history ledgers whose previous ledger
+	// hash is not the hash of the previous history ledger.
+	CheckLedgerChain Check = "ledger_chain"
+	// CheckLedgerHash mismatches are history ledgers whose hash differs from
+	// the hash of the same ledger in stellar-core.
+	CheckLedgerHash Check = "ledger_hash"
+	// CheckNativeBalance mismatches are accounts whose native balance differs
+	// from the balance derived from their effects and fees.
+	CheckNativeBalance Check = "native_balance"
+	// CheckTrustlineBalance mismatches are trustlines whose balance differs
+	// from the balance derived from the effects of their account.
+	CheckTrustlineBalance Check = "trustline_balance"
+	// CheckAssetStats mismatches are assets whose statistics differ from the
+	// trustlines to the asset.
+	CheckAssetStats Check = "asset_stats"
+)
+
+// Mismatch is a difference between the state of stellar-core and the state
+// derived from the history database.
+type Mismatch struct {
+	Check Check `json:"check"`
+	// Subject identifies the ledger, account or asset the mismatch is about.
+	Subject string `json:"subject"`
+	// Expected is the value derived from the history database.
+	Expected string `json:"expected"`
+	// Actual is the value found in stellar-core.
+	Actual string `json:"actual"`
+}
+
+// Result is the outcome of a verification run.
+type Result struct {
+	// Ledger is the latest history ledger at the time of the run.  Only
+	// stellar-core entries last modified at or before this ledger are compared.
+	Ledger            int32      `json:"ledger"`
+	LedgersChecked    int        `json:"ledgers_checked"`
+	AccountsChecked   int        `json:"accounts_checked"`
+	TrustlinesChecked int        `json:"trustlines_checked"`
+	AssetsChecked     int        `json:"assets_checked"`
+	Mismatches        []Mismatch `json:"mismatches"`
+}
+
+// Metrics tracks all the metrics for the verify subsystem
+type Metrics struct {
+	RunTimer            metrics.Timer
+	MismatchCounter     metrics.Counter
+	LastMismatchesGauge metrics.Gauge
+}
+
+// System represents the ingestion verification subsystem of horizon.
+type System struct {
+	HorizonDB *db.Session
+	CoreDB    *db.Session
+	// Interval is the delay between two verification runs triggered by Tick.
+	Interval time.Duration
+	// AccountSampleSize is the number of accounts verified per run.
+	AccountSampleSize uint64
+	// AssetSampleSize is the number of assets verified per run.
+	AssetSampleSize uint64
+	// LedgerWindow is the number of most recent history ledgers whose hash
+	// chain is verified per run.
+	LedgerWindow int32
+	// CheckNativeBalances enables the verification of native balances.  Native
+	// balances can only be derived when the fees of failed transactions are
+	// known, that is when failed transactions are ingested.
+	CheckNativeBalances bool
+	Metrics             Metrics
+
+	lock    sync.Mutex
+	running bool
+	nextRun time.Time
+}
+
+// New initializes the verify system using the provided databases and
+// reasonable defaults.  The first run happens after `interval`.
+func New(interval time.Duration, horizon *db.Session, core *db.Session) *System {
+	sys := &System{
+		HorizonDB:         horizon,
+		CoreDB:            core,
+		Interval:          interval,
+		AccountSampleSize: 100,
+		AssetSampleSize:   20,
+		LedgerWindow:      1000,
+	}
+
+	sys.Metrics.RunTimer = metrics.NewTimer()
+	sys.Metrics.MismatchCounter = metrics.NewCounter()
+	sys.Metrics.LastMismatchesGauge = metrics.NewGauge()
+	sys.nextRun = time.Now().Add(interval)
+	return sys
+}
@@ -0,0 +1,412 @@
+package verify
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/services/horizon/internal/assets"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	herr "github.com/stellar/go/services/horizon/internal/errors"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+	ilog "github.com/stellar/go/support/log"
+)
+
+// Tick triggers the verify system to run if it is the appropriate time.  If a
+// previous run is still in progress, Tick returns immediately.
+func (sys *System) Tick() {
+	sys.lock.Lock()
+	if sys.running || time.Now().Before(sys.nextRun) {
+		sys.lock.Unlock()
+		return
+	}
+	sys.running = true
+	sys.lock.Unlock()
+
+	defer func() {
+		sys.lock.Lock()
+		sys.running = false
+		sys.nextRun = time.Now().Add(sys.Interval)
+		sys.lock.Unlock()
+	}()
+
+	sys.runOnce()
+}
+
+func (sys *System) runOnce() {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err := herr.FromPanic(rec)
+			log.Errorf("verifier panicked: %s", err)
+			herr.ReportToSentry(err, nil)
+		}
+	}()
+
+	var result *Result
+	var err error
+	sys.Metrics.RunTimer.Time(func() {
+		result, err = sys.Run()
+	})
+	if err != nil {
+		log.Errorf("verifier failed: %s", err)
+		return
+	}
+
+	sys.Metrics.MismatchCounter.Inc(int64(len(result.Mismatches)))
+	sys.Metrics.LastMismatchesGauge.Update(int64(len(result.Mismatches)))
+
+	for _, m := range result.Mismatches {
+		log.WithFields(ilog.F{
+			"ledger":   result.Ledger,
+			"check":    m.Check,
+			"subject":  m.Subject,
+			"expected": m.Expected,
+			"actual":   m.Actual,
+		}).Error("verifier found a mismatch")
+	}
+
+	log.WithFields(ilog.F{
+		"ledger":     result.Ledger,
+		"ledgers":    result.LedgersChecked,
+		"accounts":   result.AccountsChecked,
+		"trustlines": result.TrustlinesChecked,
+		"assets":     result.AssetsChecked,
+		"mismatches": len(result.Mismatches),
+	}).Info("verifier finished")
+}
+
+// Run verifies a sample of the state of stellar-core against the history
+// database.  The history database is read in a single snapshot, and only
+// stellar-core entries that were not modified after the latest ledger of the
+// snapshot are compared, so that a run can happen while ledgers are ingested.
+func (sys *System) Run() (*Result, error) {
+	hq := &history.Q{Session: sys.HorizonDB.Clone()}
+	err := beginSnapshot(hq.Session)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin horizon snapshot failed")
+	}
+	defer hq.Rollback()
+
+	cq := &core.Q{Session: sys.CoreDB.Clone()}
+	err = beginSnapshot(cq.Session)
+	if err != nil {
+		return nil, errors.Wrap(err, "begin core snapshot failed")
+	}
+	defer cq.Rollback()
+
+	result := &Result{}
+	err = hq.LatestLedger(&result.Ledger)
+	if err != nil {
+		return nil, errors.Wrap(err, "hq.LatestLedger failed")
+	}
+	if result.Ledger == 0 {
+		return result, nil
+	}
+
+	err = sys.verifyLedgers(hq, cq, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "verifying ledgers failed")
+	}
+
+	err = sys.verifyAccounts(hq, cq, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "verifying accounts failed")
+	}
+
+	err = sys.verifyAssets(hq, cq, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "verifying assets failed")
+	}
+
+	return result, nil
+}
+
+// verifyLedgers checks the hash chain of the last LedgerWindow history ledgers
+// and compares the hash of the latest history ledger with stellar-core.
+func (sys *System) verifyLedgers(hq *history.Q, cq *core.Q, result *Result) error {
+	var elder int32
+	err := hq.ElderLedger(&elder)
+	if err != nil {
+		return errors.Wrap(err, "hq.ElderLedger failed")
+	}
+
+	from := result.Ledger - sys.LedgerWindow + 1
+	if from < elder {
+		from = elder
+	}
+
+	var breaks []history.LedgerChainBreak
+	err = hq.LedgerChainBreaks(&breaks, from)
+	if err != nil {
+		return errors.Wrap(err, "hq.LedgerChainBreaks failed")
+	}
+	for _, b := range breaks {
+		result.Mismatches = append(result.Mismatches, Mismatch{
+			Check:    CheckLedgerChain,
+			Subject:  fmt.Sprintf("ledger %d", b.Sequence),
+			Expected: b.ActualPreviousHash,
+			Actual:   b.PreviousLedgerHash,
+		})
+	}
+	result.LedgersChecked = int(result.Ledger - from + 1)
+
+	var ledger history.Ledger
+	err = hq.LedgerBySequence(&ledger, result.Ledger)
+	if err != nil {
+		return errors.Wrap(err, "hq.LedgerBySequence failed")
+	}
+
+	var header core.LedgerHeader
+	err = cq.LedgerHeaderBySequence(&header, result.Ledger)
+	if err == sql.ErrNoRows {
+		// stellar-core is behind horizon, or no longer has the ledger
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "cq.LedgerHeaderBySequence failed")
+	}
+
+	if header.LedgerHash != ledger.LedgerHash {
+		result.Mismatches = append(result.Mismatches, Mismatch{
+			Check:    CheckLedgerHash,
+			Subject:  fmt.Sprintf("ledger %d", result.Ledger),
+			Expected: ledger.LedgerHash,
+			Actual:   header.LedgerHash,
+		})
+	}
+
+	return nil
+}
+
+// verifyAccounts compares the balances of a sample of stellar-core accounts
+// with the balances derived from their effects.  Accounts whose history is
+// incomplete, because they were created before the oldest history ledger,
+// are skipped.
+func (sys *System) verifyAccounts(hq *history.Q, cq *core.Q, result *Result) error {
+	var accounts []core.Account
+	err := cq.RandomAccounts(&accounts, sys.AccountSampleSize, result.Ledger)
+	if err != nil {
+		return errors.Wrap(err, "cq.RandomAccounts failed")
+	}
+
+	for _, account := range accounts {
+		var haccount history.Account
+		err = hq.AccountByAddress(&haccount, account.Accountid)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "hq.AccountByAddress failed")
+		}
+
+		var created int
+		err = hq.CountAccountEffects(&created, haccount.ID, history.EffectAccountCreated)
+		if err != nil {
+			return errors.Wrap(err, "hq.CountAccountEffects failed")
+		}
+		if created == 0 {
+			continue
+		}
+
+		var rows []history.DerivedBalance
+		err = hq.DerivedBalances(&rows, haccount.ID, account.Accountid)
+		if err != nil {
+			return errors.Wrap(err, "hq.DerivedBalances failed")
+		}
+		derived, err := derivedBalances(rows)
+		if err != nil {
+			return errors.Wrapf(err, "invalid derived balance for %s", account.Accountid)
+		}
+
+		var fees int64
+		if sys.CheckNativeBalances {
+			err = hq.FeesPaid(&fees, account.Accountid)
+			if err != nil {
+				return errors.Wrap(err, "hq.FeesPaid failed")
+			}
+		}
+
+		var trustlines []core.Trustline
+		err = cq.TrustlinesByAddress(&trustlines, account.Accountid)
+		if err != nil {
+			return errors.Wrap(err, "cq.TrustlinesByAddress failed")
+		}
+
+		checked, mismatches := compareAccount(
+			account, trustlines, derived, fees, result.Ledger, sys.CheckNativeBalances,
+		)
+		result.AccountsChecked++
+		result.TrustlinesChecked += checked
+		result.Mismatches = append(result.Mismatches, mismatches...)
+	}
+
+	return nil
+}
+
+// verifyAssets compares the statistics of a sample of assets with the
+// trustlines to the assets.  Trustlines that were removed leave no trace, so
+// assets are only verified when stellar-core is at the latest history ledger.
+func (sys *System) verifyAssets(hq *history.Q, cq *core.Q, result *Result) error {
+	var coreLatest int32
+	err := cq.LatestLedger(&coreLatest)
+	if err != nil {
+		return errors.Wrap(err, "cq.LatestLedger failed")
+	}
+	if coreLatest != result.Ledger {
+		return nil
+	}
+
+	var stats []history.SampledAssetStat
+	err = hq.RandomAssetStats(&stats, sys.AssetSampleSize)
+	if err != nil {
+		return errors.Wrap(err, "hq.RandomAssetStats failed")
+	}
+
+	for _, stat := range stats {
+		assetType, err := assets.Parse(stat.AssetType)
+		if err != nil {
+			return errors.Wrapf(err, "invalid asset type %s", stat.AssetType)
+		}
+
+		numAccounts, sum, err := cq.BalancesForAsset(int32(assetType), stat.AssetCode, stat.AssetIssuer)
+		if err != nil {
+			return errors.Wrap(err, "cq.BalancesForAsset failed")
+		}
+
+		unauthorized, err := cq.UnauthorizedTrustlinesForAsset(int32(assetType), stat.AssetCode, stat.AssetIssuer)
+		if err != nil {
+			return errors.Wrap(err, "cq.UnauthorizedTrustlinesForAsset failed")
+		}
+
+		result.AssetsChecked++
+		result.Mismatches = append(result.Mismatches,
+			compareAssetStat(stat, numAccounts, sum, unauthorized)...,
+		)
+	}
+
+	return nil
+}
+
+// assetKey identifies an asset held by an account.
+type assetKey struct {
+	Type   string
+	Code   string
+	Issuer string
+}
+
+func (k assetKey) String() string {
+	if k.Type == "native" {
+		return "native"
+	}
+	return k.Code + ":" + k.Issuer
+}
+
+// derivedBalances converts the rows loaded by history.Q.DerivedBalances to
+// balances in stroops.
+func derivedBalances(rows []history.DerivedBalance) (map[assetKey]int64, error) {
+	balances := map[assetKey]int64{}
+	for _, row := range rows {
+		balance, err := amount.ParseInt64(row.Amount)
+		if err != nil {
+			return nil, err
+		}
+		balances[assetKey{row.AssetType, row.AssetCode, row.AssetIssuer}] = balance
+	}
+	return balances, nil
+}
+
+// compareAccount compares the stellar-core state of `account` and its
+// `trustlines` with the balances derived from history, and returns the
+// number of trustlines compared along with the mismatches.  Trustlines
+// modified after `ledger` are skipped.
+func compareAccount(
+	account core.Account,
+	trustlines []core.Trustline,
+	derived map[assetKey]int64,
+	fees int64,
+	ledger int32,
+	checkNative bool,
+) (int, []Mismatch) {
+	var mismatches []Mismatch
+	mismatch := func(check Check, subject string, expected, actual int64) {
+		mismatches = append(mismatches, Mismatch{
+			Check:    check,
+			Subject:  subject,
+			Expected: amount.StringFromInt64(expected),
+			Actual:   amount.StringFromInt64(actual),
+		})
+	}
+
+	if checkNative {
+		expected := derived[assetKey{Type: "native"}] - fees
+		if expected != int64(account.Balance) {
+			mismatch(CheckNativeBalance, account.Accountid, expected, int64(account.Balance))
+		}
+	}
+
+	checked := 0
+	seen := map[assetKey]bool{}
+	for _, tl := range trustlines {
+		key := assetKey{assets.MustString(tl.Assettype), tl.Assetcode, tl.Issuer}
+		seen[key] = true
+		if int32(tl.LastModified) > ledger {
+			continue
+		}
+
+		checked++
+		if derived[key] != int64(tl.Balance) {
+			mismatch(CheckTrustlineBalance, account.Accountid+" "+key.String(), derived[key], int64(tl.Balance))
+		}
+	}
+
+	// A trustline can only be removed once its balance is zero, so any other
+	// derived balance must have a trustline.  Issuers hold no trustlines to
+	// their own assets.
+	for key, balance := range derived {
+		if key.Type == "native" || seen[key] || key.Issuer == account.Accountid || balance == 0 {
+			continue
+		}
+		mismatch(CheckTrustlineBalance, account.Accountid+" "+key.String(), balance, 0)
+	}
+
+	return checked, mismatches
+}
+
+// compareAssetStat compares the statistics of an asset with the number of
+// authorized trustlines to the asset, their balances and the number of
+// unauthorized trustlines, as found in stellar-core.
+func compareAssetStat(stat history.SampledAssetStat, numAccounts int32, sum string, unauthorized int32) []Mismatch {
+	var mismatches []Mismatch
+	subject := assetKey{stat.AssetType, stat.AssetCode, stat.AssetIssuer}.String()
+	add := func(field, expected, actual string) {
+		if expected == actual {
+			return
+		}
+		mismatches = append(mismatches, Mismatch{
+			Check:    CheckAssetStats,
+			Subject:  subject + " " + field,
+			Expected: expected,
+			Actual:   actual,
+		})
+	}
+
+	add("amount", stat.Amount, sum)
+	add("num_accounts", fmt.Sprint(stat.NumAccounts), fmt.Sprint(numAccounts))
+	add("num_unauthorized_accounts", fmt.Sprint(stat.NumUnauthorizedAccounts), fmt.Sprint(unauthorized))
+	return mismatches
+}
+
+// beginSnapshot starts a read only transaction on `session` that sees a single
+// snapshot of the database.
+func beginSnapshot(session *db.Session) error {
+	err := session.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = session.ExecRaw("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY")
+	return err
+}
@@ -0,0 +1,160 @@
+package verify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	account = "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+	issuer  = "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"
+)
+
+func TestDerivedBalances(t *testing.T) {
+	balances, err := derivedBalances([]history.DerivedBalance{
+		{AssetType: "native", Amount: "99.9999900"},
+		{AssetType: "credit_alphanum4", AssetCode: "USD", AssetIssuer: issuer, Amount: "-1.0000000"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[assetKey]int64{
+		{Type: "native"}:                    999999900,
+		{"credit_alphanum4", "USD", issuer}: -10000000,
+	}, balances)
+
+	_, err = derivedBalances([]history.DerivedBalance{{AssetType: "native", Amount: "0.00000001"}})
+	assert.Error(t, err)
+}
+
+func TestCompareAccount(t *testing.T) {
+	usd := assetKey{"credit_alphanum4", "USD", issuer}
+	eur := assetKey{"credit_alphanum4", "EUR", issuer}
+	own := assetKey{"credit_alphanum4", "OWN", account}
+
+	acc := core.Account{Accountid: account, Balance: 999999900}
+	trustlines := []core.Trustline{
+		{Accountid: account, Assettype: xdr.AssetTypeAssetTypeCreditAlphanum4, Assetcode: "USD", Issuer: issuer, Balance: 50000000, LastModified: 5},
+	}
+	derived := map[assetKey]int64{
+		{Type: "native"}: 1000000000,
+		usd:              50000000,
+		own:              -70000000,
+	}
+
+	// matching state
+	checked, mismatches := compareAccount(acc, trustlines, derived, 100, 10, true)
+	assert.Equal(t, 1, checked)
+	assert.Empty(t, mismatches)
+
+	// native balances are only compared when enabled
+	_, mismatches = compareAccount(acc, trustlines, derived, 0, 10, false)
+	assert.Empty(t, mismatches)
+
+	_, mismatches = compareAccount(acc, trustlines, derived, 0, 10, true)
+	assert.Equal(t, []Mismatch{{
+		Check:    CheckNativeBalance,
+		Subject:  account,
+		Expected: "100.0000000",
+		Actual:   "99.9999900",
+	}}, mismatches)
+
+	// trustline balances
+	derived[usd] = 40000000
+	_, mismatches = compareAccount(acc, trustlines, derived, 100, 10, true)
+	assert.Equal(t, []Mismatch{{
+		Check:    CheckTrustlineBalance,
+		Subject:  account + " USD:" + issuer,
+		Expected: "4.0000000",
+		Actual:   "5.0000000",
+	}}, mismatches)
+
+	// trustlines modified after the verified ledger are skipped
+	checked, mismatches = compareAccount(acc, trustlines, derived, 100, 4, true)
+	assert.Equal(t, 0, checked)
+	assert.Empty(t, mismatches)
+
+	// derived balances without a trustline
+	derived[usd] = 50000000
+	derived[eur] = 10000000
+	_, mismatches = compareAccount(acc, trustlines, derived, 100, 10, true)
+	assert.Equal(t, []Mismatch{{
+		Check:    CheckTrustlineBalance,
+		Subject:  account + " EUR:" + issuer,
+		Expected: "1.0000000",
+		Actual:   "0.0000000",
+	}}, mismatches)
+
+	derived[eur] = 0
+	_, mismatches = compareAccount(acc, trustlines, derived, 100, 10, true)
+	assert.Empty(t, mismatches)
+}
+
+func TestCompareAssetStat(t *testing.T) {
+	stat := history.SampledAssetStat{
+		AssetType:               "credit_alphanum4",
+		AssetCode:               "USD",
+		AssetIssuer:             issuer,
+		Amount:                  "100",
+		NumAccounts:             2,
+		NumUnauthorizedAccounts: 1,
+	}
+
+	assert.Empty(t, compareAssetStat(stat, 2, "100", 1))
+	assert.Equal(t, []Mismatch{
+		{Check: CheckAssetStats, Subject: "USD:" + issuer + " amount", Expected: "100", Actual: "90"},
+		{Check: CheckAssetStats, Subject: "USD:" + issuer + " num_unauthorized_accounts", Expected: "1", Actual: "0"},
+	}, compareAssetStat(stat, 2, "90", 0))
+}
+
+func TestTickInterval(t *testing.T) {
+	sys := New(time.Hour, nil, nil)
+
+	// the first run happens after the interval, so that Tick does not touch
+	// the (nil) databases
+	sys.Tick()
+	assert.True(t, sys.nextRun.After(time.Now()))
+}
+
+func TestRun(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+
+	sys := New(time.Hour, tt.HorizonSession(), tt.CoreSession())
+	result, err := sys.Run()
+	tt.Require.NoError(err)
+
+	var latest int32
+	q := &history.Q{Session: tt.HorizonSession()}
+	tt.Require.NoError(q.LatestLedger(&latest))
+
+	tt.Assert.Equal(latest, result.Ledger)
+	tt.Assert.True(result.LedgersChecked > 0)
+	tt.Assert.True(result.AccountsChecked > 0)
+	tt.Assert.Empty(result.Mismatches)
+}
+
+func TestRunLedgerChainBreak(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+
+	_, err := tt.HorizonSession().ExecRaw(
+		`UPDATE history_ledgers SET previous_ledger_hash = $1 WHERE sequence = 3`,
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	)
+	tt.Require.NoError(err)
+
+	sys := New(time.Hour, tt.HorizonSession(), tt.CoreSession())
+	result, err := sys.Run()
+	tt.Require.NoError(err)
+
+	if tt.Assert.NotEmpty(result.Mismatches) {
+		tt.Assert.Equal(CheckLedgerChain, result.Mismatches[0].Check)
+		tt.Assert.Equal("ledger 3", result.Mismatches[0].Subject)
+	}
+}
@@ -41,6 +41,9 @@ type web struct {
 
 	historyQ *history.Q
 	coreQ    *core.Q
+	// historyReplicas, when set, routes the history queries of read-only
+	// requests to replicas of the horizon database.
+	historyReplicas *historyReplicas
 
 	requestTimer metrics.Timer
 	failureMeter metrics.Meter
@@ -115,7 +118,7 @@ func (w *web) mustInstallMiddlewares(app *App, connTimeout time.Duration) {
 
 // mustInstallActions installs the routing configuration of horizon onto the
 // provided app.  All route registration should be implemented here.
-func (w *web) mustInstallActions(enableAssetStats bool, friendbotURL *url.URL) {
+func (w *web) mustInstallActions(enableAssetStats bool, enableWebhooks bool, webhooksAdminToken string, friendbotURL *url.URL) {
 	if w == nil {
 		log.Fatal("missing web instance for installing web actions")
 	}
@@ -123,6 +126,8 @@ func (w *web) mustInstallActions(enableAssetStats bool, friendbotURL *url.URL) {
 	r := w.router
 	r.Get("/", RootAction{}.Handle)
 	r.Get("/metrics", MetricsAction{}.Handle)
+	r.Get("/health", HealthAction{}.Handle)
+	r.Get("/ready", ReadyAction{}.Handle)
 
 	// ledger actions
 	r.Route("/ledgers", func(r chi.Router) {
@@ -138,6 +143,7 @@ func (w *web) mustInstallActions(enableAssetStats bool, friendbotURL *url.URL) {
 
 	// account actions
 	r.Route("/accounts", func(r chi.Router) {
+		r.Get("/", withQueryParam("id", AccountBatchAction{}.Handle, NotFoundAction{}.Handle))
 		r.Route("/{account_id}", func(r chi.Router) {
 			r.Get("/", w.accountHandler(w.getAccountInfo))
 			r.Get("/transactions", w.transactionHandler(w.getTransactionPageByAccount, w.streamTransactionByAccount))
@@ -146,13 +152,15 @@ func (w *web) mustInstallActions(enableAssetStats bool, friendbotURL *url.URL) {
 			r.Get("/effects", EffectIndexAction{}.Handle)
 			r.Get("/offers", OffersByAccountAction{}.Handle)
 			r.Get("/trades", TradeIndexAction{}.Handle)
+			r.Get("/data", DataIndexAction{}.Handle)
 			r.Get("/data/{key}", DataShowAction{}.Handle)
+			r.Get("/data/{key}/history", EffectIndexAction{}.Handle)
 		})
 	})
 
 	// transaction history actions
 	r.Route("/transactions", func(r chi.Router) {
-		r.Get("/", TransactionIndexAction{}.Handle)
+		r.Get("/", withQueryParam("hash", TransactionBatchAction{}.Handle, TransactionIndexAction{}.Handle))
 		r.Route("/{tx_id}", func(r chi.Router) {
 			r.Get("/", TransactionShowAction{}.Handle)
 			r.Get("/operations", OperationIndexAction{}.Handle)
@@ -177,11 +185,16 @@ func (w *web) mustInstallActions(enableAssetStats bool, friendbotURL *url.URL) {
 	// trading related endpoints
 	r.Get("/trades", TradeIndexAction{}.Handle)
 	r.Get("/trade_aggregations", TradeAggregateIndexAction{}.Handle)
+	r.Get("/trade_aggregations/batch", TradeAggregateBatchAction{}.Handle)
 	r.Route("/offers", func(r chi.Router) {
 		r.Get("/{id}", NotImplementedAction{}.Handle)
 		r.Get("/{offer_id}/trades", TradeIndexAction{}.Handle)
 	})
 	r.Get("/order_book", OrderBookShowAction{}.Handle)
+	r.Get("/order_book/ticker", OrderBookTickerAction{}.Handle)
+
+	// websocket subscriptions to the streaming endpoints
+	r.Get("/ws", w.websocketHandler)
 
 	// Transaction submission API
 	r.Post("/transactions", TransactionCreateAction{}.Handle)
@@ -192,6 +205,22 @@ func (w *web) mustInstallActions(enableAssetStats bool, friendbotURL *url.URL) {
 		r.Get("/assets", AssetsAction{}.Handle)
 	}
 
+	if enableWebhooks {
+		// Webhook management endpoints, only available to admins since they
+		// make horizon send requests to the registered URLs
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Use(requireBearerToken(webhooksAdminToken))
+			r.Get("/", WebhookIndexAction{}.Handle)
+			r.Post("/", WebhookCreateAction{}.Handle)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", WebhookShowAction{}.Handle)
+				r.Delete("/", WebhookDestroyAction{}.Handle)
+				r.Post("/replay", WebhookReplayAction{}.Handle)
+				r.Get("/dead_letters", WebhookDeadLetterIndexAction{}.Handle)
+			})
+		})
+	}
+
 	// Network state related endpoints
 	r.Get("/fee_stats", OperationFeeStatsAction{}.Handle)
 	// Deprecated - remove in: horizon-v0.18.0
@@ -210,6 +239,18 @@ func (w *web) mustInstallActions(enableAssetStats bool, friendbotURL *url.URL) {
 	r.NotFound(NotFoundAction{}.Handle)
 }
 
+// withQueryParam routes requests carrying the `param` query parameter to
+// `withParam` and all the other requests to `withoutParam`.
+func withQueryParam(param string, withParam, withoutParam http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()[param]; ok {
+			withParam(w, r)
+			return
+		}
+		withoutParam(w, r)
+	}
+}
+
 func maybeInitWebRateLimiter(rateQuota *throttled.RateQuota) *throttled.HTTPRateLimiter {
 	// Disabled
 	if rateQuota == nil {
@@ -252,6 +293,10 @@ func (w *web) horizonSession(ctx context.Context) (*db.Session, error) {
 		return nil, err
 	}
 
+	if w.historyReplicas != nil {
+		return w.historyReplicas.Session(ctx), nil
+	}
+
 	return &db.Session{DB: w.historyQ.Session.DB, Ctx: ctx}, nil
 }
 
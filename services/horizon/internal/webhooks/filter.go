@@ -0,0 +1,108 @@
+package webhooks
+
+import (
+	"strings"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/xdr"
+)
+
+// item is an operation or effect loaded from the history database, along with
+// the data needed to match it against webhook filters.
+type item struct {
+	Type          string
+	PagingToken   string
+	OperationID   int64
+	Order         int32
+	OperationType xdr.OperationType
+	Accounts      []string
+	Details       map[string]interface{}
+	Resource      interface{}
+}
+
+// matches returns true if `it` passes every filter configured on `hook`.  A
+// webhook without any filters matches everything.
+func matches(hook history.Webhook, it item) bool {
+	return matchesOperationType(hook, it) &&
+		matchesAccount(hook, it) &&
+		matchesAsset(hook, it)
+}
+
+// matchesOperationType checks the type of the operation, or for effects the
+// type of the operation that produced the effect.
+func matchesOperationType(hook history.Webhook, it item) bool {
+	if len(hook.OperationTypes) == 0 {
+		return true
+	}
+
+	for _, typ := range hook.OperationTypes {
+		if typ == int64(it.OperationType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesAccount checks whether the webhook's account participated in the
+// operation, or is the account an effect applies to.
+func matchesAccount(hook history.Webhook, it item) bool {
+	if !hook.Account.Valid {
+		return true
+	}
+
+	for _, address := range it.Accounts {
+		if address == hook.Account.String {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesAsset checks whether any asset referenced by the item's details
+// (e.g. `asset_type`, `selling_asset_type`, `bought_asset_type`) is the
+// webhook's asset.
+func matchesAsset(hook history.Webhook, it item) bool {
+	if !hook.AssetType.Valid {
+		return true
+	}
+
+	for key := range it.Details {
+		if !strings.HasSuffix(key, "asset_type") {
+			continue
+		}
+
+		prefix := strings.TrimSuffix(key, "asset_type")
+		if detailString(it.Details, prefix+"asset_type") != hook.AssetType.String {
+			continue
+		}
+
+		if hook.AssetType.String == "native" {
+			return true
+		}
+
+		if detailString(it.Details, prefix+"asset_code") == hook.AssetCode.String &&
+			detailString(it.Details, prefix+"asset_issuer") == hook.AssetIssuer.String {
+			return true
+		}
+	}
+
+	return false
+}
+
+func detailString(details map[string]interface{}, key string) string {
+	s, _ := details[key].(string)
+	return s
+}
+
+// after returns true if `it` comes after the position identified by
+// `operationID` and `order` in the stream of events.  Operations are at
+// position (id, 0) and are followed by their effects, which start at order 1.
+func (it item) after(operationID int64, order int32) bool {
+	if it.OperationID != operationID {
+		return it.OperationID > operationID
+	}
+
+	return it.Order > order
+}
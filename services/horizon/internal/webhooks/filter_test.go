@@ -0,0 +1,114 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/lib/pq"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAccount = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	testIssuer  = "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+)
+
+func TestMatches(t *testing.T) {
+	payment := item{
+		Type:          EventTypeOperation,
+		OperationType: xdr.OperationTypePayment,
+		Accounts:      []string{testAccount},
+		Details: map[string]interface{}{
+			"asset_type":   "credit_alphanum4",
+			"asset_code":   "USD",
+			"asset_issuer": testIssuer,
+		},
+	}
+
+	offer := item{
+		Type:          EventTypeOperation,
+		OperationType: xdr.OperationTypeManageOffer,
+		Accounts:      []string{testIssuer},
+		Details: map[string]interface{}{
+			"buying_asset_type":   "credit_alphanum4",
+			"buying_asset_code":   "USD",
+			"buying_asset_issuer": testIssuer,
+			"selling_asset_type":  "native",
+		},
+	}
+
+	cases := []struct {
+		Name     string
+		Hook     history.Webhook
+		Item     item
+		Expected bool
+	}{
+		{"no filters", history.Webhook{}, payment, true},
+		{"account match", history.Webhook{Account: null.StringFrom(testAccount)}, payment, true},
+		{"account mismatch", history.Webhook{Account: null.StringFrom(testAccount)}, offer, false},
+		{"operation type match", history.Webhook{OperationTypes: pq.Int64Array{1, 3}}, offer, true},
+		{"operation type mismatch", history.Webhook{OperationTypes: pq.Int64Array{3}}, payment, false},
+		{
+			"credit asset match",
+			history.Webhook{
+				AssetType:   null.StringFrom("credit_alphanum4"),
+				AssetCode:   null.StringFrom("USD"),
+				AssetIssuer: null.StringFrom(testIssuer),
+			},
+			payment,
+			true,
+		},
+		{
+			"prefixed credit asset match",
+			history.Webhook{
+				AssetType:   null.StringFrom("credit_alphanum4"),
+				AssetCode:   null.StringFrom("USD"),
+				AssetIssuer: null.StringFrom(testIssuer),
+			},
+			offer,
+			true,
+		},
+		{
+			"credit asset mismatch",
+			history.Webhook{
+				AssetType:   null.StringFrom("credit_alphanum4"),
+				AssetCode:   null.StringFrom("EUR"),
+				AssetIssuer: null.StringFrom(testIssuer),
+			},
+			payment,
+			false,
+		},
+		{"native asset match", history.Webhook{AssetType: null.StringFrom("native")}, offer, true},
+		{"native asset mismatch", history.Webhook{AssetType: null.StringFrom("native")}, payment, false},
+		{
+			"all filters",
+			history.Webhook{
+				Account:        null.StringFrom(testIssuer),
+				AssetType:      null.StringFrom("native"),
+				OperationTypes: pq.Int64Array{3},
+			},
+			offer,
+			true,
+		},
+	}
+
+	for _, kase := range cases {
+		t.Run(kase.Name, func(t *testing.T) {
+			assert.Equal(t, kase.Expected, matches(kase.Hook, kase.Item))
+		})
+	}
+}
+
+func TestItemAfter(t *testing.T) {
+	op := item{OperationID: 100}
+	eff := item{OperationID: 100, Order: 2}
+
+	assert.True(t, op.after(99, 5))
+	assert.False(t, op.after(100, 0))
+	assert.True(t, eff.after(100, 0))
+	assert.True(t, eff.after(100, 1))
+	assert.False(t, eff.after(100, 2))
+	assert.False(t, eff.after(101, 0))
+}
@@ -0,0 +1,133 @@
+// Package webhooks contains the webhook delivery subsystem for horizon.  Clients
+// register a URL along with optional account, asset and operation type
+// filters.  After each ledger is ingested, the operations and effects that
+// match a webhook's filters are enqueued in the horizon database and POSTed to
+// the registered URL as signed JSON payloads.  Failed deliveries are retried
+// with exponential backoff and are moved to a dead-letter table once the
+// maximum number of attempts has been reached.
+package webhooks
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/stellar/go/support/db"
+	ilog "github.com/stellar/go/support/log"
+)
+
+var log = ilog.DefaultLogger.WithField("service", "webhooks")
+
+const (
+	// EventTypeOperation is the type of events that carry an operation resource.
+	EventTypeOperation = "operation"
+	// EventTypeEffect is the type of events that carry an effect resource.
+	EventTypeEffect = "effect"
+
+	// SignatureHeader is the request header carrying the hex-encoded
+	// HMAC-SHA256 signature of the request body, computed using the webhook's
+	// secret.
+	SignatureHeader = "X-Horizon-Signature"
+	// WebhookIDHeader is the request header carrying the id of the webhook a
+	// delivery is made for.
+	WebhookIDHeader = "X-Horizon-Webhook-ID"
+	// DeliveryIDHeader is the request header carrying the id of the delivery.
+	// Retries of the same delivery share the same id.
+	DeliveryIDHeader = "X-Horizon-Delivery-ID"
+)
+
+// Event is the JSON payload delivered to a webhook for every matching
+// operation or effect.
+type Event struct {
+	WebhookID   int64       `json:"webhook_id"`
+	Type        string      `json:"type"`
+	PagingToken string      `json:"paging_token"`
+	Ledger      int32       `json:"ledger"`
+	Data        interface{} `json:"data"`
+}
+
+// Metrics tracks all the metrics for the webhooks subsystem
+type Metrics struct {
+	EnqueuedMeter   metrics.Meter
+	DeliveredMeter  metrics.Meter
+	FailedMeter     metrics.Meter
+	DeadLetterMeter metrics.Meter
+}
+
+// System represents the webhook delivery subsystem of horizon.
+type System struct {
+	// HorizonDB is the connection to the horizon database that webhooks,
+	// deliveries and history are read from.
+	HorizonDB *db.Session
+	// Client is the http client used to deliver payloads.
+	Client *http.Client
+	// MaxAttempts is the number of delivery attempts after which a delivery is
+	// moved to the dead-letter table.
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry of a failed delivery.
+	// Every subsequent retry doubles the delay, up to MaxBackoff.
+	BaseBackoff time.Duration
+	// MaxBackoff is the upper bound of the delay between two attempts.
+	MaxBackoff time.Duration
+	// BatchSize is the maximum number of deliveries attempted per tick.
+	BatchSize uint64
+	// MaxLedgersPerTick is the maximum number of ledgers enqueued per tick, so
+	// that a webhook far behind the latest ledger catches up gradually.
+	MaxLedgersPerTick int32
+	// MaxReplayLedgers is the maximum number of ledgers a single replay
+	// request will re-enqueue.
+	MaxReplayLedgers int32
+	// ReplayInterval is the minimum delay between the start of two replays of
+	// the same webhook.
+	ReplayInterval time.Duration
+	// AllowPrivateTargets allows webhooks to be registered for and delivered
+	// to loopback, link-local and private addresses.  It is only meant for
+	// tests.
+	AllowPrivateTargets bool
+	Metrics             Metrics
+
+	lock    sync.Mutex
+	running bool
+	// replays holds the start time of the last replay of each webhook and
+	// replaying the webhooks being replayed.
+	replays   map[int64]time.Time
+	replaying map[int64]bool
+}
+
+// New initializes the webhooks system using the provided horizon database
+// session and reasonable defaults.
+func New(horizon *db.Session) *System {
+	sys := &System{
+		HorizonDB:         horizon,
+		MaxAttempts:       10,
+		BaseBackoff:       10 * time.Second,
+		MaxBackoff:        1 * time.Hour,
+		BatchSize:         100,
+		MaxLedgersPerTick: 10,
+		MaxReplayLedgers:  100,
+		ReplayInterval:    1 * time.Minute,
+		replays:           map[int64]time.Time{},
+		replaying:         map[int64]bool{},
+	}
+
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: sys.checkDialTarget,
+	}
+	sys.Client = &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConnsPerHost: 2,
+		},
+	}
+
+	sys.Metrics.EnqueuedMeter = metrics.NewMeter()
+	sys.Metrics.DeliveredMeter = metrics.NewMeter()
+	sys.Metrics.FailedMeter = metrics.NewMeter()
+	sys.Metrics.DeadLetterMeter = metrics.NewMeter()
+	return sys
+}
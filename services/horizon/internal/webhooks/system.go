@@ -0,0 +1,562 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	herr "github.com/stellar/go/services/horizon/internal/errors"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/services/horizon/internal/resourceadapter"
+	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/support/errors"
+	ilog "github.com/stellar/go/support/log"
+)
+
+// Tick triggers the webhooks system to enqueue events for newly ingested
+// ledgers and to attempt the deliveries that are due.  If a previous tick is
+// still in progress, Tick returns immediately.
+func (sys *System) Tick() {
+	sys.lock.Lock()
+	if sys.running {
+		sys.lock.Unlock()
+		return
+	}
+	sys.running = true
+	sys.lock.Unlock()
+
+	defer func() {
+		sys.lock.Lock()
+		sys.running = false
+		sys.lock.Unlock()
+	}()
+
+	sys.runOnce()
+}
+
+var (
+	// ErrReplayInProgress is returned by StartReplay while a previous replay
+	// of the webhook is running.
+	ErrReplayInProgress = errors.New("a replay of the webhook is in progress")
+	// ErrReplayTooSoon is returned by StartReplay when the previous replay of
+	// the webhook started less than ReplayInterval ago.
+	ErrReplayTooSoon = errors.New("the webhook was replayed too recently")
+)
+
+// StartReplay re-enqueues, in the background, the events of `hook` that come
+// after `cursor`, which is the paging token of a previously delivered event.
+// At most MaxReplayLedgers ledgers are replayed, starting at the ledger of the
+// cursor; clients that need more should replay again using the paging token
+// of the last event they received.  A webhook is replayed at most once per
+// ReplayInterval.  StartReplay returns the range of ledgers being replayed.
+func (sys *System) StartReplay(hook history.Webhook, cursor string) (from, to int32, err error) {
+	opID, order, err := ParseCursor(cursor)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	from = toid.Parse(opID).LedgerSequence
+	if elder := ledger.CurrentState().HistoryElder; from < elder {
+		from = elder
+	}
+
+	to = hook.LastLedger
+	if to-from+1 > sys.MaxReplayLedgers {
+		to = from + sys.MaxReplayLedgers - 1
+	}
+
+	sys.lock.Lock()
+	if sys.replaying[hook.ID] {
+		sys.lock.Unlock()
+		return 0, 0, ErrReplayInProgress
+	}
+	if last, ok := sys.replays[hook.ID]; ok && time.Since(last) < sys.ReplayInterval {
+		sys.lock.Unlock()
+		return 0, 0, ErrReplayTooSoon
+	}
+	sys.replaying[hook.ID] = true
+	sys.replays[hook.ID] = time.Now()
+	sys.lock.Unlock()
+
+	go func() {
+		defer func() {
+			sys.lock.Lock()
+			delete(sys.replaying, hook.ID)
+			sys.lock.Unlock()
+		}()
+
+		sys.replay(hook, from, to, opID, order)
+	}()
+
+	return from, to, nil
+}
+
+// replay enqueues the events of `hook` in ledgers `from` to `to` that come
+// after the operation `opID` and effect `order`, within a single transaction.
+func (sys *System) replay(hook history.Webhook, from, to int32, opID int64, order int32) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err := herr.FromPanic(rec)
+			log.Errorf("webhooks replay panicked: %s", err)
+			herr.ReportToSentry(err, nil)
+		}
+	}()
+
+	enqueued, err := sys.enqueueReplay(hook, from, to, opID, order)
+	if err != nil {
+		log.WithField("webhook_id", hook.ID).Errorf("webhooks: replay failed: %s", err)
+		return
+	}
+
+	sys.Metrics.EnqueuedMeter.Mark(int64(enqueued))
+	log.WithFields(ilog.F{
+		"webhook_id":  hook.ID,
+		"from_ledger": from,
+		"to_ledger":   to,
+		"enqueued":    enqueued,
+	}).Info("webhooks: replay finished")
+}
+
+func (sys *System) enqueueReplay(hook history.Webhook, from, to int32, opID int64, order int32) (int, error) {
+	q := &history.Q{Session: sys.HorizonDB.Clone()}
+	err := q.Begin()
+	if err != nil {
+		return 0, errors.Wrap(err, "begin failed")
+	}
+	defer q.Rollback()
+
+	enqueued := 0
+	for seq := from; seq <= to; seq++ {
+		items, err := loadItems(q, seq)
+		if err != nil {
+			return 0, errors.Wrapf(err, "loading ledger %d failed", seq)
+		}
+
+		for _, it := range items {
+			if !it.after(opID, order) || !matches(hook, it) {
+				continue
+			}
+
+			err = sys.enqueueItem(q, hook, seq, it)
+			if err != nil {
+				return 0, err
+			}
+			enqueued++
+		}
+	}
+
+	err = q.Commit()
+	if err != nil {
+		return 0, errors.Wrap(err, "commit failed")
+	}
+
+	return enqueued, nil
+}
+
+// ParseCursor parses the paging token of an operation ("<id>") or an effect
+// ("<operation id>-<order>") into a position in the stream of events.
+func ParseCursor(cursor string) (operationID int64, order int32, err error) {
+	parts := strings.SplitN(cursor, "-", 2)
+
+	operationID, err = strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || operationID < 0 {
+		return 0, 0, errors.New("invalid cursor")
+	}
+
+	if len(parts) == 1 {
+		return operationID, 0, nil
+	}
+
+	o, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil || o < 0 {
+		return 0, 0, errors.New("invalid cursor")
+	}
+
+	return operationID, int32(o), nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of `body`, keyed by `secret`.  The
+// value is sent in the SignatureHeader of every delivery so that receivers
+// can verify a payload was produced by this horizon instance.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the delay before the next attempt of a delivery that has
+// failed `attempts` times.
+func (sys *System) Backoff(attempts int32) time.Duration {
+	delay := sys.BaseBackoff
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= sys.MaxBackoff || delay <= 0 {
+			return sys.MaxBackoff
+		}
+	}
+
+	if delay > sys.MaxBackoff {
+		return sys.MaxBackoff
+	}
+
+	return delay
+}
+
+func (sys *System) runOnce() {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err := herr.FromPanic(rec)
+			log.Errorf("webhooks panicked: %s", err)
+			herr.ReportToSentry(err, nil)
+		}
+	}()
+
+	err := sys.enqueue()
+	if err != nil {
+		log.Errorf("webhooks: enqueue failed: %s", err)
+	}
+
+	err = sys.deliver()
+	if err != nil {
+		log.Errorf("webhooks: delivery failed: %s", err)
+	}
+}
+
+// enqueue loads the ledgers ingested since the webhooks were last processed
+// and enqueues a delivery for every matching operation and effect.
+func (sys *System) enqueue() error {
+	q := &history.Q{Session: sys.HorizonDB}
+
+	var hooks []history.Webhook
+	err := q.Webhooks(&hooks)
+	if err != nil {
+		return errors.Wrap(err, "loading webhooks failed")
+	}
+
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	state := ledger.CurrentState()
+	from := hooks[0].LastLedger
+	for _, hook := range hooks {
+		if hook.LastLedger < from {
+			from = hook.LastLedger
+		}
+	}
+	from++
+
+	if from < state.HistoryElder {
+		from = state.HistoryElder
+	}
+
+	to := state.HistoryLatest
+	if to-from+1 > sys.MaxLedgersPerTick {
+		to = from + sys.MaxLedgersPerTick - 1
+	}
+
+	for seq := from; seq <= to; seq++ {
+		err = sys.enqueueLedger(hooks, seq)
+		if err != nil {
+			return errors.Wrapf(err, "enqueueing ledger %d failed", seq)
+		}
+
+		for i := range hooks {
+			if hooks[i].LastLedger < seq {
+				hooks[i].LastLedger = seq
+			}
+		}
+	}
+
+	return nil
+}
+
+// enqueueLedger enqueues the events of ledger `seq` for every webhook that
+// has not seen it yet, and advances those webhooks' last ledger, within a
+// single transaction.
+func (sys *System) enqueueLedger(hooks []history.Webhook, seq int32) error {
+	var pending []history.Webhook
+	for _, hook := range hooks {
+		if hook.LastLedger < seq {
+			pending = append(pending, hook)
+		}
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	q := &history.Q{Session: sys.HorizonDB.Clone()}
+	err := q.Begin()
+	if err != nil {
+		return errors.Wrap(err, "begin failed")
+	}
+	defer q.Rollback()
+
+	items, err := loadItems(q, seq)
+	if err != nil {
+		return err
+	}
+
+	enqueued := 0
+	ids := make([]int64, 0, len(pending))
+	for _, hook := range pending {
+		ids = append(ids, hook.ID)
+
+		for _, it := range items {
+			if !matches(hook, it) {
+				continue
+			}
+
+			err = sys.enqueueItem(q, hook, seq, it)
+			if err != nil {
+				return err
+			}
+			enqueued++
+		}
+	}
+
+	err = q.UpdateWebhooksLastLedger(ids, seq)
+	if err != nil {
+		return errors.Wrap(err, "updating last ledger failed")
+	}
+
+	err = q.Commit()
+	if err != nil {
+		return errors.Wrap(err, "commit failed")
+	}
+
+	sys.Metrics.EnqueuedMeter.Mark(int64(enqueued))
+	return nil
+}
+
+func (sys *System) enqueueItem(q *history.Q, hook history.Webhook, seq int32, it item) error {
+	payload, err := json.Marshal(Event{
+		WebhookID:   hook.ID,
+		Type:        it.Type,
+		PagingToken: it.PagingToken,
+		Ledger:      seq,
+		Data:        it.Resource,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshaling event failed")
+	}
+
+	err = q.InsertWebhookDelivery(hook.ID, it.PagingToken, string(payload))
+	if err != nil {
+		return errors.Wrap(err, "inserting delivery failed")
+	}
+
+	return nil
+}
+
+// loadItems loads the successful operations of ledger `seq` and their
+// effects, in stream order.
+func loadItems(q *history.Q, seq int32) ([]item, error) {
+	var (
+		lg           history.Ledger
+		ops          []history.Operation
+		effs         []history.Effect
+		participants []history.OperationParticipant
+	)
+
+	err := q.LedgerBySequence(&lg, seq)
+	if q.NoRows(err) {
+		// the ledger has been reaped or is not ingested yet.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading ledger failed")
+	}
+
+	err = q.Operations().ForLedger(seq).Select(&ops)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading operations failed")
+	}
+
+	err = q.Effects().ForLedger(seq).Select(&effs)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading effects failed")
+	}
+
+	err = q.OperationParticipantsByLedger(&participants, seq)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading participants failed")
+	}
+
+	accounts := map[int64][]string{}
+	for _, p := range participants {
+		accounts[p.OperationID] = append(accounts[p.OperationID], p.Address)
+	}
+
+	opIndex := map[int64]int{}
+	items := make([]item, 0, len(ops)+len(effs))
+	for i, op := range ops {
+		resource, err := resourceadapter.NewOperation(context.Background(), op, lg)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating operation resource failed")
+		}
+
+		var details map[string]interface{}
+		err = op.UnmarshalDetails(&details)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshaling operation details failed")
+		}
+
+		opIndex[op.ID] = i
+		items = append(items, item{
+			Type:          EventTypeOperation,
+			PagingToken:   op.PagingToken(),
+			OperationID:   op.ID,
+			OperationType: op.Type,
+			Accounts:      append(accounts[op.ID], op.SourceAccount),
+			Details:       details,
+			Resource:      resource,
+		})
+	}
+
+	for _, eff := range effs {
+		i, ok := opIndex[eff.HistoryOperationID]
+		if !ok {
+			continue
+		}
+
+		resource, err := resourceadapter.NewEffect(context.Background(), eff, lg)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating effect resource failed")
+		}
+
+		var details map[string]interface{}
+		err = eff.UnmarshalDetails(&details)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item{
+			Type:          EventTypeEffect,
+			PagingToken:   eff.PagingToken(),
+			OperationID:   eff.HistoryOperationID,
+			Order:         eff.Order,
+			OperationType: ops[i].Type,
+			Accounts:      []string{eff.Account},
+			Details:       details,
+			Resource:      resource,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[j].after(items[i].OperationID, items[i].Order)
+	})
+
+	return items, nil
+}
+
+// deliver attempts every delivery that is due, up to BatchSize.
+func (sys *System) deliver() error {
+	q := &history.Q{Session: sys.HorizonDB}
+
+	var due []history.WebhookDelivery
+	err := q.DueWebhookDeliveries(&due, time.Now(), sys.BatchSize)
+	if err != nil {
+		return errors.Wrap(err, "loading deliveries failed")
+	}
+
+	if len(due) == 0 {
+		return nil
+	}
+
+	hooks := map[int64]history.Webhook{}
+	for _, d := range due {
+		hook, ok := hooks[d.WebhookID]
+		if !ok {
+			err = q.WebhookByID(&hook, d.WebhookID)
+			if q.NoRows(err) {
+				// the webhook was removed while loading its deliveries, which
+				// are removed along with it.
+				continue
+			}
+			if err != nil {
+				return errors.Wrap(err, "loading webhook failed")
+			}
+			hooks[d.WebhookID] = hook
+		}
+
+		err = sys.attempt(q, hook, d)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// attempt POSTs a single delivery to its webhook, then removes, reschedules
+// or dead-letters it depending on the outcome.
+func (sys *System) attempt(q *history.Q, hook history.Webhook, d history.WebhookDelivery) error {
+	postErr := sys.post(hook, d)
+	if postErr == nil {
+		sys.Metrics.DeliveredMeter.Mark(1)
+		return errors.Wrap(q.DeleteWebhookDelivery(d.ID), "deleting delivery failed")
+	}
+
+	sys.Metrics.FailedMeter.Mark(1)
+	attempts := d.Attempts + 1
+
+	log.WithFields(ilog.F{
+		"webhook_id":  hook.ID,
+		"delivery_id": d.ID,
+		"attempts":    attempts,
+	}).Warnf("webhooks: delivery failed: %s", postErr)
+
+	if int(attempts) >= sys.MaxAttempts {
+		sys.Metrics.DeadLetterMeter.Mark(1)
+		return errors.Wrap(
+			q.MoveWebhookDeliveryToDeadLetters(d.ID, postErr.Error()),
+			"moving delivery to dead letters failed",
+		)
+	}
+
+	next := time.Now().Add(sys.Backoff(attempts))
+	return errors.Wrap(
+		q.RescheduleWebhookDelivery(d.ID, next, postErr.Error()),
+		"rescheduling delivery failed",
+	)
+}
+
+func (sys *System) post(hook history.Webhook, d history.WebhookDelivery) error {
+	body := []byte(d.Payload)
+	req, err := http.NewRequest("POST", hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+	req.Header.Set(WebhookIDHeader, strconv.FormatInt(hook.ID, 10))
+	req.Header.Set(DeliveryIDHeader, strconv.FormatInt(d.ID, 10))
+
+	resp, err := sys.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
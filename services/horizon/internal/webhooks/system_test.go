@@ -0,0 +1,140 @@
+package webhooks
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCursor(t *testing.T) {
+	op, order, err := ParseCursor("12884905985")
+	require.NoError(t, err)
+	assert.Equal(t, int64(12884905985), op)
+	assert.Equal(t, int32(0), order)
+
+	op, order, err = ParseCursor("12884905985-2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(12884905985), op)
+	assert.Equal(t, int32(2), order)
+
+	for _, invalid := range []string{"", "now", "-1", "1-", "1-a", "1--2"} {
+		_, _, err = ParseCursor(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	sys := &System{BaseBackoff: 10 * time.Second, MaxBackoff: time.Minute}
+
+	assert.Equal(t, 10*time.Second, sys.Backoff(1))
+	assert.Equal(t, 20*time.Second, sys.Backoff(2))
+	assert.Equal(t, 40*time.Second, sys.Backoff(3))
+	assert.Equal(t, time.Minute, sys.Backoff(4))
+	assert.Equal(t, time.Minute, sys.Backoff(100))
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '{"a":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"aa9e2e3575f5d7098b6caccd790888c36d5fdb63342a73bada2d6a51747a8494",
+		Sign("secret", []byte(`{"a":1}`)),
+	)
+	assert.NotEqual(t, Sign("secret", []byte("body")), Sign("other", []byte("body")))
+}
+
+func TestPost(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = ioutil.ReadAll(r.Body)
+		if r.Header.Get(DeliveryIDHeader) == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	sys := New(nil)
+	sys.AllowPrivateTargets = true
+	hook := history.Webhook{ID: 7, URL: server.URL, Secret: "secret"}
+
+	err := sys.post(hook, history.WebhookDelivery{ID: 1, Payload: `{"a":1}`})
+	require.NoError(t, err)
+	assert.Equal(t, "POST", received.Method)
+	assert.Equal(t, `{"a":1}`, string(body))
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, Sign("secret", body), received.Header.Get(SignatureHeader))
+	assert.Equal(t, "7", received.Header.Get(WebhookIDHeader))
+	assert.Equal(t, "1", received.Header.Get(DeliveryIDHeader))
+
+	err = sys.post(hook, history.WebhookDelivery{ID: 2, Payload: `{}`})
+	assert.EqualError(t, err, "unexpected response status: 500")
+}
+
+func TestPostRefusesPrivateTargets(t *testing.T) {
+	delivered := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered = true
+	}))
+	defer server.Close()
+
+	sys := New(nil)
+	hook := history.Webhook{ID: 7, URL: server.URL, Secret: "secret"}
+
+	err := sys.post(hook, history.WebhookDelivery{ID: 1, Payload: `{}`})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "delivery to private address 127.0.0.1 refused")
+	assert.False(t, delivered)
+}
+
+func TestValidateURL(t *testing.T) {
+	sys := New(nil)
+
+	assert.NoError(t, sys.ValidateURL("https://93.184.216.34/hooks"))
+	assert.NoError(t, sys.ValidateURL("http://[2606:2800:220:1:248:1893:25c8:1946]:8080/hooks"))
+
+	for _, invalid := range []string{
+		"",
+		"/hooks",
+		"ftp://93.184.216.34/hooks",
+		"http://127.0.0.1/hooks",
+		"http://localhost:8000/hooks",
+		"http://10.0.0.1/hooks",
+		"http://172.16.5.4/hooks",
+		"http://192.168.1.1/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"http://0.0.0.0/hooks",
+		"http://[::1]/hooks",
+		"http://[fe80::1]/hooks",
+		"http://[fd00::1]/hooks",
+		"http://[::ffff:127.0.0.1]/hooks",
+	} {
+		assert.Error(t, sys.ValidateURL(invalid), invalid)
+	}
+
+	sys.AllowPrivateTargets = true
+	assert.NoError(t, sys.ValidateURL("http://127.0.0.1/hooks"))
+}
+
+func TestStartReplayRateLimit(t *testing.T) {
+	sys := New(nil)
+	hook := history.Webhook{ID: 7}
+
+	sys.replaying[hook.ID] = true
+	_, _, err := sys.StartReplay(hook, "12884905985")
+	assert.Equal(t, ErrReplayInProgress, err)
+
+	delete(sys.replaying, hook.ID)
+	sys.replays[hook.ID] = time.Now()
+	_, _, err = sys.StartReplay(hook, "12884905985")
+	assert.Equal(t, ErrReplayTooSoon, err)
+
+	_, _, err = sys.StartReplay(hook, "now")
+	assert.EqualError(t, err, "invalid cursor")
+}
@@ -0,0 +1,117 @@
+package webhooks
+
+import (
+	"net"
+	"net/url"
+	"syscall"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// MaxURLLength is the maximum length of a webhook URL.
+const MaxURLLength = 2048
+
+// privateNetworks are the networks webhooks are not delivered to: loopback,
+// link-local (including cloud metadata endpoints), private, shared and
+// unspecified addresses.
+var privateNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// isPublicIP returns true when `ip` is a unicast address outside of the
+// private networks.
+func isPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsMulticast() {
+		return false
+	}
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateURL checks that `raw` is an absolute http(s) URL whose host only
+// resolves to public addresses, unless AllowPrivateTargets is set.
+func (sys *System) ValidateURL(raw string) error {
+	if raw == "" {
+		return errors.New("url is required")
+	}
+
+	if len(raw) > MaxURLLength {
+		return errors.New("url is too long")
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("url must be an absolute http or https url")
+	}
+
+	if sys.AllowPrivateTargets {
+		return nil
+	}
+
+	ips, err := net.LookupIP(u.Hostname())
+	if err != nil {
+		return errors.New("url host cannot be resolved")
+	}
+
+	for _, ip := range ips {
+		if !isPublicIP(ip) {
+			return errors.New("url must not resolve to a loopback, link-local or private address")
+		}
+	}
+
+	return nil
+}
+
+// checkDialTarget is the net.Dialer control function of the delivery client.
+// It runs after the host of a webhook URL (or of a redirect) is resolved so
+// that deliveries cannot reach private addresses, even when the DNS records
+// of a webhook change after its registration.
+func (sys *System) checkDialTarget(network, address string, _ syscall.RawConn) error {
+	if sys.AllowPrivateTargets {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !isPublicIP(net.ParseIP(host)) {
+		return errors.Errorf("delivery to private address %s refused", host)
+	}
+
+	return nil
+}
@@ -0,0 +1,391 @@
+package horizon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/actions"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/services/horizon/internal/render/sse"
+	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/render/problem"
+	"golang.org/x/net/websocket"
+)
+
+// maxWebsocketSubscriptions is the maximum number of subscriptions a single
+// websocket connection can hold at once.
+const maxWebsocketSubscriptions = 20
+
+// websocketPageLimit is the page size used when loading the events of a
+// subscription.  Subscriptions catching up with history load pages until one
+// is not full.
+const websocketPageLimit = 200
+
+// websocketAction is an action which events can be subscribed to over a
+// websocket connection.  Actions must also implement either
+// actions.EventStreamer or actions.SingleObjectStreamer.
+type websocketAction interface {
+	Prepare(w http.ResponseWriter, r *http.Request)
+}
+
+// websocketTopics are the topics clients can subscribe to, along with the
+// streaming actions producing their events.
+var websocketTopics = map[string]func() websocketAction{
+	"transactions": func() websocketAction { return &TransactionIndexAction{} },
+	"payments":     func() websocketAction { return &PaymentsIndexAction{} },
+	"effects":      func() websocketAction { return &EffectIndexAction{} },
+	"trades":       func() websocketAction { return &TradeIndexAction{} },
+	"order_book":   func() websocketAction { return &OrderBookShowAction{} },
+}
+
+// Types of the messages exchanged over websocket connections.
+const (
+	websocketSubscribe    = "subscribe"
+	websocketUnsubscribe  = "unsubscribe"
+	websocketSubscribed   = "subscribed"
+	websocketUnsubscribed = "unsubscribed"
+	websocketEvent        = "event"
+	websocketError        = "error"
+	websocketClose        = "close"
+)
+
+// websocketRequest is a message sent by clients to manage their
+// subscriptions.  Params are the query parameters of the streaming endpoint of
+// the topic.
+type websocketRequest struct {
+	Type   string            `json:"type"`
+	ID     string            `json:"id"`
+	Topic  string            `json:"topic,omitempty"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// websocketMessage is a message sent to clients.  Events carry the id of the
+// subscription they belong to and, except for order books, the cursor to
+// resume the subscription from.
+type websocketMessage struct {
+	Type   string      `json:"type"`
+	ID     string      `json:"id,omitempty"`
+	Event  string      `json:"event,omitempty"`
+	Cursor string      `json:"cursor,omitempty"`
+	Data   interface{} `json:"data,omitempty"`
+	Error  *problem.P  `json:"error,omitempty"`
+}
+
+// websocketSubscription is the state of a single subscription of a
+// connection.
+type websocketSubscription struct {
+	id     string
+	topic  string
+	params url.Values
+
+	// action is kept across ledgers by single object streams, which only send
+	// events that differ from the previous one.
+	action   websocketAction
+	lastHash [32]byte
+}
+
+// websocketConn serves a single websocket connection.
+type websocketConn struct {
+	web  *web
+	conn *websocket.Conn
+	r    *http.Request
+	subs []*websocketSubscription
+	err  error
+}
+
+// websocketHandler lets clients subscribe to the events of multiple streaming
+// endpoints over a single websocket connection.  Every subscription has its
+// own cursor and is updated whenever a new ledger is ingested.
+func (we *web) websocketHandler(w http.ResponseWriter, r *http.Request) {
+	server := websocket.Server{
+		// Like event streams, websockets are available from any origin.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			c := &websocketConn{web: we, conn: conn, r: r}
+			c.serve()
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+func (c *websocketConn) serve() {
+	ctx := c.r.Context()
+
+	done := make(chan struct{})
+	defer close(done)
+	requests := make(chan websocketRequest)
+	go c.receive(requests, done)
+
+	frequency := c.web.sseUpdateFrequency
+	if frequency <= 0 {
+		frequency = time.Second
+	}
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	lastLedger := ledger.CurrentState().HistoryLatest
+	for c.err == nil {
+		select {
+		case req, ok := <-requests:
+			if !ok {
+				return
+			}
+			c.handle(req)
+
+		case <-ticker.C:
+			latest := ledger.CurrentState().HistoryLatest
+			if latest <= lastLedger {
+				continue
+			}
+			lastLedger = latest
+
+			if !c.rateLimit() {
+				return
+			}
+			// failing subscriptions are removed while updating
+			subs := append([]*websocketSubscription(nil), c.subs...)
+			for _, sub := range subs {
+				c.update(sub)
+			}
+
+		case <-ctx.Done():
+			c.send(websocketMessage{Type: websocketClose})
+			return
+		case <-c.web.appCtx.Done():
+			c.send(websocketMessage{Type: websocketClose})
+			return
+		}
+	}
+}
+
+// receive reads the requests of the client until the connection is closed.
+func (c *websocketConn) receive(requests chan<- websocketRequest, done <-chan struct{}) {
+	defer close(requests)
+	for {
+		var req websocketRequest
+		err := websocket.JSON.Receive(c.conn, &req)
+		if err != nil {
+			return
+		}
+
+		select {
+		case requests <- req:
+		case <-done:
+			return
+		}
+	}
+}
+
+func (c *websocketConn) handle(req websocketRequest) {
+	switch req.Type {
+	case websocketSubscribe:
+		c.subscribe(req)
+	case websocketUnsubscribe:
+		for i, sub := range c.subs {
+			if sub.id == req.ID {
+				c.subs = append(c.subs[:i], c.subs[i+1:]...)
+				c.send(websocketMessage{Type: websocketUnsubscribed, ID: req.ID})
+				return
+			}
+		}
+		c.sendError(req.ID, &problem.NotFound)
+	default:
+		c.sendError(req.ID, problem.MakeInvalidFieldProblem(
+			"type", fmt.Errorf("unknown message type: %s", req.Type),
+		))
+	}
+}
+
+func (c *websocketConn) subscribe(req websocketRequest) {
+	if req.ID == "" {
+		c.sendError(req.ID, problem.MakeInvalidFieldProblem("id", errors.New("id is required")))
+		return
+	}
+	for _, sub := range c.subs {
+		if sub.id == req.ID {
+			c.sendError(req.ID, problem.MakeInvalidFieldProblem("id", errors.New("id is already subscribed")))
+			return
+		}
+	}
+	if _, ok := websocketTopics[req.Topic]; !ok {
+		c.sendError(req.ID, problem.MakeInvalidFieldProblem(
+			"topic", fmt.Errorf("unknown topic: %s", req.Topic),
+		))
+		return
+	}
+	if len(c.subs) >= maxWebsocketSubscriptions {
+		c.sendError(req.ID, problem.MakeInvalidFieldProblem(
+			"id", fmt.Errorf("at most %d subscriptions are allowed", maxWebsocketSubscriptions),
+		))
+		return
+	}
+
+	sub := &websocketSubscription{id: req.ID, topic: req.Topic, params: url.Values{}}
+	for key, value := range req.Params {
+		sub.params.Set(key, value)
+	}
+
+	if _, ok := websocketTopics[req.Topic]().(actions.EventStreamer); ok {
+		// "now" is resolved once, so that the events of the ledgers ingested
+		// while the subscription is active are not skipped.
+		if sub.params.Get(actions.ParamCursor) == "now" {
+			sub.params.Set(actions.ParamCursor, toid.AfterLedger(ledger.CurrentState().HistoryLatest).String())
+		}
+		sub.params.Set(actions.ParamOrder, "asc")
+		sub.params.Set(actions.ParamLimit, strconv.Itoa(websocketPageLimit))
+	}
+
+	c.send(websocketMessage{Type: websocketSubscribed, ID: sub.id})
+	if c.update(sub) {
+		c.subs = append(c.subs, sub)
+	}
+}
+
+// update sends the new events of `sub`.  Subscriptions failing to load their
+// events are reported to the client and removed, in which case update returns
+// false.
+func (c *websocketConn) update(sub *websocketSubscription) bool {
+	err := c.loadEvents(sub)
+	if err == nil {
+		return true
+	}
+
+	c.sendError(sub.id, err)
+	for i, s := range c.subs {
+		if s == sub {
+			c.subs = append(c.subs[:i], c.subs[i+1:]...)
+			break
+		}
+	}
+	return false
+}
+
+func (c *websocketConn) loadEvents(sub *websocketSubscription) error {
+	if sub.action != nil {
+		return c.loadObject(sub)
+	}
+
+	for c.err == nil {
+		action := websocketTopics[sub.topic]()
+		streamer, ok := action.(actions.EventStreamer)
+		if !ok {
+			sub.action = action
+			action.Prepare(discardResponseWriter{}, c.request(sub))
+			return c.loadObject(sub)
+		}
+		action.Prepare(discardResponseWriter{}, c.request(sub))
+
+		stream := sse.NewCallbackStream(c.r.Context(), func(e sse.Event) {
+			sub.params.Set(actions.ParamCursor, e.ID)
+			c.send(websocketMessage{
+				Type:   websocketEvent,
+				ID:     sub.id,
+				Cursor: e.ID,
+				Data:   e.Data,
+			})
+		})
+		err := streamer.SSE(stream)
+		if err != nil {
+			return err
+		}
+
+		if stream.SentCount() < websocketPageLimit {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// loadObject sends the current state of single object subscriptions, unless
+// it did not change since the previous event.
+func (c *websocketConn) loadObject(sub *websocketSubscription) error {
+	event, err := sub.action.(actions.SingleObjectStreamer).LoadEvent()
+	if err != nil {
+		return err
+	}
+
+	resource, err := json.Marshal(event.Data)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal next action resource")
+	}
+
+	nextHash := sha256.Sum256(resource)
+	if bytes.Equal(nextHash[:], sub.lastHash[:]) {
+		return nil
+	}
+	sub.lastHash = nextHash
+
+	c.send(websocketMessage{
+		Type:  websocketEvent,
+		ID:    sub.id,
+		Event: event.Event,
+		Data:  event.Data,
+	})
+	return nil
+}
+
+// request returns the request the action of `sub` is run with.  It shares the
+// context of the websocket connection.
+func (c *websocketConn) request(sub *websocketSubscription) *http.Request {
+	u := *c.r.URL
+	u.RawQuery = sub.params.Encode()
+
+	return (&http.Request{
+		Method: http.MethodGet,
+		URL:    &u,
+		Header: http.Header{},
+		Host:   c.r.Host,
+	}).WithContext(c.r.Context())
+}
+
+// rateLimit charges the rate limiter of the client for every subscription
+// updated, like it is charged for every update of an event stream.  It
+// returns false when the connection is closed because of the rate limit.
+func (c *websocketConn) rateLimit() bool {
+	rateLimiter := c.web.rateLimiter
+	if rateLimiter == nil || len(c.subs) == 0 {
+		return true
+	}
+
+	limited, _, err := rateLimiter.RateLimiter.RateLimit(rateLimiter.VaryBy.Key(c.r), len(c.subs))
+	if err != nil {
+		c.sendError("", errors.Wrap(err, "RateLimiter error"))
+		return false
+	}
+	if limited {
+		c.sendError("", sse.ErrRateLimited)
+		return false
+	}
+	return true
+}
+
+func (c *websocketConn) sendError(id string, err interface{}) {
+	p := problem.Convert(c.r.Context(), err)
+	c.send(websocketMessage{Type: websocketError, ID: id, Error: &p})
+}
+
+// send writes `msg` to the client.  Once a write fails, the connection is
+// closed and further messages are dropped.
+func (c *websocketConn) send(msg websocketMessage) {
+	if c.err != nil {
+		return
+	}
+	c.err = websocket.JSON.Send(c.conn, msg)
+}
+
+// discardResponseWriter is the response writer of the actions run by
+// websocket subscriptions, which deliver their events through the connection
+// instead.
+type discardResponseWriter struct{}
+
+func (discardResponseWriter) Header() http.Header         { return http.Header{} }
+func (discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (discardResponseWriter) WriteHeader(int)             {}
@@ -0,0 +1,89 @@
+package horizon
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestWebsocketSubscriptions(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	server := httptest.NewServer(ht.App.web.router)
+	defer server.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", "", server.URL)
+	ht.Require.NoError(err)
+	defer conn.Close()
+
+	send := func(req websocketRequest) {
+		ht.Require.NoError(websocket.JSON.Send(conn, req))
+	}
+	receive := func() websocketMessage {
+		var msg websocketMessage
+		ht.Require.NoError(websocket.JSON.Receive(conn, &msg))
+		return msg
+	}
+
+	// the history of payments is sent right after subscribing
+	send(websocketRequest{Type: "subscribe", ID: "payments", Topic: "payments"})
+	ht.Assert.Equal(websocketMessage{Type: "subscribed", ID: "payments"}, receive())
+
+	var cursors []string
+	for i := 0; i < 4; i++ {
+		msg := receive()
+		ht.Assert.Equal("event", msg.Type)
+		ht.Assert.Equal("payments", msg.ID)
+		ht.Assert.NotEmpty(msg.Data)
+		cursors = append(cursors, msg.Cursor)
+	}
+	ht.Assert.Equal(4, len(cursors))
+
+	// subscriptions resume from their own cursor
+	send(websocketRequest{
+		Type:   "subscribe",
+		ID:     "latest",
+		Topic:  "payments",
+		Params: map[string]string{"cursor": cursors[2]},
+	})
+	ht.Assert.Equal(websocketMessage{Type: "subscribed", ID: "latest"}, receive())
+	msg := receive()
+	ht.Assert.Equal("latest", msg.ID)
+	ht.Assert.Equal(cursors[3], msg.Cursor)
+
+	// ids must be unique
+	send(websocketRequest{Type: "subscribe", ID: "payments", Topic: "effects"})
+	msg = receive()
+	if ht.Assert.Equal("error", msg.Type) && ht.Assert.NotNil(msg.Error) {
+		ht.Assert.Equal("id", msg.Error.Extras["invalid_field"])
+	}
+
+	// topics must be known
+	send(websocketRequest{Type: "subscribe", ID: "unknown", Topic: "unknown"})
+	msg = receive()
+	if ht.Assert.Equal("error", msg.Type) && ht.Assert.NotNil(msg.Error) {
+		ht.Assert.Equal("unknown", msg.ID)
+		ht.Assert.Equal("topic", msg.Error.Extras["invalid_field"])
+	}
+
+	// invalid params are reported and the subscription is dropped
+	send(websocketRequest{Type: "subscribe", ID: "book", Topic: "order_book"})
+	ht.Assert.Equal(websocketMessage{Type: "subscribed", ID: "book"}, receive())
+	msg = receive()
+	if ht.Assert.Equal("error", msg.Type) && ht.Assert.NotNil(msg.Error) {
+		ht.Assert.Equal("book", msg.ID)
+		ht.Assert.Equal(400, msg.Error.Status)
+	}
+
+	send(websocketRequest{Type: "unsubscribe", ID: "book"})
+	msg = receive()
+	if ht.Assert.Equal("error", msg.Type) && ht.Assert.NotNil(msg.Error) {
+		ht.Assert.Equal(404, msg.Error.Status)
+	}
+
+	send(websocketRequest{Type: "unsubscribe", ID: "payments"})
+	ht.Assert.Equal(websocketMessage{Type: "unsubscribed", ID: "payments"}, receive())
+}
@@ -62,6 +62,8 @@ type PaymentRequest struct {
 	SendAssetIssuer string `form:"send_asset_issuer" valid:"optional,stellar_accountid"`
 	// path[n][asset_code] path[n][asset_issuer]
 	Path []protocols.Asset `form:"path" valid:"optional"`
+	// ID of a quote returned by /quote. The path and send_max of the quote are used.
+	QuoteID string `form:"quote_id" valid:"optional"`
 	// Determined whether to use compliance protocol or to send a simple payment.
 	UseCompliance bool `form:"use_compliance" valid:"-"`
 	// Extra memo. If set, UseCompliance value will be ignored and it will use compliance.
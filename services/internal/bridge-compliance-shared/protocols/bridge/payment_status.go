@@ -0,0 +1,41 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/http/helpers"
+)
+
+// PaymentNotFound is an error response
+var PaymentNotFound = &helpers.ErrorResponse{Code: "not_found", Message: "Payment not found.", Status: http.StatusNotFound}
+
+// PaymentStatusResponse represents response returned by /payment/{id} endpoint
+type PaymentStatusResponse struct {
+	helpers.SuccessResponse
+	// ID of the payment sent in /payment request
+	ID string `json:"id"`
+	// Status of the transaction: sending, success or failure
+	Status        string     `json:"status"`
+	TransactionID string     `json:"transaction_id"`
+	Source        string     `json:"source"`
+	SubmittedAt   time.Time  `json:"submitted_at"`
+	SucceededAt   *time.Time `json:"succeeded_at,omitempty"`
+	Ledger        *int32     `json:"ledger,omitempty"`
+	EnvelopeXdr   string     `json:"envelope_xdr"`
+	ResultXdr     *string    `json:"result_xdr,omitempty"`
+	// ResultCodes are the result codes of failed transactions
+	ResultCodes *PaymentResultCodes `json:"result_codes,omitempty"`
+}
+
+// PaymentResultCodes represents the result codes of a failed transaction
+type PaymentResultCodes struct {
+	TransactionCode string   `json:"transaction"`
+	OperationCodes  []string `json:"operations,omitempty"`
+}
+
+// Marshal marshals PaymentStatusResponse
+func (response *PaymentStatusResponse) Marshal() ([]byte, error) {
+	return json.MarshalIndent(response, "", "  ")
+}
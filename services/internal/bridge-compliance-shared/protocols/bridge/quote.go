@@ -0,0 +1,91 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/http/helpers"
+	"github.com/stellar/go/services/internal/bridge-compliance-shared/protocols"
+)
+
+var (
+	// QuoteNoPath is an error response
+	QuoteNoPath = &helpers.ErrorResponse{Code: "no_path", Message: "No path found to send the given amount.", Status: http.StatusBadRequest}
+	// QuoteNotFound is an error response
+	QuoteNotFound = &helpers.ErrorResponse{Code: "quote_not_found", Message: "Quote not found.", Status: http.StatusBadRequest}
+	// QuoteExpired is an error response
+	QuoteExpired = &helpers.ErrorResponse{Code: "quote_expired", Message: "Quote expired. Request a new quote.", Status: http.StatusBadRequest}
+	// QuoteMismatch is an error response
+	QuoteMismatch = &helpers.ErrorResponse{Code: "quote_mismatch", Message: "Payment does not match the quote.", Status: http.StatusBadRequest}
+)
+
+// QuoteRequest represents request made to /quote endpoint of the bridge server
+type QuoteRequest struct {
+	// Account ID of the sender, base account by default
+	Source string `form:"source" valid:"optional,stellar_accountid"`
+	// Destination address (like bob*stellar.org) or account ID
+	Destination string `form:"destination" valid:"required,stellar_destination"`
+	// Amount destination should receive
+	Amount string `form:"amount" valid:"required,stellar_amount"`
+	// Code of the asset destination should receive
+	AssetCode string `form:"asset_code" valid:"optional,stellar_asset_code"`
+	// Issuer of the asset destination should receive
+	AssetIssuer string `form:"asset_issuer" valid:"optional,stellar_accountid"`
+	// Only return paths sending this asset
+	SendAssetCode string `form:"send_asset_code" valid:"optional,stellar_asset_code"`
+	// Only return paths sending this asset
+	SendAssetIssuer string `form:"send_asset_issuer" valid:"optional,stellar_accountid"`
+	// Fraction of the source amount added to send_max (ex. 0.01), `quotes.slippage` by default
+	Slippage string `form:"slippage" valid:"optional"`
+}
+
+// Validate is additional validation method to validate special fields.
+func (request *QuoteRequest) Validate(params ...interface{}) error {
+	asset := protocols.Asset{request.AssetCode, request.AssetIssuer}
+	err := asset.Validate()
+	if err != nil {
+		return helpers.NewInvalidParameterError("asset", err.Error())
+	}
+
+	sendAsset := protocols.Asset{request.SendAssetCode, request.SendAssetIssuer}
+	err = sendAsset.Validate()
+	if err != nil {
+		return helpers.NewInvalidParameterError("send_asset", err.Error())
+	}
+
+	if request.Slippage != "" {
+		slippage, err := strconv.ParseFloat(request.Slippage, 64)
+		if err != nil || slippage < 0 || slippage > 1 {
+			return helpers.NewInvalidParameterError("slippage", "Slippage must be a number between 0 and 1.")
+		}
+	}
+
+	return nil
+}
+
+// QuoteResponse represents response returned by /quote endpoint
+type QuoteResponse struct {
+	helpers.SuccessResponse
+	// Quotes of the paths found, sorted by source amount
+	Quotes []Quote `json:"quotes"`
+}
+
+// Quote is a single path which can be used by /payment by sending its ID in
+// `quote_id` parameter
+type Quote struct {
+	ID                string            `json:"id"`
+	SendAssetCode     string            `json:"send_asset_code"`
+	SendAssetIssuer   string            `json:"send_asset_issuer"`
+	SourceAmount      string            `json:"source_amount"`
+	SendMax           string            `json:"send_max"`
+	Path              []protocols.Asset `json:"path"`
+	DestinationAmount string            `json:"destination_amount"`
+	ExpiresAt         time.Time         `json:"expires_at"`
+}
+
+// Marshal marshals QuoteResponse
+func (response *QuoteResponse) Marshal() ([]byte, error) {
+	return json.MarshalIndent(response, "", "  ")
+}
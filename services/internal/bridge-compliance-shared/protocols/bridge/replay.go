@@ -0,0 +1,43 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ReplayRequest represents request made to /admin/replay endpoint of bridge server
+type ReplayRequest struct {
+	AccountID string `form:"account_id" valid:"required,stellar_accountid"`
+	// Cursor is the paging token after which payments are replayed.
+	Cursor string `form:"cursor" valid:"required"`
+	// EndCursor is the paging token of the last payment replayed.  It defaults
+	// to the cursor persisted by the payment listener.
+	EndCursor string `form:"end_cursor" valid:"optional"`
+}
+
+func (r ReplayRequest) Validate(params ...interface{}) error {
+	// No custom validations
+	return nil
+}
+
+// ReplayResponse represents a response returned by /admin/replay endpoint
+type ReplayResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+	// Cursor is the paging token after which payments are replayed.
+	Cursor string `json:"cursor,omitempty"`
+	// EndCursor is the paging token after which the replay stops.
+	EndCursor string `json:"end_cursor,omitempty"`
+}
+
+func (r ReplayResponse) HTTPStatus() int {
+	if r.Status == "ok" {
+		return http.StatusOK
+	} else {
+		return http.StatusBadRequest
+	}
+}
+
+func (r ReplayResponse) Marshal() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
@@ -38,23 +38,29 @@ func Init(db *db.Session, latest []byte) error {
 // upward back to the current version at the start of the process. If count is
 // 0, a count of 1 will be assumed.
 func Migrate(db *sql.DB, migrations migrate.MigrationSource, dir MigrateDir, count int) (int, error) {
+	return MigrateDialect(db, "postgres", migrations, dir, count)
+}
+
+// MigrateDialect performs schema migration like Migrate, against a database
+// of the given dialect (mysql, postgres or sqlite3).
+func MigrateDialect(db *sql.DB, dialect string, migrations migrate.MigrationSource, dir MigrateDir, count int) (int, error) {
 	switch dir {
 	case MigrateUp:
-		return migrate.ExecMax(db, "postgres", migrations, migrate.Up, count)
+		return migrate.ExecMax(db, dialect, migrations, migrate.Up, count)
 	case MigrateDown:
-		return migrate.ExecMax(db, "postgres", migrations, migrate.Down, count)
+		return migrate.ExecMax(db, dialect, migrations, migrate.Down, count)
 	case MigrateRedo:
 
 		if count == 0 {
 			count = 1
 		}
 
-		down, err := migrate.ExecMax(db, "postgres", migrations, migrate.Down, count)
+		down, err := migrate.ExecMax(db, dialect, migrations, migrate.Down, count)
 		if err != nil {
 			return down, err
 		}
 
-		return migrate.ExecMax(db, "postgres", migrations, migrate.Up, down)
+		return migrate.ExecMax(db, dialect, migrations, migrate.Up, down)
 	default:
 		return 0, errors.New("Invalid migration direction")
 	}
@@ -1,6 +1,8 @@
 package hal
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 )
@@ -14,7 +16,15 @@ func RenderToString(data interface{}, pretty bool) ([]byte, error) {
 	return json.Marshal(data)
 }
 
-// Render write data to w, after marshalling to json
+// ETag returns a strong entity tag for the rendered resource `body`.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// Render write data to w, after marshalling to json.  Unless the response
+// already carries one, an ETag derived from the rendered json is set so that
+// clients can make conditional requests.
 func Render(w http.ResponseWriter, data interface{}) {
 	js, err := RenderToString(data, true)
 	if err != nil {
@@ -24,5 +34,8 @@ func Render(w http.ResponseWriter, data interface{}) {
 
 	w.Header().Set("Content-Disposition", "inline")
 	w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+	if w.Header().Get("ETag") == "" {
+		w.Header().Set("ETag", ETag(js))
+	}
 	w.Write(js)
 }
@@ -0,0 +1,31 @@
+package hal
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderETag(t *testing.T) {
+	w := httptest.NewRecorder()
+	Render(w, map[string]int{"a": 1})
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, ETag(w.Body.Bytes()), etag)
+	assert.Len(t, etag, 34)
+
+	// identical resources share the same etag
+	w = httptest.NewRecorder()
+	Render(w, map[string]int{"a": 1})
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+
+	w = httptest.NewRecorder()
+	Render(w, map[string]int{"a": 2})
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	// an etag set by the caller is kept
+	w = httptest.NewRecorder()
+	w.Header().Set("ETag", `"ledger-10"`)
+	Render(w, map[string]int{"a": 1})
+	assert.Equal(t, `"ledger-10"`, w.Header().Get("ETag"))
+}
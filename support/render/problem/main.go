@@ -68,23 +68,32 @@ type HasProblem interface {
 // of the `HasProblem` interface, or an error.  Any other value for `p` will
 // panic.
 func Render(ctx context.Context, w http.ResponseWriter, p interface{}) {
+	render(ctx, w, Convert(ctx, p))
+}
+
+// Convert returns the problem that Render writes for `p`, which accepts the
+// same values as Render.  It is useful to report problems through other means
+// than a http response.
+func Convert(ctx context.Context, p interface{}) P {
+	var result P
 	switch p := p.(type) {
 	case P:
-		render(ctx, w, p)
+		result = p
 	case *P:
-		render(ctx, w, *p)
+		result = *p
 	case HasProblem:
-		render(ctx, w, p.Problem())
+		result = p.Problem()
 	case error:
-		renderErr(ctx, w, p)
+		result = convertErr(ctx, p)
 	default:
 		panic(fmt.Sprintf("Invalid problem: %v+", p))
 	}
+
+	Inflate(&result)
+	return result
 }
 
 func render(ctx context.Context, w http.ResponseWriter, p P) {
-	Inflate(&p)
-
 	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
 	js, err := json.MarshalIndent(p, "", "  ")
 
@@ -99,7 +108,7 @@ func render(ctx context.Context, w http.ResponseWriter, p P) {
 	w.Write(js)
 }
 
-func renderErr(ctx context.Context, w http.ResponseWriter, err error) {
+func convertErr(ctx context.Context, err error) P {
 	origErr := errors.Cause(err)
 
 	p, ok := errToProblemMap[origErr]
@@ -111,7 +120,7 @@ func renderErr(ctx context.Context, w http.ResponseWriter, err error) {
 		p = ServerError
 	}
 
-	render(ctx, w, p)
+	return p
 }
 
 // ServerError is a well-known problem type. Use it as a shortcut.
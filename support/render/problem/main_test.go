@@ -139,6 +139,23 @@ func TestInflate(t *testing.T) {
 	})
 }
 
+// TestConvert tests that problems are converted like they are rendered
+func TestConvert(t *testing.T) {
+	ctx := context.Background()
+
+	p := Convert(ctx, &NotFound)
+	assert.Equal(t, "https://stellar.org/horizon-errors/not_found", p.Type)
+	assert.Equal(t, 404, p.Status)
+
+	registered := errors.New("registered")
+	RegisterError(registered, BadRequest)
+	p = Convert(ctx, registered)
+	assert.Equal(t, "https://stellar.org/horizon-errors/bad_request", p.Type)
+
+	p = Convert(ctx, errors.New("unregistered"))
+	assert.Equal(t, 500, p.Status)
+}
+
 func testRender(ctx context.Context, p interface{}) *httptest.ResponseRecorder {
 	w := httptest.NewRecorder()
 	Render(ctx, w, p)